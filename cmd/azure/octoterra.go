@@ -11,11 +11,9 @@ import (
 	"strings"
 
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/entry"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/environment"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/export"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/logger"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/output"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
@@ -123,26 +121,21 @@ func octoterraHandler(w http.ResponseWriter, r *http.Request) {
 		commandLineArgs = append(commandLineArgs, "-redirectorRedirections", redirectorRedirections)
 	}
 
-	webArgs, _, err := args.ParseArgs(commandLineArgs)
+	arguments, _, err := args.ParseArgs(commandLineArgs)
 
 	if err != nil {
 		handleError(err, w)
 		return
 	}
 
-	files, err := entry.Entry(webArgs, "")
+	files, _, err := export.Export(r.Context(), export.Options{Arguments: arguments})
 
 	if err != nil {
 		handleError(err, w)
 		return
 	}
 
-	var sb strings.Builder
-	for _, str := range strutil.UnEscapeDollarInMap(files) {
-		sb.WriteString(str + "\n\n")
-	}
-
-	result := output.WriteString(files)
+	result := files.String()
 
 	w.Header()["Content-Type"] = []string{"text/plain; charset=utf-8"}
 	w.WriteHeader(200)
@@ -181,7 +174,8 @@ func useRedirector(octopusUrl string, redirectorServiceApiKey string, redirector
 }
 
 // sanitizeConfig removes sensitive information from the config so it is not
-// persisted to the disk.
+// persisted to the disk. Only the arguments that shape the export are kept, so
+// callers can not read or write files like snapshots, secrets or other configs.
 func sanitizeConfig(rawConfig []byte) ([]byte, error) {
 	if len(rawConfig) == 0 {
 		return rawConfig, nil
@@ -192,8 +186,10 @@ func sanitizeConfig(rawConfig []byte) ([]byte, error) {
 		return nil, err
 	}
 
-	for _, varName := range args.SensitiveArgs {
-		delete(config, varName)
+	for varName := range config {
+		if !args.IsRemoteArg(varName) {
+			delete(config, varName)
+		}
 	}
 	return json.Marshal(config)
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"net/url"
 	"testing"
 )
@@ -53,3 +54,20 @@ func TestHostIsCloudOrLocalParseUrl(t *testing.T) {
 		}
 	}
 }
+
+func TestSanitizeConfig(t *testing.T) {
+	sanitized, err := sanitizeConfig([]byte(`{"space":"Spaces-1","excludeAllTargets":true,"apiKey":"API-XXXX","recordSnapshot":"/tmp/record.zip","replaySnapshot":"/tmp/replay.zip","secretsFile":"/etc/passwd","configFile":"other"}`))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := map[string]any{}
+	if err := json.Unmarshal(sanitized, &config); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(config) != 2 || config["space"] != "Spaces-1" || config["excludeAllTargets"] != true {
+		t.Errorf("sanitizeConfig kept unexpected values: %s", sanitized)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/diff"
+)
+
+// errDifferences is returned when the exports are different and the -exitCode argument was set.
+var errDifferences = errors.New("the exports are different")
+
+// compare exports the left and right sources and writes the differences to the console. Any arguments after "--"
+// are the regular export arguments, and are used to export both sources.
+func compare(arguments []string) error {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	left := flags.String("left", "", "The export to compare against. This is a directory holding a previous export, an export_manifest.json file, a snapshot archive created with -recordSnapshot, or a space ID to export.")
+	right := flags.String("right", "", "The export to compare. This accepts the same values as -left.")
+	format := flags.String("format", "text", "The format of the report. Either \"text\" or \"json\".")
+	exitCode := flags.Bool("exitCode", false, "Exit with the code 2 if the exports are different.")
+
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	if *left == "" || *right == "" {
+		return errors.New("the -left and -right arguments are required")
+	}
+
+	if *format != "text" && *format != "json" {
+		return errors.New("the -format argument must be either \"text\" or \"json\"")
+	}
+
+	exportArgs, argsErrors, err := args.ParseArgs(flags.Args())
+
+	if err != nil {
+		return errors.Join(err, errors.New(argsErrors))
+	}
+
+	leftCollection, err := diff.LoadSource(context.Background(), *left, exportArgs, Version)
+
+	if err != nil {
+		return err
+	}
+
+	rightCollection, err := diff.LoadSource(context.Background(), *right, exportArgs, Version)
+
+	if err != nil {
+		return err
+	}
+
+	report, err := diff.Compare(leftCollection, rightCollection)
+
+	if err != nil {
+		return err
+	}
+
+	output := []byte(report.Text())
+	if *format == "json" {
+		if output, err = report.Json(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := os.Stdout.Write(output); err != nil {
+		return err
+	}
+
+	if *exitCode && report.HasDifferences() {
+		return errDifferences
+	}
+
+	return nil
+}
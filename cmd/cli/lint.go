@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/export"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/generators"
+)
+
+// errFindings is returned when the export has issues at least as severe as the -failOn argument.
+var errFindings = errors.New("the export has issues")
+
+// lint runs the export without writing any files and writes the issues found while converting each resource to the
+// console. Any arguments after "--" are the regular export arguments.
+func lint(arguments []string) error {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	format := flags.String("format", "text", "The format of the report. Either \"text\" or \"json\".")
+	failOn := flags.String("failOn", string(data.LintSeverityError), "The least severe finding that exits the command with the code 2. One of \"error\", \"lossy\" or \"warning\".")
+
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	if *format != "text" && *format != "json" {
+		return errors.New("the -format argument must be either \"text\" or \"json\"")
+	}
+
+	severity := data.LintSeverity(*failOn)
+	if severity != data.LintSeverityError && severity != data.LintSeverityLossy && severity != data.LintSeverityWarning {
+		return errors.New("the -failOn argument must be one of \"error\", \"lossy\" or \"warning\"")
+	}
+
+	exportArgs, argsErrors, err := args.ParseArgs(flags.Args())
+
+	if err != nil {
+		return errors.Join(err, errors.New(argsErrors))
+	}
+
+	// Lint only reports on the export, so it does not write a snapshot
+	if exportArgs.RecordSnapshot != "" {
+		return errors.New("the -recordSnapshot argument can not be used with lint")
+	}
+
+	if exportArgs.ReplaySnapshot == "" && (exportArgs.Url == "" || (exportArgs.ApiKey == "" && exportArgs.AccessToken == "")) {
+		return errors.New("linting a space requires the -url and -apiKey arguments")
+	}
+
+	if err := exportArgs.Validate(); err != nil {
+		return err
+	}
+
+	files, _, err := export.Export(context.Background(), export.Options{
+		Arguments: exportArgs,
+		Version:   Version,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	generator := generators.LintReportGenerator{}
+	report := generator.Build(&data.ResourceDetailsCollection{})
+
+	// The report is only generated when the converters found issues
+	if reportJson, ok := files[generators.LintReportFileName]; ok {
+		if err := json.Unmarshal([]byte(reportJson), &report); err != nil {
+			return err
+		}
+	}
+
+	output := []byte(generator.Text(report))
+	if *format == "json" {
+		if output, err = generator.Json(report); err != nil {
+			return err
+		}
+	}
+
+	if _, err := os.Stdout.Write(output); err != nil {
+		return err
+	}
+
+	if report.HasSeverity(severity) {
+		return fmt.Errorf("%w: %d errors, %d lossy conversions and %d warnings", errFindings, report.Errors, report.Lossy,
+			report.Warnings)
+	}
+
+	return nil
+}
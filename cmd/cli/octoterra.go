@@ -1,15 +1,15 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"os"
 
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/entry"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/export"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/logger"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/output"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"go.uber.org/zap"
 )
 
@@ -18,6 +18,39 @@ var Version = "development"
 func main() {
 	logger.BuildLogger()
 
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		if err := serve(os.Args[2:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+			errorExit(err.Error())
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		if err := compare(os.Args[2:]); errors.Is(err, errDifferences) {
+			os.Exit(2)
+		} else if err != nil && !errors.Is(err, flag.ErrHelp) {
+			errorExit(err.Error())
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		if err := lint(os.Args[2:]); errors.Is(err, errFindings) {
+			zap.L().Error(err.Error())
+			os.Exit(2)
+		} else if err != nil && !errors.Is(err, flag.ErrHelp) {
+			errorExit(err.Error())
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		if err := validate(os.Args[2:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+			errorExit(err.Error())
+		}
+		return
+	}
+
 	parseArgs, argsErrors, err := args.ParseArgs(os.Args[1:])
 
 	if errors.Is(err, flag.ErrHelp) {
@@ -37,46 +70,31 @@ func main() {
 		os.Exit(0)
 	}
 
-	if parseArgs.Url == "" {
-		errorExit("You must specify the URL with the -url argument")
-	}
-
-	if parseArgs.ApiKey == "" && parseArgs.AccessToken == "" {
-		errorExit("You must specify the API key with the -apiKey argument")
-	}
-
-	if parseArgs.RunbookName != "" && len(parseArgs.ProjectName) != 1 && len(parseArgs.ProjectId) == 1 {
-		errorExit("runbookName requires either a single projectId or projectName to be set")
-	}
-
-	if parseArgs.Stateless {
-		if parseArgs.StepTemplateKey == "" {
-			errorExit("stepTemplate requires stepTemplateKey to be defined (e.g. EKS, AKS, Lambda, WebApp)")
+	// Replaying a snapshot does not require access to the Octopus server
+	if parseArgs.ReplaySnapshot == "" {
+		if parseArgs.Url == "" {
+			errorExit("You must specify the URL with the -url argument")
 		}
 
-		if parseArgs.StepTemplateName == "" {
-			errorExit("stepTemplate requires stepTemplateName to be defined")
+		if parseArgs.ApiKey == "" && parseArgs.AccessToken == "" {
+			errorExit("You must specify the API key with the -apiKey argument")
 		}
-
-		// Don't generate scripts
-		parseArgs.GenerateImportScripts = false
 	}
 
-	if !parseArgs.ExcludeCaCProjectSettings && parseArgs.ExcludeAllGitCredentials {
-		errorExit("excludeAllGitCredentials requires excludeCaCProjectSettings to be true")
-	}
-
-	if parseArgs.LookupProjectDependencies && parseArgs.Stateless {
-		errorExit("lookupProjectDependencies can not be used with stepTemplate")
+	if err := parseArgs.Validate(); err != nil {
+		errorExit(err.Error())
 	}
 
-	files, err := entry.Entry(parseArgs, Version)
+	files, _, err := export.Export(context.Background(), export.Options{
+		Arguments: parseArgs,
+		Version:   Version,
+	})
 
 	if err != nil {
 		errorExit(err.Error())
 	}
 
-	err = output.WriteFiles(strutil.UnEscapeDollarInMap(files), parseArgs.Destination, parseArgs.Console)
+	err = output.WriteFiles(files, parseArgs.Destination, parseArgs.Console)
 
 	if err != nil {
 		errorExit(err.Error())
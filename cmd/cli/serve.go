@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"net/http"
+	"time"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/server"
+	"go.uber.org/zap"
+)
+
+// serve runs the HTTP export service until the listener fails.
+func serve(arguments []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	listen := flags.String("listen", ":8080", "The address the server listens on.")
+	maxConcurrentExports := flags.Int("maxConcurrentExports", 1, "The number of exports that run at the same time. Additional exports are queued.")
+	maxQueuedExports := flags.Int("maxQueuedExports", 0, "The number of exports that can be queued. New exports are rejected when the queue is full. 0 means the queue is unbounded.")
+	resultExpiry := flags.Int("resultExpiry", 3600, "The number of seconds a finished export is kept before it is removed.")
+
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	if *maxConcurrentExports < 1 {
+		return errors.New("the -maxConcurrentExports argument must be at least 1")
+	}
+
+	if *resultExpiry < 1 {
+		return errors.New("the -resultExpiry argument must be at least 1")
+	}
+
+	exportServer := server.Server{
+		MaxConcurrentExports: *maxConcurrentExports,
+		MaxQueuedExports:     *maxQueuedExports,
+		ResultExpiry:         time.Duration(*resultExpiry) * time.Second,
+		Version:              Version,
+	}
+
+	zap.L().Info("Listening on " + *listen)
+	return http.ListenAndServe(*listen, exportServer.Handler())
+}
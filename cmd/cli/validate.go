@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/validation"
+)
+
+// validate checks the resources and data sources in an export against the schema of the Octopus provider. The
+// schema is a file created with "terraform providers schema -json", so neither Terraform nor network access is
+// required to validate the export.
+func validate(arguments []string) error {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	dir := flags.String("dir", "", "The directory holding the exported Terraform configuration.")
+	providerSchema := flags.String("providerSchema", "", "The provider schema file created with \"terraform providers schema -json\".")
+
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	if *dir == "" {
+		return errors.New("the -dir argument is required")
+	}
+
+	if *providerSchema == "" {
+		return errors.New("the -providerSchema argument is required")
+	}
+
+	schemas, err := validation.LoadProviderSchemas(*providerSchema)
+
+	if err != nil {
+		return err
+	}
+
+	problems, err := validation.Validator{Schemas: schemas}.ValidateDirectory(*dir)
+
+	if err != nil {
+		return err
+	}
+
+	for _, problem := range problems {
+		fmt.Println(problem.String())
+	}
+
+	if len(problems) != 0 {
+		return errors.New(strconv.Itoa(len(problems)) + " problems found")
+	}
+
+	return nil
+}
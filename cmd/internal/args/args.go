@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
@@ -29,10 +30,89 @@ var SensitiveArgs = []string{
 	"redirectorRedirections",
 }
 
+// LocalArgs are the arguments that read or write files on the machine running the export, or change the behaviour of
+// the process itself. They are never accepted from remote callers like the export server, MCP server or Azure function.
+var LocalArgs = []string{
+	"configFile",
+	"configPath",
+	"profiling",
+	"recordSnapshot",
+	"replaySnapshot",
+	"secretsFile",
+	"secretsEnvironmentPrefix",
+	"dest",
+	"console",
+	"version",
+	"insecureTls",
+}
+
+// RemoteArgs are the arguments, other than the exclusion arguments starting with "exclude", that shape an export and
+// can be accepted from remote callers. Arguments that are not listed here are ignored by IsRemoteArg, so new
+// arguments must be added here before remote callers can use them.
+var RemoteArgs = []string{
+	"experimentalEnableStepTemplates",
+	"ignoreInvalidExcludeExcept",
+	"space",
+	"projectId",
+	"projectName",
+	"runbookId",
+	"runbookName",
+	"lookupProjectDependencies",
+	"lookupProjectLinkTenants",
+	"stepTemplate",
+	"stepTemplateAdditionalParameters",
+	"stepTemplateName",
+	"stepTemplateKey",
+	"stepTemplateDescription",
+	"ignoreCacManagedValues",
+	"terraformBackend",
+	"detachProjectTemplates",
+	"defaultSecretVariableValues",
+	"dummySecretVariableValues",
+	"inlineVariableValues",
+	"extractScripts",
+	"providerVersion",
+	"includeProviderServerDetails",
+	"includeOctopusOutputVars",
+	"limitAttributeLength",
+	"limitResourceCount",
+	"generateImportScripts",
+	"generateImportBlocks",
+	"outputFormat",
+	"exportTimestamp",
+	"checksums",
+	"requestTimeout",
+	"exportTimeout",
+	"explain",
+	"ignoreCacErrors",
+	"ignoreUnauthorized",
+	"ignoreServerError",
+	"octopusManagedTerraformVars",
+	"ignoreProjectChanges",
+	"ignoreProjectVariableChanges",
+	"ignoreProjectGroupChanges",
+	"ignoreProjectNameChanges",
+	"lookUpDefaultWorkerPools",
+	"includeIds",
+	"includeSpaceInPopulation",
+	"includeDefaultChannel",
+}
+
+// IsRemoteArg returns true if the argument with the JSON name can be accepted from a remote caller.
+func IsRemoteArg(name string) bool {
+	if slices.Contains(SensitiveArgs, name) || slices.Contains(LocalArgs, name) {
+		return false
+	}
+
+	return strings.HasPrefix(name, "exclude") || slices.Contains(RemoteArgs, name)
+}
+
 type Arguments struct {
 	InsecureTls                     bool            `json:"insecureTls,omitempty" jsonschema:"Ignore certificate errors when connecting to the Octopus server."`
 	ExperimentalEnableStepTemplates bool            `json:"experimentalEnableStepTemplates,omitempty" jsonschema:"Has no effect. This option used to enable the export of step templates, but this is now a standard feature. This option is left in for compatibility."`
 	Profiling                       bool            `json:"profiling,omitempty" jsonschema:"Enable profiling. Run 'pprof -http=:8080 octoterra.prof' to view the results."`
+	RecordSnapshot                  string          `json:"recordSnapshot,omitempty" jsonschema:"Save the responses from the Octopus API to a snapshot archive with the supplied file name."`
+	ReplaySnapshot                  string          `json:"replaySnapshot,omitempty" jsonschema:"Export the space from a snapshot archive created with recordSnapshot rather than querying the Octopus API."`
 	ExcludeTerraformVariables       bool            `json:"excludeTerraformVariables,omitempty" jsonschema:"This option means the exported module does not expose Terraform variables for common inputs like the value of project or library variables set variables. This reduces the size of the Terraform configuration files, but makes the module less configurable because values are hard coded."`
 	ExcludeSpaceCreation            bool            `json:"excludeSpaceCreation,omitempty" jsonschema:"This option excludes the Terraform configuration that is used to create the space."`
 	ConfigFile                      string          `json:"configFile,omitempty" jsonschema:"The name of the configuration file to use. Do not include the extension. Defaults to octoterra"`
@@ -67,7 +147,10 @@ type Arguments struct {
 	DetachProjectTemplates          bool            `json:"detachProjectTemplates,omitempty" jsonschema:"Detaches any step templates in the exported Terraform."`
 	DefaultSecretVariableValues     bool            `json:"defaultSecretVariableValues,omitempty" jsonschema:"Pass this to set the default value of secret variables to the octostache template referencing the variable."`
 	DummySecretVariableValues       bool            `json:"dummySecretVariableValues,omitempty" jsonschema:"Pass this to set the default value of secret variables, account secrets, feed credentials to a dummy value. This allows resources with secret values to be created without knowing the secrets, while still allowing the secret values to be specified if they are known. This option takes precedence over the defaultSecretVariableValues option."`
+	SecretsFile                     string          `json:"secretsFile,omitempty" jsonschema:"A JSON or YAML file mapping the names of sensitive Terraform variables to their values. The values are written to the secrets.auto.tfvars file."`
+	SecretsEnvironmentPrefix        string          `json:"secretsEnvironmentPrefix,omitempty" jsonschema:"The prefix of environment variables holding the values of sensitive Terraform variables. For example, with the prefix OCTOTERRA_SECRET_, the variable account_aws is read from OCTOTERRA_SECRET_account_aws. The values are written to the secrets.auto.tfvars file."`
 	InlineVariableValues            bool            `json:"inlineVariableValues,omitempty" jsonschema:"Inline the project and library variable set variable values rather than exposing their value as a Terraform variable. Secret variables will be inlined as dummy values. This option takes precedence over DummySecretVariableValues and DefaultSecretVariableValues."`
+	ExtractScripts                  bool            `json:"extractScripts,omitempty" jsonschema:"Write the inline scripts of steps, script modules and machine policy health checks to files in the scripts directory, and reference them with the file() or templatefile() functions."`
 	ProviderVersion                 string          `json:"providerVersion,omitempty" jsonschema:"Specifies the Octopus Terraform provider version."`
 	ExcludeProvider                 bool            `json:"excludeProvider,omitempty" jsonschema:"Exclude the provider from the exported Terraform configuration files. This is useful when you want to use a parent module to define the backend, as the parent module must define the provider."`
 	IncludeProviderServerDetails    bool            `json:"includeProviderServerDetails,omitempty" jsonschema:"Define the server URL and API keys as variables passed to the provider. Set this to false to use the OCTOPUS_ACCESS_TOKEN, OCTOPUS_URL, and OCTOPUS_APIKEY environment variables to configure the provider."`
@@ -75,6 +158,13 @@ type Arguments struct {
 	LimitAttributeLength            int             `json:"limitAttributeLength,omitempty" jsonschema:"For internal use only. Limits the length of the attribute names."`
 	LimitResourceCount              int             `json:"limitResourceCount,omitempty" jsonschema:"For internal use only. Limits the number of resources of a given type that are returned. For example, a value of 30 will ensure the exported Terraform only includes up to 30 accounts, and up to 30 feeds, and up to 30 projects etc. This is used to reduce the output when octoterra is used to generate a context for an LLM. This limit is a guide and it is possible that more than the specified number of resources are returned due to multiple goroutines adding resources to the output."`
 	GenerateImportScripts           bool            `json:"generateImportScripts,omitempty" jsonschema:"Generate Bash and Powershell scripts used to import resources into the Terraform state."`
+	GenerateImportBlocks            bool            `json:"generateImportBlocks,omitempty" jsonschema:"Generate Terraform import blocks, using the IDs of the exported resources, to import resources into the Terraform state."`
+	OutputFormat                    string          `json:"outputFormat,omitempty" jsonschema:"The format of the exported Terraform configuration. Either hcl or json. Defaults to hcl."`
+	ExportTimestamp                 string          `json:"exportTimestamp,omitempty" jsonschema:"An RFC 3339 timestamp, like 2024-01-01T00:00:00Z, used in place of the current time in exported files. This makes exports of the same resources identical."`
+	Checksums                       bool            `json:"checksums,omitempty" jsonschema:"Write the SHA-256 checksum of each exported file to the checksums.txt file."`
+	RequestTimeout                  int             `json:"requestTimeout,omitempty" jsonschema:"The maximum number of seconds a single request to the Octopus API can take. Zero means no timeout."`
+	ExportTimeout                   int             `json:"exportTimeout,omitempty" jsonschema:"The maximum number of seconds the export can take. Zero means no timeout."`
+	Explain                         string          `json:"explain,omitempty" jsonschema:"The name or ID of a resource. The chain of references that caused the resource to be included in the export is reported."`
 	IgnoreCacErrors                 bool            `json:"ignoreCacErrors,omitempty" jsonschema:"Ignores errors that would arise when a project can not resolve configuration in a Git repo."`
 	IgnoreUnauthorized              bool            `json:"ignoreUnauthorized,omitempty" jsonschema:"Ignores errors that would arise when a resources can not be accessed due to an unauthorized error."`
 	IgnoreServerError               bool            `json:"ignoreServerError,omitempty" jsonschema:"Ignores errors that would arise when the server returns a 500 internal server error."`
@@ -221,6 +311,11 @@ type Arguments struct {
 	ExcludeDeploymentFreezesExcept StringSliceArgs `json:"excludeDeploymentFreezesExcept,omitempty" jsonschema:"All deployment freezes except those defined with excludeDeploymentFreezesExcept are excluded."`
 	ExcludeDeploymentFreezesRegex  StringSliceArgs `json:"excludeDeploymentFreezesRegex,omitempty" jsonschema:"Exclude a deployment freeze from being exported based on regex match."`
 
+	ExcludeAllTeams    bool            `json:"excludeAllTeams,omitempty" jsonschema:"Exclude all teams from being exported."`
+	ExcludeTeams       StringSliceArgs `json:"excludeTeams,omitempty" jsonschema:"Exclude a team from being exported."`
+	ExcludeTeamsExcept StringSliceArgs `json:"excludeTeamsExcept,omitempty" jsonschema:"All teams except those defined with excludeTeamsExcept are excluded."`
+	ExcludeTeamsRegex  StringSliceArgs `json:"excludeTeamsRegex,omitempty" jsonschema:"Exclude a team from being exported based on regex match."`
+
 	ExcludePlatformHubVersionControl bool `json:"excludePlatformHubVersionControl,omitempty" jsonschema:"Exclude the Platform Hub version control settings."`
 
 	ExcludeAllStepTemplates    bool            `json:"excludeAllStepTemplates,omitempty" jsonschema:"Exclude all step templates from being exported."`
@@ -255,18 +350,163 @@ func (i *StringSliceArgs) Set(value string) error {
 	return nil
 }
 
-func ParseArgs(args []string) (Arguments, string, error) {
-	flags := flag.NewFlagSet("octoterra", flag.ContinueOnError)
+// DefaultArguments returns the arguments with the default value of every flag. Unlike ParseArgs, no config file or
+// environment variable is read.
+func DefaultArguments() Arguments {
+	arguments := Arguments{}
+	// Defining the flags assigns their default values to the arguments
+	newFlagSet(&arguments)
+	return arguments
+}
+
+// ParseFlags parses the command line arguments on top of the default value of every flag. Unlike ParseArgs, no config
+// file or environment variable is read, and no global setting is changed. The usage text is returned with any error.
+func ParseFlags(args []string) (Arguments, string, error) {
+	arguments := Arguments{}
+	flags := newFlagSet(&arguments)
 	var buf bytes.Buffer
 	flags.SetOutput(&buf)
 
+	if err := flags.Parse(args); err != nil {
+		return Arguments{}, buf.String(), err
+	}
+
+	return arguments, "", nil
+}
+
+func ParseArgs(args []string) (Arguments, string, error) {
 	arguments := Arguments{}
+	flags := newFlagSet(&arguments)
+	var buf bytes.Buffer
+	flags.SetOutput(&buf)
+
+	err := flags.Parse(args)
+
+	if err != nil {
+		return Arguments{}, buf.String(), err
+	}
+
+	err = overrideArgs(flags, arguments.ConfigPath, arguments.ConfigFile)
+
+	if err != nil {
+		zap.L().Error("Error overriding arguments with config file values", zap.Error(err))
+		return Arguments{}, buf.String(), err
+	}
+
+	if arguments.Url == "" {
+		arguments.Url = os.Getenv("OCTOPUS_CLI_SERVER")
+	}
+
+	if arguments.ApiKey == "" && arguments.AccessToken == "" {
+		arguments.ApiKey = os.Getenv("OCTOPUS_CLI_API_KEY")
+	}
+
+	if err := arguments.ValidateExcludeExceptArgs(); err != nil {
+		return Arguments{}, "", err
+	}
+
+	if err := arguments.ConfigureGlobalSettings(); err != nil {
+		return Arguments{}, "", err
+	}
+
+	return arguments, buf.String(), nil
+}
+
+// ToArgs returns the command line arguments that are parsed by ParseArgs into a copy of these arguments. Every flag
+// is included, so the values are not affected by any config file or environment variable when they are parsed.
+func (arguments Arguments) ToArgs() []string {
+	values := Arguments{}
+	flags := newFlagSet(&values)
+	// The flags are bound to the fields of values, so copying the arguments exposes them through the flags
+	values = arguments
+
+	args := []string{}
+	flags.VisitAll(func(f *flag.Flag) {
+		if slice, ok := f.Value.(*StringSliceArgs); ok {
+			for _, value := range *slice {
+				args = append(args, "-"+f.Name+"="+value)
+			}
+			return
+		}
+
+		args = append(args, "-"+f.Name+"="+f.Value.String())
+	})
+
+	return args
+}
+
+// ClearLocalArguments resets the arguments listed in LocalArgs, other than insecureTls, which remains the choice of
+// whoever runs the process. Remote callers can not set these arguments, but they may still have been read from the
+// config file or environment variables of the machine running the export.
+func (arguments *Arguments) ClearLocalArguments() {
+	arguments.ConfigFile = ""
+	arguments.ConfigPath = ""
+	arguments.Profiling = false
+	arguments.RecordSnapshot = ""
+	arguments.ReplaySnapshot = ""
+	arguments.SecretsFile = ""
+	arguments.SecretsEnvironmentPrefix = ""
+	arguments.Destination = ""
+	arguments.Console = false
+	arguments.Version = false
+}
+
+// Validate returns an error if the arguments include options that can not be used together.
+func (arguments *Arguments) Validate() error {
+	if arguments.RecordSnapshot != "" && arguments.ReplaySnapshot != "" {
+		return errors.New("recordSnapshot can not be used with replaySnapshot")
+	}
+
+	if arguments.RunbookName != "" && len(arguments.ProjectName) != 1 && len(arguments.ProjectId) == 1 {
+		return errors.New("runbookName requires either a single projectId or projectName to be set")
+	}
+
+	if arguments.Stateless {
+		if arguments.StepTemplateKey == "" {
+			return errors.New("stepTemplate requires stepTemplateKey to be defined (e.g. EKS, AKS, Lambda, WebApp)")
+		}
+
+		if arguments.StepTemplateName == "" {
+			return errors.New("stepTemplate requires stepTemplateName to be defined")
+		}
+	}
+
+	if !arguments.ExcludeCaCProjectSettings && arguments.ExcludeAllGitCredentials {
+		return errors.New("excludeAllGitCredentials requires excludeCaCProjectSettings to be true")
+	}
+
+	if arguments.LookupProjectDependencies && arguments.Stateless {
+		return errors.New("lookupProjectDependencies can not be used with stepTemplate")
+	}
+
+	if arguments.ExtractScripts && arguments.Stateless {
+		return errors.New("extractScripts can not be used with stepTemplate")
+	}
+
+	if arguments.OutputFormat != "" && arguments.OutputFormat != "hcl" && arguments.OutputFormat != "json" {
+		return errors.New("outputFormat must be either hcl or json")
+	}
+
+	if arguments.ExportTimestamp != "" {
+		if _, err := time.Parse(time.RFC3339, arguments.ExportTimestamp); err != nil {
+			return errors.New("exportTimestamp must be an RFC 3339 timestamp, like 2024-01-01T00:00:00Z")
+		}
+	}
+
+	return nil
+}
+
+// newFlagSet defines the octoterra flags, binding them to the fields of the arguments.
+func newFlagSet(arguments *Arguments) *flag.FlagSet {
+	flags := flag.NewFlagSet("octoterra", flag.ContinueOnError)
 
 	flags.StringVar(&arguments.ConfigFile, "configFile", "octoterra", "The name of the configuration file to use. Do not include the extension. Defaults to octoterra")
 	flags.StringVar(&arguments.ConfigPath, "configPath", ".", "The path of the configuration file to use. Defaults to the current directory")
 	flags.IntVar(&arguments.LimitAttributeLength, "limitAttributeLength", 0, "For internal use only. Limits the length of the attribute names.")
 	flags.IntVar(&arguments.LimitResourceCount, "limitResourceCount", 0, "For internal use only. Limits the number of resources of a given type that are returned. For example, a value of 30 will ensure the exported Terraform only includes up to 30 accounts, and up to 30 feeds, and up to 30 projects etc. This is used to reduce the output when octoterra is used to generate a context for an LLM. This limit is a guide and it is possible that more than the specified number of resources are returned due to multiple goroutines adding resources to the output.")
 	flags.BoolVar(&arguments.Profiling, "profiling", false, "Enable profiling. Run \"pprof -http=:8080 octoterra.prof\" to view the results.")
+	flags.StringVar(&arguments.RecordSnapshot, "recordSnapshot", "", "Save the responses from the Octopus API to a snapshot archive with the supplied file name. The snapshot can be used with the -replaySnapshot argument to repeat the export without access to the Octopus server.")
+	flags.StringVar(&arguments.ReplaySnapshot, "replaySnapshot", "", "Export the space from a snapshot archive created with the -recordSnapshot argument rather than querying the Octopus API.")
 	flags.BoolVar(&arguments.IgnoreCacErrors, "ignoreCacErrors", false, "Ignores errors that would arise when a project can not resolve configuration in a Git repo.")
 	flags.BoolVar(&arguments.IgnoreUnauthorized, "ignoreUnauthorized", false, "Ignores errors that would arise when a resources can not be accessed due to an unauthorized error.")
 	flags.BoolVar(&arguments.IgnoreServerError, "ignoreServerError", false, "Ignores errors that would arise when the server returns a 500 internal server error.")
@@ -278,6 +518,13 @@ func ParseArgs(args []string) (Arguments, string, error) {
 	flags.BoolVar(&arguments.IncludeIds, "includeIds", false, "For internal use only. Include the \"id\" field on generated resources. Note that this is almost always unnecessary and undesirable.")
 	flags.BoolVar(&arguments.IncludeSpaceInPopulation, "includeSpaceInPopulation", false, "For internal use only. Include the space resource in the space population script. Note that this is almost always unnecessary and undesirable, as the space resources are included in the space creation module.")
 	flags.BoolVar(&arguments.GenerateImportScripts, "generateImportScripts", false, "Generate Bash and Powershell scripts used to import resources into the Terraform state.")
+	flags.BoolVar(&arguments.GenerateImportBlocks, "generateImportBlocks", false, "Generate Terraform import blocks, using the IDs of the exported resources, to import resources into the Terraform state. This requires Terraform 1.5 or later.")
+	flags.StringVar(&arguments.OutputFormat, "outputFormat", "hcl", "The format of the exported Terraform configuration. Either \"hcl\" to write .tf files, or \"json\" to write the equivalent .tf.json files.")
+	flags.StringVar(&arguments.ExportTimestamp, "exportTimestamp", "", "An RFC 3339 timestamp, like 2024-01-01T00:00:00Z, used in place of the current time in exported files. This makes exports of the same resources identical.")
+	flags.BoolVar(&arguments.Checksums, "checksums", false, "Write the SHA-256 checksum of each exported file to the checksums.txt file. The file can be verified with \"sha256sum -c checksums.txt\".")
+	flags.IntVar(&arguments.RequestTimeout, "requestTimeout", 0, "The maximum number of seconds a single request to the Octopus API can take. Zero means no timeout.")
+	flags.IntVar(&arguments.ExportTimeout, "exportTimeout", 0, "The maximum number of seconds the export can take. Requests to the Octopus API stop once the timeout is exceeded. Zero means no timeout.")
+	flags.StringVar(&arguments.Explain, "explain", "", "The name or ID of a resource. The chain of references that caused the resource to be included in the export is printed and saved to explain.txt.")
 	flags.BoolVar(&arguments.InsecureTls, "insecureTls", false, "Ignore certificate errors when connecting to the Octopus server.")
 	flags.StringVar(&arguments.Url, "url", "", "The Octopus URL e.g. https://myinstance.octopus.app - this is also defined in the OCTOPUS_CLI_SERVER environment variable")
 	flags.StringVar(&arguments.Space, "space", "", "The Octopus space name or ID")
@@ -307,7 +554,10 @@ func ParseArgs(args []string) (Arguments, string, error) {
 	flags.BoolVar(&arguments.ExcludeCaCProjectSettings, "excludeCaCProjectSettings", false, "Pass this to exclude any Config-As-Code settings in the exported projects. Typically you set -ignoreCacManagedValues=false -excludeCaCProjectSettings=true to essentially \"convert\" a CaC project to a regular project. Values from the \"main\" or \"master\" branches will be used first, or just fall back to the first configured branch.")
 	flags.BoolVar(&arguments.DefaultSecretVariableValues, "defaultSecretVariableValues", false, "Pass this to set the default value of secret variables to the octostache template referencing the variable.")
 	flags.BoolVar(&arguments.DummySecretVariableValues, "dummySecretVariableValues", false, "Pass this to set the default value of secret variables, account secrets, feed credentials to a dummy value. This allows resources with secret values to be created without knowing the secrets, while still allowing the secret values to be specified if they are known. This option takes precedence over the defaultSecretVariableValues option.")
+	flags.StringVar(&arguments.SecretsFile, "secretsFile", "", "A JSON or YAML file mapping the names of sensitive Terraform variables to their values. The values are written to the secrets.auto.tfvars file.")
+	flags.StringVar(&arguments.SecretsEnvironmentPrefix, "secretsEnvironmentPrefix", "", "The prefix of environment variables holding the values of sensitive Terraform variables. For example, with the prefix OCTOTERRA_SECRET_, the variable account_aws is read from OCTOTERRA_SECRET_account_aws. The values are written to the secrets.auto.tfvars file.")
 	flags.BoolVar(&arguments.InlineVariableValues, "inlineVariableValues", false, "Inline the project and library variable set variable values rather than exposing their value as a Terraform variable. Secret variables will be inlined as dummy values. This option takes precedence over DummySecretVariableValues and DefaultSecretVariableValues.")
+	flags.BoolVar(&arguments.ExtractScripts, "extractScripts", false, "Write the inline scripts of steps, script modules and machine policy health checks to files in the scripts directory, and reference them with the file() or templatefile() functions.")
 	flags.StringVar(&arguments.BackendBlock, "terraformBackend", "", "Specifies the backend type to be added to the exported Terraform configuration.")
 	flags.StringVar(&arguments.ProviderVersion, "providerVersion", "", "Specifies the Octopus Terraform provider version.")
 	flags.StringVar(&arguments.OctopusManagedTerraformVars, "octopusManagedTerraformVars", "", "Specifies the name of an Octopus variable to be used as a template string in the body of the terraform.tfvars file. This allows Octopus to inject all the variables used by Terraform from a variable containing the contents of a terraform.tfvars file.")
@@ -433,6 +683,11 @@ func ParseArgs(args []string) (Arguments, string, error) {
 	flags.Var(&arguments.ExcludeDeploymentFreezesRegex, "excludeDeploymentFreezesRegex", "Exclude a deployment freezes from being exported.")
 	flags.Var(&arguments.ExcludeDeploymentFreezesExcept, "excludeDeploymentFreezesExcept", "All deployment freezes except those defined with excludeProjectsExcept are excluded.")
 
+	flags.BoolVar(&arguments.ExcludeAllTeams, "excludeAllTeams", false, "Exclude all teams from being exported.")
+	flags.Var(&arguments.ExcludeTeams, "excludeTeams", "Exclude a team from being exported.")
+	flags.Var(&arguments.ExcludeTeamsRegex, "excludeTeamsRegex", "Exclude a team from being exported based on regex match.")
+	flags.Var(&arguments.ExcludeTeamsExcept, "excludeTeamsExcept", "All teams except those defined with excludeTeamsExcept are excluded.")
+
 	flags.BoolVar(&arguments.ExcludeProvider, "excludeProvider", false, "Exclude the provider from the exported Terraform configuration files. This is useful when you want to use a parent module to define the backend, as the parent module must define the provider.")
 	flags.BoolVar(&arguments.IncludeProviderServerDetails, "includeProviderServerDetails", true, "Define the server UL and API keys as variables passed to the provider. Set this to false to use the OCTOPUS_ACCESS_TOKEN, OCTOPUS_URL, and OCTOPUS_APIKEY environment variables to configure the provider.")
 
@@ -450,36 +705,7 @@ func ParseArgs(args []string) (Arguments, string, error) {
 	flags.Var(&arguments.ExcludeStepTemplatesRegex, "excludeStepTemplatesRegex", "Exclude step templates from being exported based on regex match.")
 	flags.Var(&arguments.ExcludeStepTemplatesExcept, "excludeStepTemplatesExcept", "Exclude all step templates except for those defined in this list. The step templates in excludeStepTemplates take precedence, so a step template defined here and in excludeStepTemplates is excluded.")
 
-	err := flags.Parse(args)
-
-	if err != nil {
-		return Arguments{}, buf.String(), err
-	}
-
-	err = overrideArgs(flags, arguments.ConfigPath, arguments.ConfigFile)
-
-	if err != nil {
-		zap.L().Error("Error overriding arguments with config file values", zap.Error(err))
-		return Arguments{}, buf.String(), err
-	}
-
-	if arguments.Url == "" {
-		arguments.Url = os.Getenv("OCTOPUS_CLI_SERVER")
-	}
-
-	if arguments.ApiKey == "" && arguments.AccessToken == "" {
-		arguments.ApiKey = os.Getenv("OCTOPUS_CLI_API_KEY")
-	}
-
-	if err := arguments.ValidateExcludeExceptArgs(); err != nil {
-		return Arguments{}, "", err
-	}
-
-	if err := arguments.ConfigureGlobalSettings(); err != nil {
-		return Arguments{}, "", err
-	}
-
-	return arguments, buf.String(), nil
+	return flags
 }
 
 func (arguments *Arguments) ConfigureGlobalSettings() error {
@@ -497,6 +723,11 @@ func (arguments *Arguments) ValidateExcludeExceptArgs() (funcErr error) {
 		return
 	}
 
+	// There is no Octopus server to validate the arguments against when replaying a snapshot
+	if arguments.ReplaySnapshot != "" {
+		return
+	}
+
 	octopusClient := client.OctopusApiClient{
 		Url:                     arguments.Url,
 		ApiKey:                  arguments.ApiKey,
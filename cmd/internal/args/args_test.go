@@ -1,6 +1,9 @@
 package args
 
 import (
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -231,3 +234,98 @@ func TestParseFlagsCorrect(t *testing.T) {
 		t.Fatalf("exclude library variable sets except should have been set")
 	}
 }
+
+func TestToArgsRoundTrip(t *testing.T) {
+	original := Arguments{
+		Url:                          "https://example.org",
+		Space:                        "Spaces-1",
+		ApiKey:                       "API-xxxx",
+		ProjectName:                  StringSliceArgs{"First", "Second"},
+		ExcludeAllTargets:            true,
+		IncludeProviderServerDetails: false,
+		LimitResourceCount:           30,
+		OutputFormat:                 "json",
+	}
+
+	parsed, _, err := ParseArgs(original.ToArgs())
+
+	if err != nil {
+		t.Fatalf("Should not have returned an error: %v", err)
+	}
+
+	if !reflect.DeepEqual(original, parsed) {
+		t.Fatalf("The parsed arguments did not match the original arguments:\n%v\n%v", original, parsed)
+	}
+}
+
+func TestValidateExportTimestamp(t *testing.T) {
+	if err := (&Arguments{ExportTimestamp: "2024-01-01T00:00:00Z"}).Validate(); err != nil {
+		t.Fatalf("Should not have returned an error: %v", err)
+	}
+
+	if err := (&Arguments{ExportTimestamp: "yesterday"}).Validate(); err == nil {
+		t.Fatal("Should have returned an error for a timestamp that is not in the RFC 3339 format")
+	}
+}
+
+func TestArgumentsAreClassified(t *testing.T) {
+	argumentsType := reflect.TypeOf(Arguments{})
+
+	for i := 0; i < argumentsType.NumField(); i++ {
+		name := strings.Split(argumentsType.Field(i).Tag.Get("json"), ",")[0]
+
+		if name == "" || name == "-" {
+			continue
+		}
+
+		sensitive := slices.Contains(SensitiveArgs, name)
+		local := slices.Contains(LocalArgs, name)
+
+		if (sensitive && local) || (!sensitive && !local && !IsRemoteArg(name)) {
+			t.Fatalf("the argument %s must be listed in exactly one of SensitiveArgs, LocalArgs or RemoteArgs", name)
+		}
+
+		if (sensitive || local) && IsRemoteArg(name) {
+			t.Fatalf("the argument %s must not be accepted from remote callers", name)
+		}
+	}
+}
+
+func TestDefaultArguments(t *testing.T) {
+	t.Setenv("OCTOPUS_CLI_SERVER", "https://example.org")
+	t.Setenv("OCTOPUS_CLI_API_KEY", "API-xxxx")
+
+	arguments := DefaultArguments()
+
+	if arguments.OutputFormat != "hcl" || !arguments.IgnoreCacManagedValues || !arguments.IncludeProviderServerDetails ||
+		!arguments.IncludeOctopusOutputVars {
+		t.Fatalf("expected the default flag values, got %v", arguments)
+	}
+
+	if arguments.Url != "" || arguments.ApiKey != "" {
+		t.Fatal("expected the environment variables to be ignored")
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	t.Setenv("OCTOPUS_CLI_SERVER", "https://example.org")
+	t.Setenv("OCTOTERRA_EXCLUDEALLTENANTS", "true")
+
+	arguments, _, err := ParseFlags([]string{"-excludeAllTargets"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !arguments.ExcludeAllTargets || arguments.OutputFormat != "hcl" {
+		t.Fatalf("expected the flag on top of the default values, got %v", arguments)
+	}
+
+	if arguments.Url != "" || arguments.ExcludeAllTenants {
+		t.Fatal("expected the environment variables to be ignored")
+	}
+
+	if _, usage, err := ParseFlags([]string{"-notAFlag"}); err == nil || usage == "" {
+		t.Fatal("expected an error and the usage for an unknown flag")
+	}
+}
@@ -50,7 +50,11 @@ func (c *BatchingOctopusApiClient[T]) GetAllResourcesBatch(done <-chan struct{},
 			err := c.Client.GetAllResources(resourceType, collection, []string{"take", fmt.Sprint(pageSize)}, []string{"skip", fmt.Sprint(skip)})
 
 			if err != nil {
-				chnl <- ResultError[T]{Res: *new(T), Err: err}
+				// The consumer may have stopped reading, for example when the export was cancelled
+				select {
+				case <-done:
+				case chnl <- ResultError[T]{Res: *new(T), Err: err}:
+				}
 				break
 			}
 
@@ -96,7 +100,11 @@ func (c *BatchingOctopusApiClient[T]) GetAllResourcesBatchWithQueryParams(done <
 			err := c.Client.GetAllResources(versionedResourceType, collection, queryParams...)
 
 			if err != nil {
-				chnl <- ResultError[T]{Res: *new(T), Err: err}
+				// The consumer may have stopped reading, for example when the export was cancelled
+				select {
+				case <-done:
+				case chnl <- ResultError[T]{Res: *new(T), Err: err}:
+				}
 				break
 			}
 
@@ -136,7 +144,11 @@ func (c *BatchingOctopusApiClient[T]) GetAllResourcesArrayBatch(done <-chan stru
 			err := c.Client.GetAllResources(resourceType, collection, []string{"take", fmt.Sprint(pageSize)}, []string{"skip", fmt.Sprint(skip)})
 
 			if err != nil {
-				chnl <- ResultError[T]{Res: *new(T), Err: err}
+				// The consumer may have stopped reading, for example when the export was cancelled
+				select {
+				case <-done:
+				case chnl <- ResultError[T]{Res: *new(T), Err: err}:
+				}
 				break
 			}
 
@@ -178,7 +190,11 @@ func (c *BatchingOctopusApiClient[T]) GetAllGlobalResourcesBatch(done <-chan str
 			err := c.Client.GetAllGlobalResources(resourceType, collection, []string{"take", fmt.Sprint(pageSize)}, []string{"skip", fmt.Sprint(skip)})
 
 			if err != nil {
-				chnl <- ResultError[T]{Res: *new(T), Err: err}
+				// The consumer may have stopped reading, for example when the export was cancelled
+				select {
+				case <-done:
+				case chnl <- ResultError[T]{Res: *new(T), Err: err}:
+				}
 				break
 			}
 
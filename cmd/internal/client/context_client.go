@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+)
+
+// ContextOctopusClient wraps another client, returning the context error from every request once the context is
+// done. This stops an export that was cancelled or exceeded its deadline from making further requests, regardless
+// of how the wrapped client accesses the Octopus API.
+type ContextOctopusClient struct {
+	Client  OctopusClient
+	Context context.Context
+}
+
+func (c *ContextOctopusClient) GetSpaceBaseUrl() (string, error) {
+	if err := c.Context.Err(); err != nil {
+		return "", err
+	}
+	return c.Client.GetSpaceBaseUrl()
+}
+
+func (c *ContextOctopusClient) GetSpace(resources *octopus.Space) error {
+	if err := c.Context.Err(); err != nil {
+		return err
+	}
+	return c.Client.GetSpace(resources)
+}
+
+func (c *ContextOctopusClient) GetSpaces() ([]octopus.Space, error) {
+	if err := c.Context.Err(); err != nil {
+		return nil, err
+	}
+	return c.Client.GetSpaces()
+}
+
+func (c *ContextOctopusClient) EnsureSpaceDeleted(spaceId string) (deleted bool, funcErr error) {
+	if err := c.Context.Err(); err != nil {
+		return false, err
+	}
+	return c.Client.EnsureSpaceDeleted(spaceId)
+}
+
+func (c *ContextOctopusClient) GetResource(resourceType string, resources any) (exists bool, funcErr error) {
+	if err := c.Context.Err(); err != nil {
+		return false, err
+	}
+	return c.Client.GetResource(resourceType, resources)
+}
+
+func (c *ContextOctopusClient) GetResourceById(resourceType string, id string, resources any) (funcErr error) {
+	if err := c.Context.Err(); err != nil {
+		return err
+	}
+	return c.Client.GetResourceById(resourceType, id, resources)
+}
+
+func (c *ContextOctopusClient) GetResourceByName(resourceType string, name string, resources any) (exists bool, funcErr error) {
+	if err := c.Context.Err(); err != nil {
+		return false, err
+	}
+	return c.Client.GetResourceByName(resourceType, name, resources)
+}
+
+func (c *ContextOctopusClient) GetSpaceResourceById(resourceType string, id string, resources any) (exists bool, funcErr error) {
+	if err := c.Context.Err(); err != nil {
+		return false, err
+	}
+	return c.Client.GetSpaceResourceById(resourceType, id, resources)
+}
+
+func (c *ContextOctopusClient) GetGlobalResourceById(resourceType string, id string, resources any) (exists bool, funcErr error) {
+	if err := c.Context.Err(); err != nil {
+		return false, err
+	}
+	return c.Client.GetGlobalResourceById(resourceType, id, resources)
+}
+
+func (c *ContextOctopusClient) GetResourceNameById(resourceType string, id string) (name string, funcErr error) {
+	if err := c.Context.Err(); err != nil {
+		return "", err
+	}
+	return c.Client.GetResourceNameById(resourceType, id)
+}
+
+func (c *ContextOctopusClient) GetResourceNamesByIds(resourceType string, ids []string) (names []string, funcErr error) {
+	if err := c.Context.Err(); err != nil {
+		return nil, err
+	}
+	return c.Client.GetResourceNamesByIds(resourceType, ids)
+}
+
+func (c *ContextOctopusClient) GetAllResources(resourceType string, resources any, queryParams ...[]string) (funcErr error) {
+	if err := c.Context.Err(); err != nil {
+		return err
+	}
+	return c.Client.GetAllResources(resourceType, resources, queryParams...)
+}
+
+func (c *ContextOctopusClient) GetAllGlobalResources(resourceType string, resources any, queryParams ...[]string) (funcErr error) {
+	if err := c.Context.Err(); err != nil {
+		return err
+	}
+	return c.Client.GetAllGlobalResources(resourceType, resources, queryParams...)
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestContextClientStopsRequests(t *testing.T) {
+	snapshot := NewSnapshot("Spaces-1")
+
+	snapshot.Record(snapshotKey("GetResourceById", "Projects", "Projects-1"), false, nil, json.RawMessage(`{"Id":"Projects-1"}`))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	contextClient := ContextOctopusClient{Client: &ReplayOctopusClient{Snapshot: snapshot}, Context: ctx}
+
+	if err := contextClient.GetResourceById("Projects", "Projects-1", &map[string]any{}); err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+
+	if err := contextClient.GetResourceById("Projects", "Projects-1", &map[string]any{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Requests made after the context is cancelled must return the context error, got %v", err)
+	}
+}
+
+func TestRetriesStopWhenContextIsDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	apiClient := OctopusApiClient{Url: server.URL, Context: ctx}
+
+	req, err := http.NewRequestWithContext(apiClient.getContext(), http.MethodGet, server.URL, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	_, err = apiClient.doRequest(req)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the deadline to be exceeded, got %v", err)
+	}
+
+	if time.Since(start) > 10*time.Second {
+		t.Fatalf("The retry delay must not be waited for once the context is done")
+	}
+}
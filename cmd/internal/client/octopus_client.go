@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -71,6 +72,27 @@ type OctopusApiClient struct {
 	// ApiVersion is an optional version string (e.g. "v2") appended to the resource type path.
 	// When set, the API path becomes "{resourceType}/{ApiVersion}" instead of just "{resourceType}".
 	ApiVersion string
+	// Context cancels any in progress requests and retries when it is done. Defaults to context.Background().
+	Context context.Context
+	// RequestTimeout is the maximum time a single request can take, including reading the response body.
+	// Zero means no timeout.
+	RequestTimeout time.Duration
+}
+
+func (o *OctopusApiClient) getContext() context.Context {
+	if o.Context == nil {
+		return context.Background()
+	}
+
+	return o.Context
+}
+
+func (o *OctopusApiClient) getHttpClient() *http.Client {
+	if o.RequestTimeout == 0 {
+		return http.DefaultClient
+	}
+
+	return &http.Client{Timeout: o.RequestTimeout}
 }
 
 func (o *OctopusApiClient) buildUserAgent() string {
@@ -117,7 +139,7 @@ func (o *OctopusApiClient) lookupSpaceAsId() (bool, error) {
 	}
 
 	requestURL := fmt.Sprintf("%s/api/Spaces/%s", baseUrl, o.Space)
-	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	req, err := http.NewRequestWithContext(o.getContext(), http.MethodGet, requestURL, nil)
 
 	if err != nil {
 		return false, err
@@ -172,10 +194,11 @@ const (
 
 // doRequest executes the supplied request, retrying when the server responds with a 429 (Too Many
 // Requests). When present, the Retry-After header is honoured to determine how long to sleep before
-// retrying. All callers should use this method instead of http.DefaultClient.Do directly.
+// retrying. All callers should use this method instead of http.DefaultClient.Do directly. The retries stop
+// when the context of the request is done.
 func (o *OctopusApiClient) doRequest(req *http.Request) (*http.Response, error) {
 	for attempt := 0; ; attempt++ {
-		res, err := http.DefaultClient.Do(req)
+		res, err := o.getHttpClient().Do(req)
 
 		if err != nil {
 			return nil, err
@@ -194,7 +217,11 @@ func (o *OctopusApiClient) doRequest(req *http.Request) (*http.Response, error)
 		zap.L().Info(fmt.Sprintf("Received 429 Too Many Requests for %s, sleeping %s before retry %d/%d",
 			req.URL.String(), delay, attempt+1, maxRetryAttempts))
 
-		time.Sleep(delay)
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
 
 		// Reset the request body for the retry if one was provided.
 		if req.GetBody != nil {
@@ -246,7 +273,7 @@ func (o *OctopusApiClient) lookupSpaceAsName() (spaceName string, funcErr error)
 
 	requestURL := fmt.Sprintf("%s/api/Spaces?take=1000&partialName=%s", baseUrl, strictQueryEscape(o.Space))
 
-	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	req, err := http.NewRequestWithContext(o.getContext(), http.MethodGet, requestURL, nil)
 
 	if err != nil {
 		return "", err
@@ -365,7 +392,7 @@ func (o *OctopusApiClient) GetSpaceBaseUrl() (string, error) {
 		}
 
 		return "", errors.New("GetSpaceBaseUrl did not find space with name or id '" + o.Space + "'")
-	}, retry.Attempts(3), retry.Delay(1*time.Second))
+	}, retry.Attempts(3), retry.Delay(1*time.Second), retry.Context(o.getContext()))
 }
 
 func (o *OctopusApiClient) getSpaceRequest() (*http.Request, error) {
@@ -375,7 +402,7 @@ func (o *OctopusApiClient) getSpaceRequest() (*http.Request, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodGet, spaceUrl, nil)
+	req, err := http.NewRequestWithContext(o.getContext(), http.MethodGet, spaceUrl, nil)
 
 	if err != nil {
 		return nil, err
@@ -403,7 +430,7 @@ func (o *OctopusApiClient) getRequest(resourceType string, id string, global boo
 
 	requestURL := spaceUrl + "/" + resourceType + "/" + id
 
-	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	req, err := http.NewRequestWithContext(o.getContext(), http.MethodGet, requestURL, nil)
 
 	if err != nil {
 		return nil, err
@@ -447,7 +474,7 @@ func (o *OctopusApiClient) getCollectionRequest(resourceType string, queryParams
 
 	requestURL.RawQuery = strictQueryEncode(params)
 
-	req, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
+	req, err := http.NewRequestWithContext(o.getContext(), http.MethodGet, requestURL.String(), nil)
 
 	if err != nil {
 		return nil, err
@@ -491,7 +518,7 @@ func (o *OctopusApiClient) getGlobalCollectionRequest(resourceType string, query
 
 	requestURL.RawQuery = strictQueryEncode(params)
 
-	req, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
+	req, err := http.NewRequestWithContext(o.getContext(), http.MethodGet, requestURL.String(), nil)
 
 	if err != nil {
 		return nil, err
@@ -550,7 +577,7 @@ func (o *OctopusApiClient) GetSpaces() (spaces []octopus.Space, funcErr error) {
 
 	requestURL := fmt.Sprintf("%s/api/Spaces", baseUrl)
 
-	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	req, err := http.NewRequestWithContext(o.getContext(), http.MethodGet, requestURL, nil)
 
 	if err != nil {
 		return nil, err
@@ -598,7 +625,7 @@ func (o *OctopusApiClient) EnsureSpaceDeleted(spaceId string) (deleted bool, fun
 
 	// Get the details of the space
 	space, err := func() (*octopus.Space, error) {
-		getReq, err := http.NewRequest(http.MethodGet, requestURL, nil)
+		getReq, err := http.NewRequestWithContext(o.getContext(), http.MethodGet, requestURL, nil)
 
 		if err != nil {
 			return nil, err
@@ -658,7 +685,7 @@ func (o *OctopusApiClient) EnsureSpaceDeleted(spaceId string) (deleted bool, fun
 			return err
 		}
 
-		putReq, err := http.NewRequest(http.MethodPut, requestURL, bytes.NewReader(spaceJson))
+		putReq, err := http.NewRequestWithContext(o.getContext(), http.MethodPut, requestURL, bytes.NewReader(spaceJson))
 
 		if err != nil {
 			return err
@@ -695,7 +722,7 @@ func (o *OctopusApiClient) EnsureSpaceDeleted(spaceId string) (deleted bool, fun
 
 	// Delete the space
 	err = func() error {
-		req, err := http.NewRequest(http.MethodDelete, requestURL, nil)
+		req, err := http.NewRequestWithContext(o.getContext(), http.MethodDelete, requestURL, nil)
 
 		if err != nil {
 			return err
@@ -749,7 +776,7 @@ func (o *OctopusApiClient) GetResource(resourceType string, resources any) (exis
 
 	requestURL := spaceUrl + "/" + resourceType
 
-	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	req, err := http.NewRequestWithContext(o.getContext(), http.MethodGet, requestURL, nil)
 
 	if err != nil {
 		return false, err
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+)
+
+// RecordingOctopusClient wraps another client and records every response in a Snapshot. The snapshot can
+// then be saved and used by the ReplayOctopusClient to repeat an export without access to the Octopus server.
+type RecordingOctopusClient struct {
+	Client   OctopusClient
+	Snapshot *Snapshot
+}
+
+// recordBody passes a json.RawMessage to the wrapped client, so the body is recorded exactly as the Octopus API
+// returned it, including any fields the caller's object does not define. The body is then unmarshalled into the
+// caller's object.
+func (c *RecordingOctopusClient) recordBody(key string, resources any, request func(body *json.RawMessage) (bool, error)) (bool, error) {
+	body := json.RawMessage{}
+	exists, err := request(&body)
+
+	c.Snapshot.Record(key, exists, err, body)
+
+	if err != nil {
+		return exists, err
+	}
+
+	if len(body) != 0 {
+		if err := json.Unmarshal(body, resources); err != nil {
+			return exists, fmt.Errorf("failed to unmarshal the response for %s: %w", key, err)
+		}
+	}
+
+	return exists, nil
+}
+
+// recordValue records a value returned by the wrapped client.
+func (c *RecordingOctopusClient) recordValue(key string, err error, value any) error {
+	if err != nil {
+		c.Snapshot.Record(key, false, err, nil)
+		return err
+	}
+
+	response, marshalErr := json.Marshal(value)
+
+	if marshalErr != nil {
+		return fmt.Errorf("failed to serialize the response for %s: %w", key, marshalErr)
+	}
+
+	c.Snapshot.Record(key, false, nil, response)
+
+	return nil
+}
+
+func (c *RecordingOctopusClient) GetSpaceBaseUrl() (string, error) {
+	url, err := c.Client.GetSpaceBaseUrl()
+	return url, c.recordValue(snapshotKey("GetSpaceBaseUrl"), err, url)
+}
+
+func (c *RecordingOctopusClient) GetSpace(resources *octopus.Space) error {
+	err := c.Client.GetSpace(resources)
+	return c.recordValue(snapshotKey("GetSpace"), err, resources)
+}
+
+func (c *RecordingOctopusClient) GetSpaces() ([]octopus.Space, error) {
+	spaces, err := c.Client.GetSpaces()
+	return spaces, c.recordValue(snapshotKey("GetSpaces"), err, spaces)
+}
+
+// EnsureSpaceDeleted modifies the Octopus server, so the response is not recorded.
+func (c *RecordingOctopusClient) EnsureSpaceDeleted(spaceId string) (deleted bool, funcErr error) {
+	return c.Client.EnsureSpaceDeleted(spaceId)
+}
+
+func (c *RecordingOctopusClient) GetResource(resourceType string, resources any) (exists bool, funcErr error) {
+	return c.recordBody(snapshotKey("GetResource", resourceType), resources, func(body *json.RawMessage) (bool, error) {
+		return c.Client.GetResource(resourceType, body)
+	})
+}
+
+func (c *RecordingOctopusClient) GetResourceById(resourceType string, id string, resources any) (funcErr error) {
+	_, err := c.recordBody(snapshotKey("GetResourceById", resourceType, id), resources, func(body *json.RawMessage) (bool, error) {
+		return false, c.Client.GetResourceById(resourceType, id, body)
+	})
+	return err
+}
+
+func (c *RecordingOctopusClient) GetResourceByName(resourceType string, name string, resources any) (exists bool, funcErr error) {
+	return c.recordBody(snapshotKey("GetResourceByName", resourceType, name), resources, func(body *json.RawMessage) (bool, error) {
+		return c.Client.GetResourceByName(resourceType, name, body)
+	})
+}
+
+func (c *RecordingOctopusClient) GetSpaceResourceById(resourceType string, id string, resources any) (exists bool, funcErr error) {
+	return c.recordBody(snapshotKey("GetSpaceResourceById", resourceType, id), resources, func(body *json.RawMessage) (bool, error) {
+		return c.Client.GetSpaceResourceById(resourceType, id, body)
+	})
+}
+
+func (c *RecordingOctopusClient) GetGlobalResourceById(resourceType string, id string, resources any) (exists bool, funcErr error) {
+	return c.recordBody(snapshotKey("GetGlobalResourceById", resourceType, id), resources, func(body *json.RawMessage) (bool, error) {
+		return c.Client.GetGlobalResourceById(resourceType, id, body)
+	})
+}
+
+func (c *RecordingOctopusClient) GetResourceNameById(resourceType string, id string) (name string, funcErr error) {
+	name, err := c.Client.GetResourceNameById(resourceType, id)
+	return name, c.recordValue(snapshotKey("GetResourceNameById", resourceType, id), err, name)
+}
+
+func (c *RecordingOctopusClient) GetResourceNamesByIds(resourceType string, ids []string) (names []string, funcErr error) {
+	names, err := c.Client.GetResourceNamesByIds(resourceType, ids)
+	return names, c.recordValue(snapshotKey("GetResourceNamesByIds", append([]string{resourceType}, ids...)...), err, names)
+}
+
+func (c *RecordingOctopusClient) GetAllResources(resourceType string, resources any, queryParams ...[]string) (funcErr error) {
+	_, err := c.recordBody(snapshotKey("GetAllResources", resourceType, queryParamsToString(queryParams)), resources, func(body *json.RawMessage) (bool, error) {
+		return false, c.Client.GetAllResources(resourceType, body, queryParams...)
+	})
+	return err
+}
+
+func (c *RecordingOctopusClient) GetAllGlobalResources(resourceType string, resources any, queryParams ...[]string) (funcErr error) {
+	_, err := c.recordBody(snapshotKey("GetAllGlobalResources", resourceType, queryParamsToString(queryParams)), resources, func(body *json.RawMessage) (bool, error) {
+		return false, c.Client.GetAllGlobalResources(resourceType, body, queryParams...)
+	})
+	return err
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+)
+
+// ReplayOctopusClient serves responses from a Snapshot captured by the RecordingOctopusClient. It makes no
+// network requests, so an export can be repeated with different arguments without credentials or access to the
+// Octopus server. Any request that was not recorded returns an error.
+type ReplayOctopusClient struct {
+	Snapshot *Snapshot
+}
+
+// replay unmarshals the recorded response into the supplied object, returning the recorded exists flag.
+func (c *ReplayOctopusClient) replay(key string, resources any) (bool, error) {
+	entry, ok := c.Snapshot.Lookup(key)
+
+	if !ok {
+		return false, fmt.Errorf("the request %s was not recorded in the snapshot", key)
+	}
+
+	if entry.Error != "" {
+		return entry.Exists, errors.New(entry.Error)
+	}
+
+	if len(entry.Response) != 0 && resources != nil {
+		if err := json.Unmarshal(entry.Response, resources); err != nil {
+			return false, fmt.Errorf("failed to unmarshal the recorded response for %s: %w", key, err)
+		}
+	}
+
+	return entry.Exists, nil
+}
+
+func (c *ReplayOctopusClient) GetSpaceBaseUrl() (string, error) {
+	url := ""
+	_, err := c.replay(snapshotKey("GetSpaceBaseUrl"), &url)
+	return url, err
+}
+
+func (c *ReplayOctopusClient) GetSpace(resources *octopus.Space) error {
+	_, err := c.replay(snapshotKey("GetSpace"), resources)
+	return err
+}
+
+func (c *ReplayOctopusClient) GetSpaces() ([]octopus.Space, error) {
+	spaces := []octopus.Space{}
+	_, err := c.replay(snapshotKey("GetSpaces"), &spaces)
+	return spaces, err
+}
+
+func (c *ReplayOctopusClient) EnsureSpaceDeleted(spaceId string) (deleted bool, funcErr error) {
+	return false, errors.New("spaces can not be deleted when replaying a snapshot")
+}
+
+func (c *ReplayOctopusClient) GetResource(resourceType string, resources any) (exists bool, funcErr error) {
+	return c.replay(snapshotKey("GetResource", resourceType), resources)
+}
+
+func (c *ReplayOctopusClient) GetResourceById(resourceType string, id string, resources any) (funcErr error) {
+	_, err := c.replay(snapshotKey("GetResourceById", resourceType, id), resources)
+	return err
+}
+
+func (c *ReplayOctopusClient) GetResourceByName(resourceType string, name string, resources any) (exists bool, funcErr error) {
+	return c.replay(snapshotKey("GetResourceByName", resourceType, name), resources)
+}
+
+func (c *ReplayOctopusClient) GetSpaceResourceById(resourceType string, id string, resources any) (exists bool, funcErr error) {
+	return c.replay(snapshotKey("GetSpaceResourceById", resourceType, id), resources)
+}
+
+func (c *ReplayOctopusClient) GetGlobalResourceById(resourceType string, id string, resources any) (exists bool, funcErr error) {
+	return c.replay(snapshotKey("GetGlobalResourceById", resourceType, id), resources)
+}
+
+func (c *ReplayOctopusClient) GetResourceNameById(resourceType string, id string) (name string, funcErr error) {
+	_, err := c.replay(snapshotKey("GetResourceNameById", resourceType, id), &name)
+	return name, err
+}
+
+func (c *ReplayOctopusClient) GetResourceNamesByIds(resourceType string, ids []string) (names []string, funcErr error) {
+	_, err := c.replay(snapshotKey("GetResourceNamesByIds", append([]string{resourceType}, ids...)...), &names)
+	return names, err
+}
+
+func (c *ReplayOctopusClient) GetAllResources(resourceType string, resources any, queryParams ...[]string) (funcErr error) {
+	_, err := c.replay(snapshotKey("GetAllResources", resourceType, queryParamsToString(queryParams)), resources)
+	return err
+}
+
+func (c *ReplayOctopusClient) GetAllGlobalResources(resourceType string, resources any, queryParams ...[]string) (funcErr error) {
+	_, err := c.replay(snapshotKey("GetAllGlobalResources", resourceType, queryParamsToString(queryParams)), resources)
+	return err
+}
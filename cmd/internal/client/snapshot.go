@@ -0,0 +1,231 @@
+package client
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const snapshotManifestFile = "manifest.json"
+const snapshotResponseDir = "responses/"
+const snapshotFormatVersion = 1
+
+// SnapshotManifest describes the contents of a snapshot archive.
+type SnapshotManifest struct {
+	FormatVersion int
+	Space         string
+	Entries       int
+}
+
+// SnapshotEntry is a single response captured from the Octopus API.
+type SnapshotEntry struct {
+	// Key uniquely identifies the client method and the arguments that were passed to it
+	Key string
+	// Exists captures the boolean returned by methods like GetResource and GetSpaceResourceById
+	Exists bool
+	// Error captures the message of any error returned by the client
+	Error string
+	// Response is the body returned by the Octopus API. Methods that return typed values rather than
+	// unmarshalling into the caller's object, like GetSpace and GetResourceNameById, record the JSON
+	// representation of the value.
+	Response json.RawMessage
+}
+
+// Snapshot is a collection of responses from the Octopus API. Snapshots are captured by the
+// RecordingOctopusClient, saved to a zip archive, and then served by the ReplayOctopusClient.
+type Snapshot struct {
+	Space   string
+	entries map[string]SnapshotEntry
+	mu      sync.Mutex
+}
+
+// NewSnapshot creates an empty snapshot.
+func NewSnapshot(space string) *Snapshot {
+	return &Snapshot{
+		Space:   space,
+		entries: map[string]SnapshotEntry{},
+	}
+}
+
+// LoadSnapshot reads a snapshot from a zip archive.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	archive, err := zip.OpenReader(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the snapshot archive %s: %w", path, err)
+	}
+
+	defer archive.Close()
+
+	snapshot := NewSnapshot("")
+
+	for _, file := range archive.File {
+		if file.Name == snapshotManifestFile {
+			manifest := SnapshotManifest{}
+			if err := readZipJson(file, &manifest); err != nil {
+				return nil, err
+			}
+
+			if manifest.FormatVersion != snapshotFormatVersion {
+				return nil, fmt.Errorf("the snapshot archive %s has an unsupported format version %d", path, manifest.FormatVersion)
+			}
+
+			snapshot.Space = manifest.Space
+		} else if strings.HasPrefix(file.Name, snapshotResponseDir) {
+			entry := SnapshotEntry{}
+			if err := readZipJson(file, &entry); err != nil {
+				return nil, err
+			}
+
+			snapshot.entries[entry.Key] = entry
+		}
+	}
+
+	return snapshot, nil
+}
+
+// Save writes the snapshot to a zip archive. Each response is saved in its own file, sorted by key,
+// so the archive contents are consistent between recordings of the same space.
+func (s *Snapshot) Save(path string) (funcErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.Create(path)
+
+	if err != nil {
+		return fmt.Errorf("failed to create the snapshot archive %s: %w", path, err)
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			funcErr = errors.Join(funcErr, err)
+		}
+	}()
+
+	archive := zip.NewWriter(file)
+
+	if err := writeZipJson(archive, snapshotManifestFile, SnapshotManifest{
+		FormatVersion: snapshotFormatVersion,
+		Space:         s.Space,
+		Entries:       len(s.entries),
+	}); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(s.entries))
+	for key := range s.entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := writeZipJson(archive, snapshotResponseDir+hashKey(key)+".json", s.entries[key]); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// Record captures a response. The response of an error is not recorded.
+func (s *Snapshot) Record(key string, exists bool, clientErr error, response json.RawMessage) {
+	entry := SnapshotEntry{
+		Key:    key,
+		Exists: exists,
+	}
+
+	if clientErr != nil {
+		entry.Error = clientErr.Error()
+	} else {
+		entry.Response = response
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = entry
+}
+
+// Lookup returns the response recorded for the key.
+func (s *Snapshot) Lookup(key string) (SnapshotEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	return entry, ok
+}
+
+// Len returns the number of recorded responses.
+func (s *Snapshot) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.entries)
+}
+
+// snapshotKey builds a key from the client method and its arguments.
+func snapshotKey(method string, args ...string) string {
+	return method + "|" + strings.Join(args, "|")
+}
+
+// queryParamsToString converts the query params passed to the client into a string used in a snapshot key.
+func queryParamsToString(queryParams [][]string) string {
+	params := make([]string, 0, len(queryParams))
+	for _, param := range queryParams {
+		params = append(params, strings.Join(param, "="))
+	}
+
+	return strings.Join(params, "&")
+}
+
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func readZipJson(file *zip.File, target any) (funcErr error) {
+	reader, err := file.Open()
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := reader.Close(); err != nil {
+			funcErr = errors.Join(funcErr, err)
+		}
+	}()
+
+	content, err := io.ReadAll(reader)
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(content, target)
+}
+
+func writeZipJson(archive *zip.Writer, name string, source any) error {
+	writer, err := archive.Create(name)
+
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(source, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(content)
+	return err
+}
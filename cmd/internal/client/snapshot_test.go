@@ -0,0 +1,96 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	snapshot := NewSnapshot("Spaces-1")
+
+	snapshot.Record(snapshotKey("GetAllResources", "Projects", queryParamsToString([][]string{{"take", "30"}, {"skip", "0"}})), false, nil,
+		json.RawMessage(`{"ItemType":"Project","TotalResults":1,"Items":[{"Id":"Projects-1","Name":"My Project"}]}`))
+	snapshot.Record(snapshotKey("GetSpaceResourceById", "Projects", "Projects-2"), false, errors.New("not found"), nil)
+
+	path := filepath.Join(t.TempDir(), "snapshot.zip")
+
+	if err := snapshot.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadSnapshot(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.Space != "Spaces-1" || loaded.Len() != 2 {
+		t.Fatalf("The snapshot was not loaded correctly")
+	}
+
+	replayClient := ReplayOctopusClient{Snapshot: loaded}
+
+	replayedProjects := octopus.GeneralCollection[octopus.Project]{}
+	if err := replayClient.GetAllResources("Projects", &replayedProjects, []string{"take", "30"}, []string{"skip", "0"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(replayedProjects.Items) != 1 || replayedProjects.Items[0].Id != "Projects-1" || replayedProjects.Items[0].Name != "My Project" {
+		t.Fatalf("The projects were not replayed correctly")
+	}
+
+	if _, err := replayClient.GetSpaceResourceById("Projects", "Projects-2", &octopus.Project{}); err == nil || err.Error() != "not found" {
+		t.Fatalf("The recorded error was not replayed")
+	}
+
+	if err := replayClient.GetAllResources("Environments", &octopus.GeneralCollection[octopus.Environment]{}); err == nil {
+		t.Fatalf("Requests that were not recorded must return an error")
+	}
+}
+
+func TestRecordingClientRecordsResponseBodies(t *testing.T) {
+	feed := `{"Id":"Feeds-1","Name":"npm","FeedType":"Npm","Links":{"Self":"/api/Spaces-1/feeds/Feeds-1"}}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/Spaces":
+			_, _ = w.Write([]byte(`{"Items":[{"Id":"Spaces-1","Name":"Default"}]}`))
+		case "/api/Spaces-1/Feeds/Feeds-1":
+			_, _ = w.Write([]byte(feed))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	snapshot := NewSnapshot("Spaces-1")
+	recordingClient := RecordingOctopusClient{
+		Client:   &OctopusApiClient{Url: server.URL, Space: "Default"},
+		Snapshot: snapshot,
+	}
+
+	resource := octopus.Feed{}
+	exists, err := recordingClient.GetSpaceResourceById("Feeds", "Feeds-1", &resource)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !exists || resource.Id != "Feeds-1" || strutil.EmptyIfNil(resource.FeedType) != "Npm" {
+		t.Fatalf("The response was not returned to the caller: %v", resource)
+	}
+
+	// The body is recorded as the server returned it, including the fields the caller's object does not define
+	entry, ok := snapshot.Lookup(snapshotKey("GetSpaceResourceById", "Feeds", "Feeds-1"))
+
+	if !ok || !entry.Exists || string(entry.Response) != feed {
+		t.Fatalf("The response body was not recorded: %s", entry.Response)
+	}
+}
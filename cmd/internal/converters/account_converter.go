@@ -13,6 +13,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/secrets"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
@@ -27,6 +28,7 @@ type AccountConverter struct {
 	TenantConverter            ConverterById
 	DummySecretVariableValues  bool
 	DummySecretGenerator       dummy.DummySecretGenerator
+	SecretProvider             secrets.SecretProvider
 	ExcludeTenantTags          args.StringSliceArgs
 	ExcludeTenantTagSets       args.StringSliceArgs
 	Excluder                   ExcludeByName
@@ -74,8 +76,10 @@ func (c AccountConverter) allToHcl(stateless bool, dependencies *data.ResourceDe
 			continue
 		}
 
-		zap.L().Info("Account: " + resource.Id + " " + resource.Name)
-		err := c.toHcl(resource, false, stateless, dependencies)
+		err := dependencies.ConvertResource(resource.Id, c.GetResourceType(), func() error {
+			zap.L().Info("Account: " + resource.Id + " " + resource.Name)
+			return c.toHcl(resource, false, stateless, dependencies)
+		})
 
 		if err != nil {
 			return err
@@ -98,19 +102,17 @@ func (c AccountConverter) toHclById(id string, stateless bool, dependencies *dat
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.Account{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.Account{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Account: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Account: %w", err)
+		}
 
-	zap.L().Info("Account: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, true, stateless, dependencies)
+		zap.L().Info("Account: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, true, stateless, dependencies)
+	})
 }
 
 func (c AccountConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -118,43 +120,45 @@ func (c AccountConverter) ToHclLookupById(id string, dependencies *data.Resource
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.Account{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	resource := octopus.Account{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Account: %w", err)
+		}
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Account: %w", err)
-	}
+		if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllAccounts, c.ExcludeAccounts, c.ExcludeAccountsRegex, c.ExcludeAccountsExcept) {
+			return nil
+		}
 
-	if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllAccounts, c.ExcludeAccounts, c.ExcludeAccountsRegex, c.ExcludeAccountsExcept) {
-		return nil
-	}
+		thisResource := data.ResourceDetails{}
 
-	thisResource := data.ResourceDetails{}
+		resourceName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "account_", resource.Id, resource.Name)
 
-	resourceName := "account_" + sanitizer.SanitizeName(resource.Name)
+		if err != nil {
+			return err
+		}
 
-	thisResource.FileName = "space_population/" + resourceName + ".tf"
-	thisResource.Id = resource.Id
-	thisResource.Name = resource.Name
-	thisResource.ResourceType = c.GetResourceType()
-	thisResource.Lookup = "${data.octopusdeploy_accounts." + resourceName + ".accounts[0].id}"
-	thisResource.ToHcl = func() (string, error) {
-		terraformResource := c.buildData(resourceName, resource)
+		thisResource.FileName = "space_population/" + resourceName + ".tf"
+		thisResource.Id = resource.Id
+		thisResource.Name = resource.Name
+		thisResource.ResourceType = c.GetResourceType()
+		thisResource.Lookup = "${data.octopusdeploy_accounts." + resourceName + ".accounts[0].id}"
+		thisResource.ToHcl = func() (string, error) {
+			terraformResource := c.buildData(resourceName, resource)
 
-		file := hclwrite.NewEmptyFile()
-		block := gohcl.EncodeAsBlock(terraformResource, "data")
-		hcl.WriteLifecyclePostCondition(block, "Failed to resolve an account called \""+resource.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.accounts) != 0")
-		file.Body().AppendBlock(block)
+			file := hclwrite.NewEmptyFile()
+			block := gohcl.EncodeAsBlock(terraformResource, "data")
+			hcl.WriteLifecyclePostCondition(block, "Failed to resolve an account called \""+resource.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.accounts) != 0")
+			file.Body().AppendBlock(block)
 
-		return string(file.Bytes()), nil
-	}
+			return string(file.Bytes()), nil
+		}
 
-	dependencies.AddResource(thisResource)
-	return nil
+		dependencies.AddResource(thisResource)
+		return nil
+	})
 }
 
 func (c AccountConverter) buildData(resourceName string, resource octopus.Account) terraform.TerraformAccountData {
@@ -313,7 +317,11 @@ func (c AccountConverter) toHcl(account octopus.Account, recursive bool, statele
 		}
 	}
 
-	resourceName := "account_" + sanitizer.SanitizeName(account.Name)
+	resourceName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "account_", account.Id, account.Name)
+
+	if err != nil {
+		return err
+	}
 
 	thisResource := data.ResourceDetails{}
 
@@ -406,7 +414,7 @@ func (c AccountConverter) GetResourceType() string {
 	return "Accounts"
 }
 
-func (c AccountConverter) createSecretVariable(resourceName string, description string, accountName string, dependencies *data.ResourceDetailsCollection) terraform.TerraformVariable {
+func (c AccountConverter) createSecretVariable(resourceName string, description string, account octopus.Account, dependencies *data.ResourceDetailsCollection) terraform.TerraformVariable {
 	secretVariableResource := terraform.TerraformVariable{
 		Name:        resourceName,
 		Type:        "string",
@@ -415,11 +423,11 @@ func (c AccountConverter) createSecretVariable(resourceName string, description
 		Description: description,
 	}
 
-	if c.DummySecretVariableValues {
+	if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(resourceName, account), dependencies) && c.DummySecretVariableValues {
 		secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
 		dependencies.AddDummy(data.DummyVariableReference{
 			VariableName: resourceName,
-			ResourceName: accountName,
+			ResourceName: account.Name,
 			ResourceType: c.GetResourceType(),
 		})
 	}
@@ -427,7 +435,7 @@ func (c AccountConverter) createSecretVariable(resourceName string, description
 	return secretVariableResource
 }
 
-func (c AccountConverter) createSecretCertificateNoPassVariable(resourceName string, description string) terraform.TerraformVariable {
+func (c AccountConverter) createSecretCertificateNoPassVariable(resourceName string, description string, account octopus.Account, dependencies *data.ResourceDetailsCollection) (terraform.TerraformVariable, error) {
 	secretVariableResource := terraform.TerraformVariable{
 		Name:        resourceName,
 		Type:        "string",
@@ -436,14 +444,25 @@ func (c AccountConverter) createSecretCertificateNoPassVariable(resourceName str
 		Description: description,
 	}
 
-	if c.DummySecretVariableValues {
-		secretVariableResource.Default = c.DummySecretGenerator.GetDummyCertificateNoPass()
+	if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(resourceName, account), dependencies) && c.DummySecretVariableValues {
+		dummyCertificate, err := c.DummySecretGenerator.GetDummyCertificateNoPass(account.Id, account.Name)
+
+		if err != nil {
+			return terraform.TerraformVariable{}, err
+		}
+
+		secretVariableResource.Default = dummyCertificate
+		dependencies.AddDummy(data.DummyVariableReference{
+			VariableName: resourceName,
+			ResourceName: account.Name,
+			ResourceType: c.GetResourceType(),
+		})
 	}
 
-	return secretVariableResource
+	return secretVariableResource, nil
 }
 
-func (c AccountConverter) createSecretCertificateB64Variable(resourceName string, description string, accountName string, dependencies *data.ResourceDetailsCollection) terraform.TerraformVariable {
+func (c AccountConverter) createSecretCertificateB64Variable(resourceName string, description string, account octopus.Account, dependencies *data.ResourceDetailsCollection) terraform.TerraformVariable {
 	secretVariableResource := terraform.TerraformVariable{
 		Name:        resourceName,
 		Type:        "string",
@@ -452,11 +471,11 @@ func (c AccountConverter) createSecretCertificateB64Variable(resourceName string
 		Description: description,
 	}
 
-	if c.DummySecretVariableValues {
+	if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(resourceName, account), dependencies) && c.DummySecretVariableValues {
 		secretVariableResource.Default = c.DummySecretGenerator.GetDummyCertificateBase64()
 		dependencies.AddDummy(data.DummyVariableReference{
 			VariableName: resourceName,
-			ResourceName: accountName,
+			ResourceName: account.Name,
 			ResourceType: c.GetResourceType(),
 		})
 	}
@@ -464,6 +483,21 @@ func (c AccountConverter) createSecretCertificateB64Variable(resourceName string
 	return secretVariableResource
 }
 
+// secretReference returns the secrets inventory entry for a sensitive variable exposed by the account
+func (c AccountConverter) secretReference(variableName string, account octopus.Account) data.SecretVariableReference {
+	return data.SecretVariableReference{
+		VariableName: variableName,
+		ResourceType: c.GetResourceType(),
+		ResourceId:   account.Id,
+		ResourceName: account.Name,
+		Scope: data.SecretScope{
+			EnvironmentIds: account.EnvironmentIds,
+			TenantIds:      account.TenantIds,
+			TenantTags:     account.TenantTags,
+		},
+	}
+}
+
 // writeData appends the data block for stateless modules
 func (c AccountConverter) writeData(file *hclwrite.File, account octopus.Account, resourceName string) {
 	terraformResource := c.buildData(resourceName, account)
@@ -526,7 +560,7 @@ func (c AccountConverter) writeAwsAccount(stateless bool, resource *data.Resourc
 			Count:                           c.getCount(stateless, resourceName),
 		}
 
-		secretVariableResource := c.createSecretVariable(resourceName, "The AWS secret key associated with the account "+account.Name, account.Name, dependencies)
+		secretVariableResource := c.createSecretVariable(resourceName, "The AWS secret key associated with the account "+account.Name, account, dependencies)
 
 		file := hclwrite.NewEmptyFile()
 
@@ -602,7 +636,7 @@ func (c AccountConverter) writeAzureServicePrincipalAccount(stateless bool, reso
 			Count:                           c.getCount(stateless, resourceName),
 		}
 
-		secretVariableResource := c.createSecretVariable(resourceName, "The Azure secret associated with the account "+account.Name, account.Name, dependencies)
+		secretVariableResource := c.createSecretVariable(resourceName, "The Azure secret associated with the account "+account.Name, account, dependencies)
 
 		file := hclwrite.NewEmptyFile()
 
@@ -667,7 +701,11 @@ func (c AccountConverter) writeAzureSubscriptionAccount(stateless bool, resource
 			Count:                           c.getCount(stateless, resourceName),
 		}
 
-		secretVariableResource := c.createSecretCertificateNoPassVariable(resourceName+"_cert", "The Azure certificate associated with the account "+account.Name)
+		secretVariableResource, err := c.createSecretCertificateNoPassVariable(resourceName+"_cert", "The Azure certificate associated with the account "+account.Name, account, dependencies)
+
+		if err != nil {
+			return "", err
+		}
 
 		file := hclwrite.NewEmptyFile()
 
@@ -677,7 +715,7 @@ func (c AccountConverter) writeAzureSubscriptionAccount(stateless bool, resource
 
 		accountBlock := gohcl.EncodeAsBlock(terraformResource, "resource")
 
-		err := TenantTagDependencyGenerator{}.AddAndWriteTagSetDependencies(c.Client, terraformResource.TenantTags, c.TagSetConverter, accountBlock, dependencies, recursive)
+		err = TenantTagDependencyGenerator{}.AddAndWriteTagSetDependencies(c.Client, terraformResource.TenantTags, c.TagSetConverter, accountBlock, dependencies, recursive)
 		if err != nil {
 			return "", err
 		}
@@ -737,7 +775,7 @@ func (c AccountConverter) writeGoogleCloudAccount(stateless bool, resource *data
 			Count:                           c.getCount(stateless, resourceName),
 		}
 
-		secretVariableResource := c.createSecretVariable(resourceName, "The GCP JSON key associated with the account "+account.Name, account.Name, dependencies)
+		secretVariableResource := c.createSecretVariable(resourceName, "The GCP JSON key associated with the account "+account.Name, account, dependencies)
 
 		file := hclwrite.NewEmptyFile()
 
@@ -807,7 +845,7 @@ func (c AccountConverter) writeTokenAccount(stateless bool, resource *data.Resou
 			Count:                           c.getCount(stateless, resourceName),
 		}
 
-		secretVariableResource := c.createSecretVariable(resourceName, "The token associated with the account "+account.Name, account.Name, dependencies)
+		secretVariableResource := c.createSecretVariable(resourceName, "The token associated with the account "+account.Name, account, dependencies)
 
 		file := hclwrite.NewEmptyFile()
 
@@ -879,7 +917,7 @@ func (c AccountConverter) writeUsernamePasswordAccount(stateless bool, resource
 			Count:                           c.getCount(stateless, resourceName),
 		}
 
-		secretVariableResource := c.createSecretVariable(resourceName, "The password associated with the account "+account.Name, account.Name, dependencies)
+		secretVariableResource := c.createSecretVariable(resourceName, "The password associated with the account "+account.Name, account, dependencies)
 
 		file := hclwrite.NewEmptyFile()
 
@@ -961,9 +999,9 @@ func (c AccountConverter) writeSshAccount(stateless bool, resource *data.Resourc
 		}
 
 		// Because of https://github.com/OctopusDeployLabs/terraform-provider-octopusdeploy/issues/343
-		secretVariableResource := c.createSecretCertificateB64Variable(resourceName, "The password associated with the certificate for account "+account.Name, account.Name, dependencies)
+		secretVariableResource := c.createSecretCertificateB64Variable(resourceName, "The password associated with the certificate for account "+account.Name, account, dependencies)
 
-		certFileVariableResource := c.createSecretCertificateB64Variable(resourceName+"_cert", "The certificate file for account "+account.Name, account.Name, dependencies)
+		certFileVariableResource := c.createSecretCertificateB64Variable(resourceName+"_cert", "The certificate file for account "+account.Name, account, dependencies)
 
 		file := hclwrite.NewEmptyFile()
 
@@ -12,6 +12,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/secrets"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
@@ -35,6 +36,7 @@ type AzureServiceFabricTargetConverter struct {
 	ExcludeTargetsExcept       args.StringSliceArgs
 	DummySecretVariableValues  bool
 	DummySecretGenerator       dummy.DummySecretGenerator
+	SecretProvider             secrets.SecretProvider
 	ExcludeTenantTags          args.StringSliceArgs
 	ExcludeTenantTagSets       args.StringSliceArgs
 	TagSetConverter            ConvertToHclByResource[octopus.TagSet]
@@ -126,23 +128,21 @@ func (c AzureServiceFabricTargetConverter) toHclById(id string, stateless bool,
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.AzureServiceFabricResource{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.AzureServiceFabricResource{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.AzureServiceFabricResource: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.AzureServiceFabricResource: %w", err)
+		}
 
-	if !c.isAzureServiceFabricCluster(resource) {
-		return nil
-	}
+		if !c.isAzureServiceFabricCluster(resource) {
+			return nil
+		}
 
-	zap.L().Info("Azure Service Fabric Target: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, true, stateless, dependencies)
+		zap.L().Info("Azure Service Fabric Target: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, true, stateless, dependencies)
+	})
 }
 
 func (c AzureServiceFabricTargetConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -150,47 +150,48 @@ func (c AzureServiceFabricTargetConverter) ToHclLookupById(id string, dependenci
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.AzureServiceFabricResource{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
-
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.AzureServiceFabricResource: %w", err)
-	}
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.AzureServiceFabricResource{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if !c.isAzureServiceFabricCluster(resource) {
-		return nil
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.AzureServiceFabricResource: %w", err)
+		}
 
-	// Ignore excluded targets
-	if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllTargets, c.ExcludeTargets, c.ExcludeTargetsRegex, c.ExcludeTargetsExcept) {
-		return nil
-	}
+		if !c.isAzureServiceFabricCluster(resource) {
+			return nil
+		}
 
-	thisResource := data.ResourceDetails{}
+		// Ignore excluded targets
+		if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllTargets, c.ExcludeTargets, c.ExcludeTargetsRegex, c.ExcludeTargetsExcept) {
+			return nil
+		}
 
-	resourceName := "target_" + sanitizer.SanitizeName(resource.Name)
+		thisResource := data.ResourceDetails{}
 
-	thisResource.FileName = "space_population/" + resourceName + ".tf"
-	thisResource.Name = resource.Name
-	thisResource.Id = resource.Id
-	thisResource.ResourceType = c.GetResourceType()
-	thisResource.Lookup = "${data." + octopusdeployAzureServiceFabricClusterDeploymentDataType + "." + resourceName + ".deployment_targets[0].id}"
-	thisResource.ToHcl = func() (string, error) {
-		terraformResource := c.buildData(resourceName, resource)
-		file := hclwrite.NewEmptyFile()
-		block := gohcl.EncodeAsBlock(terraformResource, "data")
-		hcl.WriteLifecyclePostCondition(block, "Failed to resolve a deployment target called \""+resource.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.deployment_targets) != 0")
-		file.Body().AppendBlock(block)
+		resourceName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "target_", resource.Id, resource.Name)
+		if err != nil {
+			return err
+		}
 
-		return string(file.Bytes()), nil
-	}
+		thisResource.FileName = "space_population/" + resourceName + ".tf"
+		thisResource.Name = resource.Name
+		thisResource.Id = resource.Id
+		thisResource.ResourceType = c.GetResourceType()
+		thisResource.Lookup = "${data." + octopusdeployAzureServiceFabricClusterDeploymentDataType + "." + resourceName + ".deployment_targets[0].id}"
+		thisResource.ToHcl = func() (string, error) {
+			terraformResource := c.buildData(resourceName, resource)
+			file := hclwrite.NewEmptyFile()
+			block := gohcl.EncodeAsBlock(terraformResource, "data")
+			hcl.WriteLifecyclePostCondition(block, "Failed to resolve a deployment target called \""+resource.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.deployment_targets) != 0")
+			file.Body().AppendBlock(block)
+
+			return string(file.Bytes()), nil
+		}
 
-	dependencies.AddResource(thisResource)
-	return nil
+		dependencies.AddResource(thisResource)
+		return nil
+	})
 }
 
 func (c AzureServiceFabricTargetConverter) buildData(resourceName string, resource octopus.AzureServiceFabricResource) terraform.TerraformDeploymentTargetsData {
@@ -339,6 +340,9 @@ func (c AzureServiceFabricTargetConverter) toHcl(target octopus.AzureServiceFabr
 		return nil
 	}
 
+	recordInclusion(dependencies, target.Id, target.Name, "EnvironmentIds", target.EnvironmentIds...)
+	recordInclusion(dependencies, target.Id, target.Name, "MachinePolicyId", target.MachinePolicyId)
+
 	if recursive {
 		if stateless {
 			if err := c.exportStatelessDependencies(target, dependencies); err != nil {
@@ -351,7 +355,10 @@ func (c AzureServiceFabricTargetConverter) toHcl(target octopus.AzureServiceFabr
 		}
 	}
 
-	targetName := "target_" + sanitizer.SanitizeName(target.Name)
+	targetName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "target_", target.Id, target.Name)
+	if err != nil {
+		return err
+	}
 
 	if c.GenerateImportScripts && !stateless {
 		c.toBashImport(targetName, target.Name, dependencies)
@@ -465,7 +472,17 @@ func (c AzureServiceFabricTargetConverter) toHcl(target octopus.AzureServiceFabr
 			Description: "The aad_user_credential_password value associated with the target \"" + target.Name + "\"",
 		}
 
-		if c.DummySecretVariableValues {
+		if !secrets.AddSecretVariable(c.SecretProvider, data.SecretVariableReference{
+			VariableName: targetName,
+			ResourceType: c.GetResourceType(),
+			ResourceId:   target.Id,
+			ResourceName: target.Name,
+			Scope: data.SecretScope{
+				EnvironmentIds: target.EnvironmentIds,
+				TenantIds:      target.TenantIds,
+				TenantTags:     target.TenantTags,
+			},
+		}, dependencies) && c.DummySecretVariableValues {
 			secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
 			dependencies.AddDummy(data.DummyVariableReference{
 				VariableName: targetName,
@@ -10,7 +10,6 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
@@ -124,33 +123,31 @@ func (c AzureWebAppTargetConverter) toHclById(id string, stateless bool, depende
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.AzureWebAppResource{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.AzureWebAppResource{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.AzureWebAppResource: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.AzureWebAppResource: %w", err)
+		}
 
-	if !c.isAzureWebApp(resource) {
-		return nil
-	}
+		if !c.isAzureWebApp(resource) {
+			return nil
+		}
 
-	err, noEnvironments := c.HasNoEnvironments(resource)
+		err, noEnvironments := c.HasNoEnvironments(resource)
 
-	if err != nil {
-		return err
-	}
+		if err != nil {
+			return err
+		}
 
-	if noEnvironments {
-		return nil
-	}
+		if noEnvironments {
+			return nil
+		}
 
-	zap.L().Info("Azure Web App Target: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, true, stateless, dependencies)
+		zap.L().Info("Azure Web App Target: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, true, stateless, dependencies)
+	})
 }
 
 func (c AzureWebAppTargetConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -158,57 +155,58 @@ func (c AzureWebAppTargetConverter) ToHclLookupById(id string, dependencies *dat
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.AzureWebAppResource{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.AzureWebAppResource{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.AzureWebAppResource: %w", err)
-	}
-
-	// Ignore excluded targets
-	if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllTargets, c.ExcludeTargets, c.ExcludeTargetsRegex, c.ExcludeTargetsExcept) {
-		return nil
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.AzureWebAppResource: %w", err)
+		}
 
-	if !c.isAzureWebApp(resource) {
-		return nil
-	}
+		// Ignore excluded targets
+		if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllTargets, c.ExcludeTargets, c.ExcludeTargetsRegex, c.ExcludeTargetsExcept) {
+			return nil
+		}
 
-	err, noEnvironments := c.HasNoEnvironments(resource)
+		if !c.isAzureWebApp(resource) {
+			return nil
+		}
 
-	if err != nil {
-		return err
-	}
+		err, noEnvironments := c.HasNoEnvironments(resource)
 
-	if noEnvironments {
-		return nil
-	}
+		if err != nil {
+			return err
+		}
 
-	thisResource := data.ResourceDetails{}
+		if noEnvironments {
+			return nil
+		}
 
-	resourceName := "target_" + sanitizer.SanitizeName(resource.Name)
+		thisResource := data.ResourceDetails{}
 
-	thisResource.FileName = "space_population/" + resourceName + ".tf"
-	thisResource.Id = resource.Id
-	thisResource.Name = resource.Name
-	thisResource.ResourceType = c.GetResourceType()
-	thisResource.Lookup = "${data." + octopusdeployAzureWebAppDeploymentTargetDataType + "." + resourceName + ".deployment_targets[0].id}"
-	thisResource.ToHcl = func() (string, error) {
-		terraformResource := c.buildData(resourceName, resource)
-		file := hclwrite.NewEmptyFile()
-		block := gohcl.EncodeAsBlock(terraformResource, "data")
-		hcl.WriteLifecyclePostCondition(block, "Failed to resolve a deployment target called \""+resource.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.deployment_targets) != 0")
-		file.Body().AppendBlock(block)
+		resourceName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "target_", resource.Id, resource.Name)
+		if err != nil {
+			return err
+		}
 
-		return string(file.Bytes()), nil
-	}
+		thisResource.FileName = "space_population/" + resourceName + ".tf"
+		thisResource.Id = resource.Id
+		thisResource.Name = resource.Name
+		thisResource.ResourceType = c.GetResourceType()
+		thisResource.Lookup = "${data." + octopusdeployAzureWebAppDeploymentTargetDataType + "." + resourceName + ".deployment_targets[0].id}"
+		thisResource.ToHcl = func() (string, error) {
+			terraformResource := c.buildData(resourceName, resource)
+			file := hclwrite.NewEmptyFile()
+			block := gohcl.EncodeAsBlock(terraformResource, "data")
+			hcl.WriteLifecyclePostCondition(block, "Failed to resolve a deployment target called \""+resource.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.deployment_targets) != 0")
+			file.Body().AppendBlock(block)
+
+			return string(file.Bytes()), nil
+		}
 
-	dependencies.AddResource(thisResource)
-	return nil
+		dependencies.AddResource(thisResource)
+		return nil
+	})
 }
 
 func (c AzureWebAppTargetConverter) buildData(resourceName string, resource octopus.AzureWebAppResource) terraform.TerraformDeploymentTargetsData {
@@ -367,6 +365,10 @@ func (c AzureWebAppTargetConverter) toHcl(target octopus.AzureWebAppResource, re
 		return nil
 	}
 
+	recordInclusion(dependencies, target.Id, target.Name, "EnvironmentIds", target.EnvironmentIds...)
+	recordInclusion(dependencies, target.Id, target.Name, "MachinePolicyId", target.MachinePolicyId)
+	recordInclusion(dependencies, target.Id, target.Name, "Endpoint.AccountId", target.Endpoint.AccountId)
+
 	if recursive {
 		if stateless {
 			if err := c.exportStatelessDependencies(target, dependencies); err != nil {
@@ -379,7 +381,10 @@ func (c AzureWebAppTargetConverter) toHcl(target octopus.AzureWebAppResource, re
 		}
 	}
 
-	targetName := "target_" + sanitizer.SanitizeName(target.Name)
+	targetName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "target_", target.Id, target.Name)
+	if err != nil {
+		return err
+	}
 
 	if c.GenerateImportScripts && !stateless {
 		c.toBashImport(targetName, target.Name, dependencies)
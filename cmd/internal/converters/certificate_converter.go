@@ -11,6 +11,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/naming"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/secrets"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
@@ -25,6 +26,7 @@ type CertificateConverter struct {
 	Client                    client.OctopusClient
 	DummySecretVariableValues bool
 	DummySecretGenerator      dummy.DummySecretGenerator
+	SecretProvider            secrets.SecretProvider
 	ExcludeTenantTags         args.StringSliceArgs
 	ExcludeTenantTagSets      args.StringSliceArgs
 	Excluder                  ExcludeByName
@@ -96,23 +98,21 @@ func (c CertificateConverter) toHclById(id string, stateless bool, dependencies
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.Certificate{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	resource := octopus.Certificate{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
-
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Certificate: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Certificate: %w", err)
+		}
 
-	if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllCertificates, c.ExcludeCertificates, c.ExcludeCertificatesRegex, c.ExcludeCertificatesExcept) {
-		return nil
-	}
+		if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllCertificates, c.ExcludeCertificates, c.ExcludeCertificatesRegex, c.ExcludeCertificatesExcept) {
+			return nil
+		}
 
-	zap.L().Info("Certificate: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, true, stateless, dependencies)
+		zap.L().Info("Certificate: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, true, stateless, dependencies)
+	})
 }
 
 func (c CertificateConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -120,42 +120,44 @@ func (c CertificateConverter) ToHclLookupById(id string, dependencies *data.Reso
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		certificate := octopus.Certificate{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &certificate)
 
-	certificate := octopus.Certificate{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &certificate)
-
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Certificate: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Certificate: %w", err)
+		}
 
-	if c.Excluder.IsResourceExcludedWithRegex(certificate.Name, c.ExcludeAllCertificates, c.ExcludeCertificates, c.ExcludeCertificatesRegex, c.ExcludeCertificatesExcept) {
-		return nil
-	}
+		if c.Excluder.IsResourceExcludedWithRegex(certificate.Name, c.ExcludeAllCertificates, c.ExcludeCertificates, c.ExcludeCertificatesRegex, c.ExcludeCertificatesExcept) {
+			return nil
+		}
 
-	thisResource := data.ResourceDetails{}
+		thisResource := data.ResourceDetails{}
 
-	certificateName := "certificate_" + sanitizer.SanitizeName(certificate.Name)
+		certificateName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "certificate_", certificate.Id, certificate.Name)
 
-	thisResource.FileName = "space_population/" + certificateName + ".tf"
-	thisResource.Id = certificate.Id
-	thisResource.Name = certificate.Name
-	thisResource.ResourceType = c.GetResourceType()
-	thisResource.Lookup = "${data." + octopusdeployCertificateDataType + "." + certificateName + ".certificates[0].id}"
-	thisResource.ToHcl = func() (string, error) {
-		terraformResource := c.buildData(certificateName, certificate)
-		file := hclwrite.NewEmptyFile()
-		block := gohcl.EncodeAsBlock(terraformResource, "data")
-		hcl.WriteLifecyclePostCondition(block, "Failed to resolve a certificate called \""+certificate.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.certificates) != 0")
-		file.Body().AppendBlock(block)
+		if err != nil {
+			return err
+		}
 
-		return string(file.Bytes()), nil
-	}
+		thisResource.FileName = "space_population/" + certificateName + ".tf"
+		thisResource.Id = certificate.Id
+		thisResource.Name = certificate.Name
+		thisResource.ResourceType = c.GetResourceType()
+		thisResource.Lookup = "${data." + octopusdeployCertificateDataType + "." + certificateName + ".certificates[0].id}"
+		thisResource.ToHcl = func() (string, error) {
+			terraformResource := c.buildData(certificateName, certificate)
+			file := hclwrite.NewEmptyFile()
+			block := gohcl.EncodeAsBlock(terraformResource, "data")
+			hcl.WriteLifecyclePostCondition(block, "Failed to resolve a certificate called \""+certificate.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.certificates) != 0")
+			file.Body().AppendBlock(block)
+
+			return string(file.Bytes()), nil
+		}
 
-	dependencies.AddResource(thisResource)
-	return nil
+		dependencies.AddResource(thisResource)
+		return nil
+	})
 }
 
 func (c CertificateConverter) buildData(resourceName string, resource octopus.Certificate) terraform.TerraformCertificateData {
@@ -305,7 +307,11 @@ func (c CertificateConverter) toHcl(certificate octopus.Certificate, recursive b
 		will link itself to any available environments or tenants.
 	*/
 
-	certificateName := "certificate_" + sanitizer.SanitizeName(certificate.Name)
+	certificateName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "certificate_", certificate.Id, certificate.Name)
+
+	if err != nil {
+		return err
+	}
 
 	if c.GenerateImportScripts && !stateless {
 		c.toBashImport(certificateName, certificate.Name, dependencies)
@@ -325,7 +331,7 @@ func (c CertificateConverter) toHcl(certificate octopus.Certificate, recursive b
 			ResourceName:  sanitizer.SanitizeParameterName(dependencies, certificate.Name, "Password"),
 			ParameterType: "Password",
 			Sensitive:     true,
-			VariableName:  naming.CertificatePasswordName(certificate),
+			VariableName:  naming.CertificatePasswordName(certificateName),
 		},
 		{
 			Label:         "Certificate " + certificate.Name + " contents",
@@ -333,7 +339,7 @@ func (c CertificateConverter) toHcl(certificate octopus.Certificate, recursive b
 			ResourceName:  sanitizer.SanitizeParameterName(dependencies, certificate.Name, "Data"),
 			ParameterType: "Data",
 			Sensitive:     true,
-			VariableName:  naming.CertificateDataName(certificate),
+			VariableName:  naming.CertificateDataName(certificateName),
 		},
 	}
 
@@ -369,11 +375,46 @@ func (c CertificateConverter) toHcl(certificate octopus.Certificate, recursive b
 	return nil
 }
 
+// secretReference returns the secrets inventory entry for a sensitive variable exposed by the certificate
+func (c CertificateConverter) secretReference(variableName string, certificate octopus.Certificate) data.SecretVariableReference {
+	return data.SecretVariableReference{
+		VariableName: variableName,
+		ResourceType: c.GetResourceType(),
+		ResourceId:   certificate.Id,
+		ResourceName: certificate.Name,
+		Scope: data.SecretScope{
+			EnvironmentIds: certificate.EnvironmentIds,
+			TenantIds:      certificate.TenantIds,
+			TenantTags:     certificate.TenantTags,
+		},
+	}
+}
+
 func (c CertificateConverter) writeVariables(file *hclwrite.File, certificateName string, certificate octopus.Certificate, dependencies *data.ResourceDetailsCollection) error {
 
+	// A password is only useful with the certificate it protects, so the password from the secret provider is
+	// ignored unless the provider also has the certificate data
+	hasData := secrets.AddSecretVariable(c.SecretProvider, c.secretReference(naming.CertificateDataName(certificateName), certificate), dependencies)
+	var passwordProvider secrets.SecretProvider
+	if hasData {
+		passwordProvider = c.SecretProvider
+	}
+	secrets.AddSecretVariable(passwordProvider, c.secretReference(naming.CertificatePasswordName(certificateName), certificate), dependencies)
+
+	// The dummy certificate is generated for the certificate, and can only be opened with the matching password
+	var dummyCertificate, dummyPassword *string
+	if c.DummySecretVariableValues && !hasData {
+		var err error
+		dummyCertificate, dummyPassword, err = c.DummySecretGenerator.GetDummyCertificate(certificate)
+
+		if err != nil {
+			return err
+		}
+	}
+
 	defaultPassword := ""
 	certificatePassword := terraform.TerraformVariable{
-		Name:        naming.CertificatePasswordName(certificate),
+		Name:        naming.CertificatePasswordName(certificateName),
 		Type:        "string",
 		Nullable:    true,
 		Sensitive:   true,
@@ -381,10 +422,10 @@ func (c CertificateConverter) writeVariables(file *hclwrite.File, certificateNam
 		Default:     &defaultPassword,
 	}
 
-	if c.DummySecretVariableValues {
-		certificatePassword.Default = c.DummySecretGenerator.GetDummyCertificatePassword()
+	if dummyPassword != nil {
+		certificatePassword.Default = dummyPassword
 		dependencies.AddDummy(data.DummyVariableReference{
-			VariableName: naming.CertificatePasswordName(certificate),
+			VariableName: naming.CertificatePasswordName(certificateName),
 			ResourceName: certificate.Name,
 			ResourceType: c.GetResourceType(),
 		})
@@ -395,17 +436,17 @@ func (c CertificateConverter) writeVariables(file *hclwrite.File, certificateNam
 	file.Body().AppendBlock(block)
 
 	certificateData := terraform.TerraformVariable{
-		Name:        naming.CertificateDataName(certificate),
+		Name:        naming.CertificateDataName(certificateName),
 		Type:        "string",
 		Nullable:    false,
 		Sensitive:   true,
 		Description: "The certificate data used by the certificate " + certificate.Name,
 	}
 
-	if c.DummySecretVariableValues {
-		certificateData.Default = c.DummySecretGenerator.GetDummyCertificate()
+	if dummyCertificate != nil {
+		certificateData.Default = dummyCertificate
 		dependencies.AddDummy(data.DummyVariableReference{
-			VariableName: naming.CertificateDataName(certificate),
+			VariableName: naming.CertificateDataName(certificateName),
 			ResourceName: certificate.Name,
 			ResourceType: c.GetResourceType(),
 		})
@@ -425,8 +466,8 @@ func (c CertificateConverter) writeMainResource(file *hclwrite.File, certificate
 		Name:            certificateName,
 		SpaceId:         strutil.InputIfEnabled(c.IncludeSpaceInPopulation, dependencies.GetResourceDependency("Spaces", certificate.SpaceId)),
 		ResourceName:    certificate.Name,
-		Password:        "${var." + naming.CertificatePasswordName(certificate) + "}",
-		CertificateData: "${var." + naming.CertificateDataName(certificate) + "}",
+		Password:        "${var." + naming.CertificatePasswordName(certificateName) + "}",
+		CertificateData: "${var." + naming.CertificateDataName(certificateName) + "}",
 		Archived:        &certificate.Archived,
 		//CertificateDataFormat:           certificate.CertificateDataFormat,
 		Environments: c.lookupEnvironments(certificate.EnvironmentIds, dependencies),
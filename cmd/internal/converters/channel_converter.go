@@ -155,8 +155,18 @@ func (c ChannelConverter) toHcl(channel octopus.Channel, project octopus.Project
 
 		if invalid {
 			zap.L().Info("Channel " + channel.Name + " (" + channel.Id + ") is invalid - skipping ")
+			recordLintFinding(dependencies, data.LintSeverityLossy, c.GetResourceType(), channel.Id, channel.Name,
+				"The channel references steps or packages that are not defined in the deployment process, so it was excluded. Variables and steps scoped to the channel are no longer scoped.")
 			return nil
 		}
+	} else if c.hasPackageRules(channel) {
+		// Invalid channels are still exported, but fail when the module is applied
+		if invalid, err := c.isInvalid(channel, project); err != nil {
+			zap.L().Warn("Failed to validate channel " + channel.Name + " (" + channel.Id + "): " + err.Error())
+		} else if invalid {
+			recordLintFinding(dependencies, data.LintSeverityError, c.GetResourceType(), channel.Id, channel.Name,
+				"The channel references steps or packages that are not defined in the deployment process, and will fail to apply. Use the -excludeInvalidChannels argument to exclude it.")
+		}
 	}
 
 	if c.LimitResourceCount > 0 && len(dependencies.GetAllResource(c.GetResourceType())) >= c.LimitResourceCount {
@@ -164,6 +174,10 @@ func (c ChannelConverter) toHcl(channel octopus.Channel, project octopus.Project
 		return nil
 	}
 
+	recordInclusion(dependencies, channel.ProjectId, project.Name, "channels", channel.Id)
+	recordInclusion(dependencies, channel.Id, channel.Name, "LifecycleId", strutil.EmptyIfNil(channel.LifecycleId))
+	recordInclusion(dependencies, channel.Id, channel.Name, "ParentEnvironmentId", strutil.EmptyIfNil(channel.ParentEnvironmentId))
+
 	if !strutil.IsBlankPointer(channel.LifecycleId) {
 		var err error
 		if recursive {
@@ -211,7 +225,13 @@ func (c ChannelConverter) toHcl(channel octopus.Channel, project octopus.Project
 		}
 	}
 
-	resourceName := "channel_" + sanitizer.SanitizeName(project.Name) + "_" + sanitizer.SanitizeNamePointer(&channel.Name)
+	projectResourceName, err := getProjectLabel(c.Client, dependencies, project.Id, project.Name)
+
+	if err != nil {
+		return err
+	}
+
+	resourceName := dependencies.GetResourceLabel(c.GetResourceType(), channel.Id, "channel_"+strings.TrimPrefix(projectResourceName, "project_")+"_"+sanitizer.SanitizeNamePointer(&channel.Name))
 
 	if c.GenerateImportScripts && !stateless && channel.Name != defaultChannelName {
 		c.toBashImport(resourceName, project.Name, channel.Name, dependencies)
@@ -220,7 +240,6 @@ func (c ChannelConverter) toHcl(channel octopus.Channel, project octopus.Project
 
 	thisResource := data.ResourceDetails{}
 	thisResource.Name = channel.Name
-	projectResourceName := "project_" + sanitizer.SanitizeName(project.Name)
 	thisResource.FileName = "space_population/" + resourceName + ".tf"
 	thisResource.Id = channel.Id
 	thisResource.ResourceType = c.GetResourceType()
@@ -342,23 +361,17 @@ func (c ChannelConverter) isInvalid(channel octopus.Channel, project octopus.Pro
 
 	for _, rule := range channel.Rules {
 		for _, actionPackage := range rule.ActionPackages {
-			_, stepExists := lo.Find(resource.Steps, func(item octopus.Step) bool {
-				action, actionExists := lo.Find(item.Actions, func(item octopus.Action) bool {
-					return item.Name == actionPackage.DeploymentAction
+			// The package reference is empty for the primary package of an action, which also has an empty name
+			packageExists := lo.ContainsBy(resource.Steps, func(step octopus.Step) bool {
+				return lo.ContainsBy(step.Actions, func(action octopus.Action) bool {
+					return strutil.EmptyIfNil(action.Name) == strutil.EmptyIfNil(actionPackage.DeploymentAction) &&
+						lo.ContainsBy(action.Packages, func(item octopus.Package) bool {
+							return strutil.EmptyIfNil(item.Name) == strutil.EmptyIfNil(actionPackage.PackageReference)
+						})
 				})
-
-				if !actionExists {
-					_, packageExists := lo.Find(action.Packages, func(item octopus.Package) bool {
-						return item.Name == actionPackage.DeploymentAction
-					})
-
-					return packageExists
-				}
-
-				return false
 			})
 
-			if !stepExists {
+			if !packageExists {
 				return true, nil
 			}
 		}
@@ -367,6 +380,13 @@ func (c ChannelConverter) isInvalid(channel octopus.Channel, project octopus.Pro
 	return false, nil
 }
 
+// hasPackageRules returns true if the channel has rules that reference the packages of the deployment process.
+func (c ChannelConverter) hasPackageRules(channel octopus.Channel) bool {
+	return lo.ContainsBy(channel.Rules, func(rule octopus.Rule) bool {
+		return len(rule.ActionPackages) != 0
+	})
+}
+
 func (c ChannelConverter) getLifecycleId(lifecycleId *string, dependencies *data.ResourceDetailsCollection) *string {
 	if strutil.IsBlankPointer(lifecycleId) {
 		return nil
@@ -8,7 +8,6 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
@@ -122,33 +121,31 @@ func (c CloudRegionTargetConverter) toHclById(id string, stateless bool, depende
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.CloudRegionResource{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.CloudRegionResource{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.CloudRegionResource: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.CloudRegionResource: %w", err)
+		}
 
-	if !c.isCloudTarget(resource) {
-		return nil
-	}
+		if !c.isCloudTarget(resource) {
+			return nil
+		}
 
-	err, noEnvironments := c.HasNoEnvironments(resource)
+		err, noEnvironments := c.HasNoEnvironments(resource)
 
-	if err != nil {
-		return err
-	}
+		if err != nil {
+			return err
+		}
 
-	if noEnvironments {
-		return nil
-	}
+		if noEnvironments {
+			return nil
+		}
 
-	zap.L().Info("Cloud Target: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, true, stateless, dependencies)
+		zap.L().Info("Cloud Target: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, true, stateless, dependencies)
+	})
 }
 
 func (c CloudRegionTargetConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -156,57 +153,58 @@ func (c CloudRegionTargetConverter) ToHclLookupById(id string, dependencies *dat
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.CloudRegionResource{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.CloudRegionResource{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.CloudRegionResource: %w", err)
-	}
-
-	// Ignore excluded targets
-	if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllTargets, c.ExcludeTargets, c.ExcludeTargetsRegex, c.ExcludeTargetsExcept) {
-		return nil
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.CloudRegionResource: %w", err)
+		}
 
-	if !c.isCloudTarget(resource) {
-		return nil
-	}
+		// Ignore excluded targets
+		if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllTargets, c.ExcludeTargets, c.ExcludeTargetsRegex, c.ExcludeTargetsExcept) {
+			return nil
+		}
 
-	err, noEnvironments := c.HasNoEnvironments(resource)
+		if !c.isCloudTarget(resource) {
+			return nil
+		}
 
-	if err != nil {
-		return err
-	}
+		err, noEnvironments := c.HasNoEnvironments(resource)
 
-	if noEnvironments {
-		return nil
-	}
+		if err != nil {
+			return err
+		}
 
-	thisResource := data.ResourceDetails{}
+		if noEnvironments {
+			return nil
+		}
 
-	resourceName := "target_" + sanitizer.SanitizeName(resource.Name)
+		thisResource := data.ResourceDetails{}
 
-	thisResource.FileName = "space_population/" + resourceName + ".tf"
-	thisResource.Id = resource.Id
-	thisResource.Name = resource.Name
-	thisResource.ResourceType = c.GetResourceType()
-	thisResource.Lookup = "${data." + octopusdeployCloudRegionResourceDataType + "." + resourceName + ".deployment_targets[0].id}"
-	thisResource.ToHcl = func() (string, error) {
-		terraformResource := c.buildData(resourceName, resource)
-		file := hclwrite.NewEmptyFile()
-		block := gohcl.EncodeAsBlock(terraformResource, "data")
-		hcl.WriteLifecyclePostCondition(block, "Failed to resolve a deployment target called \""+resource.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.deployment_targets) != 0")
-		file.Body().AppendBlock(block)
+		resourceName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "target_", resource.Id, resource.Name)
+		if err != nil {
+			return err
+		}
 
-		return string(file.Bytes()), nil
-	}
+		thisResource.FileName = "space_population/" + resourceName + ".tf"
+		thisResource.Id = resource.Id
+		thisResource.Name = resource.Name
+		thisResource.ResourceType = c.GetResourceType()
+		thisResource.Lookup = "${data." + octopusdeployCloudRegionResourceDataType + "." + resourceName + ".deployment_targets[0].id}"
+		thisResource.ToHcl = func() (string, error) {
+			terraformResource := c.buildData(resourceName, resource)
+			file := hclwrite.NewEmptyFile()
+			block := gohcl.EncodeAsBlock(terraformResource, "data")
+			hcl.WriteLifecyclePostCondition(block, "Failed to resolve a deployment target called \""+resource.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.deployment_targets) != 0")
+			file.Body().AppendBlock(block)
+
+			return string(file.Bytes()), nil
+		}
 
-	dependencies.AddResource(thisResource)
-	return nil
+		dependencies.AddResource(thisResource)
+		return nil
+	})
 }
 
 func (c CloudRegionTargetConverter) buildData(resourceName string, resource octopus.CloudRegionResource) terraform.TerraformDeploymentTargetsData {
@@ -365,6 +363,9 @@ func (c CloudRegionTargetConverter) toHcl(target octopus.CloudRegionResource, re
 		return nil
 	}
 
+	recordInclusion(dependencies, target.Id, target.Name, "EnvironmentIds", target.EnvironmentIds...)
+	recordInclusion(dependencies, target.Id, target.Name, "MachinePolicyId", target.MachinePolicyId)
+
 	if recursive {
 		err := c.exportDependencies(target, stateless, dependencies)
 
@@ -373,7 +374,10 @@ func (c CloudRegionTargetConverter) toHcl(target octopus.CloudRegionResource, re
 		}
 	}
 
-	targetName := "target_" + sanitizer.SanitizeName(target.Name)
+	targetName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "target_", target.Id, target.Name)
+	if err != nil {
+		return err
+	}
 
 	if c.GenerateImportScripts && !stateless {
 		c.toBashImport(targetName, target.Name, dependencies)
@@ -283,6 +283,12 @@ type ConverterByTenantId interface {
 	ToHclByTenantId(projectId string, stateless bool, dependencies *data.ResourceDetailsCollection) error
 }
 
+// ConverterByTeamIdWithName converts objects based on the relationship to a team, using the supplied team name
+// to build the Terraform resource names and the team lookup to reference the team
+type ConverterByTeamIdWithName interface {
+	ToHclByTeamIdAndName(teamId string, teamName string, teamLookup string, dependencies *data.ResourceDetailsCollection) error
+}
+
 // ConvertToHclByResource converts objects directly
 type ConvertToHclByResource[C any] interface {
 	ToHclByResource(resource C, dependencies *data.ResourceDetailsCollection) error
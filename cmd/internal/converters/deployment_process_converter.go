@@ -113,34 +113,32 @@ func (c *DeploymentProcessConverter) toHclById(id string, recursive bool, statel
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.DeploymentProcess{}
-	found, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.DeploymentProcess{}
+		found, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.DeploymentProcess: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.DeploymentProcess: %w", err)
+		}
 
-	// Projects with no deployment process will not have a deployment process resources.
-	// This is expected, so just return.
-	if !found {
-		return nil
-	}
+		// Projects with no deployment process will not have a deployment process resources.
+		// This is expected, so just return.
+		if !found {
+			return nil
+		}
 
-	project := octopus.Project{}
-	_, err = c.Client.GetSpaceResourceById("Projects", resource.ProjectId, &project)
+		project := octopus.Project{}
+		_, err = c.Client.GetSpaceResourceById("Projects", resource.ProjectId, &project)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Project: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Project: %w", err)
+		}
 
-	zap.L().Info("Deployment Process: " + resource.Id)
+		zap.L().Info("Deployment Process: " + resource.Id)
 
-	c.exportScripts(project, resource, dependencies)
-	return c.toHcl(&resource, nil, &project, recursive, false, stateless, false, dependencies)
+		c.exportScripts(project, resource, dependencies)
+		return c.toHcl(&resource, nil, &project, recursive, false, stateless, false, dependencies)
+	})
 }
 
 func (c *DeploymentProcessConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -148,64 +146,62 @@ func (c *DeploymentProcessConverter) ToHclLookupById(id string, dependencies *da
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.DeploymentProcess{}
-	found, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.DeploymentProcess{}
+		found, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.DeploymentProcess: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.DeploymentProcess: %w", err)
+		}
 
-	// Projects with no deployment process will not have a deployment process resources.
-	// This is expected, so just return.
-	if !found {
-		return nil
-	}
+		// Projects with no deployment process will not have a deployment process resources.
+		// This is expected, so just return.
+		if !found {
+			return nil
+		}
 
-	project := octopus.Project{}
-	_, err = c.Client.GetSpaceResourceById("Projects", resource.ProjectId, &project)
+		project := octopus.Project{}
+		_, err = c.Client.GetSpaceResourceById("Projects", resource.ProjectId, &project)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Project: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Project: %w", err)
+		}
 
-	c.exportScripts(project, resource, dependencies)
-	return c.toHcl(&resource, nil, &project, false, true, false, false, dependencies)
+		c.exportScripts(project, resource, dependencies)
+		return c.toHcl(&resource, nil, &project, false, true, false, false, dependencies)
+	})
 }
 
 func (c *DeploymentProcessConverter) exportScripts(project octopus.Project, resource octopus.DeploymentProcess, dependencies *data.ResourceDetailsCollection) {
 	if c.GenerateImportScripts {
-		c.toBashImport(c.generateProcessName(nil, &project), c.generateStepOrderName(nil, &project), project.GetName(), dependencies)
-		c.toPowershellImport(c.generateProcessName(nil, &project), c.generateStepOrderName(nil, &project), project.GetName(), dependencies)
+		c.toBashImport(c.generateProcessName(nil, &project, dependencies), c.generateStepOrderName(nil, &project, dependencies), project.GetName(), dependencies)
+		c.toPowershellImport(c.generateProcessName(nil, &project, dependencies), c.generateStepOrderName(nil, &project, dependencies), project.GetName(), dependencies)
 
 		validSteps := c.getValidSteps(&resource)
 
 		for _, step := range validSteps {
 			c.toStepBashImport(
-				c.generateStepName(nil, &project, &step),
-				c.generateChildStepOrderName(nil, &project, &step),
+				c.generateStepName(nil, &project, &step, dependencies),
+				c.generateChildStepOrderName(nil, &project, &step, dependencies),
 				project.GetName(),
 				step.GetName(),
 				dependencies)
 			c.toStepPowershellImport(
-				c.generateStepName(nil, &project, &step),
-				c.generateChildStepOrderName(nil, &project, &step),
+				c.generateStepName(nil, &project, &step, dependencies),
+				c.generateChildStepOrderName(nil, &project, &step, dependencies),
 				project.GetName(),
 				step.GetName(),
 				dependencies)
 
 			for _, action := range step.Actions[1:] {
 				c.toChildStepBashImport(
-					c.generateChildStepName(nil, &project, &action),
+					c.generateChildStepName(nil, &project, &action, dependencies),
 					project.GetName(),
 					step.GetName(),
 					action.GetName(),
 					dependencies)
 				c.toChildStepPowershellImport(
-					c.generateChildStepName(nil, &project, &action),
+					c.generateChildStepName(nil, &project, &action, dependencies),
 					project.GetName(),
 					step.GetName(),
 					action.GetName(),
@@ -1,6 +1,7 @@
 package converters
 
 import (
+	"fmt"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/boolutil"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
@@ -12,12 +13,16 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/regexes"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/secrets"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sliceutil"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/steps"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
 	"github.com/samber/lo"
+	"maps"
+	"slices"
+	"strings"
 )
 
 const octopusdeployProcessResourceType = "octopusdeploy_process"
@@ -47,27 +52,47 @@ type DeploymentProcessConverterBase struct {
 	ExcludeStepsExcept         args.StringSliceArgs
 	IgnoreInvalidExcludeExcept bool
 	DummySecretGenerator       dummy.DummySecretGenerator
+	SecretProvider             secrets.SecretProvider
 	DummySecretVariableValues  bool
 	IgnoreCacErrors            bool
 	DetachProjectTemplates     bool
 	GenerateImportScripts      bool
+	ExtractScripts             bool
 }
 
 func (c *DeploymentProcessConverterBase) SetActionProcessor(actionProcessor *OctopusActionProcessor) {
 	c.OctopusActionProcessor = actionProcessor
 }
 
-func (c *DeploymentProcessConverterBase) getParentName(parent octopus.NameIdParentResource, owner octopus.NameIdParentResource) string {
+// getProjectLabel returns the label of the project that owns the process, which is the parent of a runbook, or the
+// owner itself when the process belongs to a project.
+func (c *DeploymentProcessConverterBase) getProjectLabel(parent octopus.NameIdParentResource, owner octopus.NameIdParentResource, dependencies *data.ResourceDetailsCollection) string {
+	project := owner
 	if parent != nil {
-		return parent.GetName()
+		project = parent
 	}
 
-	return owner.GetName()
+	return dependencies.GetResourceLabel("Projects", project.GetId(), "project_"+sanitizer.SanitizeName(project.GetName()))
+}
+
+// getOwnerLabel returns the part of the process labels that identifies the project, or the project and runbook, that
+// owns the process.
+func (c *DeploymentProcessConverterBase) getOwnerLabel(parent octopus.NameIdParentResource, owner octopus.NameIdParentResource, dependencies *data.ResourceDetailsCollection) string {
+	label := strings.TrimPrefix(c.getProjectLabel(parent, owner, dependencies), "project_")
+
+	if parent != nil {
+		return label + "_" + sanitizer.SanitizeName(owner.GetName())
+	}
+
+	return label
 }
 
 func (c *DeploymentProcessConverterBase) toHcl(deploymentProcess octopus.OctopusProcess, parentProjectOrNil octopus.NameIdParentResource, projectOrRunbook octopus.NameIdParentResource, recursive bool, lookup bool, stateless bool, standalone bool, dependencies *data.ResourceDetailsCollection) error {
-	resourceName := c.generateProcessName(parentProjectOrNil, projectOrRunbook)
-	projectResourceName := "project_" + sanitizer.SanitizeName(c.getParentName(parentProjectOrNil, projectOrRunbook))
+	resourceName := c.generateProcessName(parentProjectOrNil, projectOrRunbook, dependencies)
+	projectResourceName := c.getProjectLabel(parentProjectOrNil, projectOrRunbook, dependencies)
+
+	recordInclusion(dependencies, projectOrRunbook.GetId(), projectOrRunbook.GetName(), "process", deploymentProcess.GetId())
+	c.OctopusActionProcessor.RecordInclusions(deploymentProcess.GetId(), deploymentProcess.GetSteps(), dependencies)
 
 	err := c.exportDependencies(recursive, lookup, stateless, deploymentProcess, dependencies)
 
@@ -160,6 +185,7 @@ func (c *DeploymentProcessConverterBase) toHcl(deploymentProcess octopus.Octopus
 
 	// Get all the valid steps
 	validSteps := c.getValidSteps(deploymentProcess)
+	c.recordStepFindings(deploymentProcess, projectOrRunbook, validSteps, dependencies)
 
 	for _, step := range validSteps {
 		parentStep := len(step.Actions) > 1
@@ -197,19 +223,63 @@ func (c *DeploymentProcessConverterBase) getValidSteps(resource octopus.OctopusP
 		c.ExcludeStepsExcept)
 }
 
+// getScriptOwner returns the directory that the scripts of a project or runbook are extracted to. Runbook names are
+// only unique within a project, so the directory of a runbook includes the label of its project, or the project ID if
+// the project is not part of the export.
+func (c *DeploymentProcessConverterBase) getScriptOwner(owner octopus.NameIdParentResource, dependencies *data.ResourceDetailsCollection) string {
+	if owner.GetUltimateParent() == owner.GetId() {
+		return c.getProjectLabel(nil, owner, dependencies)
+	}
+
+	if projects := dependencies.GetResourcesByOctopusId(owner.GetUltimateParent()); len(projects) != 0 {
+		projectLabel := dependencies.GetResourceLabel("Projects", owner.GetUltimateParent(), "project_"+sanitizer.SanitizeName(projects[0].Name))
+		return "runbook_" + strings.TrimPrefix(projectLabel, "project_") + "_" + sanitizer.SanitizeName(owner.GetName())
+	}
+
+	return "runbook_" + sanitizer.SanitizeName(owner.GetUltimateParent()) + "_" + sanitizer.SanitizeName(owner.GetName())
+}
+
+// recordStepFindings records the steps that were dropped because they have no actions, and the actions from the step
+// framework whose step packages must be available to the space the module is applied to.
+func (c *DeploymentProcessConverterBase) recordStepFindings(deploymentProcess octopus.OctopusProcess, owner octopus.NameIdParentResource, validSteps []octopus.Step, dependencies *data.ResourceDetailsCollection) {
+	for _, step := range deploymentProcess.GetSteps() {
+		if len(step.Actions) == 0 {
+			c.recordProcessFinding(owner, data.LintSeverityLossy,
+				"The step \""+strutil.EmptyIfNil(step.Name)+"\" has no actions, so it was not exported.", dependencies)
+		}
+	}
+
+	for _, step := range validSteps {
+		for _, action := range step.Actions {
+			if len(action.Inputs) != 0 {
+				c.recordProcessFinding(owner, data.LintSeverityWarning,
+					"The action \""+strutil.EmptyIfNil(action.Name)+"\" is from the step framework. Its inputs were exported, but the step package must be available to the space the module is applied to.", dependencies)
+			}
+		}
+	}
+}
+
+// recordProcessFinding records an issue found while exporting a process. Processes have no name, so the finding is
+// recorded against the ID and name of the project or runbook that owns the process.
+func (c *DeploymentProcessConverterBase) recordProcessFinding(owner octopus.NameIdParentResource, severity data.LintSeverity, message string, dependencies *data.ResourceDetailsCollection) {
+	recordLintFinding(dependencies, severity, c.ResourceType, owner.GetId(), owner.GetName(), message)
+}
+
 func (c *DeploymentProcessConverterBase) generateChildStepOrder(stateless bool, deploymentProcess octopus.OctopusProcess, parent octopus.NameIdParentResource, owner octopus.NameIdParentResource, step *octopus.Step, standalone bool, dependencies *data.ResourceDetailsCollection) {
 	if len(step.Actions) < 2 {
 		// This shouldn't happen, but if a step has no child actions, we don't create a child step order.
 		return
 	}
 
-	resourceName := c.generateChildStepOrderName(parent, owner, step)
-	projectResourceName := "project_" + sanitizer.SanitizeName(c.getParentName(parent, owner))
+	resourceName := c.generateChildStepOrderName(parent, owner, step, dependencies)
+	projectResourceName := c.getProjectLabel(parent, owner, dependencies)
 
 	thisResource := data.ResourceDetails{}
 	thisResource.FileName = "space_population/" + resourceName + ".tf"
 	thisResource.ParentId = owner.GetUltimateParent()
 	thisResource.Id = c.getStepId(deploymentProcess, owner, step)
+	// The order of the child steps belongs to the parent step, so it is imported with the ID of the parent step
+	thisResource.ImportId = c.getStepImportId(deploymentProcess, step)
 	thisResource.ResourceType = "DeploymentProcesses/StepOrder"
 	thisResource.Dependency = "${" + octopusdeployProcessChildStepsOrder + "." + resourceName + "}"
 
@@ -277,8 +347,8 @@ func (c *DeploymentProcessConverterBase) generateChildStepOrder(stateless bool,
 }
 
 func (c *DeploymentProcessConverterBase) generateStepOrder(stateless bool, resource octopus.OctopusProcess, parent octopus.NameIdParentResource, owner octopus.NameIdParentResource, steps []octopus.Step, standalone bool, dependencies *data.ResourceDetailsCollection) {
-	resourceName := c.generateStepOrderName(parent, owner)
-	projectResourceName := "project_" + sanitizer.SanitizeName(c.getParentName(parent, owner))
+	resourceName := c.generateStepOrderName(parent, owner, dependencies)
+	projectResourceName := c.getProjectLabel(parent, owner, dependencies)
 
 	thisResource := data.ResourceDetails{}
 	thisResource.FileName = "space_population/" + resourceName + ".tf"
@@ -353,13 +423,14 @@ func (c *DeploymentProcessConverterBase) generateChildSteps(stateless bool, reso
 		return
 	}
 
-	resourceName := c.generateChildStepName(parent, owner, action)
-	projectResourceName := "project_" + sanitizer.SanitizeName(c.getParentName(parent, owner))
+	resourceName := c.generateChildStepName(parent, owner, action, dependencies)
+	projectResourceName := c.getProjectLabel(parent, owner, dependencies)
 
 	thisResource := data.ResourceDetails{}
 	thisResource.FileName = "space_population/" + resourceName + ".tf"
 	thisResource.ParentId = owner.GetUltimateParent()
 	thisResource.Id = c.getActionId(resource, owner, action)
+	thisResource.ImportId = c.getChildStepImportId(resource, step, action)
 	thisResource.ResourceType = "DeploymentProcesses/ChildSteps"
 	thisResource.Dependency = "${" + octopusdeployProcessChildStepResourceType + "." + resourceName + "}"
 
@@ -397,6 +468,8 @@ func (c *DeploymentProcessConverterBase) generateChildSteps(stateless bool, reso
 			ExcludedEnvironments: sliceutil.NilIfEmpty(c.lookupEnvironments(action.ExcludedEnvironments, dependencies)),
 			ExecutionProperties:  nil, // This is assigned by assignProperties()
 			GitDependencies:      c.OctopusActionProcessor.ConvertGitDependenciesV2(action.GitDependencies, dependencies),
+			Inputs:               c.OctopusActionProcessor.ConvertInputs(action.Inputs, dependencies),
+			StepPackageVersion:   strutil.NilIfEmptyPointer(action.StepPackageVersion),
 			IsDisabled:           boolutil.NilIfFalse(action.IsDisabled),
 			IsRequired:           boolutil.NilIfFalse(action.IsRequired),
 			Notes:                action.Notes,
@@ -482,13 +555,14 @@ func (c *DeploymentProcessConverterBase) generateTemplateChildSteps(stateless bo
 		return
 	}
 
-	resourceName := c.generateChildStepName(parent, owner, action)
-	projectResourceName := "project_" + sanitizer.SanitizeName(c.getParentName(parent, owner))
+	resourceName := c.generateChildStepName(parent, owner, action, dependencies)
+	projectResourceName := c.getProjectLabel(parent, owner, dependencies)
 
 	thisResource := data.ResourceDetails{}
 	thisResource.FileName = "space_population/" + resourceName + ".tf"
 	thisResource.ParentId = owner.GetUltimateParent()
 	thisResource.Id = owner.GetId() + "/" + resource.GetId() + "/" + action.Id
+	thisResource.ImportId = c.getChildStepImportId(resource, step, action)
 	thisResource.ResourceType = "DeploymentProcesses/ChildSteps"
 	thisResource.Dependency = "${" + octopusdeployProcessTemplatedStepsOrderResourceType + "." + resourceName + "}"
 
@@ -608,14 +682,15 @@ func (c *DeploymentProcessConverterBase) generateTemplateSteps(stateless bool, r
 		return
 	}
 
-	resourceName := c.generateStepName(parent, owner, step)
-	projectResourceName := "project_" + sanitizer.SanitizeName(c.getParentName(parent, owner))
+	resourceName := c.generateStepName(parent, owner, step, dependencies)
+	projectResourceName := c.getProjectLabel(parent, owner, dependencies)
 
 	thisResource := data.ResourceDetails{}
 	thisResource.FileName = "space_population/" + resourceName + ".tf"
 	thisResource.ParentId = owner.GetUltimateParent()
 	thisResource.ImmediateParentId = resource.GetId()
 	thisResource.Id = c.getStepId(resource, owner, step)
+	thisResource.ImportId = c.getStepImportId(resource, step)
 	thisResource.SortOrder = c.getStepIndex(resource, step)
 	thisResource.ResourceType = "DeploymentProcesses/Steps"
 	thisResource.Dependency = "${" + octopusdeployProcessTemplateStepResourceType + "." + resourceName + "}"
@@ -770,8 +845,8 @@ func (c *DeploymentProcessConverterBase) generateSteps(stateless bool, deploymen
 		return
 	}
 
-	resourceName := c.generateStepName(parentProjectOrNil, projectOrRunbook, step)
-	projectResourceName := "project_" + sanitizer.SanitizeName(c.getParentName(parentProjectOrNil, projectOrRunbook))
+	resourceName := c.generateStepName(parentProjectOrNil, projectOrRunbook, step, dependencies)
+	projectResourceName := c.getProjectLabel(parentProjectOrNil, projectOrRunbook, dependencies)
 
 	thisResource := data.ResourceDetails{}
 	thisResource.FileName = "space_population/" + resourceName + ".tf"
@@ -780,6 +855,7 @@ func (c *DeploymentProcessConverterBase) generateSteps(stateless bool, deploymen
 	thisResource.SortOrder = c.getStepIndex(deploymentProcess, step)
 	thisResource.Id = c.getStepId(deploymentProcess, projectOrRunbook, step)
 	thisResource.AlternateId = c.getActionId(deploymentProcess, projectOrRunbook, &step.Actions[0])
+	thisResource.ImportId = c.getStepImportId(deploymentProcess, step)
 	thisResource.ResourceType = "DeploymentProcesses/Steps"
 	thisResource.Dependency = "${" + octopusdeployProcessStepResourceType + "." + resourceName + "}"
 
@@ -817,6 +893,7 @@ func (c *DeploymentProcessConverterBase) generateSteps(stateless bool, deploymen
 			ExcludedEnvironments: nil,
 			ExecutionProperties:  nil,
 			GitDependencies:      nil,
+			Inputs:               nil,
 			IsDisabled:           nil,
 			IsRequired:           nil,
 			Notes:                nil,
@@ -828,6 +905,7 @@ func (c *DeploymentProcessConverterBase) generateSteps(stateless bool, deploymen
 			WorkerPoolId:         nil,
 			WorkerPoolVariable:   nil,
 			StartTrigger:         step.StartTrigger,
+			StepPackageVersion:   nil,
 			Properties:           nil,
 			PackageRequirement:   step.PackageRequirement,
 		}
@@ -858,6 +936,8 @@ func (c *DeploymentProcessConverterBase) generateSteps(stateless bool, deploymen
 			terraformProcessStep.Notes = action.Notes
 			terraformProcessStep.Slug = action.Slug
 			terraformProcessStep.ResourceType = strutil.EmptyIfNil(action.ActionType)
+			terraformProcessStep.Inputs = c.OctopusActionProcessor.ConvertInputs(action.Inputs, dependencies)
+			terraformProcessStep.StepPackageVersion = strutil.NilIfEmptyPointer(action.StepPackageVersion)
 		}
 
 		if stateless {
@@ -908,24 +988,29 @@ func (c *DeploymentProcessConverterBase) generateSteps(stateless bool, deploymen
 	dependencies.AddResource(thisResource)
 }
 
-func (c *DeploymentProcessConverterBase) generateProcessName(parent octopus.NameIdParentResource, owner octopus.NameIdParentResource) string {
-	if parent != nil {
-		return "process_" + sanitizer.SanitizeName(parent.GetName()) + "_" + sanitizer.SanitizeName(owner.GetName())
-	}
-	return "process_" + sanitizer.SanitizeName(owner.GetName())
+func (c *DeploymentProcessConverterBase) generateProcessName(parent octopus.NameIdParentResource, owner octopus.NameIdParentResource, dependencies *data.ResourceDetailsCollection) string {
+	return "process_" + c.getOwnerLabel(parent, owner, dependencies)
 }
 
-func (c *DeploymentProcessConverterBase) generateStepOrderName(parent octopus.NameIdParentResource, owner octopus.NameIdParentResource) string {
-	if parent != nil {
-		return "process_step_order_" + sanitizer.SanitizeName(parent.GetName()) + "_" + sanitizer.SanitizeName(owner.GetName())
-	}
-	return "process_step_order_" + sanitizer.SanitizeName(owner.GetName())
+func (c *DeploymentProcessConverterBase) generateStepOrderName(parent octopus.NameIdParentResource, owner octopus.NameIdParentResource, dependencies *data.ResourceDetailsCollection) string {
+	return "process_step_order_" + c.getOwnerLabel(parent, owner, dependencies)
 }
 
 func (c *DeploymentProcessConverterBase) getStepId(deploymentProcess octopus.OctopusProcess, runbookOrProject octopus.NameIdParentResource, step *octopus.Step) string {
 	return runbookOrProject.GetId() + "/" + deploymentProcess.GetId() + "/" + strutil.EmptyIfNil(step.Id)
 }
 
+// getStepImportId returns the ID used by the provider to import a step, in the format "<process id>:<step id>".
+func (c *DeploymentProcessConverterBase) getStepImportId(deploymentProcess octopus.OctopusProcess, step *octopus.Step) string {
+	return deploymentProcess.GetId() + ":" + strutil.EmptyIfNil(step.Id)
+}
+
+// getChildStepImportId returns the ID used by the provider to import a child step, in the format
+// "<process id>:<parent step id>:<child step id>".
+func (c *DeploymentProcessConverterBase) getChildStepImportId(deploymentProcess octopus.OctopusProcess, step *octopus.Step, action *octopus.Action) string {
+	return c.getStepImportId(deploymentProcess, step) + ":" + action.Id
+}
+
 func (c *DeploymentProcessConverterBase) getStepIndex(deploymentProcess octopus.OctopusProcess, step *octopus.Step) int {
 	_, index, found := lo.FindIndexOf(deploymentProcess.GetSteps(), func(item octopus.Step) bool {
 		return item.Id == step.Id
@@ -942,26 +1027,16 @@ func (c *DeploymentProcessConverterBase) getActionId(deploymentProcess octopus.O
 	return runbookOrProject.GetId() + "/" + deploymentProcess.GetId() + "/" + action.Id
 }
 
-func (c *DeploymentProcessConverterBase) generateChildStepOrderName(parent octopus.NameIdParentResource, owner octopus.NameIdParentResource, named octopus.NamedResource) string {
-	if parent != nil {
-		return "process_child_step_order_" + sanitizer.SanitizeName(parent.GetName()) + "_" + sanitizer.SanitizeName(owner.GetName()) + "_" + sanitizer.SanitizeName(named.GetName())
-	}
-	return "process_child_step_order_" + sanitizer.SanitizeName(owner.GetName()) + "_" + sanitizer.SanitizeName(named.GetName())
+func (c *DeploymentProcessConverterBase) generateChildStepOrderName(parent octopus.NameIdParentResource, owner octopus.NameIdParentResource, named octopus.NamedResource, dependencies *data.ResourceDetailsCollection) string {
+	return "process_child_step_order_" + c.getOwnerLabel(parent, owner, dependencies) + "_" + sanitizer.SanitizeName(named.GetName())
 }
 
-func (c *DeploymentProcessConverterBase) generateStepName(parent octopus.NameIdParentResource, owner octopus.NameIdParentResource, named octopus.NamedResource) string {
-	if parent != nil {
-		return "process_step_" + sanitizer.SanitizeName(parent.GetName()) + "_" + sanitizer.SanitizeName(owner.GetName()) + "_" + sanitizer.SanitizeName(named.GetName())
-	}
-	return "process_step_" + sanitizer.SanitizeName(owner.GetName()) + "_" + sanitizer.SanitizeName(named.GetName())
+func (c *DeploymentProcessConverterBase) generateStepName(parent octopus.NameIdParentResource, owner octopus.NameIdParentResource, named octopus.NamedResource, dependencies *data.ResourceDetailsCollection) string {
+	return "process_step_" + c.getOwnerLabel(parent, owner, dependencies) + "_" + sanitizer.SanitizeName(named.GetName())
 }
 
-func (c *DeploymentProcessConverterBase) generateChildStepName(parent octopus.NameIdParentResource, owner octopus.NameIdParentResource, named octopus.NamedResource) string {
-	if parent != nil {
-		return "process_child_step_" + sanitizer.SanitizeName(parent.GetName()) + "_" + sanitizer.SanitizeName(owner.GetName()) + "_" + sanitizer.SanitizeName(named.GetName())
-	}
-
-	return "process_child_step_" + sanitizer.SanitizeName(owner.GetName()) + "_" + sanitizer.SanitizeName(named.GetName())
+func (c *DeploymentProcessConverterBase) generateChildStepName(parent octopus.NameIdParentResource, owner octopus.NameIdParentResource, named octopus.NamedResource, dependencies *data.ResourceDetailsCollection) string {
+	return "process_child_step_" + c.getOwnerLabel(parent, owner, dependencies) + "_" + sanitizer.SanitizeName(named.GetName())
 }
 
 func (c *DeploymentProcessConverterBase) assignProperties(propertyName string, block *hclwrite.Block, owner octopus.NameIdParentResource, properties map[string]any, keepFields []string, removeFields []string, action octopus.NamedResource, file *hclwrite.File, dependencies *data.ResourceDetailsCollection) {
@@ -971,6 +1046,7 @@ func (c *DeploymentProcessConverterBase) assignProperties(propertyName string, b
 
 	sanitizedProperties, variables := steps.MapSanitizer{
 		DummySecretGenerator:      c.DummySecretGenerator,
+		SecretProvider:            c.SecretProvider,
 		DummySecretVariableValues: c.DummySecretVariableValues,
 	}.SanitizeMap(owner, action, properties, dependencies)
 	sanitizedProperties = c.OctopusActionProcessor.RemoveFields(sanitizedProperties, removeFields)
@@ -984,9 +1060,24 @@ func (c *DeploymentProcessConverterBase) assignProperties(propertyName string, b
 	sanitizedProperties = c.OctopusActionProcessor.RemoveUnnecessaryActionFields(sanitizedProperties)
 	sanitizedProperties = c.OctopusActionProcessor.RemoveStepTemplateFields(sanitizedProperties)
 	sanitizedProperties = c.OctopusActionProcessor.FixActionFields(sanitizedProperties)
-	sanitizedProperties = c.OctopusActionProcessor.LimitPropertyLength(c.LimitAttributeLength, true, sanitizedProperties)
 
-	hcl.WriteStepProperties(propertyName, block, sanitizedProperties)
+	expressions := map[string]string{}
+	if c.ExtractScripts && propertyName == "execution_properties" {
+		sanitizedProperties, expressions = extractScriptProperties(c.getScriptOwner(owner, dependencies), action.GetName(), action.GetId(), sanitizedProperties, dependencies)
+	}
+
+	limitedProperties := c.OctopusActionProcessor.LimitPropertyLength(c.LimitAttributeLength, true, sanitizedProperties)
+
+	for _, key := range slices.Sorted(maps.Keys(limitedProperties)) {
+		if limitedProperties[key] != sanitizedProperties[key] {
+			c.recordProcessFinding(owner, data.LintSeverityLossy,
+				"The property \""+key+"\" of \""+action.GetName()+"\" was truncated to "+fmt.Sprint(c.LimitAttributeLength)+" characters.", dependencies)
+		}
+	}
+
+	sanitizedProperties = limitedProperties
+
+	hcl.WriteStepPropertiesWithExpressions(propertyName, block, sanitizedProperties, expressions)
 
 	for _, propertyVariables := range variables {
 		propertyVariablesBlock := gohcl.EncodeAsBlock(propertyVariables, "variable")
@@ -0,0 +1,107 @@
+package converters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/generators"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+)
+
+func TestChildStepOrderImportId(t *testing.T) {
+	dependencies := data.ResourceDetailsCollection{}
+	converter := DeploymentProcessConverterBase{ResourceType: "DeploymentProcesses"}
+	project := octopus.Project{NameId: octopus.NameId{Id: "Projects-1", Name: "Web"}}
+	process := octopus.DeploymentProcess{Id: "deploymentprocess-Projects-1", ProjectId: "Projects-1"}
+	step := octopus.Step{
+		Id:   strutil.StrPointer("00000000-0000-0000-0000-000000000001"),
+		Name: strutil.StrPointer("Deploy"),
+		Actions: []octopus.Action{
+			{Id: "00000000-0000-0000-0000-000000000002", Name: strutil.StrPointer("Deploy")},
+			{Id: "00000000-0000-0000-0000-000000000003", Name: strutil.StrPointer("Notify")},
+		},
+	}
+
+	converter.generateChildStepOrder(false, &process, nil, &project, &step, false, &dependencies)
+	converter.toStepBashImport(converter.generateStepName(nil, &project, &step, &dependencies), converter.generateChildStepOrderName(nil, &project, &step, &dependencies), project.GetName(), step.GetName(), &dependencies)
+
+	order := dependencies.GetAllResource("DeploymentProcesses/StepOrder")
+	if len(order) != 1 {
+		t.Fatalf("expected 1 child step order, found %d", len(order))
+	}
+
+	if order[0].ImportId != "deploymentprocess-Projects-1:00000000-0000-0000-0000-000000000001" {
+		t.Fatalf("unexpected import ID %s", order[0].ImportId)
+	}
+
+	// The import script looks up the project and step by name, and imports both the step and the child step order
+	// with the IDs it resolves
+	script, err := dependencies.Resources[1].ToHcl()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	variables, imports := parseImportScript(script, map[string]string{
+		"RESOURCE_ID": project.GetId(),
+		"STEP_ID":     step.GetId(),
+	})
+
+	if variables["RESOURCE_NAME"] != project.GetName() || !strings.Contains(script, "\"$2/api/$3/Projects\"") {
+		t.Fatalf("expected the script to look up the project %s, found %s", project.GetName(), script)
+	}
+
+	if variables["STEP_NAME"] != step.GetName() || !strings.Contains(script, "\"$2/api/$3/Projects/${RESOURCE_ID}/deploymentprocesses\"") {
+		t.Fatalf("expected the script to look up the step %s in the deployment process, found %s", step.GetName(), script)
+	}
+
+	stepAddress := octopusdeployProcessStepResourceType + "." + converter.generateStepName(nil, &project, &step, &dependencies)
+	if imports[stepAddress] != converter.getStepImportId(&process, &step) {
+		t.Fatalf("expected the step to be imported with %s, found %v", converter.getStepImportId(&process, &step), imports)
+	}
+
+	childStepOrderAddress := octopusdeployProcessChildStepsOrder + "." + converter.generateChildStepOrderName(nil, &project, &step, &dependencies)
+	if imports[childStepOrderAddress] != order[0].ImportId {
+		t.Fatalf("expected the child step order to be imported with %s, found %v", order[0].ImportId, imports)
+	}
+
+	// The import block uses the same ID as the import script
+	generators.ImportBlockGenerator{}.AddImportBlocks(&dependencies)
+
+	hcl, err := dependencies.GetAllResource("DeploymentProcesses/StepOrder")[0].ToHcl()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(hcl, "to = octopusdeploy_process_child_steps_order.process_child_step_order_web_deploy") ||
+		!strings.Contains(hcl, "id = \"deploymentprocess-Projects-1:00000000-0000-0000-0000-000000000001\"") {
+		t.Fatalf("unexpected import block %s", hcl)
+	}
+}
+
+// parseImportScript returns the quoted variables assigned by a bash import script, and the IDs imported into each
+// resource address. The values of the lookup variables are substituted into the imported IDs.
+func parseImportScript(script string, lookups map[string]string) (map[string]string, map[string]string) {
+	variables := map[string]string{}
+	imports := map[string]string{}
+
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+
+		if name, value, found := strings.Cut(line, "=\""); found && strings.HasSuffix(value, "\"") && !strings.Contains(name, " ") {
+			variables[name] = strings.TrimSuffix(value, "\"")
+		}
+
+		if strings.HasPrefix(line, "terraform import ") {
+			fields := strings.Fields(line)
+			id := fields[len(fields)-1]
+			for name, value := range lookups {
+				id = strings.ReplaceAll(id, "${"+name+"}", value)
+			}
+			imports[variables["ID"]] = id
+		}
+	}
+
+	return variables, imports
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
@@ -67,8 +66,10 @@ func (c EnvironmentConverter) allToHcl(stateless bool, dependencies *data.Resour
 			continue
 		}
 
-		zap.L().Info("Environment: " + resource.Id + " " + resource.Name)
-		err := c.toHcl(resource, false, stateless, dependencies)
+		err := dependencies.ConvertResource(resource.Id, c.GetResourceType(), func() error {
+			zap.L().Info("Environment: " + resource.Id + " " + resource.Name)
+			return c.toHcl(resource, false, stateless, dependencies)
+		})
 
 		if err != nil {
 			return err
@@ -94,25 +95,23 @@ func (c EnvironmentConverter) toHclById(id string, stateless bool, dependencies
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.Environment{}
-	found, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.Environment{}
+		found, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Environment: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Environment: %w", err)
+		}
 
-	// Environment lists can be for regular environments or parent environments.
-	// If the resource is not found, it may be a parent environment, so we will skip it.
-	if !found {
-		return nil
-	}
+		// Environment lists can be for regular environments or parent environments.
+		// If the resource is not found, it may be a parent environment, so we will skip it.
+		if !found {
+			return nil
+		}
 
-	zap.L().Info("Environment: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, true, stateless, dependencies)
+		zap.L().Info("Environment: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, true, stateless, dependencies)
+	})
 }
 
 func (c EnvironmentConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -120,42 +119,44 @@ func (c EnvironmentConverter) ToHclLookupById(id string, dependencies *data.Reso
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	environment := octopus.Environment{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &environment)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		environment := octopus.Environment{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &environment)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Environment: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Environment: %w", err)
+		}
 
-	if c.Excluder.IsResourceExcludedWithRegex(environment.Name, c.ExcludeAllEnvironments, c.ExcludeEnvironments, c.ExcludeEnvironmentsRegex, c.ExcludeEnvironmentsExcept) {
-		return nil
-	}
+		if c.Excluder.IsResourceExcludedWithRegex(environment.Name, c.ExcludeAllEnvironments, c.ExcludeEnvironments, c.ExcludeEnvironmentsRegex, c.ExcludeEnvironmentsExcept) {
+			return nil
+		}
 
-	thisResource := data.ResourceDetails{}
+		thisResource := data.ResourceDetails{}
 
-	resourceName := "environment_" + sanitizer.SanitizeName(environment.Name)
+		resourceName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "environment_", environment.Id, environment.Name)
 
-	thisResource.FileName = "space_population/" + resourceName + ".tf"
-	thisResource.Id = environment.Id
-	thisResource.Name = environment.Name
-	thisResource.ResourceType = c.GetResourceType()
-	thisResource.Lookup = "${data." + octopusdeployEnvironmentsDataType + "." + resourceName + ".environments[0].id}"
-	thisResource.ToHcl = func() (string, error) {
-		terraformResource := c.buildData(resourceName, environment)
-		file := hclwrite.NewEmptyFile()
-		block := gohcl.EncodeAsBlock(terraformResource, "data")
-		hcl.WriteLifecyclePostCondition(block, "Failed to resolve an environment called \""+environment.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.environments) != 0")
-		file.Body().AppendBlock(block)
+		if err != nil {
+			return err
+		}
 
-		return string(file.Bytes()), nil
-	}
+		thisResource.FileName = "space_population/" + resourceName + ".tf"
+		thisResource.Id = environment.Id
+		thisResource.Name = environment.Name
+		thisResource.ResourceType = c.GetResourceType()
+		thisResource.Lookup = "${data." + octopusdeployEnvironmentsDataType + "." + resourceName + ".environments[0].id}"
+		thisResource.ToHcl = func() (string, error) {
+			terraformResource := c.buildData(resourceName, environment)
+			file := hclwrite.NewEmptyFile()
+			block := gohcl.EncodeAsBlock(terraformResource, "data")
+			hcl.WriteLifecyclePostCondition(block, "Failed to resolve an environment called \""+environment.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.environments) != 0")
+			file.Body().AppendBlock(block)
+
+			return string(file.Bytes()), nil
+		}
 
-	dependencies.AddResource(thisResource)
-	return nil
+		dependencies.AddResource(thisResource)
+		return nil
+	})
 }
 
 func (c EnvironmentConverter) buildData(resourceName string, resource octopus.Environment) terraform.TerraformEnvironmentData {
@@ -325,7 +326,11 @@ func (c EnvironmentConverter) toHcl(environment octopus.Environment, _ bool, sta
 		return nil
 	}
 
-	resourceName := "environment_" + sanitizer.SanitizeName(environment.Name)
+	resourceName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "environment_", environment.Id, environment.Name)
+
+	if err != nil {
+		return err
+	}
 
 	if c.GenerateImportScripts && !stateless {
 		c.toBashImport(resourceName, environment.Name, dependencies)
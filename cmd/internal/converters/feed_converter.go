@@ -12,6 +12,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/naming"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/secrets"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
@@ -28,6 +29,10 @@ const octopusdeployHelmFeedResourceType = "octopusdeploy_helm_feed"
 const octopusdeployNugetFeedResourceType = "octopusdeploy_nuget_feed"
 const octopusdeployArtifactoryFeedResourceType = "octopusdeploy_artifactory_generic_feed"
 const octopusdeployS3FeedResourceType = "octopusdeploy_s3_feed"
+const octopusdeployOciRegistryFeedResourceType = "octopusdeploy_oci_registry_feed"
+const octopusdeployAzureContainerRegistryResourceType = "octopusdeploy_azure_container_registry"
+const octopusdeployGoogleContainerRegistryResourceType = "octopusdeploy_google_container_registry"
+const octopusdeployNpmFeedResourceType = "octopusdeploy_npm_feed"
 
 const artifactory_feed_type = "ArtifactoryGeneric"
 const s3_feed_type = "S3"
@@ -36,6 +41,7 @@ type FeedConverter struct {
 	Client                    client.OctopusClient
 	DummySecretVariableValues bool
 	DummySecretGenerator      dummy.DummySecretGenerator
+	SecretProvider            secrets.SecretProvider
 	ErrGroup                  *errgroup.Group
 	ExcludeFeeds              args.StringSliceArgs
 	ExcludeFeedsRegex         args.StringSliceArgs
@@ -84,8 +90,10 @@ func (c FeedConverter) allToHcl(stateless bool, dependencies *data.ResourceDetai
 			continue
 		}
 
-		zap.L().Info("Feed: " + resource.Id + " " + resource.Name)
-		err := c.toHcl(resource, false, false, stateless, dependencies)
+		err := dependencies.ConvertResource(resource.Id, c.GetResourceType(), func() error {
+			zap.L().Info("Feed: " + resource.Id + " " + resource.Name)
+			return c.toHcl(resource, false, false, stateless, dependencies)
+		})
 
 		if err != nil {
 			return err
@@ -108,19 +116,17 @@ func (c FeedConverter) toHclById(id string, stateless bool, dependencies *data.R
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.Feed{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.Feed{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Feed: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Feed: %w", err)
+		}
 
-	zap.L().Info("Feed: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, true, false, stateless, dependencies)
+		zap.L().Info("Feed: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, true, false, stateless, dependencies)
+	})
 }
 
 func (c FeedConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -128,22 +134,20 @@ func (c FeedConverter) ToHclLookupById(id string, dependencies *data.ResourceDet
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.Feed{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.Feed{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Feed: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Feed: %w", err)
+		}
 
-	if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllFeeds, c.ExcludeFeeds, c.ExcludeFeedsRegex, c.ExcludeFeedsExcept) {
-		return nil
-	}
+		if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllFeeds, c.ExcludeFeeds, c.ExcludeFeedsRegex, c.ExcludeFeedsExcept) {
+			return nil
+		}
 
-	return c.toHcl(resource, false, true, false, dependencies)
+		return c.toHcl(resource, false, true, false, dependencies)
+	})
 }
 
 // toBashImport creates a bash script to import the resource
@@ -282,7 +286,11 @@ func (c FeedConverter) toHcl(resource octopus.Feed, _ bool, lookup bool, statele
 		strutil.EmptyIfNil(resource.FeedType) == "BuiltIn" ||
 		strutil.EmptyIfNil(resource.FeedType) == "OctopusProject"
 
-	resourceName := "feed_" + sanitizer.SanitizeName(resource.Name)
+	resourceName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "feed_", resource.Id, resource.Name)
+
+	if err != nil {
+		return err
+	}
 
 	thisResource := data.ResourceDetails{}
 
@@ -310,8 +318,17 @@ func (c FeedConverter) toHclResource(stateless bool, dependencies *data.Resource
 		c.exportHelm(stateless, dependencies, resource, thisResource, resourceName) ||
 		c.exportArtifactory(stateless, dependencies, resource, thisResource, resourceName) ||
 		c.exportS3(stateless, dependencies, resource, thisResource, resourceName) ||
-		c.exportNuget(stateless, dependencies, resource, thisResource, resourceName)) {
-		zap.L().Error("Found unexpected feed type \"" + strutil.EmptyIfNil(resource.FeedType) + "\" with name \"" + resource.Name + "\".")
+		c.exportNuget(stateless, dependencies, resource, thisResource, resourceName) ||
+		c.exportOciRegistry(stateless, dependencies, resource, thisResource, resourceName) ||
+		c.exportAzureContainerRegistry(stateless, dependencies, resource, thisResource, resourceName) ||
+		c.exportGoogleContainerRegistry(stateless, dependencies, resource, thisResource, resourceName) ||
+		c.exportNpm(stateless, dependencies, resource, thisResource, resourceName)) {
+		// There is no provider resource for this feed type, so the best we can do is reference an existing feed.
+		// This means steps that use the feed still resolve it, as long as the feed is created in the space manually.
+		zap.L().Warn("Found unexpected feed type \"" + strutil.EmptyIfNil(resource.FeedType) + "\" with name \"" + resource.Name + "\". The feed will be looked up by name.")
+		recordLintFinding(dependencies, data.LintSeverityLossy, c.GetResourceType(), resource.Id, resource.Name,
+			"The feed type \""+strutil.EmptyIfNil(resource.FeedType)+"\" is not supported by the Terraform provider, so the feed is looked up by name and must be created manually.")
+		c.toHclLookup(resource, thisResource, resourceName)
 	}
 }
 
@@ -342,7 +359,7 @@ func (c FeedConverter) exportDocker(stateless bool, dependencies *data.ResourceD
 		thisResource.Lookup = "${" + octopusdeployDockerContainerRegistryResourceType + "." + resourceName + ".id}"
 	}
 
-	passwordName := naming.FeedSecretName(resource)
+	passwordName := naming.FeedSecretName(resourceName)
 
 	parameters := []data.ResourceParameter{}
 	if resource.Password != nil && resource.Password.HasValue {
@@ -392,7 +409,7 @@ func (c FeedConverter) exportDocker(stateless bool, dependencies *data.ResourceD
 
 			terraformResource.Password = &password
 
-			if c.DummySecretVariableValues {
+			if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(passwordName, resource), dependencies) && c.DummySecretVariableValues {
 				secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
 				dependencies.AddDummy(data.DummyVariableReference{
 					VariableName: passwordName,
@@ -437,7 +454,7 @@ func (c FeedConverter) exportAws(stateless bool, dependencies *data.ResourceDeta
 		thisResource.Lookup = "${" + octopusdeployAwsElasticContainerRegistryResourceType + "." + resourceName + ".id}"
 	}
 
-	passwordName := naming.FeedSecretKeyName(resource)
+	passwordName := naming.FeedSecretKeyName(resourceName)
 
 	thisResource.Parameters = []data.ResourceParameter{
 		{
@@ -482,7 +499,7 @@ func (c FeedConverter) exportAws(stateless bool, dependencies *data.ResourceDeta
 
 			terraformResource.SecretKey = &password
 
-			if c.DummySecretVariableValues {
+			if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(passwordName, resource), dependencies) && c.DummySecretVariableValues {
 				secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
 				dependencies.AddDummy(data.DummyVariableReference{
 					VariableName: passwordName,
@@ -529,7 +546,7 @@ func (c FeedConverter) exportMaven(stateless bool, dependencies *data.ResourceDe
 		thisResource.Lookup = "${" + octopusdeployMavenFeedResourceType + "." + resourceName + ".id}"
 	}
 
-	passwordName := naming.FeedSecretName(resource)
+	passwordName := naming.FeedSecretName(resourceName)
 
 	parameters := []data.ResourceParameter{}
 
@@ -579,7 +596,7 @@ func (c FeedConverter) exportMaven(stateless bool, dependencies *data.ResourceDe
 
 			terraformResource.Password = &password
 
-			if c.DummySecretVariableValues {
+			if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(passwordName, resource), dependencies) && c.DummySecretVariableValues {
 				secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
 				dependencies.AddDummy(data.DummyVariableReference{
 					VariableName: passwordName,
@@ -624,7 +641,7 @@ func (c FeedConverter) exportGithub(stateless bool, dependencies *data.ResourceD
 		thisResource.Lookup = "${" + octopusdeployGithubRepositoryFeedResourceType + "." + resourceName + ".id}"
 	}
 
-	passwordName := naming.FeedSecretName(resource)
+	passwordName := naming.FeedSecretName(resourceName)
 
 	parameters := []data.ResourceParameter{}
 	if resource.Password != nil && resource.Password.HasValue {
@@ -673,7 +690,7 @@ func (c FeedConverter) exportGithub(stateless bool, dependencies *data.ResourceD
 
 			terraformResource.Password = &password
 
-			if c.DummySecretVariableValues {
+			if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(passwordName, resource), dependencies) && c.DummySecretVariableValues {
 				secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
 				dependencies.AddDummy(data.DummyVariableReference{
 					VariableName: passwordName,
@@ -719,7 +736,7 @@ func (c FeedConverter) exportHelm(stateless bool, dependencies *data.ResourceDet
 		thisResource.Lookup = "${" + octopusdeployHelmFeedResourceType + "." + resourceName + ".id}"
 	}
 
-	passwordName := naming.FeedSecretName(resource)
+	passwordName := naming.FeedSecretName(resourceName)
 
 	parameters := []data.ResourceParameter{}
 	if resource.Password != nil && resource.Password.HasValue {
@@ -767,7 +784,7 @@ func (c FeedConverter) exportHelm(stateless bool, dependencies *data.ResourceDet
 
 			terraformResource.Password = &password
 
-			if c.DummySecretVariableValues {
+			if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(passwordName, resource), dependencies) && c.DummySecretVariableValues {
 				secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
 				dependencies.AddDummy(data.DummyVariableReference{
 					VariableName: passwordName,
@@ -794,6 +811,381 @@ func (c FeedConverter) exportHelm(stateless bool, dependencies *data.ResourceDet
 
 }
 
+func (c FeedConverter) exportOciRegistry(stateless bool, dependencies *data.ResourceDetailsCollection, resource octopus.Feed, thisResource *data.ResourceDetails, resourceName string) bool {
+	if strutil.EmptyIfNil(resource.FeedType) != "OciRegistry" {
+		return false
+	}
+
+	if c.GenerateImportScripts && !stateless {
+		c.toBashImport(octopusdeployOciRegistryFeedResourceType, resourceName, resource.Name, dependencies)
+		c.toPowershellImport(octopusdeployOciRegistryFeedResourceType, resourceName, resource.Name, dependencies)
+	}
+
+	if stateless {
+		thisResource.Lookup = "${length(data." + octopusdeployFeedsDataType + "." + resourceName + ".feeds) != 0 " +
+			"? data." + octopusdeployFeedsDataType + "." + resourceName + ".feeds[0].id " +
+			": " + octopusdeployOciRegistryFeedResourceType + "." + resourceName + "[0].id}"
+		thisResource.Dependency = "${" + octopusdeployOciRegistryFeedResourceType + "." + resourceName + "}"
+	} else {
+		thisResource.Lookup = "${" + octopusdeployOciRegistryFeedResourceType + "." + resourceName + ".id}"
+	}
+
+	passwordName := naming.FeedSecretName(resourceName)
+
+	parameters := []data.ResourceParameter{}
+	if resource.Password != nil && resource.Password.HasValue {
+		parameters = append(parameters, data.ResourceParameter{
+			Label:         "OCI Registry Feed " + resource.Name + " password",
+			Description:   "The password associated with the feed \"" + resource.Name + "\"",
+			ResourceName:  sanitizer.SanitizeParameterName(dependencies, resource.Name, "Password"),
+			ParameterType: "Password",
+			Sensitive:     true,
+			VariableName:  passwordName,
+		})
+	}
+
+	thisResource.Parameters = parameters
+	thisResource.ToHcl = func() (string, error) {
+
+		password := "${var." + passwordName + "}"
+
+		terraformResource := terraform.TerraformOciRegistryFeed{
+			Type:         octopusdeployOciRegistryFeedResourceType,
+			Id:           strutil.InputPointerIfEnabled(c.IncludeIds, &resource.Id),
+			SpaceId:      strutil.InputIfEnabled(c.IncludeSpaceInPopulation, dependencies.GetResourceDependency("Spaces", resource.SpaceId)),
+			Name:         resourceName,
+			ResourceName: resource.Name,
+			FeedUri:      resource.FeedUri,
+			Username:     strutil.NilIfEmptyPointer(resource.Username),
+		}
+
+		file := hclwrite.NewEmptyFile()
+
+		if stateless {
+			c.writeData(file, resource.Name, "OciRegistry", resourceName)
+			terraformResource.Count = strutil.StrPointer("${length(data." + octopusdeployFeedsDataType + "." + resourceName + ".feeds) != 0 ? 0 : 1}")
+		}
+
+		if resource.Password != nil && resource.Password.HasValue {
+			secretVariableResource := terraform.TerraformVariable{
+				Name:        passwordName,
+				Type:        "string",
+				Nullable:    false,
+				Sensitive:   true,
+				Description: "The password used by the feed " + resource.Name,
+			}
+
+			terraformResource.Password = &password
+
+			if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(passwordName, resource), dependencies) && c.DummySecretVariableValues {
+				secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
+				dependencies.AddDummy(data.DummyVariableReference{
+					VariableName: passwordName,
+					ResourceName: resource.Name,
+					ResourceType: c.GetResourceType(),
+				})
+			}
+
+			block := gohcl.EncodeAsBlock(secretVariableResource, "variable")
+			hcl.WriteUnquotedAttribute(block, "type", "string")
+			file.Body().AppendBlock(block)
+		}
+
+		targetBlock := gohcl.EncodeAsBlock(terraformResource, "resource")
+
+		c.writeLifecycleAttributes(targetBlock, "password", stateless)
+
+		file.Body().AppendBlock(targetBlock)
+
+		return string(file.Bytes()), nil
+	}
+
+	return true
+}
+
+func (c FeedConverter) exportAzureContainerRegistry(stateless bool, dependencies *data.ResourceDetailsCollection, resource octopus.Feed, thisResource *data.ResourceDetails, resourceName string) bool {
+	if strutil.EmptyIfNil(resource.FeedType) != "AzureContainerRegistry" {
+		return false
+	}
+
+	if c.GenerateImportScripts && !stateless {
+		c.toBashImport(octopusdeployAzureContainerRegistryResourceType, resourceName, resource.Name, dependencies)
+		c.toPowershellImport(octopusdeployAzureContainerRegistryResourceType, resourceName, resource.Name, dependencies)
+	}
+
+	if stateless {
+		thisResource.Lookup = "${length(data." + octopusdeployFeedsDataType + "." + resourceName + ".feeds) != 0 " +
+			"? data." + octopusdeployFeedsDataType + "." + resourceName + ".feeds[0].id " +
+			": " + octopusdeployAzureContainerRegistryResourceType + "." + resourceName + "[0].id}"
+		thisResource.Dependency = "${" + octopusdeployAzureContainerRegistryResourceType + "." + resourceName + "}"
+	} else {
+		thisResource.Lookup = "${" + octopusdeployAzureContainerRegistryResourceType + "." + resourceName + ".id}"
+	}
+
+	passwordName := naming.FeedSecretName(resourceName)
+
+	parameters := []data.ResourceParameter{}
+	if resource.Password != nil && resource.Password.HasValue {
+		parameters = append(parameters, data.ResourceParameter{
+			Label:         "ACR Feed " + resource.Name + " password",
+			Description:   "The password associated with the feed \"" + resource.Name + "\"",
+			ResourceName:  sanitizer.SanitizeParameterName(dependencies, resource.Name, "Password"),
+			ParameterType: "Password",
+			Sensitive:     true,
+			VariableName:  passwordName,
+		})
+	}
+
+	thisResource.Parameters = parameters
+	thisResource.ToHcl = func() (string, error) {
+
+		password := "${var." + passwordName + "}"
+
+		terraformResource := terraform.TerraformAzureContainerRegistryFeed{
+			Type:         octopusdeployAzureContainerRegistryResourceType,
+			Id:           strutil.InputPointerIfEnabled(c.IncludeIds, &resource.Id),
+			SpaceId:      strutil.InputIfEnabled(c.IncludeSpaceInPopulation, dependencies.GetResourceDependency("Spaces", resource.SpaceId)),
+			Name:         resourceName,
+			ResourceName: resource.Name,
+			FeedUri:      resource.FeedUri,
+			RegistryPath: resource.RegistryPath,
+			ApiVersion:   resource.ApiVersion,
+			Username:     strutil.NilIfEmptyPointer(resource.Username),
+		}
+
+		file := hclwrite.NewEmptyFile()
+
+		if stateless {
+			c.writeData(file, resource.Name, "AzureContainerRegistry", resourceName)
+			terraformResource.Count = strutil.StrPointer("${length(data." + octopusdeployFeedsDataType + "." + resourceName + ".feeds) != 0 ? 0 : 1}")
+		}
+
+		if resource.Password != nil && resource.Password.HasValue {
+			secretVariableResource := terraform.TerraformVariable{
+				Name:        passwordName,
+				Type:        "string",
+				Nullable:    false,
+				Sensitive:   true,
+				Description: "The password used by the feed " + resource.Name,
+			}
+
+			terraformResource.Password = &password
+
+			if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(passwordName, resource), dependencies) && c.DummySecretVariableValues {
+				secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
+				dependencies.AddDummy(data.DummyVariableReference{
+					VariableName: passwordName,
+					ResourceName: resource.Name,
+					ResourceType: c.GetResourceType(),
+				})
+			}
+
+			block := gohcl.EncodeAsBlock(secretVariableResource, "variable")
+			hcl.WriteUnquotedAttribute(block, "type", "string")
+			file.Body().AppendBlock(block)
+		}
+
+		targetBlock := gohcl.EncodeAsBlock(terraformResource, "resource")
+
+		c.writeLifecycleAttributes(targetBlock, "password", stateless)
+
+		file.Body().AppendBlock(targetBlock)
+
+		return string(file.Bytes()), nil
+	}
+
+	return true
+}
+
+func (c FeedConverter) exportGoogleContainerRegistry(stateless bool, dependencies *data.ResourceDetailsCollection, resource octopus.Feed, thisResource *data.ResourceDetails, resourceName string) bool {
+	if strutil.EmptyIfNil(resource.FeedType) != "GoogleContainerRegistry" {
+		return false
+	}
+
+	if c.GenerateImportScripts && !stateless {
+		c.toBashImport(octopusdeployGoogleContainerRegistryResourceType, resourceName, resource.Name, dependencies)
+		c.toPowershellImport(octopusdeployGoogleContainerRegistryResourceType, resourceName, resource.Name, dependencies)
+	}
+
+	if stateless {
+		thisResource.Lookup = "${length(data." + octopusdeployFeedsDataType + "." + resourceName + ".feeds) != 0 " +
+			"? data." + octopusdeployFeedsDataType + "." + resourceName + ".feeds[0].id " +
+			": " + octopusdeployGoogleContainerRegistryResourceType + "." + resourceName + "[0].id}"
+		thisResource.Dependency = "${" + octopusdeployGoogleContainerRegistryResourceType + "." + resourceName + "}"
+	} else {
+		thisResource.Lookup = "${" + octopusdeployGoogleContainerRegistryResourceType + "." + resourceName + ".id}"
+	}
+
+	passwordName := naming.FeedSecretName(resourceName)
+
+	parameters := []data.ResourceParameter{}
+	if resource.Password != nil && resource.Password.HasValue {
+		parameters = append(parameters, data.ResourceParameter{
+			Label:         "GCR Feed " + resource.Name + " password",
+			Description:   "The password associated with the feed \"" + resource.Name + "\"",
+			ResourceName:  sanitizer.SanitizeParameterName(dependencies, resource.Name, "Password"),
+			ParameterType: "Password",
+			Sensitive:     true,
+			VariableName:  passwordName,
+		})
+	}
+
+	thisResource.Parameters = parameters
+	thisResource.ToHcl = func() (string, error) {
+
+		password := "${var." + passwordName + "}"
+
+		terraformResource := terraform.TerraformGoogleContainerRegistryFeed{
+			Type:         octopusdeployGoogleContainerRegistryResourceType,
+			Id:           strutil.InputPointerIfEnabled(c.IncludeIds, &resource.Id),
+			SpaceId:      strutil.InputIfEnabled(c.IncludeSpaceInPopulation, dependencies.GetResourceDependency("Spaces", resource.SpaceId)),
+			Name:         resourceName,
+			ResourceName: resource.Name,
+			FeedUri:      resource.FeedUri,
+			RegistryPath: resource.RegistryPath,
+			ApiVersion:   resource.ApiVersion,
+			Username:     strutil.NilIfEmptyPointer(resource.Username),
+		}
+
+		file := hclwrite.NewEmptyFile()
+
+		if stateless {
+			c.writeData(file, resource.Name, "GoogleContainerRegistry", resourceName)
+			terraformResource.Count = strutil.StrPointer("${length(data." + octopusdeployFeedsDataType + "." + resourceName + ".feeds) != 0 ? 0 : 1}")
+		}
+
+		if resource.Password != nil && resource.Password.HasValue {
+			secretVariableResource := terraform.TerraformVariable{
+				Name:        passwordName,
+				Type:        "string",
+				Nullable:    false,
+				Sensitive:   true,
+				Description: "The password used by the feed " + resource.Name,
+			}
+
+			terraformResource.Password = &password
+
+			if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(passwordName, resource), dependencies) && c.DummySecretVariableValues {
+				secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
+				dependencies.AddDummy(data.DummyVariableReference{
+					VariableName: passwordName,
+					ResourceName: resource.Name,
+					ResourceType: c.GetResourceType(),
+				})
+			}
+
+			block := gohcl.EncodeAsBlock(secretVariableResource, "variable")
+			hcl.WriteUnquotedAttribute(block, "type", "string")
+			file.Body().AppendBlock(block)
+		}
+
+		targetBlock := gohcl.EncodeAsBlock(terraformResource, "resource")
+
+		c.writeLifecycleAttributes(targetBlock, "password", stateless)
+
+		file.Body().AppendBlock(targetBlock)
+
+		return string(file.Bytes()), nil
+	}
+
+	return true
+}
+
+func (c FeedConverter) exportNpm(stateless bool, dependencies *data.ResourceDetailsCollection, resource octopus.Feed, thisResource *data.ResourceDetails, resourceName string) bool {
+	if strutil.EmptyIfNil(resource.FeedType) != "Npm" {
+		return false
+	}
+
+	if c.GenerateImportScripts && !stateless {
+		c.toBashImport(octopusdeployNpmFeedResourceType, resourceName, resource.Name, dependencies)
+		c.toPowershellImport(octopusdeployNpmFeedResourceType, resourceName, resource.Name, dependencies)
+	}
+
+	if stateless {
+		thisResource.Lookup = "${length(data." + octopusdeployFeedsDataType + "." + resourceName + ".feeds) != 0 " +
+			"? data." + octopusdeployFeedsDataType + "." + resourceName + ".feeds[0].id " +
+			": " + octopusdeployNpmFeedResourceType + "." + resourceName + "[0].id}"
+		thisResource.Dependency = "${" + octopusdeployNpmFeedResourceType + "." + resourceName + "}"
+	} else {
+		thisResource.Lookup = "${" + octopusdeployNpmFeedResourceType + "." + resourceName + ".id}"
+	}
+
+	passwordName := naming.FeedSecretName(resourceName)
+
+	parameters := []data.ResourceParameter{}
+	if resource.Password != nil && resource.Password.HasValue {
+		parameters = append(parameters, data.ResourceParameter{
+			Label:         "npm Feed " + resource.Name + " password",
+			Description:   "The password associated with the feed \"" + resource.Name + "\"",
+			ResourceName:  sanitizer.SanitizeParameterName(dependencies, resource.Name, "Password"),
+			ParameterType: "Password",
+			Sensitive:     true,
+			VariableName:  passwordName,
+		})
+	}
+
+	thisResource.Parameters = parameters
+	thisResource.ToHcl = func() (string, error) {
+
+		password := "${var." + passwordName + "}"
+
+		terraformResource := terraform.TerraformNpmFeed{
+			Type:                              octopusdeployNpmFeedResourceType,
+			Id:                                strutil.InputPointerIfEnabled(c.IncludeIds, &resource.Id),
+			SpaceId:                           strutil.InputIfEnabled(c.IncludeSpaceInPopulation, dependencies.GetResourceDependency("Spaces", resource.SpaceId)),
+			Name:                              resourceName,
+			ResourceName:                      resource.Name,
+			FeedUri:                           resource.FeedUri,
+			Username:                          strutil.NilIfEmptyPointer(resource.Username),
+			PackageAcquisitionLocationOptions: resource.PackageAcquisitionLocationOptions,
+			DownloadAttempts:                  resource.DownloadAttempts,
+			DownloadRetryBackoffSeconds:       resource.DownloadRetryBackoffSeconds,
+		}
+
+		file := hclwrite.NewEmptyFile()
+
+		if stateless {
+			c.writeData(file, resource.Name, "Npm", resourceName)
+			terraformResource.Count = strutil.StrPointer("${length(data." + octopusdeployFeedsDataType + "." + resourceName + ".feeds) != 0 ? 0 : 1}")
+		}
+
+		if resource.Password != nil && resource.Password.HasValue {
+			secretVariableResource := terraform.TerraformVariable{
+				Name:        passwordName,
+				Type:        "string",
+				Nullable:    false,
+				Sensitive:   true,
+				Description: "The password used by the feed " + resource.Name,
+			}
+
+			terraformResource.Password = &password
+
+			if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(passwordName, resource), dependencies) && c.DummySecretVariableValues {
+				secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
+				dependencies.AddDummy(data.DummyVariableReference{
+					VariableName: passwordName,
+					ResourceName: resource.Name,
+					ResourceType: c.GetResourceType(),
+				})
+			}
+
+			block := gohcl.EncodeAsBlock(secretVariableResource, "variable")
+			hcl.WriteUnquotedAttribute(block, "type", "string")
+			file.Body().AppendBlock(block)
+		}
+
+		targetBlock := gohcl.EncodeAsBlock(terraformResource, "resource")
+
+		c.writeLifecycleAttributes(targetBlock, "password", stateless)
+
+		file.Body().AppendBlock(targetBlock)
+
+		return string(file.Bytes()), nil
+	}
+
+	return true
+}
+
 func (c FeedConverter) exportNuget(stateless bool, dependencies *data.ResourceDetailsCollection, resource octopus.Feed, thisResource *data.ResourceDetails, resourceName string) bool {
 	if strutil.EmptyIfNil(resource.FeedType) != "NuGet" {
 		return false
@@ -813,7 +1205,7 @@ func (c FeedConverter) exportNuget(stateless bool, dependencies *data.ResourceDe
 		thisResource.Lookup = "${" + octopusdeployNugetFeedResourceType + "." + resourceName + ".id}"
 	}
 
-	passwordName := naming.FeedSecretName(resource)
+	passwordName := naming.FeedSecretName(resourceName)
 
 	thisResource.Parameters = []data.ResourceParameter{
 		{
@@ -860,7 +1252,7 @@ func (c FeedConverter) exportNuget(stateless bool, dependencies *data.ResourceDe
 
 			terraformResource.Password = &password
 
-			if c.DummySecretVariableValues {
+			if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(passwordName, resource), dependencies) && c.DummySecretVariableValues {
 				secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
 				dependencies.AddDummy(data.DummyVariableReference{
 					VariableName: passwordName,
@@ -905,7 +1297,7 @@ func (c FeedConverter) exportArtifactory(stateless bool, dependencies *data.Reso
 		thisResource.Lookup = "${" + octopusdeployArtifactoryFeedResourceType + "." + resourceName + ".id}"
 	}
 
-	passwordName := naming.FeedSecretName(resource)
+	passwordName := naming.FeedSecretName(resourceName)
 
 	thisResource.Parameters = []data.ResourceParameter{
 		{
@@ -950,7 +1342,7 @@ func (c FeedConverter) exportArtifactory(stateless bool, dependencies *data.Reso
 
 			terraformResource.Password = &password
 
-			if c.DummySecretVariableValues {
+			if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(passwordName, resource), dependencies) && c.DummySecretVariableValues {
 				secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
 				dependencies.AddDummy(data.DummyVariableReference{
 					VariableName: passwordName,
@@ -995,8 +1387,8 @@ func (c FeedConverter) exportS3(stateless bool, dependencies *data.ResourceDetai
 		thisResource.Lookup = "${" + octopusdeployS3FeedResourceType + "." + resourceName + ".id}"
 	}
 
-	passwordName := naming.FeedSecretName(resource)
-	secretKeyName := naming.FeedSecretKeyName(resource)
+	passwordName := naming.FeedSecretName(resourceName)
+	secretKeyName := naming.FeedSecretKeyName(resourceName)
 
 	thisResource.Parameters = []data.ResourceParameter{
 		{
@@ -1043,7 +1435,7 @@ func (c FeedConverter) exportS3(stateless bool, dependencies *data.ResourceDetai
 
 			terraformResource.Password = &password
 
-			if c.DummySecretVariableValues {
+			if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(passwordName, resource), dependencies) && c.DummySecretVariableValues {
 				secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
 				dependencies.AddDummy(data.DummyVariableReference{
 					VariableName: passwordName,
@@ -1068,10 +1460,10 @@ func (c FeedConverter) exportS3(stateless bool, dependencies *data.ResourceDetai
 
 			terraformResource.SecretKey = &secretKey
 
-			if c.DummySecretVariableValues {
+			if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(secretKeyName, resource), dependencies) && c.DummySecretVariableValues {
 				secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
 				dependencies.AddDummy(data.DummyVariableReference{
-					VariableName: passwordName,
+					VariableName: secretKeyName,
 					ResourceName: resource.Name,
 					ResourceType: c.GetResourceType(),
 				})
@@ -1112,6 +1504,16 @@ func (c FeedConverter) writeLifecycleAttributes(targetBlock *hclwrite.Block, sec
 	}
 }
 
+// secretReference returns the secrets inventory entry for a sensitive variable exposed by the feed
+func (c FeedConverter) secretReference(variableName string, resource octopus.Feed) data.SecretVariableReference {
+	return data.SecretVariableReference{
+		VariableName: variableName,
+		ResourceType: c.GetResourceType(),
+		ResourceId:   resource.Id,
+		ResourceName: resource.Name,
+	}
+}
+
 func (c FeedConverter) toHclLookup(resource octopus.Feed, thisResource *data.ResourceDetails, resourceName string) {
 	thisResource.Lookup = "${data." + octopusdeployFeedsDataType + "." + resourceName + ".feeds[0].id}"
 
@@ -1125,7 +1527,21 @@ func (c FeedConverter) toHclLookup(resource octopus.Feed, thisResource *data.Res
 		c.lookupS3(resource, thisResource, resourceName) ||
 		c.lookupArtifactory(resource, thisResource, resourceName) ||
 		c.lookupOctopusProject(resource, thisResource, resourceName)) {
-		zap.L().Error("Found unexpected feed type \"" + strutil.EmptyIfNil(resource.FeedType) + "\" with name \"" + resource.Name + "\".")
+		c.lookupByFeedType(resource, thisResource, resourceName)
+	}
+}
+
+// lookupByFeedType looks up a feed by its name and type. It is used for feed types that have no dedicated lookup,
+// including the feed types that have no provider resource.
+func (c FeedConverter) lookupByFeedType(resource octopus.Feed, thisResource *data.ResourceDetails, resourceName string) {
+	thisResource.ToHcl = func() (string, error) {
+		terraformResource := c.buildData(resourceName, resource.Name, strutil.EmptyIfNil(resource.FeedType))
+		file := hclwrite.NewEmptyFile()
+		block := gohcl.EncodeAsBlock(terraformResource, "data")
+		hcl.WriteLifecyclePostCondition(block, "Failed to resolve a feed called \""+resource.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.feeds) != 0")
+		file.Body().AppendBlock(block)
+
+		return string(file.Bytes()), nil
 	}
 }
 
@@ -0,0 +1,247 @@
+package converters
+
+import (
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+	"github.com/samber/lo"
+	"golang.org/x/sync/errgroup"
+)
+
+// slowFeedClient serves a single feed by ID, counting the requests and delaying the response so concurrent
+// conversions of the feed overlap.
+type slowFeedClient struct {
+	collectionClient
+	feed    octopus.Feed
+	fetches *atomic.Int32
+}
+
+func (c slowFeedClient) GetSpaceResourceById(resourceType string, id string, resources any) (bool, error) {
+	c.fetches.Add(1)
+	time.Sleep(10 * time.Millisecond)
+
+	content, err := json.Marshal(c.feed)
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, json.Unmarshal(content, resources)
+}
+
+func TestConcurrentFeedConversionConvertsFeedOnce(t *testing.T) {
+	dependencies := data.ResourceDetailsCollection{}
+	feed := octopus.Feed{
+		Id:       "Feeds-1",
+		Name:     "npm",
+		FeedType: strutil.StrPointer("Npm"),
+	}
+	fetches := atomic.Int32{}
+
+	converter := FeedConverter{
+		Client: slowFeedClient{
+			collectionClient: collectionClient{collections: map[string][]any{"Feeds": {feed}}},
+			feed:             feed,
+			fetches:          &fetches,
+		},
+		Excluder: DefaultExcluder{},
+	}
+
+	group := errgroup.Group{}
+	for i := 0; i < 10; i++ {
+		group.Go(func() error {
+			if err := converter.ToHclById(feed.Id, &dependencies); err != nil {
+				return err
+			}
+
+			// Callers that lost the race wait for the feed to be converted
+			if !dependencies.HasResource(feed.Id, "Feeds") {
+				t.Errorf("expected the feed to be converted when ToHclById returned")
+			}
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		t.Fatal(err)
+	}
+
+	if fetches.Load() != 1 {
+		t.Fatalf("expected the feed to be loaded once, loaded %d times", fetches.Load())
+	}
+
+	if resources := dependencies.GetAllResource("Feeds"); len(resources) != 1 {
+		t.Fatalf("expected 1 feed, found %d", len(resources))
+	}
+
+	// Once converted, the feed is not loaded again
+	if err := converter.ToHclById(feed.Id, &dependencies); err != nil {
+		t.Fatal(err)
+	}
+
+	if fetches.Load() != 1 {
+		t.Fatalf("expected the feed to be loaded once, loaded %d times", fetches.Load())
+	}
+}
+
+func TestNpmFeedExportsPassword(t *testing.T) {
+	dependencies := data.ResourceDetailsCollection{}
+	feed := octopus.Feed{
+		Id:       "Feeds-1",
+		Name:     "npm",
+		FeedType: strutil.StrPointer("Npm"),
+		FeedUri:  strutil.StrPointer("https://registry.npmjs.org"),
+		Username: strutil.StrPointer("user"),
+		Password: &octopus.Secret{HasValue: true},
+	}
+
+	converter := FeedConverter{
+		Client:   collectionClient{collections: map[string][]any{"Feeds": {feed}}},
+		Excluder: DefaultExcluder{},
+	}
+
+	if err := converter.toHcl(feed, false, false, false, &dependencies); err != nil {
+		t.Fatal(err)
+	}
+
+	resources := dependencies.GetAllResource("Feeds")
+	if len(resources) != 1 {
+		t.Fatalf("expected 1 feed, found %d", len(resources))
+	}
+
+	if resources[0].Lookup != "${octopusdeploy_npm_feed.feed_npm.id}" {
+		t.Fatalf("unexpected lookup %s", resources[0].Lookup)
+	}
+
+	hcl, err := resources[0].ToHcl()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(hcl, "resource \"octopusdeploy_npm_feed\" \"feed_npm\"") ||
+		!strings.Contains(hcl, "variable \"feed_npm_password\"") {
+		t.Fatalf("unexpected HCL %s", hcl)
+	}
+}
+
+func TestUnsupportedFeedFallsBackToLookup(t *testing.T) {
+	dependencies := data.ResourceDetailsCollection{}
+	feed := octopus.Feed{
+		Id:       "Feeds-1",
+		Name:     "python",
+		FeedType: strutil.StrPointer("PyPI"),
+	}
+
+	converter := FeedConverter{
+		Client:   collectionClient{collections: map[string][]any{"Feeds": {feed}}},
+		Excluder: DefaultExcluder{},
+	}
+
+	if err := converter.toHcl(feed, false, false, false, &dependencies); err != nil {
+		t.Fatal(err)
+	}
+
+	resources := dependencies.GetAllResource("Feeds")
+	if len(resources) != 1 {
+		t.Fatalf("expected 1 feed, found %d", len(resources))
+	}
+
+	if resources[0].Lookup != "${data.octopusdeploy_feeds.feed_python.feeds[0].id}" {
+		t.Fatalf("unexpected lookup %s", resources[0].Lookup)
+	}
+
+	hcl, err := resources[0].ToHcl()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(hcl, "data \"octopusdeploy_feeds\" \"feed_python\"") ||
+		!strings.Contains(hcl, "feed_type    = \"PyPI\"") {
+		t.Fatalf("unexpected HCL %s", hcl)
+	}
+}
+
+func TestFeedsWithSameSanitizedNameHaveUniqueLabels(t *testing.T) {
+	dependencies := data.ResourceDetailsCollection{}
+	feeds := []octopus.Feed{
+		{Id: "Feeds-1", Name: "Docker Hub", FeedType: strutil.StrPointer("Npm"), Password: &octopus.Secret{HasValue: true}},
+		{Id: "Feeds-2", Name: "docker-hub", FeedType: strutil.StrPointer("Npm"), Password: &octopus.Secret{HasValue: true}},
+	}
+	converter := FeedConverter{
+		Client:   collectionClient{collections: map[string][]any{"Feeds": lo.ToAnySlice(feeds)}},
+		Excluder: DefaultExcluder{},
+	}
+
+	for _, feed := range feeds {
+		if err := converter.toHcl(feed, false, false, false, &dependencies); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resources := dependencies.GetAllResource("Feeds")
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 feeds, found %d", len(resources))
+	}
+
+	if resources[0].FileName != "space_population/feed_docker_hub.tf" || resources[1].FileName != "space_population/feed_docker_hub_feeds_2.tf" {
+		t.Fatalf("unexpected file names %s and %s", resources[0].FileName, resources[1].FileName)
+	}
+
+	hcl, err := resources[1].ToHcl()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(hcl, "resource \"octopusdeploy_npm_feed\" \"feed_docker_hub_feeds_2\"") ||
+		!strings.Contains(hcl, "variable \"feed_docker_hub_feeds_2_password\"") {
+		t.Fatalf("unexpected HCL %s", hcl)
+	}
+}
+
+func TestFeedsReplayedFromSnapshot(t *testing.T) {
+	snapshot, err := client.LoadSnapshot("testdata/feeds_snapshot.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	converter := FeedConverter{Client: &client.ReplayOctopusClient{Snapshot: snapshot}, Excluder: DefaultExcluder{}}
+
+	dependencies := data.ResourceDetailsCollection{}
+	if err := converter.allToHcl(false, &dependencies); err != nil {
+		t.Fatal(err)
+	}
+
+	resources := dependencies.GetAllResource("Feeds")
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 feeds, found %d", len(resources))
+	}
+
+	if !lo.ContainsBy(resources, func(item data.ResourceDetails) bool {
+		return item.Lookup == "${octopusdeploy_docker_container_registry.feed_docker_hub.id}"
+	}) {
+		t.Fatalf("expected the docker feed to be exported")
+	}
+
+	dependencies = data.ResourceDetailsCollection{}
+	if err := converter.ToHclById("Feeds-1", &dependencies); err != nil {
+		t.Fatal(err)
+	}
+
+	resources = dependencies.GetAllResource("Feeds")
+	if len(resources) != 1 || resources[0].Lookup != "${octopusdeploy_npm_feed.feed_npm.id}" {
+		t.Fatalf("expected the npm feed to be exported by ID")
+	}
+
+	// Requests that were not recorded fail rather than reaching an Octopus server
+	if err := converter.ToHclById("Feeds-3", &dependencies); err == nil {
+		t.Fatalf("expected an error for a feed that was not recorded")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/naming"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/secrets"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
@@ -25,6 +26,7 @@ type GitCredentialsConverter struct {
 	Client                    client.OctopusClient
 	DummySecretVariableValues bool
 	DummySecretGenerator      dummy.DummySecretGenerator
+	SecretProvider            secrets.SecretProvider
 	ExcludeAllGitCredentials  bool
 	ErrGroup                  *errgroup.Group
 	IncludeIds                bool
@@ -90,19 +92,17 @@ func (c GitCredentialsConverter) toHclById(id string, stateless bool, dependenci
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.GitCredentials{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.GitCredentials{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.GitCredentials: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.GitCredentials: %w", err)
+		}
 
-	zap.L().Info("Git Credentials: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, true, false, stateless, dependencies)
+		zap.L().Info("Git Credentials: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, true, false, stateless, dependencies)
+	})
 }
 
 func (c GitCredentialsConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -114,18 +114,16 @@ func (c GitCredentialsConverter) ToHclLookupById(id string, dependencies *data.R
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		gitCredentials := octopus.GitCredentials{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &gitCredentials)
 
-	gitCredentials := octopus.GitCredentials{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &gitCredentials)
-
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.GitCredentials: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.GitCredentials: %w", err)
+		}
 
-	return c.toHcl(gitCredentials, false, true, false, dependencies)
+		return c.toHcl(gitCredentials, false, true, false, dependencies)
+	})
 }
 
 // toBashImport creates a bash script to import the resource
@@ -252,7 +250,11 @@ func (c GitCredentialsConverter) toHcl(gitCredentials octopus.GitCredentials, _
 		return nil
 	}
 
-	gitCredentialsName := "gitcredential_" + sanitizer.SanitizeName(gitCredentials.Name)
+	gitCredentialsName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "gitcredential_", gitCredentials.Id, gitCredentials.Name)
+
+	if err != nil {
+		return err
+	}
 
 	if c.GenerateImportScripts && !stateless {
 		c.toBashImport(gitCredentialsName, gitCredentials.Name, dependencies)
@@ -315,7 +317,7 @@ func (c GitCredentialsConverter) toHclResource(stateless bool, gitCredentials oc
 		thisResource.Lookup = "${" + octopusdeployGitCredentialResourceType + "." + gitCredentialsName + ".id}"
 	}
 
-	gitCredentialSecretName := naming.GitCredentialSecretName(gitCredentials)
+	gitCredentialSecretName := naming.GitCredentialSecretName(gitCredentialsName)
 
 	thisResource.Parameters = []data.ResourceParameter{
 		{
@@ -380,7 +382,12 @@ func (c GitCredentialsConverter) toHclResource(stateless bool, gitCredentials oc
 			Description: "The secret variable value associated with the git credential \"" + gitCredentials.Name + "\"",
 		}
 
-		if c.DummySecretVariableValues {
+		if !secrets.AddSecretVariable(c.SecretProvider, data.SecretVariableReference{
+			VariableName: gitCredentialSecretName,
+			ResourceType: c.GetResourceType(),
+			ResourceId:   gitCredentials.Id,
+			ResourceName: gitCredentials.Name,
+		}, dependencies) && c.DummySecretVariableValues {
 			secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
 			dependencies.AddDummy(data.DummyVariableReference{
 				VariableName: gitCredentialSecretName,
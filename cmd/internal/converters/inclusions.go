@@ -0,0 +1,15 @@
+package converters
+
+import "github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+
+// recordInclusion records that the resources with the ids were included in the export because they were referenced
+// by the field of the parent resource. This allows the reason for including a resource to be reported.
+func recordInclusion(dependencies *data.ResourceDetailsCollection, parentId string, parentName string, field string, ids ...string) {
+	for _, id := range ids {
+		dependencies.RecordInclusion(id, data.InclusionReason{
+			ParentId:   parentId,
+			ParentName: parentName,
+			Field:      field,
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package converters
+
+import (
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/boolutil"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+	"github.com/hashicorp/hcl2/gohcl"
+	"github.com/hashicorp/hcl2/hclwrite"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const octopusdeployKubernetesAgentDeploymentTargetResourceType = "octopusdeploy_kubernetes_agent_deployment_target"
+
+type KubernetesAgentTargetConverter struct {
+	TargetConverter
+
+	TargetDependencyConverters
+
+	ExcludeAllTargets        bool
+	ExcludeTargets           args.StringSliceArgs
+	ExcludeTargetsRegex      args.StringSliceArgs
+	ExcludeTargetsExcept     args.StringSliceArgs
+	ExcludeTenantTags        args.StringSliceArgs
+	ExcludeTenantTagSets     args.StringSliceArgs
+	TagSetConverter          ConvertToHclByResource[octopus.TagSet]
+	ErrGroup                 *errgroup.Group
+	IncludeIds               bool
+	LimitResourceCount       int
+	IncludeSpaceInPopulation bool
+	GenerateImportScripts    bool
+}
+
+func (c KubernetesAgentTargetConverter) AllToHcl(dependencies *data.ResourceDetailsCollection) {
+	c.ErrGroup.Go(func() error { return c.allToHcl(false, dependencies) })
+}
+
+func (c KubernetesAgentTargetConverter) AllToStatelessHcl(dependencies *data.ResourceDetailsCollection) {
+	c.ErrGroup.Go(func() error { return c.allToHcl(true, dependencies) })
+}
+
+func (c KubernetesAgentTargetConverter) allToHcl(stateless bool, dependencies *data.ResourceDetailsCollection) error {
+	if c.ExcludeAllTargets {
+		return nil
+	}
+
+	batchClient := client.BatchingOctopusApiClient[octopus.KubernetesAgentTarget]{
+		Client: c.Client,
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	channel := batchClient.GetAllResourcesBatch(done, c.GetResourceType())
+
+	for resourceWrapper := range channel {
+		if resourceWrapper.Err != nil {
+			return resourceWrapper.Err
+		}
+
+		resource := resourceWrapper.Res
+
+		valid, err := c.validTarget(resource)
+
+		if err != nil {
+			return err
+		}
+
+		if !valid {
+			continue
+		}
+
+		zap.L().Info("Kubernetes Agent Target: " + resource.Id + " " + resource.Name)
+		err = c.toHcl(resource, false, stateless, dependencies)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c KubernetesAgentTargetConverter) validTarget(item octopus.KubernetesAgentTarget) (bool, error) {
+	err, noEnvironments := c.HasNoEnvironments(item)
+
+	if err != nil {
+		return false, err
+	}
+
+	if noEnvironments {
+		return false, nil
+	}
+
+	return c.isKubernetesAgentTarget(item), nil
+}
+
+func (c KubernetesAgentTargetConverter) isKubernetesAgentTarget(resource octopus.KubernetesAgentTarget) bool {
+	return resource.Endpoint.CommunicationStyle == "KubernetesTentacle"
+}
+
+func (c KubernetesAgentTargetConverter) ToHclStatelessById(id string, dependencies *data.ResourceDetailsCollection) error {
+	return c.toHclById(id, true, dependencies)
+}
+
+func (c KubernetesAgentTargetConverter) ToHclById(id string, dependencies *data.ResourceDetailsCollection) error {
+	return c.toHclById(id, false, dependencies)
+}
+
+func (c KubernetesAgentTargetConverter) toHclById(id string, stateless bool, dependencies *data.ResourceDetailsCollection) error {
+	if id == "" {
+		return nil
+	}
+
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.KubernetesAgentTarget{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.KubernetesAgentTarget: %w", err)
+		}
+
+		if !c.isKubernetesAgentTarget(resource) {
+			return nil
+		}
+
+		err, noEnvironments := c.HasNoEnvironments(resource)
+
+		if err != nil {
+			return err
+		}
+
+		if noEnvironments {
+			return nil
+		}
+
+		zap.L().Info("Kubernetes Agent Target: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, true, stateless, dependencies)
+	})
+}
+
+func (c KubernetesAgentTargetConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
+	if id == "" {
+		return nil
+	}
+
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.KubernetesAgentTarget{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.KubernetesAgentTarget: %w", err)
+		}
+
+		// Ignore excluded targets
+		if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllTargets, c.ExcludeTargets, c.ExcludeTargetsRegex, c.ExcludeTargetsExcept) {
+			return nil
+		}
+
+		if !c.isKubernetesAgentTarget(resource) {
+			return nil
+		}
+
+		err, noEnvironments := c.HasNoEnvironments(resource)
+
+		if err != nil {
+			return err
+		}
+
+		if noEnvironments {
+			return nil
+		}
+
+		return c.toLookupHcl(resource.Id, resource.Name, dependencies)
+	})
+}
+
+func (c KubernetesAgentTargetConverter) toHcl(target octopus.KubernetesAgentTarget, recursive bool, stateless bool, dependencies *data.ResourceDetailsCollection) error {
+	// Ignore excluded targets
+	if c.Excluder.IsResourceExcludedWithRegex(target.Name, c.ExcludeAllTargets, c.ExcludeTargets, c.ExcludeTargetsRegex, c.ExcludeTargetsExcept) {
+		return nil
+	}
+
+	if c.LimitResourceCount > 0 && len(dependencies.GetAllResource(c.GetResourceType())) >= c.LimitResourceCount {
+		zap.L().Info(c.GetResourceType() + " hit limit of " + fmt.Sprint(c.LimitResourceCount) + " - skipping " + target.Id)
+		return nil
+	}
+
+	if !c.isKubernetesAgentTarget(target) {
+		return nil
+	}
+
+	err, noEnvironments := c.HasNoEnvironments(target)
+
+	if err != nil {
+		return err
+	}
+
+	if noEnvironments {
+		return nil
+	}
+
+	recordInclusion(dependencies, target.Id, target.Name, "EnvironmentIds", target.EnvironmentIds...)
+	recordInclusion(dependencies, target.Id, target.Name, "MachinePolicyId", target.MachinePolicyId)
+
+	if recursive {
+		if stateless {
+			if err := c.exportStatelessDependencies(target.MachinePolicyId, target.EnvironmentIds, dependencies); err != nil {
+				return err
+			}
+		} else {
+			if err := c.exportDependencies(target.MachinePolicyId, target.EnvironmentIds, dependencies); err != nil {
+				return err
+			}
+		}
+	}
+
+	targetName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "target_", target.Id, target.Name)
+	if err != nil {
+		return err
+	}
+
+	if c.GenerateImportScripts && !stateless {
+		c.toBashImport(octopusdeployKubernetesAgentDeploymentTargetResourceType, targetName, target.Name, dependencies)
+		c.toPowershellImport(octopusdeployKubernetesAgentDeploymentTargetResourceType, targetName, target.Name, dependencies)
+	}
+
+	thisResource := data.ResourceDetails{}
+	thisResource.FileName = "space_population/" + targetName + ".tf"
+	thisResource.Id = target.Id
+	thisResource.Name = target.Name
+	thisResource.ResourceType = c.GetResourceType()
+
+	thisResource.Lookup, thisResource.Dependency = c.getLookup(octopusdeployKubernetesAgentDeploymentTargetResourceType, targetName, stateless)
+
+	thisResource.ToHcl = func() (string, error) {
+
+		terraformResource := terraform.TerraformKubernetesAgentDeploymentTarget{
+			Type:                            octopusdeployKubernetesAgentDeploymentTargetResourceType,
+			Name:                            targetName,
+			Id:                              strutil.InputPointerIfEnabled(c.IncludeIds, &target.Id),
+			SpaceId:                         strutil.InputIfEnabled(c.IncludeSpaceInPopulation, dependencies.GetResourceDependency("Spaces", target.SpaceId)),
+			ResourceName:                    target.Name,
+			Environments:                    c.lookupEnvironments(target.EnvironmentIds, dependencies),
+			Roles:                           target.Roles,
+			Thumbprint:                      target.Thumbprint,
+			Uri:                             target.Endpoint.TentacleEndpointConfiguration.Uri,
+			CommunicationMode:               strutil.NilIfEmpty(target.Endpoint.TentacleEndpointConfiguration.CommunicationMode),
+			DefaultNamespace:                strutil.NilIfEmptyPointer(target.Endpoint.DefaultNamespace),
+			IsDisabled:                      boolutil.NilIfFalse(target.IsDisabled),
+			MachinePolicyId:                 c.getMachinePolicy(target.MachinePolicyId, dependencies),
+			TenantTags:                      c.Excluder.FilteredTenantTags(target.TenantTags, c.ExcludeTenantTags, c.ExcludeTenantTagSets),
+			TenantedDeploymentParticipation: strutil.NilIfEmpty(target.TenantedDeploymentParticipation),
+			Tenants:                         dependencies.GetResources("Tenants", target.TenantIds...),
+			UpgradeLocked:                   boolutil.NilIfFalse(target.Endpoint.UpgradeLocked),
+		}
+		file := hclwrite.NewEmptyFile()
+
+		if stateless {
+			c.writeData(file, target.Name, targetName)
+			terraformResource.Count = c.getStatelessCount(targetName)
+		}
+
+		block := gohcl.EncodeAsBlock(terraformResource, "resource")
+
+		if stateless {
+			hcl.WriteLifecyclePreventDestroyAttribute(block)
+		}
+
+		err := TenantTagDependencyGenerator{}.AddAndWriteTagSetDependencies(c.Client, terraformResource.TenantTags, c.TagSetConverter, block, dependencies, recursive)
+		if err != nil {
+			return "", err
+		}
+		file.Body().AppendBlock(block)
+
+		return string(file.Bytes()), nil
+	}
+
+	dependencies.AddResource(thisResource)
+
+	return nil
+}
+
+func (c KubernetesAgentTargetConverter) GetResourceType() string {
+	return "Machines"
+}
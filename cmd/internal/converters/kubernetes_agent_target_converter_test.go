@@ -0,0 +1,204 @@
+package converters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+)
+
+func newKubernetesAgentTarget() octopus.KubernetesAgentTarget {
+	return octopus.KubernetesAgentTarget{
+		Target:          octopus.Target{EnvironmentIds: []string{"Environments-1"}},
+		Id:              "Machines-1",
+		Name:            "K8s Agent",
+		Roles:           []string{"k8s"},
+		Thumbprint:      "ABCDEF",
+		MachinePolicyId: "MachinePolicies-1",
+		Endpoint: octopus.KubernetesAgentWorkerEndpoint{
+			CommunicationStyle: "KubernetesTentacle",
+			TentacleEndpointConfiguration: octopus.KubernetesAgentWorkerEndpointTentacleEndpointConfiguration{
+				CommunicationMode: "Polling",
+				Uri:               "poll://abcdef/",
+			},
+			DefaultNamespace: strutil.StrPointer("octopus"),
+			UpgradeLocked:    true,
+		},
+	}
+}
+
+func newKubernetesAgentTargetConverter() KubernetesAgentTargetConverter {
+	return KubernetesAgentTargetConverter{
+		TargetConverter: TargetConverter{
+			Client:   collectionClient{},
+			Excluder: DefaultExcluder{},
+		},
+	}
+}
+
+func newKubernetesAgentTargetDependencies() *data.ResourceDetailsCollection {
+	dependencies := data.ResourceDetailsCollection{}
+	dependencies.AddResource(
+		data.ResourceDetails{Id: "Environments-1", ResourceType: "Environments", Lookup: "${octopusdeploy_environment.environment_dev.id}"},
+		data.ResourceDetails{Id: "MachinePolicies-1", ResourceType: "MachinePolicies", Lookup: "${octopusdeploy_machine_policy.machinepolicy_default.id}"})
+	return &dependencies
+}
+
+func TestKubernetesAgentTargetHcl(t *testing.T) {
+	dependencies := newKubernetesAgentTargetDependencies()
+
+	if err := newKubernetesAgentTargetConverter().toHcl(newKubernetesAgentTarget(), false, false, dependencies); err != nil {
+		t.Fatal(err)
+	}
+
+	resources := dependencies.GetAllResource("Machines")
+	if len(resources) != 1 {
+		t.Fatalf("expected 1 target, found %d", len(resources))
+	}
+
+	if resources[0].Lookup != "${octopusdeploy_kubernetes_agent_deployment_target.target_k8s_agent.id}" {
+		t.Fatalf("unexpected lookup %s", resources[0].Lookup)
+	}
+
+	hcl, err := resources[0].ToHcl()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Ignore the alignment of the attributes
+	hcl = strings.Join(strings.Fields(hcl), " ")
+
+	for _, expected := range []string{
+		`resource "octopusdeploy_kubernetes_agent_deployment_target" "target_k8s_agent"`,
+		`name = "K8s Agent"`,
+		`environments = ["$${octopusdeploy_environment.environment_dev.id}"]`,
+		`uri = "poll://abcdef/"`,
+		`communication_mode = "Polling"`,
+		`default_namespace = "octopus"`,
+		`machine_policy_id = "$${octopusdeploy_machine_policy.machinepolicy_default.id}"`,
+		`upgrade_locked = true`,
+	} {
+		if !strings.Contains(hcl, expected) {
+			t.Fatalf("expected the HCL to contain %s, found %s", expected, hcl)
+		}
+	}
+}
+
+func TestKubernetesAgentTargetStatelessHcl(t *testing.T) {
+	dependencies := newKubernetesAgentTargetDependencies()
+
+	if err := newKubernetesAgentTargetConverter().toHcl(newKubernetesAgentTarget(), false, true, dependencies); err != nil {
+		t.Fatal(err)
+	}
+
+	resources := dependencies.GetAllResource("Machines")
+	if len(resources) != 1 {
+		t.Fatalf("expected 1 target, found %d", len(resources))
+	}
+
+	if !strings.HasPrefix(resources[0].Lookup, "${length(data.octopusdeploy_deployment_targets.target_k8s_agent.deployment_targets) != 0 ") {
+		t.Fatalf("unexpected lookup %s", resources[0].Lookup)
+	}
+
+	hcl, err := resources[0].ToHcl()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Ignore the alignment of the attributes
+	hcl = strings.Join(strings.Fields(hcl), " ")
+
+	if !strings.Contains(hcl, `data "octopusdeploy_deployment_targets" "target_k8s_agent"`) ||
+		!strings.Contains(hcl, `count = "$${length(data.octopusdeploy_deployment_targets.target_k8s_agent.deployment_targets) != 0 ? 0 : 1}"`) {
+		t.Fatalf("expected the stateless HCL to look up the existing target, found %s", hcl)
+	}
+}
+
+func TestKubernetesAgentTargetExcluded(t *testing.T) {
+	tests := map[string]func(converter *KubernetesAgentTargetConverter, target *octopus.KubernetesAgentTarget){
+		"all targets": func(converter *KubernetesAgentTargetConverter, target *octopus.KubernetesAgentTarget) {
+			converter.ExcludeAllTargets = true
+		},
+		"by name": func(converter *KubernetesAgentTargetConverter, target *octopus.KubernetesAgentTarget) {
+			converter.ExcludeTargets = args.StringSliceArgs{"K8s Agent"}
+		},
+		"by regex": func(converter *KubernetesAgentTargetConverter, target *octopus.KubernetesAgentTarget) {
+			converter.ExcludeTargetsRegex = args.StringSliceArgs{"^K8s.*"}
+		},
+		"not in the except list": func(converter *KubernetesAgentTargetConverter, target *octopus.KubernetesAgentTarget) {
+			converter.ExcludeTargetsExcept = args.StringSliceArgs{"Another Agent"}
+		},
+		"not a kubernetes agent": func(converter *KubernetesAgentTargetConverter, target *octopus.KubernetesAgentTarget) {
+			target.Endpoint.CommunicationStyle = "TentacleActive"
+		},
+	}
+
+	for name, exclude := range tests {
+		t.Run(name, func(t *testing.T) {
+			converter := newKubernetesAgentTargetConverter()
+			target := newKubernetesAgentTarget()
+			exclude(&converter, &target)
+			dependencies := newKubernetesAgentTargetDependencies()
+
+			if err := converter.toHcl(target, false, false, dependencies); err != nil {
+				t.Fatal(err)
+			}
+
+			if resources := dependencies.GetAllResource("Machines"); len(resources) != 0 {
+				t.Fatalf("expected the target to be excluded, found %v", resources)
+			}
+		})
+	}
+}
+
+func TestKubernetesAgentTargetImportScripts(t *testing.T) {
+	converter := newKubernetesAgentTargetConverter()
+	converter.GenerateImportScripts = true
+	dependencies := newKubernetesAgentTargetDependencies()
+
+	if err := converter.toHcl(newKubernetesAgentTarget(), false, false, dependencies); err != nil {
+		t.Fatal(err)
+	}
+
+	scripts := map[string]string{}
+	for _, resource := range dependencies.Resources {
+		if strings.HasPrefix(resource.FileName, "space_population/import_") {
+			script, err := resource.ToHcl()
+			if err != nil {
+				t.Fatal(err)
+			}
+			scripts[resource.FileName] = script
+		}
+	}
+
+	for _, fileName := range []string{"space_population/import_target_k8s_agent.sh", "space_population/import_target_k8s_agent.ps1"} {
+		script, ok := scripts[fileName]
+
+		if !ok {
+			t.Fatalf("expected the import script %s, found %v", fileName, scripts)
+		}
+
+		// The script looks the target up by name and imports it into the agent target resource
+		if !strings.Contains(script, `"K8s Agent"`) ||
+			!strings.Contains(script, "/Machines") ||
+			!strings.Contains(script, "octopusdeploy_kubernetes_agent_deployment_target.target_k8s_agent") {
+			t.Fatalf("unexpected import script %s", script)
+		}
+	}
+
+	// Stateless modules are not imported
+	dependencies = newKubernetesAgentTargetDependencies()
+
+	if err := converter.toHcl(newKubernetesAgentTarget(), false, true, dependencies); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, resource := range dependencies.Resources {
+		if strings.HasPrefix(resource.FileName, "space_population/import_") {
+			t.Fatalf("expected no import scripts for a stateless module, found %s", resource.FileName)
+		}
+	}
+}
@@ -83,23 +83,21 @@ func (c KubernetesAgentWorkerConverter) toHclById(id string, stateless bool, dep
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.KubernetesAgentWorker{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.KubernetesAgentWorker{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.KubernetesEndpointResource: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.KubernetesEndpointResource: %w", err)
+		}
 
-	if !c.isKubernetesWorker(resource) {
-		return nil
-	}
+		if !c.isKubernetesWorker(resource) {
+			return nil
+		}
 
-	zap.L().Info("Kubernetes Target: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, true, stateless, dependencies)
+		zap.L().Info("Kubernetes Target: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, true, stateless, dependencies)
+	})
 }
 
 func (c KubernetesAgentWorkerConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -107,47 +105,45 @@ func (c KubernetesAgentWorkerConverter) ToHclLookupById(id string, dependencies
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.KubernetesAgentWorker{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.KubernetesAgentWorker{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.KubernetesEndpointResource: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.KubernetesEndpointResource: %w", err)
+		}
 
-	// Ignore excluded targets
-	if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllWorkers, c.ExcludeWorkers, c.ExcludeWorkersRegex, c.ExcludeWorkersExcept) {
-		return nil
-	}
+		// Ignore excluded targets
+		if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllWorkers, c.ExcludeWorkers, c.ExcludeWorkersRegex, c.ExcludeWorkersExcept) {
+			return nil
+		}
 
-	if !c.isKubernetesWorker(resource) {
-		return nil
-	}
+		if !c.isKubernetesWorker(resource) {
+			return nil
+		}
 
-	thisResource := data.ResourceDetails{}
+		thisResource := data.ResourceDetails{}
 
-	resourceName := "worker_" + sanitizer.SanitizeName(resource.Name)
+		resourceName := "worker_" + sanitizer.SanitizeName(resource.Name)
 
-	thisResource.FileName = "space_population/" + resourceName + ".tf"
-	thisResource.Id = resource.Id
-	thisResource.Name = resource.Name
-	thisResource.ResourceType = c.GetResourceType()
-	thisResource.Lookup = "${data." + octopusdeployKubernetesAgentWorkerDataType + "." + resourceName + ".workers[0].id}"
-	thisResource.ToHcl = func() (string, error) {
-		terraformResource := c.buildData(resourceName, resource)
-		file := hclwrite.NewEmptyFile()
-		block := gohcl.EncodeAsBlock(terraformResource, "data")
-		hcl.WriteLifecyclePostCondition(block, "Failed to resolve a worker called \""+resource.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.deployment_targets) != 0")
-		file.Body().AppendBlock(block)
+		thisResource.FileName = "space_population/" + resourceName + ".tf"
+		thisResource.Id = resource.Id
+		thisResource.Name = resource.Name
+		thisResource.ResourceType = c.GetResourceType()
+		thisResource.Lookup = "${data." + octopusdeployKubernetesAgentWorkerDataType + "." + resourceName + ".workers[0].id}"
+		thisResource.ToHcl = func() (string, error) {
+			terraformResource := c.buildData(resourceName, resource)
+			file := hclwrite.NewEmptyFile()
+			block := gohcl.EncodeAsBlock(terraformResource, "data")
+			hcl.WriteLifecyclePostCondition(block, "Failed to resolve a worker called \""+resource.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.deployment_targets) != 0")
+			file.Body().AppendBlock(block)
 
-		return string(file.Bytes()), nil
-	}
+			return string(file.Bytes()), nil
+		}
 
-	dependencies.AddResource(thisResource)
-	return nil
+		dependencies.AddResource(thisResource)
+		return nil
+	})
 }
 
 func (c KubernetesAgentWorkerConverter) buildData(resourceName string, resource octopus.KubernetesAgentWorker) terraform.TerraformWorkersData {
@@ -0,0 +1,57 @@
+package converters
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
+)
+
+// getResourceLabel returns the unique Terraform label of a resource, built from the prefix and the sanitized name.
+// The first time a label of the resource type is requested, every resource of the type in the space is listed and
+// their labels are reserved. This means the resource that keeps a label shared with other resources does not depend
+// on which converter happens to request its label first.
+func getResourceLabel(octopusClient client.OctopusClient, dependencies *data.ResourceDetailsCollection, resourceType string, prefix string, id string, name string) (string, error) {
+	err := reserveResourceLabels(octopusClient, dependencies, resourceType, resourceType, func(resource octopus.NameId) (string, string) {
+		return resource.Id, prefix + sanitizer.SanitizeName(resource.Name)
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return dependencies.GetResourceLabel(resourceType, id, prefix+sanitizer.SanitizeName(name)), nil
+}
+
+// getProjectLabel returns the unique Terraform label of the project, like project_my_app. The resources that belong to
+// a project, like channels, runbooks and triggers, include the label in their own labels, so they are also unique
+// when the names of different projects sanitize to the same string.
+func getProjectLabel(octopusClient client.OctopusClient, dependencies *data.ResourceDetailsCollection, id string, name string) (string, error) {
+	return getResourceLabel(octopusClient, dependencies, "Projects", "project_", id, name)
+}
+
+// reserveResourceLabels lists the resources returned by the API path, and reserves the label built for each resource.
+// The label function returns the ID and label of a resource. The path is listed once for each resource type, no matter
+// how many converters reserve it.
+func reserveResourceLabels[T any](octopusClient client.OctopusClient, dependencies *data.ResourceDetailsCollection, resourceType string, path string, label func(resource T) (string, string)) error {
+	return dependencies.ReserveResourceLabels(resourceType, path, func() (map[string]string, error) {
+		batchClient := client.BatchingOctopusApiClient[T]{
+			Client: octopusClient,
+		}
+
+		done := make(chan struct{})
+		defer close(done)
+
+		labels := map[string]string{}
+		for resourceWrapper := range batchClient.GetAllResourcesBatch(done, path) {
+			if resourceWrapper.Err != nil {
+				return nil, resourceWrapper.Err
+			}
+
+			id, resourceLabel := label(resourceWrapper.Res)
+			labels[id] = resourceLabel
+		}
+
+		return labels, nil
+	})
+}
@@ -0,0 +1,141 @@
+package converters
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+	"github.com/samber/lo"
+)
+
+// collectionClient serves the first page of the collections from GetAllResources. Any other request panics.
+type collectionClient struct {
+	client.OctopusClient
+	collections map[string][]any
+}
+
+func (c collectionClient) GetAllResources(resourceType string, resources any, queryParams ...[]string) error {
+	items := c.collections[resourceType]
+
+	for _, param := range queryParams {
+		if len(param) == 2 && param[0] == "skip" && param[1] != "0" {
+			items = nil
+		}
+	}
+
+	content, err := json.Marshal(map[string]any{"Items": items})
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(content, resources)
+}
+
+func TestResourceLabelsDoNotDependOnOrder(t *testing.T) {
+	feeds := []octopus.Feed{
+		{Id: "Feeds-2", Name: "docker-hub", FeedType: strutil.StrPointer("Npm")},
+		{Id: "Feeds-1", Name: "Docker Hub", FeedType: strutil.StrPointer("Npm")},
+		{Id: "Feeds-3", Name: "npm", FeedType: strutil.StrPointer("Npm")},
+	}
+
+	converter := FeedConverter{
+		Client:   collectionClient{collections: map[string][]any{"Feeds": lo.ToAnySlice(feeds)}},
+		Excluder: DefaultExcluder{},
+	}
+
+	for _, order := range [][]octopus.Feed{feeds, lo.Reverse(append([]octopus.Feed{}, feeds...))} {
+		dependencies := data.ResourceDetailsCollection{}
+
+		for _, feed := range order {
+			if err := converter.toHcl(feed, false, false, false, &dependencies); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		expected := map[string]string{
+			"Feeds-1": "space_population/feed_docker_hub.tf",
+			"Feeds-2": "space_population/feed_docker_hub_feeds_2.tf",
+			"Feeds-3": "space_population/feed_npm.tf",
+		}
+
+		for id, fileName := range expected {
+			resources := dependencies.GetResourcesByOctopusId(id)
+			if len(resources) != 1 || resources[0].FileName != fileName {
+				t.Fatalf("expected %s to be written to %s, found %v", id, fileName, resources)
+			}
+		}
+	}
+}
+
+func TestResourceLabelsReservedForResourcesNotInTheSpace(t *testing.T) {
+	dependencies := data.ResourceDetailsCollection{}
+	octopusClient := collectionClient{collections: map[string][]any{"Environments": {octopus.NameId{Id: "Environments-1", Name: "Dev"}}}}
+
+	label, err := getResourceLabel(octopusClient, &dependencies, "Environments", "environment_", "Environments-2", "dev")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if label != "environment_dev_environments_2" {
+		t.Fatalf("unexpected label %s", label)
+	}
+}
+
+func TestProjectLabelsAreUsedByProjectResources(t *testing.T) {
+	projects := []octopus.Project{
+		{NameId: octopus.NameId{Id: "Projects-1", Name: "My App"}},
+		{NameId: octopus.NameId{Id: "Projects-2", Name: "my-app"}},
+	}
+
+	octopusClient := collectionClient{collections: map[string][]any{"Projects": lo.ToAnySlice(projects)}}
+	channelConverter := ChannelConverter{Client: octopusClient, Excluder: DefaultExcluder{}}
+	processConverter := DeploymentProcessConverterBase{}
+	variableSetConverter := VariableSetConverter{}
+	dependencies := data.ResourceDetailsCollection{}
+
+	// Convert the projects in reverse order to show the labels do not depend on which is converted first
+	for _, project := range lo.Reverse(append([]octopus.Project{}, projects...)) {
+		channel := octopus.Channel{Id: "Channels-" + project.Id, Name: "Release", ProjectId: project.Id}
+		if err := channelConverter.toHcl(channel, project, false, false, false, nil, &dependencies); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := map[string]string{
+		"Projects-1": "my_app",
+		"Projects-2": "my_app_projects_2",
+	}
+
+	for _, project := range projects {
+		suffix := expected[project.Id]
+
+		label, err := getProjectLabel(octopusClient, &dependencies, project.Id, project.Name)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if label != "project_"+suffix {
+			t.Fatalf("unexpected label %s for %s", label, project.Id)
+		}
+
+		channels := dependencies.GetResourcesByOctopusId("Channels-" + project.Id)
+		if len(channels) != 1 || channels[0].FileName != "space_population/channel_"+suffix+"_release.tf" {
+			t.Fatalf("expected the channel of %s to use the project label, found %v", project.Id, channels)
+		}
+
+		if name := processConverter.generateProcessName(nil, &project, &dependencies); name != "process_"+suffix {
+			t.Fatalf("expected the process of %s to use the project label, found %s", project.Id, name)
+		}
+
+		variableSet := octopus.VariableSet{OwnerId: strutil.StrPointer(project.Id)}
+		if prefix := variableSetConverter.getLabelPrefix(variableSet, project.Name, &dependencies); prefix != suffix {
+			t.Fatalf("expected the variables of %s to use the project label, found %s", project.Id, prefix)
+		}
+	}
+}
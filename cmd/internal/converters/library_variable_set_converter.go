@@ -38,6 +38,7 @@ type LibraryVariableSetConverter struct {
 	ErrGroup                                *errgroup.Group
 	LimitResourceCount                      int
 	GenerateImportScripts                   bool
+	ExtractScripts                          bool
 }
 
 func (c *LibraryVariableSetConverter) AllToHcl(dependencies *data.ResourceDetailsCollection) {
@@ -96,19 +97,17 @@ func (c *LibraryVariableSetConverter) toHclById(id string, stateless bool, depen
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.LibraryVariableSet{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	resource := octopus.LibraryVariableSet{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
-
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.LibraryVariableSet: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.LibraryVariableSet: %w", err)
+		}
 
-	zap.L().Info("Library Variable Set: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, true, stateless, dependencies)
+		zap.L().Info("Library Variable Set: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, true, stateless, dependencies)
+	})
 }
 
 func (c *LibraryVariableSetConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -116,60 +115,61 @@ func (c *LibraryVariableSetConverter) ToHclLookupById(id string, dependencies *d
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.LibraryVariableSet{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	resource := octopus.LibraryVariableSet{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.LibraryVariableSet: %w", err)
+		}
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.LibraryVariableSet: %w", err)
-	}
+		// Ignore excluded runbooks
+		if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllLibraryVariableSets, c.Excluded, c.ExcludeLibraryVariableSetsRegex, c.ExcludeLibraryVariableSetsExcept) {
+			return nil
+		}
 
-	// Ignore excluded runbooks
-	if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllLibraryVariableSets, c.Excluded, c.ExcludeLibraryVariableSetsRegex, c.ExcludeLibraryVariableSetsExcept) {
-		return nil
-	}
+		thisResource := data.ResourceDetails{}
 
-	thisResource := data.ResourceDetails{}
+		resourceName, err := c.getResourceLabel(dependencies, c.GetResourceType()+"Lookup", resource, libraryVariableSetLookupLabel)
+		if err != nil {
+			return err
+		}
 
-	resourceName := "library_variable_set_" + sanitizer.SanitizeName(resource.Name)
+		thisResource.FileName = "space_population/" + resourceName + ".tf"
+		thisResource.Id = resource.Id
+		thisResource.Name = resource.Name
+		thisResource.ResourceType = c.GetResourceType()
+		thisResource.Lookup = "${data." + octopusdeployLibraryVariableSetsDataType + "." + resourceName + ".library_variable_sets[0].id}"
+		thisResource.ToHcl = func() (string, error) {
+			terraformResource := c.buildData(resourceName, resource)
 
-	thisResource.FileName = "space_population/" + resourceName + ".tf"
-	thisResource.Id = resource.Id
-	thisResource.Name = resource.Name
-	thisResource.ResourceType = c.GetResourceType()
-	thisResource.Lookup = "${data." + octopusdeployLibraryVariableSetsDataType + "." + resourceName + ".library_variable_sets[0].id}"
-	thisResource.ToHcl = func() (string, error) {
-		terraformResource := c.buildData(resourceName, resource)
+			file := hclwrite.NewEmptyFile()
+			block := gohcl.EncodeAsBlock(terraformResource, "data")
+			hcl.WriteLifecyclePostCondition(block, "Failed to resolve a library variable set called \""+resource.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.library_variable_sets) != 0")
+			file.Body().AppendBlock(block)
 
-		file := hclwrite.NewEmptyFile()
-		block := gohcl.EncodeAsBlock(terraformResource, "data")
-		hcl.WriteLifecyclePostCondition(block, "Failed to resolve a library variable set called \""+resource.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.library_variable_sets) != 0")
-		file.Body().AppendBlock(block)
+			return string(file.Bytes()), nil
+		}
 
-		return string(file.Bytes()), nil
-	}
+		dependencies.AddResource(thisResource)
 
-	dependencies.AddResource(thisResource)
+		// Export templates individually so projects can reference them when creating tenant common variables if
+		// -lookupProjectLinkTenants is passed in as an argument.
+		for _, template := range resource.Templates {
+			if template.Name == nil {
+				continue
+			}
 
-	// Export templates individually so projects can reference them when creating tenant common variables if
-	// -lookupProjectLinkTenants is passed in as an argument.
-	for _, template := range resource.Templates {
-		if template.Name == nil {
-			continue
+			templateResource := data.ResourceDetails{}
+			templateResource.Id = template.Id
+			templateResource.Name = strutil.EmptyIfNil(template.Name)
+			templateResource.ResourceType = "CommonTemplateMap"
+			templateResource.Lookup = "${data." + octopusdeployLibraryVariableSetsDataType + "." + resourceName + ".library_variable_sets[0].template_ids[\"" + strutil.EmptyIfNil(template.Name) + "\"]}"
+			dependencies.AddResource(templateResource)
 		}
 
-		templateResource := data.ResourceDetails{}
-		templateResource.Id = template.Id
-		templateResource.Name = strutil.EmptyIfNil(template.Name)
-		templateResource.ResourceType = "CommonTemplateMap"
-		templateResource.Lookup = "${data." + octopusdeployLibraryVariableSetsDataType + "." + resourceName + ".library_variable_sets[0].template_ids[\"" + strutil.EmptyIfNil(template.Name) + "\"]}"
-		dependencies.AddResource(templateResource)
-	}
-
-	return nil
+		return nil
+	})
 }
 
 func (c *LibraryVariableSetConverter) buildData(resourceName string, resource octopus.LibraryVariableSet) terraform.TerraformLibraryVariableSetData {
@@ -316,9 +316,10 @@ func (c *LibraryVariableSetConverter) toHcl(resource octopus.LibraryVariableSet,
 
 	thisResource := data.ResourceDetails{}
 
-	// embedding the type allows files to be distinguished by script module and variable
-	resourceName := "library_variable_set_" + sanitizer.SanitizeName(strutil.EmptyIfNil(resource.ContentType)) +
-		"_" + sanitizer.SanitizeName(resource.Name)
+	resourceName, err := c.getResourceLabel(dependencies, c.GetResourceType(), resource, libraryVariableSetLabel)
+	if err != nil {
+		return err
+	}
 
 	// The templates are dependencies that we export as part of the project
 	projectTemplates, projectTemplateMap := c.convertTemplates(resource.Templates, resourceName, stateless)
@@ -385,7 +386,7 @@ func (c *LibraryVariableSetConverter) toHcl(resource octopus.LibraryVariableSet,
 			c.toPowershellImport(octopusdeployScriptModuleResourceType, resourceName, resource.Name, dependencies)
 		}
 		thisResource.ToHcl = func() (string, error) {
-			return c.writeScriptModule(resource, resourceName, stateless)
+			return c.writeScriptModule(resource, resourceName, stateless, dependencies)
 		}
 	}
 
@@ -420,7 +421,7 @@ func (c *LibraryVariableSetConverter) writeLibraryVariableSet(resource octopus.L
 	return string(file.Bytes()), nil
 }
 
-func (c *LibraryVariableSetConverter) writeScriptModule(resource octopus.LibraryVariableSet, resourceName string, stateless bool) (string, error) {
+func (c *LibraryVariableSetConverter) writeScriptModule(resource octopus.LibraryVariableSet, resourceName string, stateless bool, dependencies *data.ResourceDetailsCollection) (string, error) {
 	variable := octopus.VariableSet{}
 	_, err := c.Client.GetSpaceResourceById("Variables", resource.VariableSetId, &variable)
 
@@ -465,6 +466,11 @@ func (c *LibraryVariableSetConverter) writeScriptModule(resource octopus.Library
 
 	block := gohcl.EncodeAsBlock(terraformResource, "resource")
 
+	// The script block is the only block in the script module
+	if c.ExtractScripts && strings.TrimSpace(script) != "" && len(block.Body().Blocks()) != 0 {
+		hcl.WriteUnquotedAttribute(block.Body().Blocks()[0], "body", extractScript(resourceName, "script_module", resource.Id, getScriptExtension(scriptLanguage), script, dependencies))
+	}
+
 	if stateless {
 		hcl.WriteLifecyclePreventDestroyAttribute(block)
 	}
@@ -505,6 +511,30 @@ func (c *LibraryVariableSetConverter) getLibraryVariableSetLookup(stateless bool
 	return "${" + octopusdeployLibraryVariableSetsResourceType + "." + resourceName + ".id}"
 }
 
+// getResourceLabel returns the unique label of the library variable set. Data source and resource labels are built
+// differently, so they are reserved as separate label types.
+func (c *LibraryVariableSetConverter) getResourceLabel(dependencies *data.ResourceDetailsCollection, labelType string, resource octopus.LibraryVariableSet, label func(resource octopus.LibraryVariableSet) string) (string, error) {
+	err := reserveResourceLabels(c.Client, dependencies, labelType, c.GetResourceType(), func(resource octopus.LibraryVariableSet) (string, string) {
+		return resource.Id, label(resource)
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return dependencies.GetResourceLabel(labelType, resource.Id, label(resource)), nil
+}
+
+func libraryVariableSetLookupLabel(resource octopus.LibraryVariableSet) string {
+	return "library_variable_set_" + sanitizer.SanitizeName(resource.Name)
+}
+
+// libraryVariableSetLabel embeds the type, which allows files to be distinguished by script module and variable
+func libraryVariableSetLabel(resource octopus.LibraryVariableSet) string {
+	return "library_variable_set_" + sanitizer.SanitizeName(strutil.EmptyIfNil(resource.ContentType)) +
+		"_" + sanitizer.SanitizeName(resource.Name)
+}
+
 func (c *LibraryVariableSetConverter) getLibraryVariableSetDependency(stateless bool, resourceName string) string {
 	if stateless {
 		return "${" + octopusdeployLibraryVariableSetsResourceType + "." + resourceName + "}"
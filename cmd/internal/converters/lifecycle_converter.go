@@ -10,7 +10,6 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
@@ -117,24 +116,21 @@ func (c LifecycleConverter) toHclById(id string, stateless bool, dependencies *d
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.Lifecycle{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
-
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Lifecycle: %w", err)
-	}
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.Lifecycle{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllLifecycles, c.ExcludeLifecycles, c.ExcludeLifecyclesRegex, c.ExcludeLifecyclesExcept) {
-		return nil
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Lifecycle: %w", err)
+		}
 
-	zap.L().Info("Lifecycle: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, true, false, stateless, dependencies)
+		if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllLifecycles, c.ExcludeLifecycles, c.ExcludeLifecyclesRegex, c.ExcludeLifecyclesExcept) {
+			return nil
+		}
 
+		zap.L().Info("Lifecycle: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, true, false, stateless, dependencies)
+	})
 }
 
 func (c LifecycleConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -143,23 +139,20 @@ func (c LifecycleConverter) ToHclLookupById(id string, dependencies *data.Resour
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		lifecycle := octopus.Lifecycle{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &lifecycle)
 
-	lifecycle := octopus.Lifecycle{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &lifecycle)
-
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Lifecycle: %w", err)
-	}
-
-	if c.Excluder.IsResourceExcludedWithRegex(lifecycle.Name, c.ExcludeAllLifecycles, c.ExcludeLifecycles, c.ExcludeLifecyclesRegex, c.ExcludeLifecyclesExcept) {
-		return nil
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Lifecycle: %w", err)
+		}
 
-	return c.toHcl(lifecycle, false, true, false, dependencies)
+		if c.Excluder.IsResourceExcludedWithRegex(lifecycle.Name, c.ExcludeAllLifecycles, c.ExcludeLifecycles, c.ExcludeLifecyclesRegex, c.ExcludeLifecyclesExcept) {
+			return nil
+		}
 
+		return c.toHcl(lifecycle, false, true, false, dependencies)
+	})
 }
 
 func (c LifecycleConverter) buildData(resourceName string, lifecycleName string) terraform.TerraformLifecycleData {
@@ -321,7 +314,11 @@ func (c LifecycleConverter) toHcl(lifecycle octopus.Lifecycle, recursive bool, l
 
 	forceLookup := lookup || lifecycle.Name == defaultLifecycleName
 
-	resourceName := "lifecycle_" + sanitizer.SanitizeName(lifecycle.Name)
+	resourceName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "lifecycle_", lifecycle.Id, lifecycle.Name)
+
+	if err != nil {
+		return err
+	}
 
 	if c.GenerateImportScripts && lifecycle.Name != defaultLifecycleName && !stateless && !lookup {
 		c.toBashImport(resourceName, lifecycle.Name, dependencies)
@@ -460,6 +457,9 @@ func (c LifecycleConverter) exportDependencies(lifecycle octopus.Lifecycle, stat
 	// The environments are a dependency that we need to lookup
 	for _, phase := range lifecycle.Phases {
 
+		recordInclusion(dependencies, lifecycle.Id, lifecycle.Name, "phase \""+strutil.EmptyIfNil(phase.Name)+"\"",
+			append(append([]string{}, phase.AutomaticDeploymentTargets...), phase.OptionalDeploymentTargets...)...)
+
 		for _, auto := range phase.AutomaticDeploymentTargets {
 			if stateless {
 				err := c.EnvironmentConverter.ToHclStatelessById(auto, dependencies)
@@ -0,0 +1,19 @@
+package converters
+
+import "github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+
+// recordLintFinding records an issue found while exporting a resource. The findings are reported by the lint command
+// and written to the lint report, so resources that can not be exported faithfully are not silently degraded.
+func recordLintFinding(dependencies *data.ResourceDetailsCollection, severity data.LintSeverity, resourceType string, resourceId string, resourceName string, message string) {
+	if dependencies == nil {
+		return
+	}
+
+	dependencies.AddLintFinding(data.LintFinding{
+		Severity:     severity,
+		ResourceType: resourceType,
+		ResourceId:   resourceId,
+		ResourceName: resourceName,
+		Message:      message,
+	})
+}
@@ -8,10 +8,10 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"strconv"
@@ -34,6 +34,7 @@ type MachinePolicyConverter struct {
 	IncludeIds                   bool
 	IncludeSpaceInPopulation     bool
 	GenerateImportScripts        bool
+	ExtractScripts               bool
 }
 
 func (c MachinePolicyConverter) AllToHcl(dependencies *data.ResourceDetailsCollection) {
@@ -92,23 +93,21 @@ func (c MachinePolicyConverter) toHclById(id string, stateless bool, dependencie
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.MachinePolicy{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	resource := octopus.MachinePolicy{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
-
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.MachinePolicy: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.MachinePolicy: %w", err)
+		}
 
-	if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllMachinePolicies, c.ExcludeMachinePolicies, c.ExcludeMachinePoliciesRegex, c.ExcludeMachinePoliciesExcept) {
-		return nil
-	}
+		if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllMachinePolicies, c.ExcludeMachinePolicies, c.ExcludeMachinePoliciesRegex, c.ExcludeMachinePoliciesExcept) {
+			return nil
+		}
 
-	zap.L().Info("Machine Policy: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, true, stateless, dependencies)
+		zap.L().Info("Machine Policy: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, true, stateless, dependencies)
+	})
 }
 
 func (c MachinePolicyConverter) buildData(resourceName string, resource octopus.MachinePolicy) terraform.TerraformMachinePolicyData {
@@ -253,7 +252,11 @@ func (c MachinePolicyConverter) toHcl(machinePolicy octopus.MachinePolicy, _ boo
 		return nil
 	}
 
-	policyName := "machinepolicy_" + sanitizer.SanitizeName(machinePolicy.Name)
+	policyName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "machinepolicy_", machinePolicy.Id, machinePolicy.Name)
+
+	if err != nil {
+		return err
+	}
 
 	if c.GenerateImportScripts && !stateless {
 		c.toBashImport(policyName, machinePolicy.Name, dependencies)
@@ -339,6 +342,10 @@ func (c MachinePolicyConverter) toHcl(machinePolicy octopus.MachinePolicy, _ boo
 
 			block := gohcl.EncodeAsBlock(terraformResource, "resource")
 
+			if c.ExtractScripts {
+				c.extractHealthCheckScripts(block, policyName, machinePolicy, dependencies)
+			}
+
 			if stateless {
 				hcl.WriteLifecyclePreventDestroyAttribute(block)
 			}
@@ -353,6 +360,31 @@ func (c MachinePolicyConverter) toHcl(machinePolicy octopus.MachinePolicy, _ boo
 	return nil
 }
 
+// extractHealthCheckScripts replaces the health check scripts with references to extracted script files. The
+// health check blocks are found by their script_body attribute, and the bash block is encoded before the PowerShell
+// block, matching the order of the fields in TerraformMachineHealthCheckPolicy.
+func (c MachinePolicyConverter) extractHealthCheckScripts(block *hclwrite.Block, policyName string, machinePolicy octopus.MachinePolicy, dependencies *data.ResourceDetailsCollection) {
+	for _, healthCheckBlock := range block.Body().Blocks() {
+		scriptBlocks := lo.Filter(healthCheckBlock.Body().Blocks(), func(item *hclwrite.Block, index int) bool {
+			return item.Body().GetAttribute("script_body") != nil
+		})
+
+		if len(scriptBlocks) != 2 {
+			continue
+		}
+
+		bashScript := machinePolicy.MachineHealthCheckPolicy.BashHealthCheckPolicy.ScriptBody
+		if strings.TrimSpace(bashScript) != "" {
+			hcl.WriteUnquotedAttribute(scriptBlocks[0], "script_body", extractScript(policyName, "bash_health_check", machinePolicy.Id, "sh", bashScript, dependencies))
+		}
+
+		powershellScript := machinePolicy.MachineHealthCheckPolicy.PowerShellHealthCheckPolicy.ScriptBody
+		if strings.TrimSpace(powershellScript) != "" {
+			hcl.WriteUnquotedAttribute(scriptBlocks[1], "script_body", extractScript(policyName, "powershell_health_check", machinePolicy.Id, "ps1", powershellScript, dependencies))
+		}
+	}
+}
+
 func (c MachinePolicyConverter) GetResourceType() string {
 	return "MachinePolicies"
 }
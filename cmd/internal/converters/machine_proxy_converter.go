@@ -12,6 +12,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/naming"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/secrets"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
@@ -36,6 +37,7 @@ type MachineProxyConverter struct {
 	GenerateImportScripts       bool
 	DummySecretVariableValues   bool
 	DummySecretGenerator        dummy.DummySecretGenerator
+	SecretProvider              secrets.SecretProvider
 }
 
 func (c MachineProxyConverter) AllToHcl(dependencies *data.ResourceDetailsCollection) {
@@ -101,28 +103,26 @@ func (c MachineProxyConverter) toHclById(id string, stateless bool, dependencies
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.MachineProxy{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.MachineProxy{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.MachineProxy: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.MachineProxy: %w", err)
+		}
 
-	if c.Excluder.IsResourceExcludedWithRegex(
-		resource.Name,
-		c.ExcludeAllMachineProxies,
-		c.ExcludeMachineProxies,
-		c.ExcludeMachineProxiesRegex,
-		c.ExcludeMachineProxiesExcept) {
-		return nil
-	}
+		if c.Excluder.IsResourceExcludedWithRegex(
+			resource.Name,
+			c.ExcludeAllMachineProxies,
+			c.ExcludeMachineProxies,
+			c.ExcludeMachineProxiesRegex,
+			c.ExcludeMachineProxiesExcept) {
+			return nil
+		}
 
-	zap.L().Info("Machine proxy: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, false, false, stateless, dependencies)
+		zap.L().Info("Machine proxy: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, false, false, stateless, dependencies)
+	})
 }
 
 func (c MachineProxyConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -130,26 +130,24 @@ func (c MachineProxyConverter) ToHclLookupById(id string, dependencies *data.Res
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.MachineProxy{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.MachineProxy{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.MachineProxy: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.MachineProxy: %w", err)
+		}
 
-	if c.Excluder.IsResourceExcludedWithRegex(resource.Name,
-		c.ExcludeAllMachineProxies,
-		c.ExcludeMachineProxies,
-		c.ExcludeMachineProxiesRegex,
-		c.ExcludeMachineProxiesExcept) {
-		return nil
-	}
+		if c.Excluder.IsResourceExcludedWithRegex(resource.Name,
+			c.ExcludeAllMachineProxies,
+			c.ExcludeMachineProxies,
+			c.ExcludeMachineProxiesRegex,
+			c.ExcludeMachineProxiesExcept) {
+			return nil
+		}
 
-	return c.toHcl(resource, false, true, false, dependencies)
+		return c.toHcl(resource, false, true, false, dependencies)
+	})
 }
 
 func (c MachineProxyConverter) buildData(resourceName string, name string) terraform.TerraformProjectGroupData {
@@ -300,7 +298,11 @@ func (c MachineProxyConverter) toHcl(resource octopus.MachineProxy, recursive bo
 
 	thisResource := data.ResourceDetails{}
 
-	machineProxyName := "machine_proxy_" + sanitizer.SanitizeName(resource.Name)
+	machineProxyName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "machine_proxy_", resource.Id, resource.Name)
+
+	if err != nil {
+		return err
+	}
 
 	if c.GenerateImportScripts && !stateless {
 		c.toBashImport(machineProxyName, resource.Name, dependencies)
@@ -345,7 +347,7 @@ func (c MachineProxyConverter) toHcl(resource octopus.MachineProxy, recursive bo
 		}
 
 		thisResource.ToHcl = func() (string, error) {
-			passwordName := naming.MachineProxyPassword(resource)
+			passwordName := naming.MachineProxyPassword(machineProxyName)
 
 			terraformResource := terraform.TerraformMachineProxy{
 				Type:         octopusdeployMachineProxyResourceType,
@@ -384,7 +386,12 @@ func (c MachineProxyConverter) toHcl(resource octopus.MachineProxy, recursive bo
 				Description: "The secret variable value associated with the machine proxy \"" + resource.Name + "\"",
 			}
 
-			if c.DummySecretVariableValues {
+			if !secrets.AddSecretVariable(c.SecretProvider, data.SecretVariableReference{
+				VariableName: passwordName,
+				ResourceType: c.GetResourceType(),
+				ResourceId:   resource.Id,
+				ResourceName: resource.Name,
+			}, dependencies) && c.DummySecretVariableValues {
 				secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
 				dependencies.AddDummy(data.DummyVariableReference{
 					VariableName: passwordName,
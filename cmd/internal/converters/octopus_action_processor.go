@@ -1,7 +1,10 @@
 package converters
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"regexp"
 	"slices"
 	"strings"
 
@@ -13,6 +16,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sliceutil"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/samber/lo"
+	"go.uber.org/zap"
 )
 
 // OctopusActionProcessor exposes a bunch of common functions for exporting the processes associated with
@@ -86,22 +90,20 @@ func (c OctopusActionProcessor) ExportFeeds(recursive bool, lookup bool, statele
 				}
 			}
 
-			for _, prop := range action.Properties {
-				for _, feed := range regexes.FeedRegex.FindAllString(fmt.Sprint(prop), -1) {
-					var err error
-					if recursive {
-						if stateless {
-							err = c.FeedConverter.ToHclStatelessById(feed, dependencies)
-						} else {
-							err = c.FeedConverter.ToHclById(feed, dependencies)
-						}
-					} else if lookup {
-						err = c.FeedConverter.ToHclLookupById(feed, dependencies)
+			for _, feed := range c.findActionReferences(action, regexes.FeedRegex) {
+				var err error
+				if recursive {
+					if stateless {
+						err = c.FeedConverter.ToHclStatelessById(feed, dependencies)
+					} else {
+						err = c.FeedConverter.ToHclById(feed, dependencies)
 					}
+				} else if lookup {
+					err = c.FeedConverter.ToHclLookupById(feed, dependencies)
+				}
 
-					if err != nil {
-						return err
-					}
+				if err != nil {
+					return err
 				}
 			}
 		}
@@ -114,22 +116,20 @@ func (c OctopusActionProcessor) ExportAccounts(recursive bool, lookup bool, stat
 
 	for _, step := range steps {
 		for _, action := range step.Actions {
-			for _, prop := range action.Properties {
-				for _, account := range regexes.AccountRegex.FindAllString(fmt.Sprint(prop), -1) {
-					var err error
-					if recursive {
-						if stateless {
-							err = c.AccountConverter.ToHclStatelessById(account, dependencies)
-						} else {
-							err = c.AccountConverter.ToHclById(account, dependencies)
-						}
-					} else if lookup {
-						err = c.AccountConverter.ToHclLookupById(account, dependencies)
+			for _, account := range c.findActionReferences(action, regexes.AccountRegex) {
+				var err error
+				if recursive {
+					if stateless {
+						err = c.AccountConverter.ToHclStatelessById(account, dependencies)
+					} else {
+						err = c.AccountConverter.ToHclById(account, dependencies)
 					}
+				} else if lookup {
+					err = c.AccountConverter.ToHclLookupById(account, dependencies)
+				}
 
-					if err != nil {
-						return err
-					}
+				if err != nil {
+					return err
 				}
 			}
 		}
@@ -147,16 +147,22 @@ func (c OctopusActionProcessor) ExportWorkerPools(recursive bool, lookup bool, s
 				return err
 			}
 
+			// Steps from the step framework can also reference worker pools in their inputs
+			workerPoolIds := regexes.WorkerPoolsRegex.FindAllString(c.inputsToString(action.Inputs), -1)
+
 			if workerPoolId != "" {
+				workerPoolIds = append(workerPoolIds, workerPoolId)
+			}
 
+			for _, workerPool := range lo.Uniq(workerPoolIds) {
 				if recursive {
 					if stateless {
-						err = c.WorkerPoolConverter.ToHclStatelessById(workerPoolId, dependencies)
+						err = c.WorkerPoolConverter.ToHclStatelessById(workerPool, dependencies)
 					} else {
-						err = c.WorkerPoolConverter.ToHclById(workerPoolId, dependencies)
+						err = c.WorkerPoolConverter.ToHclById(workerPool, dependencies)
 					}
 				} else if lookup {
-					err = c.WorkerPoolConverter.ToHclLookupById(workerPoolId, dependencies)
+					err = c.WorkerPoolConverter.ToHclLookupById(workerPool, dependencies)
 				}
 
 				if err != nil {
@@ -169,6 +175,126 @@ func (c OctopusActionProcessor) ExportWorkerPools(recursive bool, lookup bool, s
 	return nil
 }
 
+// inclusionRegexes are the regular expressions used to find references to other resources in action properties.
+var inclusionRegexes = []*regexp.Regexp{
+	regexes.FeedRegex,
+	regexes.AccountRegex,
+	regexes.WorkerPoolsRegex,
+	regexes.GitCredentialsRegex,
+	regexes.CertificatesRegex,
+	regexes.ProjectsRegex,
+}
+
+// RecordInclusions records the resources referenced by the steps in a process, so the reason each resource was
+// included in the export can be reported.
+func (c OctopusActionProcessor) RecordInclusions(processId string, steps []octopus.Step, dependencies *data.ResourceDetailsCollection) {
+	for _, step := range steps {
+		for _, action := range step.Actions {
+			record := func(id string, field string) {
+				recordInclusion(dependencies, processId, "", "step \""+strutil.EmptyIfNil(step.Name)+"\" action \""+strutil.EmptyIfNil(action.Name)+"\" "+field, id)
+			}
+
+			record(strutil.EmptyIfNil(action.Container.FeedId), "container feed")
+			record(action.WorkerPoolId, "worker pool")
+
+			for _, pack := range action.Packages {
+				if regexes.FeedRegex.MatchString(strutil.EmptyIfNil(pack.FeedId)) {
+					record(strutil.EmptyIfNil(pack.FeedId), "package \""+strutil.EmptyIfNil(pack.Name)+"\" feed")
+				}
+			}
+
+			for _, environment := range action.Environments {
+				record(environment, "environment scope")
+			}
+
+			for _, environment := range action.ExcludedEnvironments {
+				record(environment, "excluded environment scope")
+			}
+
+			for _, gitDependency := range action.GitDependencies {
+				record(strutil.EmptyIfNil(gitDependency.GitCredentialId), "git dependency \""+strutil.EmptyIfNil(gitDependency.Name)+"\" credential")
+			}
+
+			keys := lo.Keys(action.Properties)
+			slices.Sort(keys)
+
+			for _, key := range keys {
+				value := fmt.Sprint(action.Properties[key])
+
+				if key == "Octopus.Action.Template.Id" {
+					record(value, "property \""+key+"\"")
+				}
+
+				for _, regex := range inclusionRegexes {
+					for _, id := range regex.FindAllString(value, -1) {
+						record(id, "property \""+key+"\"")
+					}
+				}
+			}
+
+			inputs := c.inputsToString(action.Inputs)
+			for _, regex := range inclusionRegexes {
+				for _, id := range regex.FindAllString(inputs, -1) {
+					record(id, "inputs")
+				}
+			}
+		}
+	}
+}
+
+// findActionReferences returns the unique IDs matched by the regex in the action properties and in the inputs of
+// actions from the step framework.
+func (c OctopusActionProcessor) findActionReferences(action octopus.Action, regex *regexp.Regexp) []string {
+	references := []string{}
+
+	for _, prop := range action.Properties {
+		references = append(references, regex.FindAllString(fmt.Sprint(prop), -1)...)
+	}
+
+	references = append(references, regex.FindAllString(c.inputsToString(action.Inputs), -1)...)
+
+	return lo.Uniq(references)
+}
+
+// inputsToString serializes the inputs of an action from the step framework to a JSON string. Inputs are
+// nested objects, so they are serialized to allow IDs to be found and replaced anywhere in the structure.
+func (c OctopusActionProcessor) inputsToString(inputs map[string]any) string {
+	if len(inputs) == 0 {
+		return ""
+	}
+
+	buffer := bytes.Buffer{}
+	encoder := json.NewEncoder(&buffer)
+	// Inputs can contain scripts, so don't escape characters like < and >
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(inputs); err != nil {
+		zap.L().Error("Failed to serialize the step inputs", zap.Error(err))
+		return ""
+	}
+
+	return strings.TrimSpace(buffer.String())
+}
+
+// ConvertInputs converts the inputs of an action from the step framework to a JSON string, with any references
+// to accounts, feeds, worker pools, projects, git credentials and step templates replaced with the
+// Terraform resource lookups.
+func (c OctopusActionProcessor) ConvertInputs(inputs map[string]any, dependencies *data.ResourceDetailsCollection) *string {
+	inputsJson := c.inputsToString(inputs)
+
+	if inputsJson == "" {
+		return nil
+	}
+
+	properties := map[string]string{"inputs": inputsJson}
+	properties = c.EscapeDollars(properties)
+	properties = c.EscapePercents(properties)
+	properties = c.ReplaceIds(properties, dependencies)
+	properties = c.replaceWorkerPoolIds(properties, dependencies)
+
+	return strutil.StrPointer(properties["inputs"])
+}
+
 func (c OctopusActionProcessor) ConvertContainer(container octopus.Container, dependencies *data.ResourceDetailsCollection) *terraform.TerraformProcessStepContainer {
 	if container.Image != nil || container.FeedId != nil || container.Dockerfile != nil || container.GitUrl != nil {
 		return &terraform.TerraformProcessStepContainer{
@@ -483,6 +609,20 @@ func (c OctopusActionProcessor) replaceAccountIds(properties map[string]string,
 	return properties
 }
 
+// replaceWorkerPoolIds looks for any property value that is a valid worker pool ID and replaces it with a resource ID lookup.
+// This also looks in the property values, for instance when you export a JSON blob that has worker pool references.
+func (c OctopusActionProcessor) replaceWorkerPoolIds(properties map[string]string, dependencies *data.ResourceDetailsCollection) map[string]string {
+	for k, v := range properties {
+		for _, v2 := range dependencies.GetAllResource("WorkerPools") {
+			if len(v2.Id) != 0 && strings.Contains(v, v2.Id) {
+				properties[k] = strings.ReplaceAll(properties[k], v2.Id, v2.Lookup)
+			}
+		}
+	}
+
+	return properties
+}
+
 // replaceProjectIds looks for any property value that is a valid project ID and replaces it with a resource ID lookup.
 // This also looks in the property values, for instance when you export a JSON blob that has feed references.
 func (c OctopusActionProcessor) replaceProjectIds(properties map[string]string, dependencies *data.ResourceDetailsCollection) map[string]string {
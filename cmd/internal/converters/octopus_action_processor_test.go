@@ -1,6 +1,13 @@
 package converters
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/regexes"
+	"github.com/samber/lo"
+)
 
 func TestLimitAttributeLength(t *testing.T) {
 	processor := OctopusActionProcessor{}
@@ -27,3 +34,61 @@ func TestLimitAttributeLengthZero(t *testing.T) {
 		t.Fatalf("Property was not processed correctly")
 	}
 }
+
+func TestFindActionReferencesInInputs(t *testing.T) {
+	processor := OctopusActionProcessor{}
+	action := octopus.Action{
+		Properties: map[string]any{
+			"Octopus.Action.Aws.AccountId": "Accounts-1",
+		},
+		Inputs: map[string]any{
+			"awsAccount": "Accounts-2",
+			"nested": map[string]any{
+				"account": "Accounts-1",
+				"feedId":  "Feeds-3",
+			},
+		},
+	}
+
+	accounts := processor.findActionReferences(action, regexes.AccountRegex)
+
+	if len(accounts) != 2 || !lo.Contains(accounts, "Accounts-1") || !lo.Contains(accounts, "Accounts-2") {
+		t.Fatalf("Accounts were not found in the properties and inputs")
+	}
+
+	feeds := processor.findActionReferences(action, regexes.FeedRegex)
+
+	if len(feeds) != 1 || feeds[0] != "Feeds-3" {
+		t.Fatalf("Feeds were not found in the nested inputs")
+	}
+}
+
+func TestConvertInputs(t *testing.T) {
+	processor := OctopusActionProcessor{}
+	dependencies := data.ResourceDetailsCollection{}
+	dependencies.AddResource(data.ResourceDetails{
+		Id:           "Accounts-1",
+		ResourceType: "Accounts",
+		Lookup:       "${octopusdeploy_aws_account.account.id}",
+	})
+	dependencies.AddResource(data.ResourceDetails{
+		Id:           "WorkerPools-2",
+		ResourceType: "WorkerPools",
+		Lookup:       "${octopusdeploy_static_worker_pool.pool.id}",
+	})
+
+	inputs := processor.ConvertInputs(map[string]any{
+		"account":    "Accounts-1",
+		"workerPool": "WorkerPools-2",
+		"script":     "echo ${HOME} <done>",
+	}, &dependencies)
+
+	expected := `{"account":"${octopusdeploy_aws_account.account.id}","script":"echo $${HOME} <done>","workerPool":"${octopusdeploy_static_worker_pool.pool.id}"}`
+	if inputs == nil || *inputs != expected {
+		t.Fatalf("Inputs were not converted correctly: %v", inputs)
+	}
+
+	if processor.ConvertInputs(map[string]any{}, &dependencies) != nil {
+		t.Fatalf("Empty inputs must be nil")
+	}
+}
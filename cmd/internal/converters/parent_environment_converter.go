@@ -104,25 +104,23 @@ func (c ParentEnvironmentConverter) toHclById(id string, stateless bool, depende
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.ParentEnvironment{}
-	found, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.ParentEnvironment{}
+		found, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.ParentEnvironment: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.ParentEnvironment: %w", err)
+		}
 
-	// Environment lists can be for regular environments or parent environments.
-	// If the resource is not found, it may be a regular environment, so we will skip it.
-	if !found {
-		return nil
-	}
+		// Environment lists can be for regular environments or parent environments.
+		// If the resource is not found, it may be a regular environment, so we will skip it.
+		if !found {
+			return nil
+		}
 
-	zap.L().Info("Parent Environment: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, true, stateless, dependencies)
+		zap.L().Info("Parent Environment: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, true, stateless, dependencies)
+	})
 }
 
 func (c ParentEnvironmentConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -130,48 +128,46 @@ func (c ParentEnvironmentConverter) ToHclLookupById(id string, dependencies *dat
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	environment := octopus.ParentEnvironment{}
-	found, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &environment)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		environment := octopus.ParentEnvironment{}
+		found, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &environment)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.ParentEnvironment: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.ParentEnvironment: %w", err)
+		}
 
-	// Environment lists can be for regular environments or parent environments.
-	// If the resource is not found, it may be a regular environment, so we will skip it.
-	if !found {
-		return nil
-	}
+		// Environment lists can be for regular environments or parent environments.
+		// If the resource is not found, it may be a regular environment, so we will skip it.
+		if !found {
+			return nil
+		}
 
-	if c.Excluder.IsResourceExcludedWithRegex(environment.Name, c.ExcludeAllEnvironments, c.ExcludeEnvironments, c.ExcludeEnvironmentsRegex, c.ExcludeEnvironmentsExcept) {
-		return nil
-	}
+		if c.Excluder.IsResourceExcludedWithRegex(environment.Name, c.ExcludeAllEnvironments, c.ExcludeEnvironments, c.ExcludeEnvironmentsRegex, c.ExcludeEnvironmentsExcept) {
+			return nil
+		}
 
-	thisResource := data.ResourceDetails{}
+		thisResource := data.ResourceDetails{}
 
-	resourceName := "parent_environment_" + sanitizer.SanitizeName(environment.Name)
+		resourceName := "parent_environment_" + sanitizer.SanitizeName(environment.Name)
 
-	thisResource.FileName = "space_population/" + resourceName + ".tf"
-	thisResource.Id = environment.Id
-	thisResource.Name = environment.Name
-	thisResource.ResourceType = c.GetResourceType()
-	thisResource.Lookup = "${data." + octopusdeployParentEnvironmentsDataType + "." + resourceName + ".parent_environments[0].id}"
-	thisResource.ToHcl = func() (string, error) {
-		terraformResource := c.buildData(resourceName, environment)
-		file := hclwrite.NewEmptyFile()
-		block := gohcl.EncodeAsBlock(terraformResource, "data")
-		hcl.WriteLifecyclePostCondition(block, "Failed to resolve a parent environment called \""+environment.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.environments) != 0")
-		file.Body().AppendBlock(block)
+		thisResource.FileName = "space_population/" + resourceName + ".tf"
+		thisResource.Id = environment.Id
+		thisResource.Name = environment.Name
+		thisResource.ResourceType = c.GetResourceType()
+		thisResource.Lookup = "${data." + octopusdeployParentEnvironmentsDataType + "." + resourceName + ".parent_environments[0].id}"
+		thisResource.ToHcl = func() (string, error) {
+			terraformResource := c.buildData(resourceName, environment)
+			file := hclwrite.NewEmptyFile()
+			block := gohcl.EncodeAsBlock(terraformResource, "data")
+			hcl.WriteLifecyclePostCondition(block, "Failed to resolve a parent environment called \""+environment.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.environments) != 0")
+			file.Body().AppendBlock(block)
 
-		return string(file.Bytes()), nil
-	}
+			return string(file.Bytes()), nil
+		}
 
-	dependencies.AddResource(thisResource)
-	return nil
+		dependencies.AddResource(thisResource)
+		return nil
+	})
 }
 
 func (c ParentEnvironmentConverter) buildData(resourceName string, resource octopus.ParentEnvironment) terraform.TerraformParentEnvironmentData {
@@ -7,6 +7,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/secrets"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
@@ -18,6 +19,7 @@ type PlatformHubConverter struct {
 	ErrGroup                         *errgroup.Group
 	DummySecretVariableValues        bool
 	DummySecretGenerator             dummy.DummySecretGenerator
+	SecretProvider                   secrets.SecretProvider
 	ExcludePlatformHubVersionControl bool
 }
 
@@ -121,7 +123,11 @@ func (c PlatformHubConverter) generateUsernamePasswordHcl(resource *octopus.Octo
 		Description: "The secret variable value associated with the platform hub version control settings",
 	}
 
-	if c.DummySecretVariableValues {
+	if !secrets.AddSecretVariable(c.SecretProvider, data.SecretVariableReference{
+		VariableName: "PlatformHubVersionControlPassword",
+		ResourceType: "PlatformHubVersionControl",
+		ResourceName: "PlatformHubVersionControlPassword",
+	}, dependencies) && c.DummySecretVariableValues {
 		secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
 		dependencies.AddDummy(data.DummyVariableReference{
 			VariableName: "PlatformHubVersionControlPassword",
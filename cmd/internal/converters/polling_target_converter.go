@@ -8,37 +8,32 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
-	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
-	"strings"
 )
 
-const octopusdeployPollingTentacleDeploymentTargetDataType = "octopusdeploy_deployment_targets"
 const octopusdeployPollingTentacleDeploymentTargetResourceType = "octopusdeploy_polling_tentacle_deployment_target"
 
 type PollingTargetConverter struct {
 	TargetConverter
 
-	MachinePolicyConverter     ConverterWithStatelessById
-	EnvironmentConverter       ConverterAndLookupWithStatelessById
-	ParentEnvironmentConverter ConverterAndLookupWithStatelessById
-	ExcludeAllTargets          bool
-	ExcludeTargets             args.StringSliceArgs
-	ExcludeTargetsRegex        args.StringSliceArgs
-	ExcludeTargetsExcept       args.StringSliceArgs
-	ExcludeTenantTags          args.StringSliceArgs
-	ExcludeTenantTagSets       args.StringSliceArgs
-	TagSetConverter            ConvertToHclByResource[octopus.TagSet]
-	ErrGroup                   *errgroup.Group
-	IncludeIds                 bool
-	LimitResourceCount         int
-	IncludeSpaceInPopulation   bool
-	GenerateImportScripts      bool
+	TargetDependencyConverters
+
+	ExcludeAllTargets        bool
+	ExcludeTargets           args.StringSliceArgs
+	ExcludeTargetsRegex      args.StringSliceArgs
+	ExcludeTargetsExcept     args.StringSliceArgs
+	ExcludeTenantTags        args.StringSliceArgs
+	ExcludeTenantTagSets     args.StringSliceArgs
+	TagSetConverter          ConvertToHclByResource[octopus.TagSet]
+	ErrGroup                 *errgroup.Group
+	IncludeIds               bool
+	LimitResourceCount       int
+	IncludeSpaceInPopulation bool
+	GenerateImportScripts    bool
 }
 
 func (c PollingTargetConverter) AllToHcl(dependencies *data.ResourceDetailsCollection) {
@@ -122,33 +117,31 @@ func (c PollingTargetConverter) toHclById(id string, stateless bool, dependencie
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.PollingEndpointResource{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.PollingEndpointResource{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.PollingEndpointResource: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.PollingEndpointResource: %w", err)
+		}
 
-	if !c.isPollingTarget(resource) {
-		return nil
-	}
+		if !c.isPollingTarget(resource) {
+			return nil
+		}
 
-	err, noEnvironments := c.HasNoEnvironments(resource)
+		err, noEnvironments := c.HasNoEnvironments(resource)
 
-	if err != nil {
-		return err
-	}
+		if err != nil {
+			return err
+		}
 
-	if noEnvironments {
-		return nil
-	}
+		if noEnvironments {
+			return nil
+		}
 
-	zap.L().Info("Polling Target: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, true, stateless, dependencies)
+		zap.L().Info("Polling Target: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, true, stateless, dependencies)
+	})
 }
 
 func (c PollingTargetConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -156,185 +149,34 @@ func (c PollingTargetConverter) ToHclLookupById(id string, dependencies *data.Re
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.PollingEndpointResource{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.PollingEndpointResource{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.PollingEndpointResource: %w", err)
-	}
-
-	// Ignore excluded targets
-	if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllTargets, c.ExcludeTargets, c.ExcludeTargetsRegex, c.ExcludeTargetsExcept) {
-		return nil
-	}
-
-	if !c.isPollingTarget(resource) {
-		return nil
-	}
-
-	err, noEnvironments := c.HasNoEnvironments(resource)
-
-	if err != nil {
-		return err
-	}
-
-	if noEnvironments {
-		return nil
-	}
-
-	thisResource := data.ResourceDetails{}
-
-	resourceName := "target_" + sanitizer.SanitizeName(resource.Name)
-
-	thisResource.FileName = "space_population/" + resourceName + ".tf"
-	thisResource.Id = resource.Id
-	thisResource.Name = resource.Name
-	thisResource.ResourceType = c.GetResourceType()
-	thisResource.Lookup = "${data." + octopusdeployPollingTentacleDeploymentTargetDataType + "." + resourceName + ".deployment_targets[0].id}"
-	thisResource.ToHcl = func() (string, error) {
-		terraformResource := c.buildData(resourceName, resource)
-		file := hclwrite.NewEmptyFile()
-		file.Body().AppendBlock(gohcl.EncodeAsBlock(terraformResource, "data"))
-
-		return string(file.Bytes()), nil
-	}
-
-	dependencies.AddResource(thisResource)
-	return nil
-}
-
-func (c PollingTargetConverter) buildData(resourceName string, resource octopus.PollingEndpointResource) terraform.TerraformDeploymentTargetsData {
-	return terraform.TerraformDeploymentTargetsData{
-		Type:        octopusdeployPollingTentacleDeploymentTargetDataType,
-		Name:        resourceName,
-		Ids:         nil,
-		PartialName: &resource.Name,
-		Skip:        0,
-		Take:        1,
-	}
-}
-
-// writeData appends the data block for stateless modules
-func (c PollingTargetConverter) writeData(file *hclwrite.File, resource octopus.PollingEndpointResource, resourceName string) {
-	terraformResource := c.buildData(resourceName, resource)
-	block := gohcl.EncodeAsBlock(terraformResource, "data")
-	file.Body().AppendBlock(block)
-}
-
-// toBashImport creates a bash script to import the resource
-func (c PollingTargetConverter) toBashImport(resourceName string, octopusResourceName string, dependencies *data.ResourceDetailsCollection) {
-	dependencies.AddResource(data.ResourceDetails{
-		FileName: "space_population/import_" + resourceName + ".sh",
-		ToHcl: func() (string, error) {
-			return fmt.Sprintf(`#!/bin/bash
-
-# This script is used to import an exiting resource into the Terraform state.
-# It is useful when importing a Terraform module into an Octopus space that
-# already has existing resources.
-
-# Make the script executable with the command:
-# chmod +x ./import_%s.sh
-
-# Alternativly, run the script with bash directly:
-# /bin/bash ./import_%s.sh <options>
-
-# Run "terraform init" to download any required providers and to configure the
-# backend configuration
-
-# Then run the import script. Replace the API key, instance URL, and Space ID 
-# in the example below with the values of the space that the Terraform module 
-# will be imported into.
-
-# ./import_%s.sh API-xxxxxxxxxxxx https://yourinstance.octopus.app Spaces-1234
-
-if [[ $# -ne 3 ]]
-then
-	echo "Usage: ./import_%s.sh <API Key> <Octopus URL> <Space ID>"
-    echo "Example: ./import_%s.sh API-xxxxxxxxxxxx https://yourinstance.octopus.app Spaces-1234"
-	exit 1
-fi
-
-if ! command -v jq &> /dev/null
-then
-    echo "jq is required" >&2
-    exit 1
-fi
-
-if ! command -v curl &> /dev/null
-then
-    echo "curl is required" >&2
-    exit 1
-fi
-
-RESOURCE_NAME="%s"
-RESOURCE_ID=$(curl --silent -G --data-urlencode "partialName=${RESOURCE_NAME}" --data-urlencode "take=10000" --header "X-Octopus-ApiKey: $1" "$2/api/$3/Machines" | jq -r ".Items[] | select(.Name == \"${RESOURCE_NAME}\") | .Id")
-
-if [[ -z "${RESOURCE_ID}" ]]
-then
-	echo "No target found with the name ${RESOURCE_NAME}"
-	exit 1
-fi
-
-echo "Importing target ${RESOURCE_ID}"
-
-terraform import "-var=octopus_server=$2" "-var=octopus_apikey=$1" "-var=octopus_space_id=$3" %s.%s ${RESOURCE_ID}`, resourceName, resourceName, resourceName, resourceName, resourceName, octopusResourceName, octopusdeployPollingTentacleDeploymentTargetDataType, resourceName), nil
-		},
-	})
-}
-
-// toPowershellImport creates a powershell script to import the resource
-func (c PollingTargetConverter) toPowershellImport(resourceName string, projectName string, dependencies *data.ResourceDetailsCollection) {
-	dependencies.AddResource(data.ResourceDetails{
-		FileName: "space_population/import_" + resourceName + ".ps1",
-		ToHcl: func() (string, error) {
-			return fmt.Sprintf(`# This script is used to import an exiting resource into the Terraform state.
-# It is useful when importing a Terraform module into an Octopus space that
-# already has existing resources.
-
-# Run "terraform init" to download any required providers and to configure the
-# backend configuration
-
-# Then run the import script. Replace the API key, instance URL, and Space ID 
-# in the example below with the values of the space that the Terraform module 
-# will be imported into.
-
-# ./import_%s.ps1 API-xxxxxxxxxxxx https://yourinstance.octopus.app Spaces-1234
-
-param (
-    [Parameter(Mandatory=$true)]
-    [string]$ApiKey,
-
-    [Parameter(Mandatory=$true)]
-    [string]$Url,
-
-    [Parameter(Mandatory=$true)]
-    [string]$SpaceId
-)
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.PollingEndpointResource: %w", err)
+		}
 
-$ResourceName="%s"
+		// Ignore excluded targets
+		if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllTargets, c.ExcludeTargets, c.ExcludeTargetsRegex, c.ExcludeTargetsExcept) {
+			return nil
+		}
 
-$headers = @{
-    "X-Octopus-ApiKey" = $ApiKey
-}
+		if !c.isPollingTarget(resource) {
+			return nil
+		}
 
-$ResourceId = Invoke-RestMethod -Uri "$Url/api/$SpaceId/Machines?take=10000&partialName=$([System.Web.HttpUtility]::UrlEncode($ResourceName))" -Method Get -Headers $headers |
-	Select-Object -ExpandProperty Items | 
-	Where-Object {$_.Name -eq $ResourceName} | 
-	Select-Object -ExpandProperty Id
+		err, noEnvironments := c.HasNoEnvironments(resource)
 
-if ([System.String]::IsNullOrEmpty($ResourceId)) {
-	Write-Error "No target found with the name $ResourceName"
-	exit 1
-}
+		if err != nil {
+			return err
+		}
 
-echo "Importing target $ResourceId"
+		if noEnvironments {
+			return nil
+		}
 
-terraform import "-var=octopus_server=$Url" "-var=octopus_apikey=$ApiKey" "-var=octopus_space_id=$SpaceId" %s.%s $ResourceId`, resourceName, projectName, octopusdeployPollingTentacleDeploymentTargetDataType, resourceName), nil
-		},
+		return c.toLookupHcl(resource.Id, resource.Name, dependencies)
 	})
 }
 
@@ -363,23 +205,29 @@ func (c PollingTargetConverter) toHcl(target octopus.PollingEndpointResource, re
 		return nil
 	}
 
+	recordInclusion(dependencies, target.Id, target.Name, "EnvironmentIds", target.EnvironmentIds...)
+	recordInclusion(dependencies, target.Id, target.Name, "MachinePolicyId", target.MachinePolicyId)
+
 	if recursive {
 		if stateless {
-			if err := c.exportStatelessDependencies(target, dependencies); err != nil {
+			if err := c.exportStatelessDependencies(target.MachinePolicyId, target.EnvironmentIds, dependencies); err != nil {
 				return err
 			}
 		} else {
-			if err := c.exportDependencies(target, dependencies); err != nil {
+			if err := c.exportDependencies(target.MachinePolicyId, target.EnvironmentIds, dependencies); err != nil {
 				return err
 			}
 		}
 	}
 
-	targetName := "target_" + sanitizer.SanitizeName(target.Name)
+	targetName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "target_", target.Id, target.Name)
+	if err != nil {
+		return err
+	}
 
 	if c.GenerateImportScripts && !stateless {
-		c.toBashImport(targetName, target.Name, dependencies)
-		c.toPowershellImport(targetName, target.Name, dependencies)
+		c.toBashImport(octopusdeployPollingTentacleDeploymentTargetResourceType, targetName, target.Name, dependencies)
+		c.toPowershellImport(octopusdeployPollingTentacleDeploymentTargetResourceType, targetName, target.Name, dependencies)
 	}
 
 	thisResource := data.ResourceDetails{}
@@ -388,14 +236,7 @@ func (c PollingTargetConverter) toHcl(target octopus.PollingEndpointResource, re
 	thisResource.Name = target.Name
 	thisResource.ResourceType = c.GetResourceType()
 
-	if stateless {
-		thisResource.Lookup = "${length(data." + octopusdeployPollingTentacleDeploymentTargetDataType + "." + targetName + ".deployment_targets) != 0 " +
-			"? data." + octopusdeployPollingTentacleDeploymentTargetDataType + "." + targetName + ".deployment_targets[0].id " +
-			": " + octopusdeployPollingTentacleDeploymentTargetResourceType + "." + targetName + "[0].id}"
-		thisResource.Dependency = "${" + octopusdeployPollingTentacleDeploymentTargetResourceType + "." + targetName + "}"
-	} else {
-		thisResource.Lookup = "${" + octopusdeployPollingTentacleDeploymentTargetResourceType + "." + targetName + ".id}"
-	}
+	thisResource.Lookup, thisResource.Dependency = c.getLookup(octopusdeployPollingTentacleDeploymentTargetResourceType, targetName, stateless)
 
 	thisResource.ToHcl = func() (string, error) {
 
@@ -427,8 +268,8 @@ func (c PollingTargetConverter) toHcl(target octopus.PollingEndpointResource, re
 		file := hclwrite.NewEmptyFile()
 
 		if stateless {
-			c.writeData(file, target, targetName)
-			terraformResource.Count = strutil.StrPointer("${length(data." + octopusdeployPollingTentacleDeploymentTargetDataType + "." + targetName + ".deployment_targets) != 0 ? 0 : 1}")
+			c.writeData(file, target.Name, targetName)
+			terraformResource.Count = c.getStatelessCount(targetName)
 		}
 
 		block := gohcl.EncodeAsBlock(terraformResource, "resource")
@@ -454,90 +295,3 @@ func (c PollingTargetConverter) toHcl(target octopus.PollingEndpointResource, re
 func (c PollingTargetConverter) GetResourceType() string {
 	return "Machines"
 }
-
-func (c PollingTargetConverter) lookupEnvironments(envs []string, dependencies *data.ResourceDetailsCollection) []string {
-	newEnvs := make([]string, len(envs))
-	for i, v := range envs {
-		environment := dependencies.GetResource("Environments", v)
-		if environment == "" {
-			environment = dependencies.GetResource("ParentEnvironments", v)
-		}
-		newEnvs[i] = environment
-	}
-	return lo.Filter(newEnvs, func(item string, index int) bool {
-		return strings.TrimSpace(item) != ""
-	})
-}
-
-func (c PollingTargetConverter) getMachinePolicy(machine string, dependencies *data.ResourceDetailsCollection) *string {
-	machineLookup := dependencies.GetResource("MachinePolicies", machine)
-	if machineLookup == "" {
-		return nil
-	}
-
-	return &machineLookup
-}
-
-func (c PollingTargetConverter) exportDependencies(target octopus.PollingEndpointResource, dependencies *data.ResourceDetailsCollection) error {
-
-	// The machine policies need to be exported
-	err := c.MachinePolicyConverter.ToHclById(target.MachinePolicyId, dependencies)
-
-	if err != nil {
-		return err
-	}
-
-	// Export the environments
-	for _, e := range target.EnvironmentIds {
-		err = c.EnvironmentConverter.ToHclById(e, dependencies)
-
-		if err != nil {
-			return err
-		}
-	}
-
-	// Export the parent environments
-	if c.ParentEnvironmentConverter != nil {
-		for _, e := range target.EnvironmentIds {
-			err = c.ParentEnvironmentConverter.ToHclById(e, dependencies)
-
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
-
-func (c PollingTargetConverter) exportStatelessDependencies(target octopus.PollingEndpointResource, dependencies *data.ResourceDetailsCollection) error {
-
-	// The machine policies need to be exported
-	err := c.MachinePolicyConverter.ToHclStatelessById(target.MachinePolicyId, dependencies)
-
-	if err != nil {
-		return err
-	}
-
-	// Export the environments
-	for _, e := range target.EnvironmentIds {
-		err = c.EnvironmentConverter.ToHclStatelessById(e, dependencies)
-
-		if err != nil {
-			return err
-		}
-	}
-
-	// Export the parent environments
-	if c.ParentEnvironmentConverter != nil {
-		for _, e := range target.EnvironmentIds {
-			err = c.ParentEnvironmentConverter.ToHclStatelessById(e, dependencies)
-
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
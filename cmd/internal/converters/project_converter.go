@@ -16,6 +16,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/secrets"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
@@ -53,6 +54,7 @@ type ProjectConverter struct {
 	ExcludeAllProjects          bool
 	DummySecretVariableValues   bool
 	DummySecretGenerator        dummy.DummySecretGenerator
+	SecretProvider              secrets.SecretProvider
 	Excluder                    ExcludeByName
 	// This is set to true when this converter is only to be used to call ToHclLookupById
 	LookupOnlyMode             bool
@@ -171,52 +173,54 @@ func (c *ProjectConverter) ToHclLookupById(id string, dependencies *data.Resourc
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	project := octopus.Project{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &project)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		project := octopus.Project{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &project)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Project: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Project: %w", err)
+		}
 
-	thisResource := data.ResourceDetails{}
+		thisResource := data.ResourceDetails{}
 
-	projectName := "project_" + sanitizer.SanitizeName(project.Name)
+		projectName, err := getProjectLabel(c.Client, dependencies, project.Id, project.Name)
 
-	thisResource.FileName = "space_population/project_" + projectName + ".tf"
-	thisResource.Id = project.Id
-	thisResource.Name = project.Name
-	thisResource.ResourceType = c.GetResourceType()
-	thisResource.Lookup = "${data." + octopusdeployProjectsDataType + "." + projectName + ".projects[0].id}"
-	thisResource.ToHcl = func() (string, error) {
-		terraformResource := c.buildData(projectName, "${var."+projectName+"_name}")
-		projectNameVariable := terraform.TerraformVariable{
-			Name:        projectName + "_name",
-			Type:        "string",
-			Nullable:    false,
-			Sensitive:   false,
-			Description: "The name of the project to attach the runbook to",
-			Default:     &project.Name,
+		if err != nil {
+			return err
 		}
 
-		file := hclwrite.NewEmptyFile()
+		thisResource.FileName = "space_population/project_" + projectName + ".tf"
+		thisResource.Id = project.Id
+		thisResource.Name = project.Name
+		thisResource.ResourceType = c.GetResourceType()
+		thisResource.Lookup = "${data." + octopusdeployProjectsDataType + "." + projectName + ".projects[0].id}"
+		thisResource.ToHcl = func() (string, error) {
+			terraformResource := c.buildData(projectName, "${var."+projectName+"_name}")
+			projectNameVariable := terraform.TerraformVariable{
+				Name:        projectName + "_name",
+				Type:        "string",
+				Nullable:    false,
+				Sensitive:   false,
+				Description: "The name of the project to attach the runbook to",
+				Default:     &project.Name,
+			}
 
-		variableBlock := gohcl.EncodeAsBlock(projectNameVariable, "variable")
-		hcl.WriteUnquotedAttribute(variableBlock, "type", "string")
-		file.Body().AppendBlock(variableBlock)
+			file := hclwrite.NewEmptyFile()
 
-		dataBlock := gohcl.EncodeAsBlock(terraformResource, "data")
-		hcl.WriteLifecyclePostCondition(dataBlock, "Failed to resolve an project called \""+project.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.projects) != 0")
-		file.Body().AppendBlock(dataBlock)
+			variableBlock := gohcl.EncodeAsBlock(projectNameVariable, "variable")
+			hcl.WriteUnquotedAttribute(variableBlock, "type", "string")
+			file.Body().AppendBlock(variableBlock)
 
-		return string(file.Bytes()), nil
-	}
+			dataBlock := gohcl.EncodeAsBlock(terraformResource, "data")
+			hcl.WriteLifecyclePostCondition(dataBlock, "Failed to resolve an project called \""+project.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "length(self.projects) != 0")
+			file.Body().AppendBlock(dataBlock)
 
-	dependencies.AddResource(thisResource)
-	return nil
+			return string(file.Bytes()), nil
+		}
+
+		dependencies.AddResource(thisResource)
+		return nil
+	})
 }
 
 // ToHclByIdWithLookups exports a self-contained representation of the project where external resources like
@@ -250,18 +254,16 @@ func (c *ProjectConverter) ToHclStatelessById(id string, dependencies *data.Reso
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		project := octopus.Project{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &project)
 
-	project := octopus.Project{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &project)
-
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Project: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Project: %w", err)
+		}
 
-	return c.toHcl(project, true, false, true, dependencies)
+		return c.toHcl(project, true, false, true, dependencies)
+	})
 }
 
 func (c *ProjectConverter) ToHclById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -269,18 +271,16 @@ func (c *ProjectConverter) ToHclById(id string, dependencies *data.ResourceDetai
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	project := octopus.Project{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &project)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		project := octopus.Project{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &project)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Project: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Project: %w", err)
+		}
 
-	return c.toHcl(project, true, false, false, dependencies)
+		return c.toHcl(project, true, false, false, dependencies)
+	})
 }
 
 func (c *ProjectConverter) buildData(resourceName string, name string) terraform.TerraformProjectData {
@@ -455,7 +455,13 @@ func (c *ProjectConverter) toHcl(project octopus.Project, recursive bool, lookup
 
 	thisResource := data.ResourceDetails{}
 
-	projectName := "project_" + sanitizer.SanitizeName(project.Name)
+	projectName, err := getProjectLabel(c.Client, dependencies, project.Id, project.Name)
+
+	if err != nil {
+		return err
+	}
+
+	c.recordInclusions(project, dependencies)
 
 	if recursive {
 		if err := c.exportDependencies(project, stateless, dependencies); err != nil {
@@ -533,8 +539,8 @@ func (c *ProjectConverter) toHcl(project octopus.Project, recursive bool, lookup
 			return "", err
 		}
 
-		jsm := c.convertJiraSettings(file, project)
-		snow := c.convertServiceNowSettings(file, project)
+		jsm := c.convertJiraSettings(file, project, projectName)
+		snow := c.convertServiceNowSettings(file, project, projectName)
 
 		terraformResource := terraform.TerraformProject{
 			Type:                                   octopusdeployProjectResourceType,
@@ -607,6 +613,7 @@ func (c *ProjectConverter) toHcl(project octopus.Project, recursive bool, lookup
 
 		// write any variables used to define the value of tenant template secrets
 		for _, variable := range variables {
+			secrets.AddSecretVariable(c.SecretProvider, c.secretReference(variable.Name, project), dependencies)
 			block := gohcl.EncodeAsBlock(variable, "variable")
 			hcl.WriteUnquotedAttribute(block, "type", "string")
 			file.Body().AppendBlock(block)
@@ -622,7 +629,7 @@ func (c *ProjectConverter) toHcl(project octopus.Project, recursive bool, lookup
 					Description: "The git password for the project \"" + project.Name + "\"",
 				}
 
-				if c.DummySecretVariableValues {
+				if !secrets.AddSecretVariable(c.SecretProvider, c.secretReference(projectName+"_git_password", project), dependencies) && c.DummySecretVariableValues {
 					secretVariableResource.Default = c.DummySecretGenerator.GetDummySecret()
 					dependencies.AddDummy(data.DummyVariableReference{
 						VariableName: projectName + "_git_password",
@@ -781,6 +788,17 @@ func (c *ProjectConverter) writeGitPathVar(projectName string, project octopus.P
 	file.Body().AppendBlock(block)
 }
 
+// secretReference returns the secrets inventory entry for a sensitive variable exposed by the project
+func (c *ProjectConverter) secretReference(variableName string, project octopus.Project) data.SecretVariableReference {
+	return data.SecretVariableReference{
+		VariableName: variableName,
+		ResourceType: c.GetResourceType(),
+		ResourceId:   project.Id,
+		ResourceName: project.Name,
+		Scope:        data.SecretScope{ProjectId: project.Id},
+	}
+}
+
 func (c *ProjectConverter) GetResourceType() string {
 	return "Projects"
 }
@@ -1047,7 +1065,7 @@ func (c *ProjectConverter) convertAnonymousGitPersistence(project octopus.Projec
 	}
 }
 
-func (c *ProjectConverter) convertJiraSettings(file *hclwrite.File, project octopus.Project) *terraform.TerraformProjectJiraServiceManagementExtensionSettings {
+func (c *ProjectConverter) convertJiraSettings(file *hclwrite.File, project octopus.Project, projectName string) *terraform.TerraformProjectJiraServiceManagementExtensionSettings {
 	if project.ExtensionSettings == nil {
 		return nil
 	}
@@ -1066,8 +1084,8 @@ func (c *ProjectConverter) convertJiraSettings(file *hclwrite.File, project octo
 		return nil
 	}
 
-	jsmConnectionIdVariableName := "project_" + sanitizer.SanitizeName(project.Name) + "_jsm_connection_id"
-	jsmServiceDeskProjectNameVariableName := "project_" + sanitizer.SanitizeName(project.Name) + "_jsm_service_desk_project_name"
+	jsmConnectionIdVariableName := projectName + "_jsm_connection_id"
+	jsmServiceDeskProjectNameVariableName := projectName + "_jsm_service_desk_project_name"
 
 	jsmConnectionIdVariable := terraform.TerraformVariable{
 		Name:        jsmConnectionIdVariableName,
@@ -1102,7 +1120,7 @@ func (c *ProjectConverter) convertJiraSettings(file *hclwrite.File, project octo
 	}
 }
 
-func (c *ProjectConverter) convertServiceNowSettings(file *hclwrite.File, project octopus.Project) *terraform.TerraformProjectServicenowExtensionSettings {
+func (c *ProjectConverter) convertServiceNowSettings(file *hclwrite.File, project octopus.Project, projectName string) *terraform.TerraformProjectServicenowExtensionSettings {
 	if project.ExtensionSettings == nil {
 		return nil
 	}
@@ -1121,8 +1139,8 @@ func (c *ProjectConverter) convertServiceNowSettings(file *hclwrite.File, projec
 		return nil
 	}
 
-	snowConnectionIdVariableName := "project_" + sanitizer.SanitizeName(project.Name) + "_snow_connection_id"
-	snowStandardChangeTemplateName := "project_" + sanitizer.SanitizeName(project.Name) + "_snow_standard_change_template_name"
+	snowConnectionIdVariableName := projectName + "_snow_connection_id"
+	snowStandardChangeTemplateName := projectName + "_snow_standard_change_template_name"
 
 	jsmConnectionIdVariable := terraform.TerraformVariable{
 		Name:        snowConnectionIdVariableName,
@@ -1422,6 +1440,18 @@ func (c *ProjectConverter) exportChildDependencies(recursive bool, lookup bool,
 	return nil
 }
 
+// recordInclusions records the resources referenced by the project, so the reason each resource was included in
+// the export can be reported.
+func (c *ProjectConverter) recordInclusions(project octopus.Project, dependencies *data.ResourceDetailsCollection) {
+	recordInclusion(dependencies, project.Id, project.Name, "ProjectGroupId", project.ProjectGroupId)
+	recordInclusion(dependencies, project.Id, project.Name, "LifecycleId", project.LifecycleId)
+	recordInclusion(dependencies, project.Id, project.Name, "IncludedLibraryVariableSetIds", project.IncludedLibraryVariableSetIds...)
+
+	if project.PersistenceSettings.Credentials.Type == "Reference" {
+		recordInclusion(dependencies, project.Id, project.Name, "PersistenceSettings.Credentials.Id", project.PersistenceSettings.Credentials.Id)
+	}
+}
+
 func (c *ProjectConverter) exportDependencyLookups(project octopus.Project, dependencies *data.ResourceDetailsCollection) error {
 	// Export the project group
 	err := c.ProjectGroupConverter.ToHclLookupById(project.ProjectGroupId, dependencies)
@@ -1626,7 +1656,9 @@ func (c *ProjectConverter) linkTenantsAndCreateVars(project octopus.Project, sta
 
 		// Link the tenants to the project
 		if environmentIds, ok := tenant.ProjectEnvironments[project.Id]; ok {
-			c.TenantProjectConverter.LinkTenantToProject(tenant, project, environmentIds, dependencies)
+			if err := c.TenantProjectConverter.LinkTenantToProject(tenant, project, environmentIds, dependencies); err != nil {
+				return err
+			}
 		}
 
 		// Create the project tenant variables
@@ -8,7 +8,6 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
@@ -72,8 +71,10 @@ func (c ProjectGroupConverter) allToHcl(stateless bool, dependencies *data.Resou
 			continue
 		}
 
-		zap.L().Info("Project Group: " + resource.Id + " " + resource.Name)
-		err := c.toHcl(resource, false, false, stateless, dependencies)
+		err := dependencies.ConvertResource(resource.Id, c.GetResourceType(), func() error {
+			zap.L().Info("Project Group: " + resource.Id + " " + resource.Name)
+			return c.toHcl(resource, false, false, stateless, dependencies)
+		})
 
 		if err != nil {
 			return err
@@ -97,23 +98,21 @@ func (c ProjectGroupConverter) toHclById(id string, stateless bool, dependencies
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.ProjectGroup{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.ProjectGroup{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.ProjectGroup: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.ProjectGroup: %w", err)
+		}
 
-	if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllProjectGroups, c.ExcludeProjectGroups, c.ExcludeProjectGroupsRegex, c.ExcludeProjectGroupsExcept) {
-		return nil
-	}
+		if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllProjectGroups, c.ExcludeProjectGroups, c.ExcludeProjectGroupsRegex, c.ExcludeProjectGroupsExcept) {
+			return nil
+		}
 
-	zap.L().Info("Project Group: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, false, false, stateless, dependencies)
+		zap.L().Info("Project Group: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, false, false, stateless, dependencies)
+	})
 }
 
 func (c ProjectGroupConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -121,22 +120,20 @@ func (c ProjectGroupConverter) ToHclLookupById(id string, dependencies *data.Res
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.ProjectGroup{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.ProjectGroup{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.ProjectGroup: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.ProjectGroup: %w", err)
+		}
 
-	if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllProjectGroups, c.ExcludeProjectGroups, c.ExcludeProjectGroupsRegex, c.ExcludeProjectGroupsExcept) {
-		return nil
-	}
+		if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllProjectGroups, c.ExcludeProjectGroups, c.ExcludeProjectGroupsRegex, c.ExcludeProjectGroupsExcept) {
+			return nil
+		}
 
-	return c.toHcl(resource, false, true, false, dependencies)
+		return c.toHcl(resource, false, true, false, dependencies)
+	})
 }
 
 func (c ProjectGroupConverter) buildData(resourceName string, name string) terraform.TerraformProjectGroupData {
@@ -284,7 +281,11 @@ func (c ProjectGroupConverter) toHcl(resource octopus.ProjectGroup, recursive bo
 
 	forceLookup := lookup || resource.Name == defaultProjectGroup
 
-	projectName := "project_group_" + sanitizer.SanitizeName(resource.Name)
+	projectName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "project_group_", resource.Id, resource.Name)
+
+	if err != nil {
+		return err
+	}
 
 	if c.GenerateImportScripts && resource.Name != defaultProjectGroup && !lookup && !stateless {
 		c.toBashImport(projectName, resource.Name, dependencies)
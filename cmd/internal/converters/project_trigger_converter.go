@@ -68,6 +68,12 @@ func (c ProjectTriggerConverter) toHclByProjectIdAndName(projectId string, proje
 		return fmt.Errorf("error in OctopusClient.GetAllResources loading type octopus.GeneralCollection[octopus.ProjectTrigger]: %w", err)
 	}
 
+	projectLabel, err := getProjectLabel(c.Client, dependencies, projectId, projectName)
+
+	if err != nil {
+		return err
+	}
+
 	// We want all the triggers
 	triggers := []octopus.ProjectTrigger{}
 	triggers = append(triggers, collection.Items...)
@@ -83,7 +89,7 @@ func (c ProjectTriggerConverter) toHclByProjectIdAndName(projectId string, proje
 		}
 
 		zap.L().Info("Project Trigger: " + resource.Id + " " + resource.Name)
-		err = c.toHcl(resource, recursive, lookup, stateless, projectId, projectName, dependencies)
+		err = c.toHcl(resource, recursive, lookup, stateless, projectId, projectName, projectLabel, dependencies)
 		if err != nil {
 			return err
 		}
@@ -280,7 +286,7 @@ if ($LASTEXITCODE -ne 0) {
 	})
 }
 
-func (c ProjectTriggerConverter) toHcl(projectTrigger octopus.ProjectTrigger, recursive bool, lookup bool, stateless bool, projectId string, projectName string, dependencies *data.ResourceDetailsCollection) error {
+func (c ProjectTriggerConverter) toHcl(projectTrigger octopus.ProjectTrigger, recursive bool, lookup bool, stateless bool, projectId string, projectName string, projectLabel string, dependencies *data.ResourceDetailsCollection) error {
 	// Don't import twice
 	if dependencies.HasResource(projectTrigger.Id, c.GetResourceType()) {
 		return nil
@@ -294,6 +300,8 @@ func (c ProjectTriggerConverter) toHcl(projectTrigger octopus.ProjectTrigger, re
 	supportedTriggers := []string{"GitFilter", "ArcFeedFilter", "MachineFilter", "OnceDailySchedule", "FeedFilter", "CronExpressionSchedule", "DaysPerMonthSchedule", "ContinuousDailySchedule", "FeedFilter"}
 	if slices.Index(supportedTriggers, projectTrigger.Filter.FilterType) == -1 {
 		zap.L().Error("Found an unsupported trigger type " + projectTrigger.Filter.FilterType)
+		recordLintFinding(dependencies, data.LintSeverityLossy, c.GetResourceType(), projectTrigger.Id, projectTrigger.Name,
+			"The trigger type \""+projectTrigger.Filter.FilterType+"\" is not supported by the Terraform provider, so the trigger was not exported.")
 		return nil
 	}
 
@@ -306,30 +314,36 @@ func (c ProjectTriggerConverter) toHcl(projectTrigger octopus.ProjectTrigger, re
 		return err
 	}
 
-	c.buildTargetTrigger(projectTrigger, stateless, projectId, projectName, dependencies)
-	c.buildScheduledTriggerResources(projectTrigger, stateless, projectId, projectName, dependencies)
+	c.buildTargetTrigger(projectTrigger, stateless, projectId, projectName, projectLabel, dependencies)
+	c.buildScheduledTriggerResources(projectTrigger, stateless, projectId, projectName, projectLabel, dependencies)
 
-	err := c.buildArcTriggerResources(projectTrigger, stateless, projectId, projectName, dependencies)
+	err := c.buildArcTriggerResources(projectTrigger, stateless, projectId, projectName, projectLabel, dependencies)
 
 	if err != nil {
 		return err
 	}
 
-	err = c.buildGitTriggerResources(projectTrigger, stateless, projectId, projectName, dependencies)
+	err = c.buildGitTriggerResources(projectTrigger, stateless, projectId, projectName, projectLabel, dependencies)
 
 	if err != nil {
 		return err
 	}
 
-	return c.buildFeedTriggerResources(projectTrigger, stateless, projectId, projectName, dependencies)
+	return c.buildFeedTriggerResources(projectTrigger, stateless, projectId, projectName, projectLabel, dependencies)
+}
+
+// getTriggerLabel returns the unique Terraform label of the trigger, which includes the label of its project.
+func (c ProjectTriggerConverter) getTriggerLabel(projectTrigger octopus.ProjectTrigger, projectLabel string, dependencies *data.ResourceDetailsCollection) string {
+	return dependencies.GetResourceLabel(c.GetResourceType(), projectTrigger.Id,
+		"projecttrigger_"+strings.TrimPrefix(projectLabel, "project_")+"_"+sanitizer.SanitizeName(projectTrigger.Name))
 }
 
-func (c ProjectTriggerConverter) buildTargetTrigger(projectTrigger octopus.ProjectTrigger, stateless bool, projectId string, projectName string, dependencies *data.ResourceDetailsCollection) {
+func (c ProjectTriggerConverter) buildTargetTrigger(projectTrigger octopus.ProjectTrigger, stateless bool, projectId string, projectName string, projectLabel string, dependencies *data.ResourceDetailsCollection) {
 	if projectTrigger.Filter.FilterType != "MachineFilter" {
 		return
 	}
 
-	projectTriggerName := "projecttrigger_" + sanitizer.SanitizeName(projectName) + "_" + sanitizer.SanitizeName(projectTrigger.Name)
+	projectTriggerName := c.getTriggerLabel(projectTrigger, projectLabel, dependencies)
 
 	if c.GenerateImportScripts && !stateless {
 		c.toBashImport(projectTriggerName, projectName, projectTrigger.Name, octopusdeployProjectDeploymentTargetTriggerResourceType, dependencies)
@@ -346,7 +360,7 @@ func (c ProjectTriggerConverter) buildTargetTrigger(projectTrigger octopus.Proje
 	if stateless {
 		// There is no way to look up an existing trigger. If the project exists, the lookup is an empty string. But
 		// if the project exists, nothing will be created that needs to look up the trigger anyway.
-		thisResource.Lookup = "${length(data." + octopusdeployProjectsDataType + ".project_" + sanitizer.SanitizeName(projectName) + ".projects) != 0 " +
+		thisResource.Lookup = "${length(data." + octopusdeployProjectsDataType + "." + projectLabel + ".projects) != 0 " +
 			"? null " +
 			": " + octopusdeployProjectDeploymentTargetTriggerResourceType + "." + projectTriggerName + "[0].id}"
 		thisResource.Dependency = "${" + octopusdeployProjectDeploymentTargetTriggerResourceType + "." + projectTriggerName + "}"
@@ -372,7 +386,7 @@ func (c ProjectTriggerConverter) buildTargetTrigger(projectTrigger octopus.Proje
 
 		if stateless {
 			// when importing a stateless project, the trigger is only created if the project does not exist
-			terraformResource.Count = strutil.StrPointer("${length(data." + octopusdeployProjectsDataType + ".project_" + sanitizer.SanitizeName(projectName) + ".projects) != 0 ? 0 : 1}")
+			terraformResource.Count = strutil.StrPointer("${length(data." + octopusdeployProjectsDataType + "." + projectLabel + ".projects) != 0 ? 0 : 1}")
 		}
 
 		block := gohcl.EncodeAsBlock(terraformResource, "resource")
@@ -389,7 +403,7 @@ func (c ProjectTriggerConverter) buildTargetTrigger(projectTrigger octopus.Proje
 	dependencies.AddResource(thisResource)
 }
 
-func (c ProjectTriggerConverter) buildScheduledTrigger(projectTrigger octopus.ProjectTrigger, projectTriggerName string, stateless bool, projectId string, projectName string, dependencies *data.ResourceDetailsCollection) {
+func (c ProjectTriggerConverter) buildScheduledTrigger(projectTrigger octopus.ProjectTrigger, projectTriggerName string, stateless bool, projectId string, projectName string, projectLabel string, dependencies *data.ResourceDetailsCollection) {
 	thisResource := data.ResourceDetails{}
 	thisResource.Name = projectTrigger.Name
 	thisResource.FileName = "space_population/" + projectTriggerName + ".tf"
@@ -400,7 +414,7 @@ func (c ProjectTriggerConverter) buildScheduledTrigger(projectTrigger octopus.Pr
 	if stateless {
 		// There is no way to look up an existing trigger. If the project exists, the lookup is an empty string. But
 		// if the project exists, nothing will be created that needs to look up the trigger anyway.
-		thisResource.Lookup = "${length(data." + octopusdeployProjectsDataType + ".project_" + sanitizer.SanitizeName(projectName) + ".projects) != 0 " +
+		thisResource.Lookup = "${length(data." + octopusdeployProjectsDataType + "." + projectLabel + ".projects) != 0 " +
 			"? null " +
 			": " + octopusdeployProjectScheduledTrigger + "." + projectTriggerName + "[0].id}"
 		thisResource.Dependency = "${" + octopusdeployProjectScheduledTrigger + "." + projectTriggerName + "}"
@@ -454,7 +468,7 @@ func (c ProjectTriggerConverter) buildScheduledTrigger(projectTrigger octopus.Pr
 
 		if stateless {
 			// when importing a stateless project, the trigger is only created if the project does not exist
-			terraformResource.Count = strutil.StrPointer("${length(data." + octopusdeployProjectsDataType + ".project_" + sanitizer.SanitizeName(projectName) + ".projects) != 0 ? 0 : 1}")
+			terraformResource.Count = strutil.StrPointer("${length(data." + octopusdeployProjectsDataType + "." + projectLabel + ".projects) != 0 ? 0 : 1}")
 		}
 
 		block := gohcl.EncodeAsBlock(terraformResource, "resource")
@@ -471,22 +485,22 @@ func (c ProjectTriggerConverter) buildScheduledTrigger(projectTrigger octopus.Pr
 	dependencies.AddResource(thisResource)
 }
 
-func (c ProjectTriggerConverter) buildFeedTriggerResources(projectTrigger octopus.ProjectTrigger, stateless bool, projectId string, projectName string, dependencies *data.ResourceDetailsCollection) error {
+func (c ProjectTriggerConverter) buildFeedTriggerResources(projectTrigger octopus.ProjectTrigger, stateless bool, projectId string, projectName string, projectLabel string, dependencies *data.ResourceDetailsCollection) error {
 	if projectTrigger.Filter.FilterType != "FeedFilter" {
 		return nil
 	}
 
-	projectTriggerName := "projecttrigger_" + sanitizer.SanitizeName(projectName) + "_" + sanitizer.SanitizeName(projectTrigger.Name)
+	projectTriggerName := c.getTriggerLabel(projectTrigger, projectLabel, dependencies)
 
 	if c.GenerateImportScripts && !stateless {
 		c.toBashImport(projectTriggerName, projectName, projectTrigger.Name, octopusdeployProjectFeedTrigger, dependencies)
 		c.toPowershellImport(projectTriggerName, projectName, projectTrigger.Name, octopusdeployProjectFeedTrigger, dependencies)
 	}
 
-	return c.buildFeedTrigger(projectTrigger, projectTriggerName, stateless, projectId, projectName, dependencies)
+	return c.buildFeedTrigger(projectTrigger, projectTriggerName, stateless, projectId, projectName, projectLabel, dependencies)
 }
 
-func (c ProjectTriggerConverter) buildFeedTrigger(projectTrigger octopus.ProjectTrigger, projectTriggerName string, stateless bool, projectId string, projectName string, dependencies *data.ResourceDetailsCollection) error {
+func (c ProjectTriggerConverter) buildFeedTrigger(projectTrigger octopus.ProjectTrigger, projectTriggerName string, stateless bool, projectId string, projectName string, projectLabel string, dependencies *data.ResourceDetailsCollection) error {
 	project := octopus.Project{}
 	_, err := c.Client.GetSpaceResourceById("Projects", projectId, &project)
 
@@ -504,7 +518,7 @@ func (c ProjectTriggerConverter) buildFeedTrigger(projectTrigger octopus.Project
 	if stateless {
 		// There is no way to look up an existing trigger. If the project exists, the lookup is an empty string. But
 		// if the project exists, nothing will be created that needs to look up the trigger anyway.
-		thisResource.Lookup = "${length(data." + octopusdeployProjectsDataType + ".project_" + sanitizer.SanitizeName(projectName) + ".projects) != 0 " +
+		thisResource.Lookup = "${length(data." + octopusdeployProjectsDataType + "." + projectLabel + ".projects) != 0 " +
 			"? null " +
 			": " + octopusdeployProjectFeedTrigger + "." + projectTriggerName + "[0].id}"
 		thisResource.Dependency = "${" + octopusdeployProjectFeedTrigger + "." + projectTriggerName + "}"
@@ -531,7 +545,7 @@ func (c ProjectTriggerConverter) buildFeedTrigger(projectTrigger octopus.Project
 
 		if stateless {
 			// when importing a stateless project, the trigger is only created if the project does not exist
-			terraformResource.Count = strutil.StrPointer("${length(data." + octopusdeployProjectsDataType + ".project_" + sanitizer.SanitizeName(projectName) + ".projects) != 0 ? 0 : 1}")
+			terraformResource.Count = strutil.StrPointer("${length(data." + octopusdeployProjectsDataType + "." + projectLabel + ".projects) != 0 ? 0 : 1}")
 		}
 
 		block := gohcl.EncodeAsBlock(terraformResource, "resource")
@@ -560,19 +574,19 @@ func (c ProjectTriggerConverter) buildFeedTrigger(projectTrigger octopus.Project
 	return nil
 }
 
-func (c ProjectTriggerConverter) buildArcTriggerResources(projectTrigger octopus.ProjectTrigger, stateless bool, projectId string, projectName string, dependencies *data.ResourceDetailsCollection) error {
+func (c ProjectTriggerConverter) buildArcTriggerResources(projectTrigger octopus.ProjectTrigger, stateless bool, projectId string, projectName string, projectLabel string, dependencies *data.ResourceDetailsCollection) error {
 	if projectTrigger.Filter.FilterType != "ArcFeedFilter" {
 		return nil
 	}
 
-	projectTriggerName := "projecttrigger_" + sanitizer.SanitizeName(projectName) + "_" + sanitizer.SanitizeName(projectTrigger.Name)
+	projectTriggerName := c.getTriggerLabel(projectTrigger, projectLabel, dependencies)
 
 	if c.GenerateImportScripts && !stateless {
 		c.toBashImport(projectTriggerName, projectName, projectTrigger.Name, octopusdeployProjectArcTrigger, dependencies)
 		c.toPowershellImport(projectTriggerName, projectName, projectTrigger.Name, octopusdeployProjectArcTrigger, dependencies)
 	}
 
-	return c.buildArcTrigger(projectTrigger, projectTriggerName, stateless, projectId, projectName, dependencies)
+	return c.buildArcTrigger(projectTrigger, projectTriggerName, stateless, projectId, projectName, projectLabel, dependencies)
 }
 
 // getTriggerPackage resolves the step name and package name from the IDs returned by the API for use with the ARC trigger
@@ -624,7 +638,7 @@ func (c ProjectTriggerConverter) getTriggerPackage(projectTrigger octopus.Projec
 	return releaseCreationPackage, nil
 }
 
-func (c ProjectTriggerConverter) buildArcTrigger(projectTrigger octopus.ProjectTrigger, projectTriggerName string, stateless bool, projectId string, projectName string, dependencies *data.ResourceDetailsCollection) error {
+func (c ProjectTriggerConverter) buildArcTrigger(projectTrigger octopus.ProjectTrigger, projectTriggerName string, stateless bool, projectId string, projectName string, projectLabel string, dependencies *data.ResourceDetailsCollection) error {
 	project := octopus.Project{}
 	_, err := c.Client.GetSpaceResourceById("Projects", projectId, &project)
 
@@ -642,7 +656,7 @@ func (c ProjectTriggerConverter) buildArcTrigger(projectTrigger octopus.ProjectT
 	if stateless {
 		// There is no way to look up an existing trigger. If the project exists, the lookup is an empty string. But
 		// if the project exists, nothing will be created that needs to look up the trigger anyway.
-		thisResource.Lookup = "${length(data." + octopusdeployProjectsDataType + ".project_" + sanitizer.SanitizeName(projectName) + ".projects) != 0 " +
+		thisResource.Lookup = "${length(data." + octopusdeployProjectsDataType + "." + projectLabel + ".projects) != 0 " +
 			"? null " +
 			": " + octopusdeployProjectArcTrigger + "." + projectTriggerName + "[0].id}"
 		thisResource.Dependency = "${" + octopusdeployProjectArcTrigger + "." + projectTriggerName + "}"
@@ -713,21 +727,21 @@ func (c ProjectTriggerConverter) buildTriggerPackages(projectTrigger octopus.Pro
 	})
 }
 
-func (c ProjectTriggerConverter) buildScheduledTriggerResources(projectTrigger octopus.ProjectTrigger, stateless bool, projectId string, projectName string, dependencies *data.ResourceDetailsCollection) {
+func (c ProjectTriggerConverter) buildScheduledTriggerResources(projectTrigger octopus.ProjectTrigger, stateless bool, projectId string, projectName string, projectLabel string, dependencies *data.ResourceDetailsCollection) {
 	supportedTypes := []string{"OnceDailySchedule", "CronExpressionSchedule", "DaysPerMonthSchedule", "ContinuousDailySchedule"}
 
 	if slices.Index(supportedTypes, projectTrigger.Filter.FilterType) == -1 {
 		return
 	}
 
-	projectTriggerName := "projecttrigger_" + sanitizer.SanitizeName(projectName) + "_" + sanitizer.SanitizeName(projectTrigger.Name)
+	projectTriggerName := c.getTriggerLabel(projectTrigger, projectLabel, dependencies)
 
 	if c.GenerateImportScripts && !stateless {
 		c.toBashImport(projectTriggerName, projectName, projectTrigger.Name, octopusdeployProjectScheduledTrigger, dependencies)
 		c.toPowershellImport(projectTriggerName, projectName, projectTrigger.Name, octopusdeployProjectScheduledTrigger, dependencies)
 	}
 
-	c.buildScheduledTrigger(projectTrigger, projectTriggerName, stateless, projectId, projectName, dependencies)
+	c.buildScheduledTrigger(projectTrigger, projectTriggerName, stateless, projectId, projectName, projectLabel, dependencies)
 }
 
 func (c ProjectTriggerConverter) buildTerraformProjectScheduledTriggerContinuousDailySchedule(projectTrigger octopus.ProjectTrigger) (*terraform.TerraformProjectScheduledTriggerContinuousDailySchedule, error) {
@@ -931,22 +945,22 @@ func (c ProjectTriggerConverter) lookupEnvironment(env string, dependencies *dat
 	return environment
 }
 
-func (c ProjectTriggerConverter) buildGitTriggerResources(projectTrigger octopus.ProjectTrigger, stateless bool, projectId string, projectName string, dependencies *data.ResourceDetailsCollection) error {
+func (c ProjectTriggerConverter) buildGitTriggerResources(projectTrigger octopus.ProjectTrigger, stateless bool, projectId string, projectName string, projectLabel string, dependencies *data.ResourceDetailsCollection) error {
 	if projectTrigger.Filter.FilterType != "GitFilter" {
 		return nil
 	}
 
-	projectTriggerName := "projecttrigger_" + sanitizer.SanitizeName(projectName) + "_" + sanitizer.SanitizeName(projectTrigger.Name)
+	projectTriggerName := c.getTriggerLabel(projectTrigger, projectLabel, dependencies)
 
 	if c.GenerateImportScripts && !stateless {
 		c.toBashImport(projectTriggerName, projectName, projectTrigger.Name, octopusdeployProjectGitTrigger, dependencies)
 		c.toPowershellImport(projectTriggerName, projectName, projectTrigger.Name, octopusdeployProjectGitTrigger, dependencies)
 	}
 
-	return c.buildGitTrigger(projectTrigger, projectTriggerName, stateless, projectId, projectName, dependencies)
+	return c.buildGitTrigger(projectTrigger, projectTriggerName, stateless, projectId, projectName, projectLabel, dependencies)
 }
 
-func (c ProjectTriggerConverter) buildGitTrigger(projectTrigger octopus.ProjectTrigger, projectTriggerName string, stateless bool, projectId string, projectName string, dependencies *data.ResourceDetailsCollection) error {
+func (c ProjectTriggerConverter) buildGitTrigger(projectTrigger octopus.ProjectTrigger, projectTriggerName string, stateless bool, projectId string, projectName string, projectLabel string, dependencies *data.ResourceDetailsCollection) error {
 	project := octopus.Project{}
 	_, err := c.Client.GetSpaceResourceById("Projects", projectId, &project)
 
@@ -964,7 +978,7 @@ func (c ProjectTriggerConverter) buildGitTrigger(projectTrigger octopus.ProjectT
 	if stateless {
 		// There is no way to look up an existing trigger. If the project exists, the lookup is an empty string. But
 		// if the project exists, nothing will be created that needs to look up the trigger anyway.
-		thisResource.Lookup = "${length(data." + octopusdeployProjectsDataType + ".project_" + sanitizer.SanitizeName(projectName) + ".projects) != 0 " +
+		thisResource.Lookup = "${length(data." + octopusdeployProjectsDataType + "." + projectLabel + ".projects) != 0 " +
 			"? null " +
 			": " + octopusdeployProjectGitTrigger + "." + projectTriggerName + "[0].id}"
 		thisResource.Dependency = "${" + octopusdeployProjectGitTrigger + "." + projectTriggerName + "}"
@@ -1000,7 +1014,7 @@ func (c ProjectTriggerConverter) buildGitTrigger(projectTrigger octopus.ProjectT
 
 		if stateless {
 			// when importing a stateless project, the trigger is only created if the project does not exist
-			terraformResource.Count = strutil.StrPointer("${length(data." + octopusdeployProjectsDataType + ".project_" + sanitizer.SanitizeName(projectName) + ".projects) != 0 ? 0 : 1}")
+			terraformResource.Count = strutil.StrPointer("${length(data." + octopusdeployProjectsDataType + "." + projectLabel + ".projects) != 0 ? 0 : 1}")
 		}
 
 		block := gohcl.EncodeAsBlock(terraformResource, "resource")
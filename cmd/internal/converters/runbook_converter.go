@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strings"
 
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
@@ -383,15 +384,21 @@ func (c *RunbookConverter) toHcl(runbook *octopus.Runbook, project *octopus.Proj
 
 	thisResource := data.ResourceDetails{}
 
-	resourceNameSuffix := sanitizer.SanitizeName(project.Name) + "_" + sanitizer.SanitizeName(runbook.Name)
-	runbookName := "runbook_" + resourceNameSuffix
+	projectName, err := getProjectLabel(c.Client, dependencies, project.Id, project.Name)
+
+	if err != nil {
+		return err
+	}
+
+	runbookName := dependencies.GetResourceLabel(c.GetResourceType(), runbook.Id,
+		"runbook_"+strings.TrimPrefix(projectName, "project_")+"_"+sanitizer.SanitizeName(runbook.Name))
 
 	if c.GenerateImportScripts && !stateless {
 		c.toBashImport(runbookName, project.Name, runbook.Name, dependencies)
 		c.toPowershellImport(runbookName, project.Name, runbook.Name, dependencies)
 	}
 
-	err := c.exportChildDependencies(recursive, lookups, stateless, standalone, project, runbook, dependencies)
+	err = c.exportChildDependencies(recursive, lookups, stateless, standalone, project, runbook, dependencies)
 
 	if err != nil {
 		return err
@@ -410,7 +417,7 @@ func (c *RunbookConverter) toHcl(runbook *octopus.Runbook, project *octopus.Proj
 		} else {
 			// There is no way to look up an existing runbook. If the project exists, the lookup is an empty string. But
 			// if the project exists, nothing will be created that needs to look up the runbook anyway.
-			thisResource.Lookup = "${length(data." + octopusdeployProjectsDataType + ".project_" + sanitizer.SanitizeName(project.Name) + ".projects) != 0 " +
+			thisResource.Lookup = "${length(data." + octopusdeployProjectsDataType + "." + projectName + ".projects) != 0 " +
 				"? null " +
 				": " + octopusdeployRunbookResourceType + "." + runbookName + "[0].id}"
 		}
@@ -443,7 +450,7 @@ func (c *RunbookConverter) toHcl(runbook *octopus.Runbook, project *octopus.Proj
 		if stateless {
 			if !standalone {
 				// when importing a stateless project, the runbook is only created if the project does not exist
-				terraformResource.Count = strutil.StrPointer("${length(data." + octopusdeployProjectsDataType + ".project_" + sanitizer.SanitizeName(project.Name) + ".projects) != 0 ? 0 : 1}")
+				terraformResource.Count = strutil.StrPointer("${length(data." + octopusdeployProjectsDataType + "." + projectName + ".projects) != 0 ? 0 : 1}")
 			} else {
 				// Typically we would look up existing runbooks and skip this runbook if it existed.
 				// However, there is no runbook data source, so we just have to fail if the runbook already exists.
@@ -135,42 +135,40 @@ func (c *RunbookProcessConverter) toHclById(id string, recursive bool, stateless
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.RunbookProcess{}
-	found, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.RunbookProcess{}
+		found, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.RunbookProcess: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.RunbookProcess: %w", err)
+		}
 
-	// Projects with no deployment process will not have a deployment process resources.
-	// This is expected, so just return.
-	if !found {
-		return nil
-	}
+		// Projects with no deployment process will not have a deployment process resources.
+		// This is expected, so just return.
+		if !found {
+			return nil
+		}
 
-	runbook := octopus.Runbook{}
-	_, err = c.Client.GetSpaceResourceById("Runbooks", resource.RunbookId, &runbook)
+		runbook := octopus.Runbook{}
+		_, err = c.Client.GetSpaceResourceById("Runbooks", resource.RunbookId, &runbook)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Runbook: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Runbook: %w", err)
+		}
 
-	project := octopus.Project{}
-	_, err = c.Client.GetSpaceResourceById("Projects", runbook.ProjectId, &project)
+		project := octopus.Project{}
+		_, err = c.Client.GetSpaceResourceById("Projects", runbook.ProjectId, &project)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Project: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Project: %w", err)
+		}
 
-	zap.L().Info("Deployment Process: " + resource.Id)
+		zap.L().Info("Deployment Process: " + resource.Id)
 
-	c.exportScripts(project, runbook, resource, dependencies)
+		c.exportScripts(project, runbook, resource, dependencies)
 
-	return c.toHcl(&resource, &project, &runbook, recursive, false, stateless, standalone, dependencies)
+		return c.toHcl(&resource, &project, &runbook, recursive, false, stateless, standalone, dependencies)
+	})
 }
 
 func (c *RunbookProcessConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -178,68 +176,66 @@ func (c *RunbookProcessConverter) ToHclLookupById(id string, dependencies *data.
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	resource := octopus.RunbookProcess{}
-	found, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.RunbookProcess{}
+		found, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.RunbookProcess: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.RunbookProcess: %w", err)
+		}
 
-	// Projects with no deployment process will not have a deployment process resources.
-	// This is expected, so just return.
-	if !found {
-		return nil
-	}
+		// Projects with no deployment process will not have a deployment process resources.
+		// This is expected, so just return.
+		if !found {
+			return nil
+		}
 
-	runbook := octopus.Runbook{}
-	_, err = c.Client.GetSpaceResourceById("Runbooks", resource.RunbookId, &runbook)
+		runbook := octopus.Runbook{}
+		_, err = c.Client.GetSpaceResourceById("Runbooks", resource.RunbookId, &runbook)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Runbook: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Runbook: %w", err)
+		}
 
-	project := octopus.Project{}
-	_, err = c.Client.GetSpaceResourceById("Projects", runbook.ProjectId, &project)
+		project := octopus.Project{}
+		_, err = c.Client.GetSpaceResourceById("Projects", runbook.ProjectId, &project)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Project: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Project: %w", err)
+		}
 
-	c.exportScripts(project, runbook, resource, dependencies)
+		c.exportScripts(project, runbook, resource, dependencies)
 
-	return c.toHcl(&resource, &project, &runbook, false, true, false, false, dependencies)
+		return c.toHcl(&resource, &project, &runbook, false, true, false, false, dependencies)
+	})
 }
 
 func (c *RunbookProcessConverter) exportScripts(project octopus.Project, runbook octopus.Runbook, resource octopus.RunbookProcess, dependencies *data.ResourceDetailsCollection) {
 	if c.GenerateImportScripts {
-		c.toBashImport(c.generateProcessName(&project, &runbook), c.generateStepOrderName(&project, &runbook), project.GetName(), runbook.GetName(), dependencies)
-		c.toPowershellImport(c.generateProcessName(&project, &runbook), c.generateStepOrderName(&project, &runbook), project.GetName(), runbook.GetName(), dependencies)
+		c.toBashImport(c.generateProcessName(&project, &runbook, dependencies), c.generateStepOrderName(&project, &runbook, dependencies), project.GetName(), runbook.GetName(), dependencies)
+		c.toPowershellImport(c.generateProcessName(&project, &runbook, dependencies), c.generateStepOrderName(&project, &runbook, dependencies), project.GetName(), runbook.GetName(), dependencies)
 
 		validSteps := c.getValidSteps(&resource)
 
 		for _, step := range validSteps {
 			c.toStepBashImport(
-				c.generateStepName(&project, &runbook, &step),
-				c.generateChildStepOrderName(&project, &runbook, &step),
+				c.generateStepName(&project, &runbook, &step, dependencies),
+				c.generateChildStepOrderName(&project, &runbook, &step, dependencies),
 				project.GetName(),
 				runbook.GetName(),
 				step.GetName(),
 				dependencies)
 			c.toStepPowershellImport(
-				c.generateStepName(&project, &runbook, &step),
-				c.generateChildStepOrderName(&project, &runbook, &step),
+				c.generateStepName(&project, &runbook, &step, dependencies),
+				c.generateChildStepOrderName(&project, &runbook, &step, dependencies),
 				project.GetName(),
 				runbook.GetName(),
 				step.GetName(),
 				dependencies)
 
 			for _, action := range step.Actions[1:] {
-				c.toChildStepBashImport(c.generateChildStepName(&project, &runbook, &action), project.GetName(), runbook.GetName(), step.GetName(), action.GetName(), dependencies)
-				c.toChildStepPowershellImport(c.generateChildStepName(&project, &runbook, &action), project.GetName(), runbook.GetName(), step.GetName(), action.GetName(), dependencies)
+				c.toChildStepBashImport(c.generateChildStepName(&project, &runbook, &action, dependencies), project.GetName(), runbook.GetName(), step.GetName(), action.GetName(), dependencies)
+				c.toChildStepPowershellImport(c.generateChildStepName(&project, &runbook, &action, dependencies), project.GetName(), runbook.GetName(), step.GetName(), action.GetName(), dependencies)
 			}
 		}
 	}
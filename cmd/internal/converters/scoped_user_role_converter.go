@@ -0,0 +1,161 @@
+package converters
+
+import (
+	"fmt"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+	"github.com/hashicorp/hcl2/gohcl"
+	"github.com/hashicorp/hcl2/hclwrite"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+const octopusdeployScopedUserRoleResourceType = "octopusdeploy_scoped_user_role"
+const octopusdeployUserRolesDataType = "octopusdeploy_user_roles"
+
+// ScopedUserRoleConverter exports the roles assigned to a team in the current space, including any
+// environment, project, project group and tenant scoping. User roles are defined at the instance level,
+// so they are always referenced via a data source lookup.
+type ScopedUserRoleConverter struct {
+	Client     client.OctopusClient
+	IncludeIds bool
+}
+
+func (c ScopedUserRoleConverter) ToHclByTeamIdAndName(teamId string, teamName string, teamLookup string, dependencies *data.ResourceDetailsCollection) error {
+	if teamId == "" {
+		return nil
+	}
+
+	space := octopus.Space{}
+	if err := c.Client.GetSpace(&space); err != nil {
+		return err
+	}
+
+	collection := octopus.GeneralCollection[octopus.ScopedUserRole]{}
+	if err := c.Client.GetAllResources("Teams/"+teamId+"/ScopedUserRoles", &collection, []string{"take", "10000"}); err != nil {
+		return fmt.Errorf("error in OctopusClient.GetAllResources loading type octopus.ScopedUserRole: %w", err)
+	}
+
+	// Roles assigned at the system level, or in other spaces, are not part of this space
+	scopedUserRoles := lo.Filter(collection.Items, func(item octopus.ScopedUserRole, index int) bool {
+		return strutil.EmptyIfNil(item.SpaceId) == space.Id
+	})
+
+	// A team can have the same role assigned multiple times with different scopes
+	roleCount := map[string]int{}
+
+	for _, scopedUserRole := range scopedUserRoles {
+		roleName, err := c.exportUserRole(scopedUserRole.UserRoleId, dependencies)
+
+		if err != nil {
+			return err
+		}
+
+		resourceName := "scopeduserrole_" + teamName + "_" + sanitizer.SanitizeName(roleName)
+		if count := roleCount[resourceName]; count != 0 {
+			roleCount[resourceName] = count + 1
+			resourceName += "_" + fmt.Sprint(count)
+		} else {
+			roleCount[resourceName] = 1
+		}
+
+		c.toHcl(scopedUserRole, resourceName, teamLookup, dependencies)
+	}
+
+	return nil
+}
+
+func (c ScopedUserRoleConverter) toHcl(scopedUserRole octopus.ScopedUserRole, resourceName string, teamLookup string, dependencies *data.ResourceDetailsCollection) {
+	zap.L().Info("Scoped User Role: " + scopedUserRole.Id)
+
+	thisResource := data.ResourceDetails{}
+	thisResource.FileName = "space_population/" + resourceName + ".tf"
+	thisResource.Id = scopedUserRole.Id
+	thisResource.Name = resourceName
+	thisResource.ResourceType = c.GetResourceType()
+	thisResource.Lookup = "${" + octopusdeployScopedUserRoleResourceType + "." + resourceName + ".id}"
+
+	thisResource.ToHcl = func() (string, error) {
+		terraformResource := terraform.TerraformScopedUserRole{
+			Type:            octopusdeployScopedUserRoleResourceType,
+			Name:            resourceName,
+			Id:              strutil.InputPointerIfEnabled(c.IncludeIds, &scopedUserRole.Id),
+			SpaceId:         strutil.StrPointer("${trimspace(var.octopus_space_id)}"),
+			TeamId:          teamLookup,
+			UserRoleId:      dependencies.GetResource(c.getUserRoleResourceType(), scopedUserRole.UserRoleId),
+			EnvironmentIds:  dependencies.GetResources("Environments", scopedUserRole.EnvironmentIds...),
+			ProjectIds:      dependencies.GetResources("Projects", scopedUserRole.ProjectIds...),
+			ProjectGroupIds: dependencies.GetResources("ProjectGroups", scopedUserRole.ProjectGroupIds...),
+			TenantIds:       dependencies.GetResources("Tenants", scopedUserRole.TenantIds...),
+		}
+
+		file := hclwrite.NewEmptyFile()
+		file.Body().AppendBlock(gohcl.EncodeAsBlock(terraformResource, "resource"))
+
+		return string(file.Bytes()), nil
+	}
+
+	dependencies.AddResource(thisResource)
+}
+
+// exportUserRole creates a data source lookup for the instance level user role, returning the name of the role.
+func (c ScopedUserRoleConverter) exportUserRole(userRoleId string, dependencies *data.ResourceDetailsCollection) (string, error) {
+	userRole := octopus.Role{}
+	found, err := c.Client.GetGlobalResourceById(c.getUserRoleResourceType(), userRoleId, &userRole)
+
+	if err != nil {
+		return "", fmt.Errorf("error in OctopusClient.GetGlobalResourceById loading type octopus.Role: %w", err)
+	}
+
+	if !found {
+		return "", fmt.Errorf("failed to find user role with ID %s", userRoleId)
+	}
+
+	if dependencies.HasResource(userRoleId, c.getUserRoleResourceType()) {
+		return userRole.Name, nil
+	}
+
+	userRoleName := "userrole_" + sanitizer.SanitizeName(userRole.Name)
+
+	thisResource := data.ResourceDetails{}
+	thisResource.FileName = "space_population/" + userRoleName + ".tf"
+	thisResource.Id = userRole.Id
+	thisResource.Name = userRole.Name
+	thisResource.ResourceType = c.getUserRoleResourceType()
+	thisResource.Lookup = "${data." + octopusdeployUserRolesDataType + "." + userRoleName + ".user_roles[0].id}"
+	thisResource.ToHcl = func() (string, error) {
+		terraformResource := terraform.TerraformUserRoleData{
+			Type:        octopusdeployUserRolesDataType,
+			Name:        userRoleName,
+			Ids:         nil,
+			PartialName: userRole.Name,
+			Skip:        0,
+			Take:        1,
+		}
+
+		file := hclwrite.NewEmptyFile()
+		block := gohcl.EncodeAsBlock(terraformResource, "data")
+		hcl.WriteLifecyclePostCondition(block, "Failed to resolve a user role called \""+userRole.Name+"\". This resource must exist in the instance before this Terraform configuration is applied.", "length(self.user_roles) != 0")
+		file.Body().AppendBlock(block)
+
+		return string(file.Bytes()), nil
+	}
+
+	dependencies.AddResource(thisResource)
+
+	return userRole.Name, nil
+}
+
+func (c ScopedUserRoleConverter) getUserRoleResourceType() string {
+	return "UserRoles"
+}
+
+func (c ScopedUserRoleConverter) GetResourceType() string {
+	return "ScopedUserRoles"
+}
@@ -0,0 +1,55 @@
+package converters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+)
+
+func TestScopedUserRolesExportScopes(t *testing.T) {
+	dependencies := data.ResourceDetailsCollection{}
+	dependencies.AddResource(data.ResourceDetails{
+		Id:           "Environments-1",
+		ResourceType: "Environments",
+		Lookup:       "${octopusdeploy_environment.environment_production.id}",
+	})
+
+	team := octopus.Team{Id: "Teams-1", Name: "Deployers"}
+	converter := ScopedUserRoleConverter{
+		Client: newTeamTestClient(team,
+			octopus.ScopedUserRole{Id: "ScopedUserRoles-1", SpaceId: strutil.StrPointer("Spaces-1"), TeamId: team.Id, UserRoleId: "userroles-1", EnvironmentIds: []string{"Environments-1"}},
+			octopus.ScopedUserRole{Id: "ScopedUserRoles-2", SpaceId: strutil.StrPointer("Spaces-1"), TeamId: team.Id, UserRoleId: "userroles-1"},
+			octopus.ScopedUserRole{Id: "ScopedUserRoles-3", SpaceId: strutil.StrPointer("Spaces-2"), TeamId: team.Id, UserRoleId: "userroles-1"}),
+	}
+
+	if err := converter.ToHclByTeamIdAndName(team.Id, "team_deployers", "${octopusdeploy_team.team_deployers.id}", &dependencies); err != nil {
+		t.Fatal(err)
+	}
+
+	// The role assigned in another space is not exported, and the role assigned twice gets a unique name
+	roles := dependencies.GetAllResource("ScopedUserRoles")
+	if len(roles) != 2 ||
+		roles[0].FileName != "space_population/scopeduserrole_team_deployers_project_deployer.tf" ||
+		roles[1].FileName != "space_population/scopeduserrole_team_deployers_project_deployer_1.tf" {
+		t.Fatalf("unexpected scoped user roles %v", roles)
+	}
+
+	hcl, err := roles[0].ToHcl()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(hcl, "team_id") || !strings.Contains(hcl, "${octopusdeploy_team.team_deployers.id}") ||
+		!strings.Contains(hcl, "${octopusdeploy_environment.environment_production.id}") ||
+		!strings.Contains(hcl, "${data.octopusdeploy_user_roles.userrole_project_deployer.user_roles[0].id}") {
+		t.Fatalf("unexpected HCL %s", hcl)
+	}
+
+	// The user role is looked up once, no matter how many times it is assigned
+	if userRoles := dependencies.GetAllResource("UserRoles"); len(userRoles) != 1 {
+		t.Fatalf("expected 1 user role, found %d", len(userRoles))
+	}
+}
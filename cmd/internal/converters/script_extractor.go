@@ -0,0 +1,135 @@
+package converters
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+)
+
+// customScriptPropertyRe matches the properties holding the pre-deployment, deployment and post-deployment scripts of
+// package steps, capturing the phase and the file extension.
+var customScriptPropertyRe = regexp.MustCompile(`^Octopus\.Action\.CustomScripts\.(\w+)\.(ps1|sh|py|csx|fsx)$`)
+
+// scriptExtensions maps the script syntax used by Octopus to a file extension.
+var scriptExtensions = map[string]string{
+	"PowerShell": "ps1",
+	"Bash":       "sh",
+	"Python":     "py",
+	"CSharp":     "csx",
+	"FSharp":     "fsx",
+}
+
+// getScriptExtension returns the file extension for the script syntax. Unknown syntaxes default to PowerShell, which
+// is the syntax Octopus assumes when none is defined.
+func getScriptExtension(syntax string) string {
+	if extension, ok := scriptExtensions[syntax]; ok {
+		return extension
+	}
+
+	return "ps1"
+}
+
+// getScriptPath returns the path of an extracted script relative to the scripts directory.
+func getScriptPath(owner string, name string, extension string) string {
+	return owner + "/" + sanitizer.SanitizeName(name) + "." + extension
+}
+
+// addScriptFile records the script in the scripts directory, returning a placeholder for the path the script is saved
+// to relative to the scripts directory. The key is the ID of the resource that owns the script. Different resources
+// can sanitize to the same path, in which case the path is made unique.
+func addScriptFile(path string, key string, content string, dependencies *data.ResourceDetailsCollection) string {
+	return dependencies.AddFile(strutil.ScriptsDirectory, path, key, content)
+}
+
+// extractScript writes the script to a file and returns the HCL expression that reads it. The script is read with
+// file(), which does not interpolate the content, so the script is written exactly as it is defined in Octopus.
+func extractScript(owner string, name string, key string, extension string, script string, dependencies *data.ResourceDetailsCollection) string {
+	path := addScriptFile(getScriptPath(owner, name, extension), key, script, dependencies)
+	return "file(\"${path.module}/scripts/" + path + "\")"
+}
+
+// extractScriptTemplate writes a script that has already been escaped to be placed in a HCL string to a file, and
+// returns the HCL expression that reads it. Scripts without interpolations are unescaped and read with file().
+// Scripts that reference other resources, for example when an account ID is replaced with the account resource, are
+// read with templatefile(). Template files use the same escaping as HCL strings, and the interpolated references are
+// passed to the template as variables.
+func extractScriptTemplate(owner string, name string, key string, extension string, template string, dependencies *data.ResourceDetailsCollection) string {
+	content, references := parseScriptTemplate(template)
+
+	if len(references) == 0 {
+		return extractScript(owner, name, key, extension, unescapeScriptTemplate(template), dependencies)
+	}
+
+	path := addScriptFile(getScriptPath(owner, name, extension), key, content, dependencies)
+
+	variables := []string{}
+	for index, reference := range references {
+		variables = append(variables, "reference_"+strconv.Itoa(index)+" = "+reference)
+	}
+
+	return "templatefile(\"${path.module}/scripts/" + path + "\", { " + strings.Join(variables, ", ") + " })"
+}
+
+// parseScriptTemplate replaces the interpolations in the template with template variables named reference_0,
+// reference_1 etc., and returns the new template with the expressions that were interpolated. Escaped "$${" and
+// "%%{" sequences are left unchanged.
+func parseScriptTemplate(template string) (string, []string) {
+	references := []string{}
+
+	content := hcl.ReplaceInterpolations(template, func(interpolation string) string {
+		expression := strings.TrimSpace(interpolation[2 : len(interpolation)-1])
+		referenceIndex := slices.Index(references, expression)
+
+		if referenceIndex == -1 {
+			references = append(references, expression)
+			referenceIndex = len(references) - 1
+		}
+
+		return "${reference_" + strconv.Itoa(referenceIndex) + "}"
+	})
+
+	return content, references
+}
+
+// unescapeScriptTemplate reverses OctopusActionProcessor.EscapeDollars and OctopusActionProcessor.EscapePercents.
+func unescapeScriptTemplate(template string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(template, "$${", "${"), "%%{", "%{")
+}
+
+// extractScriptProperties moves the inline scripts in the properties of a step to files. The properties must already
+// be escaped to be placed in HCL strings. The returned properties exclude the scripts, which are returned as HCL
+// expressions reading the extracted files.
+func extractScriptProperties(owner string, name string, key string, properties map[string]string, dependencies *data.ResourceDetailsCollection) (map[string]string, map[string]string) {
+	remaining := map[string]string{}
+	expressions := map[string]string{}
+
+	for key, value := range properties {
+		if strings.TrimSpace(value) == "" {
+			remaining[key] = value
+			continue
+		}
+
+		if key == "Octopus.Action.Script.ScriptBody" {
+			expressions[key] = extractScriptTemplate(owner, name, key, getScriptExtension(properties["Octopus.Action.Script.Syntax"]), value, dependencies)
+		} else if key == "Octopus.Action.Terraform.Template" {
+			extension := "tf.tmpl"
+			if json.Valid([]byte(value)) {
+				extension = "tf.json.tmpl"
+			}
+			expressions[key] = extractScriptTemplate(owner, name+"_template", key, extension, value, dependencies)
+		} else if matches := customScriptPropertyRe.FindStringSubmatch(key); matches != nil {
+			expressions[key] = extractScriptTemplate(owner, name+"_"+matches[1], key, matches[2], value, dependencies)
+		} else {
+			remaining[key] = value
+		}
+	}
+
+	return remaining, expressions
+}
@@ -0,0 +1,122 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+)
+
+func TestExtractScriptPropertiesFile(t *testing.T) {
+	dependencies := data.ResourceDetailsCollection{}
+
+	remaining, expressions := extractScriptProperties("project_web", "Run Script", "Actions-1", map[string]string{
+		"Octopus.Action.Script.Syntax":     "Bash",
+		"Octopus.Action.Script.ScriptBody": "echo \"$${HOME} #{Octopus.Environment.Name} %%{x}\"",
+		"Octopus.Action.RunOnServer":       "true",
+	}, &dependencies)
+
+	if len(remaining) != 2 || remaining["Octopus.Action.RunOnServer"] != "true" {
+		t.Fatalf("unexpected remaining properties %v", remaining)
+	}
+
+	files := dependencies.ResolveFiles(expressions)
+
+	if files["Octopus.Action.Script.ScriptBody"] != "file(\"${path.module}/scripts/project_web/run_script.sh\")" {
+		t.Fatalf("unexpected expression %q", files["Octopus.Action.Script.ScriptBody"])
+	}
+
+	// Scripts read with file() are not interpolated, so the escaping is removed
+	if files[strutil.ScriptsDirectory+"project_web/run_script.sh"] != "echo \"${HOME} #{Octopus.Environment.Name} %{x}\"" {
+		t.Fatalf("unexpected script %q", files[strutil.ScriptsDirectory+"project_web/run_script.sh"])
+	}
+}
+
+func TestExtractScriptPropertiesTemplate(t *testing.T) {
+	dependencies := data.ResourceDetailsCollection{}
+
+	_, expressions := extractScriptProperties("project_web", "Deploy", "Actions-1", map[string]string{
+		"Octopus.Action.CustomScripts.PreDeploy.ps1": "Write-Host \"$${env:PATH} ${octopusdeploy_azure_service_principal.account.id} ${ octopusdeploy_azure_service_principal.account.id }\"",
+	}, &dependencies)
+
+	files := dependencies.ResolveFiles(expressions)
+
+	if files["Octopus.Action.CustomScripts.PreDeploy.ps1"] != "templatefile(\"${path.module}/scripts/project_web/deploy_predeploy.ps1\", { reference_0 = octopusdeploy_azure_service_principal.account.id })" {
+		t.Fatalf("unexpected expression %q", files["Octopus.Action.CustomScripts.PreDeploy.ps1"])
+	}
+
+	// Template files keep the escaping, and the references become template variables
+	if files[strutil.ScriptsDirectory+"project_web/deploy_predeploy.ps1"] != "Write-Host \"$${env:PATH} ${reference_0} ${reference_0}\"" {
+		t.Fatalf("unexpected script %q", files[strutil.ScriptsDirectory+"project_web/deploy_predeploy.ps1"])
+	}
+}
+
+func TestExtractScriptPropertiesCollision(t *testing.T) {
+	dependencies := data.ResourceDetailsCollection{}
+
+	// Both action names sanitize to deploy_app, and the second action is extracted first
+	_, second := extractScriptProperties("project_web", "deploy-app", "Actions-2", map[string]string{
+		"Octopus.Action.Script.Syntax":     "Bash",
+		"Octopus.Action.Script.ScriptBody": "echo second",
+	}, &dependencies)
+	_, first := extractScriptProperties("project_web", "Deploy App", "Actions-1", map[string]string{
+		"Octopus.Action.Script.Syntax":     "Bash",
+		"Octopus.Action.Script.ScriptBody": "echo first",
+	}, &dependencies)
+
+	files := dependencies.ResolveFiles(map[string]string{
+		"first":  first["Octopus.Action.Script.ScriptBody"],
+		"second": second["Octopus.Action.Script.ScriptBody"],
+	})
+
+	if files["first"] != "file(\"${path.module}/scripts/project_web/deploy_app.sh\")" {
+		t.Fatalf("unexpected expression %q", files["first"])
+	}
+
+	if files["second"] != "file(\"${path.module}/scripts/project_web/deploy_app_2.sh\")" {
+		t.Fatalf("unexpected expression %q", files["second"])
+	}
+
+	if files[strutil.ScriptsDirectory+"project_web/deploy_app.sh"] != "echo first" ||
+		files[strutil.ScriptsDirectory+"project_web/deploy_app_2.sh"] != "echo second" {
+		t.Fatalf("unexpected scripts %v", files)
+	}
+}
+
+func TestGetScriptOwner(t *testing.T) {
+	converter := DeploymentProcessConverterBase{}
+	dependencies := data.ResourceDetailsCollection{}
+
+	project := octopus.Project{NameId: octopus.NameId{Id: "Projects-1", Name: "Web"}}
+	if owner := converter.getScriptOwner(&project, &dependencies); owner != "project_web" {
+		t.Fatalf("unexpected project owner %q", owner)
+	}
+
+	// Runbooks with the same name in projects that are not exported must not share a directory
+	first := octopus.Runbook{NameId: octopus.NameId{Id: "Runbooks-1", Name: "Backup"}, ProjectId: "Projects-1"}
+	second := octopus.Runbook{NameId: octopus.NameId{Id: "Runbooks-2", Name: "Backup"}, ProjectId: "Projects-2"}
+	if converter.getScriptOwner(&first, &dependencies) != "runbook_projects_1_backup" ||
+		converter.getScriptOwner(&second, &dependencies) != "runbook_projects_2_backup" {
+		t.Fatalf("unexpected runbook owners %q and %q", converter.getScriptOwner(&first, &dependencies), converter.getScriptOwner(&second, &dependencies))
+	}
+
+	dependencies.AddResource(data.ResourceDetails{Id: "Projects-1", ResourceType: "Projects", Name: "Web"})
+	if owner := converter.getScriptOwner(&first, &dependencies); owner != "runbook_web_backup" {
+		t.Fatalf("unexpected runbook owner %q", owner)
+	}
+}
+
+func TestGetScriptExtension(t *testing.T) {
+	if getScriptExtension("Python") != "py" || getScriptExtension("FSharp") != "fsx" || getScriptExtension("") != "ps1" {
+		t.Fatalf("unexpected script extensions")
+	}
+}
+
+func TestParseScriptTemplateNestedBraces(t *testing.T) {
+	content, references := parseScriptTemplate(`echo ${lookup({ a = "}" }, "a")} $${HOME}`)
+
+	if content != "echo ${reference_0} $${HOME}" || len(references) != 1 || references[0] != `lookup({ a = "}" }, "a")` {
+		t.Fatalf("unexpected template %q with references %v", content, references)
+	}
+}
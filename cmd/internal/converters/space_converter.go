@@ -1,6 +1,8 @@
 package converters
 
 import (
+	"strings"
+
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
@@ -36,6 +38,7 @@ type SpaceConverter struct {
 	SshTargetConverter                Converter
 	ListeningTargetConverter          Converter
 	PollingTargetConverter            Converter
+	KubernetesAgentTargetConverter    Converter
 	CloudRegionTargetConverter        Converter
 	OfflineDropTargetConverter        Converter
 	AzureCloudServiceTargetConverter  Converter
@@ -50,6 +53,7 @@ type SpaceConverter struct {
 	SshWorkerConverter                Converter
 	MachineProxyConverter             Converter
 	PlatformHubConverter              Converter
+	TeamConverter                     Converter
 	ParentEnvironmentConverter        Converter
 	ErrGroup                          *errgroup.Group
 	ExcludeSpaceCreation              bool
@@ -149,6 +153,9 @@ func (c SpaceConverter) AllToHcl(dependencies *data.ResourceDetailsCollection) e
 	// Convert the polling targets
 	c.PollingTargetConverter.AllToHcl(dependencies)
 
+	// Convert the kubernetes agent targets
+	c.KubernetesAgentTargetConverter.AllToHcl(dependencies)
+
 	// Convert the cloud region targets
 	c.CloudRegionTargetConverter.AllToHcl(dependencies)
 
@@ -170,6 +177,9 @@ func (c SpaceConverter) AllToHcl(dependencies *data.ResourceDetailsCollection) e
 	// Convert the deployment freezes
 	c.DeploymentFreezeConverter.AllToHcl(dependencies)
 
+	// Convert the teams and their role assignments
+	c.TeamConverter.AllToHcl(dependencies)
+
 	// Convert K8s agent workers
 	c.KubernetesAgentWorkerConverter.AllToHcl(dependencies)
 
@@ -254,6 +264,9 @@ func (c SpaceConverter) AllToStatelessHcl(dependencies *data.ResourceDetailsColl
 	// Convert the polling targets
 	c.PollingTargetConverter.AllToStatelessHcl(dependencies)
 
+	// Convert the kubernetes agent targets
+	c.KubernetesAgentTargetConverter.AllToStatelessHcl(dependencies)
+
 	// Convert the cloud region targets
 	c.CloudRegionTargetConverter.AllToStatelessHcl(dependencies)
 
@@ -275,6 +288,9 @@ func (c SpaceConverter) AllToStatelessHcl(dependencies *data.ResourceDetailsColl
 	// Convert the Deployment Freezes
 	c.DeploymentFreezeConverter.AllToStatelessHcl(dependencies)
 
+	// Convert the teams and their role assignments
+	c.TeamConverter.AllToStatelessHcl(dependencies)
+
 	// Convert k8s agent workers
 	c.KubernetesAgentWorkerConverter.AllToStatelessHcl(dependencies)
 
@@ -293,6 +309,51 @@ func (c SpaceConverter) AllToStatelessHcl(dependencies *data.ResourceDetailsColl
 	return c.ErrGroup.Wait()
 }
 
+// writeSpaceManagersTeams writes data source lookups for the teams that manage the space, returning the
+// expressions that reference the team IDs. Only teams defined at the instance level can be referenced, as any
+// teams defined in the space do not exist when the space is created. The "Space Managers" team is created
+// automatically with the space, so it is also ignored.
+func (c SpaceConverter) writeSpaceManagersTeams(file *hclwrite.File, space octopus.Space) ([]string, error) {
+	lookups := []string{}
+
+	for _, teamId := range space.SpaceManagersTeams {
+		if strings.HasPrefix(teamId, "teams-spacemanagers-") {
+			continue
+		}
+
+		team := octopus.Team{}
+		found, err := c.Client.GetGlobalResourceById("Teams", teamId, &team)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !found || strutil.EmptyIfNil(team.SpaceId) != "" {
+			continue
+		}
+
+		teamName := "space_managers_team_" + sanitizer.SanitizeName(team.Name)
+
+		teamData := terraform2.TerraformTeamData{
+			Type:          octopusdeployTeamsDataType,
+			Name:          teamName,
+			Ids:           nil,
+			PartialName:   team.Name,
+			IncludeSystem: true,
+			Skip:          0,
+			Take:          1,
+		}
+
+		block := gohcl.EncodeAsBlock(teamData, "data")
+		hcl.WriteLifecyclePostCondition(block, "Failed to resolve a team called \""+team.Name+"\". This resource must exist in the instance before this Terraform configuration is applied.", "length(self.teams) != 0")
+		file.Body().AppendBlock(block)
+
+		lookups = append(lookups, "data."+octopusdeployTeamsDataType+"."+teamName+".teams[0].id")
+	}
+
+	return lookups, nil
+}
+
 func (c SpaceConverter) getResourceType() string {
 	return "Spaces"
 }
@@ -318,28 +379,39 @@ func (c SpaceConverter) createSpaceTf(dependencies *data.ResourceDetailsCollecti
 
 		file := hclwrite.NewEmptyFile()
 
+		spaceManagersTeamLookups, err := c.writeSpaceManagersTeams(file, space)
+
+		if err != nil {
+			return "", err
+		}
+
 		terraformResource := terraform2.TerraformSpace{
 			Description:        strutil.TrimPointer(space.Description),
 			IsDefault:          space.IsDefault,
 			IsTaskQueueStopped: space.TaskQueueStopped,
 			Name:               spaceResourceName,
-			//SpaceManagersTeamMembers: space.SpaceManagersTeamMembers,
-			//SpaceManagersTeams:       space.SpaceManagersTeams,
-			// TODO: import teams rather than defaulting to admins
-			SpaceManagersTeams: []string{"${var.octopus_space_managers}"},
 			ResourceName:       &spaceName,
 			Type:               "octopusdeploy_space",
 		}
 
-		file.Body().AppendBlock(gohcl.EncodeAsBlock(terraformResource, "resource"))
+		spaceBlock := gohcl.EncodeAsBlock(terraformResource, "resource")
+		// The team lookups are combined with the optional team ID passed in via the variable
+		hcl.WriteUnquotedAttribute(spaceBlock, "space_managers_teams",
+			"compact(concat(["+strings.Join(spaceManagersTeamLookups, ", ")+"], [var.octopus_space_managers]))")
+		file.Body().AppendBlock(spaceBlock)
 
+		// Default to the administrators team if no other space managers could be found
 		defaultSpaceManagers := "teams-administrators"
+		if len(spaceManagersTeamLookups) != 0 {
+			defaultSpaceManagers = ""
+		}
+
 		spaceManagerTeams := terraform2.TerraformVariable{
 			Name:        "octopus_space_managers",
 			Type:        "string",
 			Nullable:    false,
 			Sensitive:   false,
-			Description: "The space manager teams for the new space",
+			Description: "An additional space manager team ID for the new space",
 			Default:     &defaultSpaceManagers,
 		}
 
@@ -3,6 +3,7 @@ package converters
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/boolutil"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
@@ -11,7 +12,6 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/variables"
 	"github.com/google/uuid"
@@ -48,46 +48,48 @@ func (c StepTemplateConverter) ToHclLookupById(id string, dependencies *data.Res
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		template := octopus.StepTemplate{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &template)
 
-	template := octopus.StepTemplate{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &template)
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.StepTemplate: %w", err)
+		}
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.StepTemplate: %w", err)
-	}
+		if c.Excluder.IsResourceExcludedWithRegex(template.Name, c.ExcludeAllStepTemplates, c.ExcludeStepTemplates, c.ExcludeStepTemplatesRegex, c.ExcludeStepTemplatesExcept) {
+			return nil
+		}
 
-	if c.Excluder.IsResourceExcludedWithRegex(template.Name, c.ExcludeAllStepTemplates, c.ExcludeStepTemplates, c.ExcludeStepTemplatesRegex, c.ExcludeStepTemplatesExcept) {
-		return nil
-	}
+		// The first resource maps the step template name to the ID
+		thisResource := data.ResourceDetails{}
 
-	// The first resource maps the step template name to the ID
-	thisResource := data.ResourceDetails{}
+		resourceName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "steptemplate_", template.Id, template.Name)
 
-	resourceName := "steptemplate_" + sanitizer.SanitizeName(template.Name)
+		if err != nil {
+			return err
+		}
 
-	thisResource.FileName = "space_population/" + resourceName + ".tf"
-	thisResource.Id = template.Id
-	thisResource.Name = template.Name
-	thisResource.ResourceType = c.GetResourceType()
-	thisResource.Lookup = "${data." + octopusdeployStepTemplateDataType + "." + resourceName + ".step_template.id}"
-	thisResource.VersionLookup = "${data." + octopusdeployStepTemplateDataType + "." + resourceName + ".step_template.version}"
-	thisResource.VersionCurrent = strconv.Itoa(*template.Version)
-	thisResource.ToHcl = func() (string, error) {
-		terraformResource := c.buildData(resourceName, template)
-		file := hclwrite.NewEmptyFile()
-		block := gohcl.EncodeAsBlock(terraformResource, "data")
-		hcl.WriteLifecyclePostCondition(block, "Failed to resolve an step template called \""+template.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "self.step_template != null")
-		file.Body().AppendBlock(block)
+		thisResource.FileName = "space_population/" + resourceName + ".tf"
+		thisResource.Id = template.Id
+		thisResource.Name = template.Name
+		thisResource.ResourceType = c.GetResourceType()
+		thisResource.Lookup = "${data." + octopusdeployStepTemplateDataType + "." + resourceName + ".step_template.id}"
+		thisResource.VersionLookup = "${data." + octopusdeployStepTemplateDataType + "." + resourceName + ".step_template.version}"
+		thisResource.VersionCurrent = strconv.Itoa(*template.Version)
+		thisResource.ToHcl = func() (string, error) {
+			terraformResource := c.buildData(resourceName, template)
+			file := hclwrite.NewEmptyFile()
+			block := gohcl.EncodeAsBlock(terraformResource, "data")
+			hcl.WriteLifecyclePostCondition(block, "Failed to resolve an step template called \""+template.Name+"\". This resource must exist in the space before this Terraform configuration is applied.", "self.step_template != null")
+			file.Body().AppendBlock(block)
 
-		return string(file.Bytes()), nil
-	}
+			return string(file.Bytes()), nil
+		}
 
-	dependencies.AddResource(thisResource)
+		dependencies.AddResource(thisResource)
 
-	return nil
+		return nil
+	})
 }
 
 func (c StepTemplateConverter) AllToHcl(dependencies *data.ResourceDetailsCollection) {
@@ -115,29 +117,27 @@ func (c StepTemplateConverter) toHclById(id string, stateless bool, dependencies
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.StepTemplate{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	resource := octopus.StepTemplate{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
-
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.StepTemplate: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.StepTemplate: %w", err)
+		}
 
-	zap.L().Info("Step Template: " + resource.Id + " " + resource.Name)
+		zap.L().Info("Step Template: " + resource.Id + " " + resource.Name)
 
-	var communityStepTemplate *octopus.CommunityStepTemplate = nil
-	if resource.CommunityActionTemplateId != nil {
-		communityStepTemplate = &octopus.CommunityStepTemplate{}
-		_, err := c.Client.GetGlobalResourceById("CommunityActionTemplates", strutil.EmptyIfNil(resource.CommunityActionTemplateId), communityStepTemplate)
-		if err != nil {
-			return err
+		var communityStepTemplate *octopus.CommunityStepTemplate = nil
+		if resource.CommunityActionTemplateId != nil {
+			communityStepTemplate = &octopus.CommunityStepTemplate{}
+			_, err := c.Client.GetGlobalResourceById("CommunityActionTemplates", strutil.EmptyIfNil(resource.CommunityActionTemplateId), communityStepTemplate)
+			if err != nil {
+				return err
+			}
 		}
-	}
 
-	return c.toHcl(resource, communityStepTemplate, stateless, dependencies)
+		return c.toHcl(resource, communityStepTemplate, stateless, dependencies)
+	})
 }
 
 func (c StepTemplateConverter) GetResourceType() string {
@@ -197,8 +197,13 @@ func (c StepTemplateConverter) toHcl(template octopus.StepTemplate, communitySte
 		return nil
 	}
 
-	stepTemplateName := "steptemplate_" + sanitizer.SanitizeName(template.Name)
-	communityStepTemplateName := "communitysteptemplate_" + sanitizer.SanitizeName(template.Name)
+	stepTemplateName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "steptemplate_", template.Id, template.Name)
+
+	if err != nil {
+		return err
+	}
+
+	communityStepTemplateName := "communitysteptemplate_" + strings.TrimPrefix(stepTemplateName, "steptemplate_")
 
 	/*if c.GenerateImportScripts {
 		c.toBashImport(stepTemplateName, target.Name, dependencies)
@@ -373,7 +378,7 @@ func (c StepTemplateConverter) convertParameters(parameters []octopus.StepTempla
 		} else {
 			var sensitiveValue *string = nil
 			if !c.InlineVariableValues {
-				sensitiveValue = c.TerraformVariableWriter.WriteTerraformVariablesForSecret(c.GetResourceType(), file, &item, dependencies)
+				sensitiveValue = c.TerraformVariableWriter.WriteTerraformVariablesForSecret(c.GetResourceType(), file, &item, data.SecretScope{}, dependencies)
 			} else {
 				sensitiveValue = strutil.StrPointer("\"" + *c.DummySecretGenerator.GetDummySecret() + "\"")
 			}
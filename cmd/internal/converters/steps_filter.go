@@ -5,11 +5,10 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/samber/lo"
-	"go.uber.org/zap"
 )
 
-// FilterSteps removes any actions tha the terraform provider does not support (yet), and removes any
-// empty steps that result from this filtering process.
+// FilterSteps removes any steps that have been excluded, and any empty steps with no actions.
+// Actions from the step framework (i.e. those with Inputs) are retained and exported with their inputs.
 func FilterSteps(steps []octopus.Step, IgnoreInvalidExcludeExcept bool, Excluder ExcludeByName, ExcludeAllSteps bool, ExcludeSteps args.StringSliceArgs, ExcludeStepsRegex args.StringSliceArgs, ExcludeStepsExcept args.StringSliceArgs) []octopus.Step {
 
 	// If invalid exceptions are ignored, we need to check every entry in the ExcludeStepsExcept collection
@@ -31,20 +30,7 @@ func FilterSteps(steps []octopus.Step, IgnoreInvalidExcludeExcept bool, Excluder
 			return false
 		}
 
-		// Valid actions are those that are not from the new steps framework
-		validActions := lo.Filter(item.Actions, func(item octopus.Action, index int) bool {
-			if len(item.Inputs) != 0 {
-				zap.L().Error("Action " + strutil.EmptyIfNil(item.Name) +
-					" has the \"Items\" property, which indicates that it is from the new step framework. " +
-					"These steps are not supported and are not exported.")
-				return false
-			}
-			return true
-		})
-
-		item.Actions = validActions
-
 		// valid steps have at least one action
-		return len(validActions) != 0
+		return len(item.Actions) != 0
 	})
 }
@@ -212,7 +212,11 @@ func (c *TagSetConverter) toHcl(tagSet octopus.TagSet, stateless bool, dependenc
 		return nil
 	}
 
-	tagSetName := "tagset_" + sanitizer.SanitizeName(tagSet.Name)
+	tagSetName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "tagset_", tagSet.Id, tagSet.Name)
+
+	if err != nil {
+		return err
+	}
 
 	if c.GenerateImportScripts && !stateless {
 		c.toBashImport(tagSetName, tagSet.Name, dependencies)
@@ -273,21 +277,20 @@ func (c *TagSetConverter) toHcl(tagSet octopus.TagSet, stateless bool, dependenc
 		// https://go.dev/doc/faq#closures_and_goroutines
 		tag := tag
 
-		tagsetName := "tagset_" + sanitizer.SanitizeName(tagSet.Name)
 		tagName := "tag_" + sanitizer.SanitizeName(tag.Name)
 
 		tagResource := data.ResourceDetails{}
-		tagResource.FileName = "space_population/" + tagsetName + "_" + tagName + ".tf"
+		tagResource.FileName = "space_population/" + tagSetName + "_" + tagName + ".tf"
 		tagResource.Id = tag.Id
 		tagResource.Name = tag.Name
 		tagResource.ResourceType = "Tags"
-		tagResource.Lookup = c.getLookup(stateless, tagSetName, tagsetName+"_"+tagName)
-		tagResource.Dependency = c.getDependency(stateless, tagsetName+"_"+tagName)
+		tagResource.Lookup = c.getLookup(stateless, tagSetName, tagSetName+"_"+tagName)
+		tagResource.Dependency = c.getDependency(stateless, tagSetName+"_"+tagName)
 
 		tagResource.ToHcl = func() (string, error) {
 			terraformResource := terraform.TerraformTag{
 				Type:         octopusdeployTagResourceType,
-				Name:         tagsetName + "_" + tagName,
+				Name:         tagSetName + "_" + tagName,
 				ResourceName: tag.Name,
 				TagSetId:     c.getTagsetId(stateless, tagSetName, tagName),
 				Color:        tag.Color,
@@ -5,8 +5,14 @@ import (
 	"fmt"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+	"github.com/hashicorp/hcl2/gohcl"
+	"github.com/hashicorp/hcl2/hclwrite"
 	"github.com/samber/lo"
+	"strings"
 )
 
 type TargetConverter struct {
@@ -55,3 +61,275 @@ func (c TargetConverter) HasNoEnvironments(target octopus.TargetResource) (error
 
 	return nil, false
 }
+
+const octopusdeployDeploymentTargetsDataType = "octopusdeploy_deployment_targets"
+
+// buildData returns the data block that looks up a target by name.
+func (c TargetConverter) buildData(resourceName string, targetName string) terraform.TerraformDeploymentTargetsData {
+	return terraform.TerraformDeploymentTargetsData{
+		Type:        octopusdeployDeploymentTargetsDataType,
+		Name:        resourceName,
+		Ids:         nil,
+		PartialName: &targetName,
+		Skip:        0,
+		Take:        1,
+	}
+}
+
+// writeData appends the data block for stateless modules
+func (c TargetConverter) writeData(file *hclwrite.File, targetName string, resourceName string) {
+	terraformResource := c.buildData(resourceName, targetName)
+	block := gohcl.EncodeAsBlock(terraformResource, "data")
+	file.Body().AppendBlock(block)
+}
+
+// toLookupHcl adds a data block that looks up an existing target by name. Other resources reference the data block
+// instead of an exported target.
+func (c TargetConverter) toLookupHcl(id string, name string, dependencies *data.ResourceDetailsCollection) error {
+	resourceName, err := getResourceLabel(c.Client, dependencies, "Machines", "target_", id, name)
+	if err != nil {
+		return err
+	}
+
+	thisResource := data.ResourceDetails{}
+	thisResource.FileName = "space_population/" + resourceName + ".tf"
+	thisResource.Id = id
+	thisResource.Name = name
+	thisResource.ResourceType = "Machines"
+	thisResource.Lookup = "${data." + octopusdeployDeploymentTargetsDataType + "." + resourceName + ".deployment_targets[0].id}"
+	thisResource.ToHcl = func() (string, error) {
+		file := hclwrite.NewEmptyFile()
+		c.writeData(file, name, resourceName)
+
+		return string(file.Bytes()), nil
+	}
+
+	dependencies.AddResource(thisResource)
+	return nil
+}
+
+// getLookup returns the lookup and dependency of a target exported as the Terraform resourceType. Stateless modules
+// look up an existing target with the data block from writeData, and only create the target if it was not found.
+func (c TargetConverter) getLookup(resourceType string, resourceName string, stateless bool) (string, string) {
+	if stateless {
+		lookup := "${length(data." + octopusdeployDeploymentTargetsDataType + "." + resourceName + ".deployment_targets) != 0 " +
+			"? data." + octopusdeployDeploymentTargetsDataType + "." + resourceName + ".deployment_targets[0].id " +
+			": " + resourceType + "." + resourceName + "[0].id}"
+		return lookup, "${" + resourceType + "." + resourceName + "}"
+	}
+
+	return "${" + resourceType + "." + resourceName + ".id}", ""
+}
+
+// getStatelessCount returns the count of a target in a stateless module, which is zero when the target already exists.
+func (c TargetConverter) getStatelessCount(resourceName string) *string {
+	return strutil.StrPointer("${length(data." + octopusdeployDeploymentTargetsDataType + "." + resourceName + ".deployment_targets) != 0 ? 0 : 1}")
+}
+
+// lookupEnvironments resolves the target environments, which can reference regular or parent environments
+func (c TargetConverter) lookupEnvironments(envs []string, dependencies *data.ResourceDetailsCollection) []string {
+	newEnvs := make([]string, len(envs))
+	for i, v := range envs {
+		environment := dependencies.GetResource("Environments", v)
+		if environment == "" {
+			environment = dependencies.GetResource("ParentEnvironments", v)
+		}
+		newEnvs[i] = environment
+	}
+	return lo.Filter(newEnvs, func(item string, index int) bool {
+		return strings.TrimSpace(item) != ""
+	})
+}
+
+func (c TargetConverter) getMachinePolicy(machine string, dependencies *data.ResourceDetailsCollection) *string {
+	machineLookup := dependencies.GetResource("MachinePolicies", machine)
+	if machineLookup == "" {
+		return nil
+	}
+
+	return &machineLookup
+}
+
+// TargetDependencyConverters converts the machine policy and environments referenced by a target.
+type TargetDependencyConverters struct {
+	MachinePolicyConverter     ConverterWithStatelessById
+	EnvironmentConverter       ConverterAndLookupWithStatelessById
+	ParentEnvironmentConverter ConverterAndLookupWithStatelessById
+}
+
+func (c TargetDependencyConverters) exportDependencies(machinePolicyId string, environmentIds []string, dependencies *data.ResourceDetailsCollection) error {
+
+	// The machine policies need to be exported
+	err := c.MachinePolicyConverter.ToHclById(machinePolicyId, dependencies)
+
+	if err != nil {
+		return err
+	}
+
+	// Export the environments
+	for _, e := range environmentIds {
+		err = c.EnvironmentConverter.ToHclById(e, dependencies)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// Export the parent environments
+	if c.ParentEnvironmentConverter != nil {
+		for _, e := range environmentIds {
+			err = c.ParentEnvironmentConverter.ToHclById(e, dependencies)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c TargetDependencyConverters) exportStatelessDependencies(machinePolicyId string, environmentIds []string, dependencies *data.ResourceDetailsCollection) error {
+
+	// The machine policies need to be exported
+	err := c.MachinePolicyConverter.ToHclStatelessById(machinePolicyId, dependencies)
+
+	if err != nil {
+		return err
+	}
+
+	// Export the environments
+	for _, e := range environmentIds {
+		err = c.EnvironmentConverter.ToHclStatelessById(e, dependencies)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// Export the parent environments
+	if c.ParentEnvironmentConverter != nil {
+		for _, e := range environmentIds {
+			err = c.ParentEnvironmentConverter.ToHclStatelessById(e, dependencies)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// toBashImport creates a bash script to import the target as the Terraform resourceType
+func (c TargetConverter) toBashImport(resourceType string, resourceName string, octopusResourceName string, dependencies *data.ResourceDetailsCollection) {
+	dependencies.AddResource(data.ResourceDetails{
+		FileName: "space_population/import_" + resourceName + ".sh",
+		ToHcl: func() (string, error) {
+			return fmt.Sprintf(`#!/bin/bash
+
+# This script is used to import an exiting resource into the Terraform state.
+# It is useful when importing a Terraform module into an Octopus space that
+# already has existing resources.
+
+# Make the script executable with the command:
+# chmod +x ./import_%s.sh
+
+# Alternativly, run the script with bash directly:
+# /bin/bash ./import_%s.sh <options>
+
+# Run "terraform init" to download any required providers and to configure the
+# backend configuration
+
+# Then run the import script. Replace the API key, instance URL, and Space ID 
+# in the example below with the values of the space that the Terraform module 
+# will be imported into.
+
+# ./import_%s.sh API-xxxxxxxxxxxx https://yourinstance.octopus.app Spaces-1234
+
+if [[ $# -ne 3 ]]
+then
+	echo "Usage: ./import_%s.sh <API Key> <Octopus URL> <Space ID>"
+    echo "Example: ./import_%s.sh API-xxxxxxxxxxxx https://yourinstance.octopus.app Spaces-1234"
+	exit 1
+fi
+
+if ! command -v jq &> /dev/null
+then
+    echo "jq is required" >&2
+    exit 1
+fi
+
+if ! command -v curl &> /dev/null
+then
+    echo "curl is required" >&2
+    exit 1
+fi
+
+RESOURCE_NAME="%s"
+RESOURCE_ID=$(curl --silent -G --data-urlencode "partialName=${RESOURCE_NAME}" --data-urlencode "take=10000" --header "X-Octopus-ApiKey: $1" "$2/api/$3/Machines" | jq -r ".Items[] | select(.Name == \"${RESOURCE_NAME}\") | .Id")
+
+if [[ -z "${RESOURCE_ID}" ]]
+then
+	echo "No target found with the name ${RESOURCE_NAME}"
+	exit 1
+fi
+
+echo "Importing target ${RESOURCE_ID}"
+
+terraform import "-var=octopus_server=$2" "-var=octopus_apikey=$1" "-var=octopus_space_id=$3" %s.%s ${RESOURCE_ID}`, resourceName, resourceName, resourceName, resourceName, resourceName, octopusResourceName, resourceType, resourceName), nil
+		},
+	})
+}
+
+// toPowershellImport creates a powershell script to import the target as the Terraform resourceType
+func (c TargetConverter) toPowershellImport(resourceType string, resourceName string, octopusResourceName string, dependencies *data.ResourceDetailsCollection) {
+	dependencies.AddResource(data.ResourceDetails{
+		FileName: "space_population/import_" + resourceName + ".ps1",
+		ToHcl: func() (string, error) {
+			return fmt.Sprintf(`# This script is used to import an exiting resource into the Terraform state.
+# It is useful when importing a Terraform module into an Octopus space that
+# already has existing resources.
+
+# Run "terraform init" to download any required providers and to configure the
+# backend configuration
+
+# Then run the import script. Replace the API key, instance URL, and Space ID 
+# in the example below with the values of the space that the Terraform module 
+# will be imported into.
+
+# ./import_%s.ps1 API-xxxxxxxxxxxx https://yourinstance.octopus.app Spaces-1234
+
+param (
+    [Parameter(Mandatory=$true)]
+    [string]$ApiKey,
+
+    [Parameter(Mandatory=$true)]
+    [string]$Url,
+
+    [Parameter(Mandatory=$true)]
+    [string]$SpaceId
+)
+
+$ResourceName="%s"
+
+$headers = @{
+    "X-Octopus-ApiKey" = $ApiKey
+}
+
+$ResourceId = Invoke-RestMethod -Uri "$Url/api/$SpaceId/Machines?take=10000&partialName=$([System.Web.HttpUtility]::UrlEncode($ResourceName))" -Method Get -Headers $headers |
+	Select-Object -ExpandProperty Items | 
+	Where-Object {$_.Name -eq $ResourceName} | 
+	Select-Object -ExpandProperty Id
+
+if ([System.String]::IsNullOrEmpty($ResourceId)) {
+	Write-Error "No target found with the name $ResourceName"
+	exit 1
+}
+
+echo "Importing target $ResourceId"
+
+terraform import "-var=octopus_server=$Url" "-var=octopus_apikey=$ApiKey" "-var=octopus_space_id=$SpaceId" %s.%s $ResourceId`, resourceName, octopusResourceName, resourceType, resourceName), nil
+		},
+	})
+}
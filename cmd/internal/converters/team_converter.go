@@ -0,0 +1,249 @@
+package converters
+
+import (
+	"fmt"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+	"github.com/hashicorp/hcl2/gohcl"
+	"github.com/hashicorp/hcl2/hclwrite"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const octopusdeployTeamsDataType = "octopusdeploy_teams"
+const octopusdeployTeamResourceType = "octopusdeploy_team"
+
+// TeamConverter exports the teams available to a space. Teams created in the space are exported as resources,
+// while system teams (like "Octopus Administrators") and teams that Octopus creates automatically (like
+// "Space Managers") are referenced with a data source lookup. The role assignments of the teams created by the module
+// are then exported by the ScopedUserRoleConverter.
+type TeamConverter struct {
+	Client                   client.OctopusClient
+	ErrGroup                 *errgroup.Group
+	ScopedUserRoleConverter  ConverterByTeamIdWithName
+	ExcludeTeams             args.StringSliceArgs
+	ExcludeTeamsRegex        args.StringSliceArgs
+	ExcludeTeamsExcept       args.StringSliceArgs
+	ExcludeAllTeams          bool
+	Excluder                 ExcludeByName
+	LimitResourceCount       int
+	IncludeIds               bool
+	IncludeSpaceInPopulation bool
+}
+
+func (c TeamConverter) AllToHcl(dependencies *data.ResourceDetailsCollection) {
+	c.ErrGroup.Go(func() error { return c.allToHcl(false, dependencies) })
+}
+
+func (c TeamConverter) AllToStatelessHcl(dependencies *data.ResourceDetailsCollection) {
+	c.ErrGroup.Go(func() error { return c.allToHcl(true, dependencies) })
+}
+
+func (c TeamConverter) allToHcl(stateless bool, dependencies *data.ResourceDetailsCollection) error {
+	if c.ExcludeAllTeams {
+		return nil
+	}
+
+	collection := octopus.GeneralCollection[octopus.Team]{}
+	if err := c.Client.GetAllResources(c.GetResourceType(), &collection, []string{"includeSystem", "true"}, []string{"take", "10000"}); err != nil {
+		return err
+	}
+
+	for _, resource := range collection.Items {
+		zap.L().Info("Team: " + resource.Id + " " + resource.Name)
+		err := c.toHcl(resource, false, stateless, dependencies)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c TeamConverter) ToHclById(id string, dependencies *data.ResourceDetailsCollection) error {
+	return c.toHclById(id, false, false, dependencies)
+}
+
+func (c TeamConverter) ToHclStatelessById(id string, dependencies *data.ResourceDetailsCollection) error {
+	return c.toHclById(id, false, true, dependencies)
+}
+
+func (c TeamConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
+	return c.toHclById(id, true, false, dependencies)
+}
+
+func (c TeamConverter) toHclById(id string, lookup bool, stateless bool, dependencies *data.ResourceDetailsCollection) error {
+	if id == "" {
+		return nil
+	}
+
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.Team{}
+		found, err := c.Client.GetGlobalResourceById(c.GetResourceType(), id, &resource)
+
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetGlobalResourceById loading type octopus.Team: %w", err)
+		}
+
+		if !found {
+			return nil
+		}
+
+		zap.L().Info("Team: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, lookup, stateless, dependencies)
+	})
+}
+
+// isSystemTeam returns true if the team is not owned by the space, or is a team that Octopus creates
+// automatically. These teams can not be created by Terraform, and must be looked up instead.
+func (c TeamConverter) isSystemTeam(resource octopus.Team) bool {
+	return strutil.EmptyIfNil(resource.SpaceId) == "" || !resource.CanBeDeleted
+}
+
+func (c TeamConverter) toHcl(resource octopus.Team, lookup bool, stateless bool, dependencies *data.ResourceDetailsCollection) error {
+	if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllTeams, c.ExcludeTeams, c.ExcludeTeamsRegex, c.ExcludeTeamsExcept) {
+		return nil
+	}
+
+	if c.LimitResourceCount > 0 && len(dependencies.GetAllResource(c.GetResourceType())) >= c.LimitResourceCount {
+		zap.L().Info(c.GetResourceType() + " hit limit of " + fmt.Sprint(c.LimitResourceCount) + " - skipping " + resource.Id)
+		return nil
+	}
+
+	teamName := "team_" + sanitizer.SanitizeName(resource.Name)
+
+	thisResource := data.ResourceDetails{}
+	thisResource.FileName = "space_population/" + teamName + ".tf"
+	thisResource.Id = resource.Id
+	thisResource.Name = resource.Name
+	thisResource.ResourceType = c.GetResourceType()
+
+	if lookup || c.isSystemTeam(resource) {
+		thisResource.Lookup = "${data." + octopusdeployTeamsDataType + "." + teamName + ".teams[0].id}"
+		thisResource.ToHcl = func() (string, error) {
+			file := hclwrite.NewEmptyFile()
+			c.writeTeamNameVariable(file, teamName, resource.Name)
+			block := gohcl.EncodeAsBlock(c.buildData(teamName, "${var."+teamName+"_name}"), "data")
+			hcl.WriteLifecyclePostCondition(block, "Failed to resolve a team called ${var."+teamName+"_name}. This resource must exist in the space before this Terraform configuration is applied.", "length(self.teams) != 0")
+			file.Body().AppendBlock(block)
+
+			return string(file.Bytes()), nil
+		}
+	} else {
+		if stateless {
+			thisResource.Lookup = "${length(data." + octopusdeployTeamsDataType + "." + teamName + ".teams) != 0 " +
+				"? data." + octopusdeployTeamsDataType + "." + teamName + ".teams[0].id " +
+				": " + octopusdeployTeamResourceType + "." + teamName + "[0].id}"
+			thisResource.Dependency = "${" + octopusdeployTeamResourceType + "." + teamName + "}"
+		} else {
+			thisResource.Lookup = "${" + octopusdeployTeamResourceType + "." + teamName + ".id}"
+		}
+
+		thisResource.ToHcl = func() (string, error) {
+			terraformResource := terraform.TerraformTeam{
+				Type:         octopusdeployTeamResourceType,
+				Name:         teamName,
+				Id:           strutil.InputPointerIfEnabled(c.IncludeIds, &resource.Id),
+				SpaceId:      strutil.StrPointer("${trimspace(var.octopus_space_id)}"),
+				ResourceName: "${var." + teamName + "_name}",
+				Description:  strutil.NilIfEmpty(resource.Description),
+				ExternalSecurityGroup: lo.Map(resource.ExternalSecurityGroups, func(item octopus.TeamExternalSecurityGroup, index int) terraform.TerraformTeamExternalSecurityGroup {
+					return terraform.TerraformTeamExternalSecurityGroup{
+						Id:               item.Id,
+						DisplayName:      item.DisplayName,
+						DisplayIdAndName: item.DisplayIdAndName,
+					}
+				}),
+			}
+
+			if c.IncludeSpaceInPopulation {
+				terraformResource.SpaceId = strutil.StrPointer(dependencies.GetResourceDependency("Spaces", strutil.EmptyIfNil(resource.SpaceId)))
+			}
+
+			file := hclwrite.NewEmptyFile()
+
+			if stateless {
+				file.Body().AppendBlock(gohcl.EncodeAsBlock(c.buildData(teamName, "${var."+teamName+"_name}"), "data"))
+				terraformResource.Count = strutil.StrPointer("${length(data." + octopusdeployTeamsDataType + "." + teamName + ".teams) != 0 ? 0 : 1}")
+			}
+
+			c.writeTeamNameVariable(file, teamName, resource.Name)
+
+			block := gohcl.EncodeAsBlock(terraformResource, "resource")
+
+			/*
+				Team members are users, which are not exported, so any members are managed outside of Terraform.
+				The roles assigned to the team are exported as octopusdeploy_scoped_user_role resources, so
+				the inline user_role blocks are also ignored.
+			*/
+			if stateless {
+				hcl.WriteLifecyclePreventDestroyAttribute(block)
+			} else {
+				hcl.WriteLifecycleAttribute(block, "[users, user_role]")
+			}
+
+			file.Body().AppendBlock(block)
+
+			return string(file.Bytes()), nil
+		}
+	}
+
+	dependencies.AddResource(thisResource)
+
+	// Octopus assigns the roles of system teams itself, so exporting them would duplicate those assignments
+	if lookup || c.isSystemTeam(resource) || c.ScopedUserRoleConverter == nil {
+		return nil
+	}
+
+	// Role assignments are not exported for stateless modules. The team is only created when no team with the same
+	// name exists, and an existing team keeps the roles it already has, so exporting the roles would either duplicate
+	// or replace the assignments made outside the module.
+	if stateless {
+		zap.L().Info("Team: " + resource.Id + " " + resource.Name + " - skipping scoped user roles in a stateless module")
+		recordLintFinding(dependencies, data.LintSeverityLossy, c.GetResourceType(), resource.Id, resource.Name,
+			"The roles assigned to the team are not exported in stateless modules, and must be assigned manually.")
+		return nil
+	}
+
+	return c.ScopedUserRoleConverter.ToHclByTeamIdAndName(resource.Id, teamName, thisResource.Lookup, dependencies)
+}
+
+func (c TeamConverter) buildData(resourceName string, name string) terraform.TerraformTeamData {
+	return terraform.TerraformTeamData{
+		Type:          octopusdeployTeamsDataType,
+		Name:          resourceName,
+		Ids:           nil,
+		PartialName:   name,
+		IncludeSystem: true,
+		Skip:          0,
+		Take:          1,
+	}
+}
+
+func (c TeamConverter) writeTeamNameVariable(file *hclwrite.File, teamName string, teamResourceName string) {
+	teamNameVariableResource := terraform.TerraformVariable{
+		Name:        teamName + "_name",
+		Type:        "string",
+		Nullable:    false,
+		Sensitive:   false,
+		Description: "The name of the team",
+		Default:     &teamResourceName,
+	}
+
+	block := gohcl.EncodeAsBlock(teamNameVariableResource, "variable")
+	hcl.WriteUnquotedAttribute(block, "type", "string")
+	file.Body().AppendBlock(block)
+}
+
+func (c TeamConverter) GetResourceType() string {
+	return "Teams"
+}
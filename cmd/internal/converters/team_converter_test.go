@@ -0,0 +1,199 @@
+package converters
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+)
+
+// teamClient serves the space, and the instance level resources by ID, in addition to the collections.
+type teamClient struct {
+	collectionClient
+	space           octopus.Space
+	globalResources map[string]any
+}
+
+func (c teamClient) GetSpace(resources *octopus.Space) error {
+	*resources = c.space
+	return nil
+}
+
+func (c teamClient) GetGlobalResourceById(resourceType string, id string, resources any) (bool, error) {
+	resource, ok := c.globalResources[id]
+
+	if !ok {
+		return false, nil
+	}
+
+	content, err := json.Marshal(resource)
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, json.Unmarshal(content, resources)
+}
+
+func newTeamTestClient(team octopus.Team, scopedUserRoles ...any) teamClient {
+	return teamClient{
+		collectionClient: collectionClient{collections: map[string][]any{
+			"Teams/" + team.Id + "/ScopedUserRoles": scopedUserRoles,
+		}},
+		space: octopus.Space{Id: "Spaces-1", Name: "Default"},
+		globalResources: map[string]any{
+			team.Id:       team,
+			"userroles-1": octopus.Role{Id: "userroles-1", Name: "Project Deployer"},
+		},
+	}
+}
+
+func newTeamTestConverter(octopusClient teamClient) TeamConverter {
+	return TeamConverter{
+		Client: octopusClient,
+		ScopedUserRoleConverter: ScopedUserRoleConverter{
+			Client: octopusClient,
+		},
+		Excluder: DefaultExcluder{},
+	}
+}
+
+func TestTeamExportsResourceAndRoles(t *testing.T) {
+	dependencies := data.ResourceDetailsCollection{}
+	team := octopus.Team{
+		Id:           "Teams-1",
+		Name:         "Deployers",
+		SpaceId:      strutil.StrPointer("Spaces-1"),
+		CanBeDeleted: true,
+	}
+
+	converter := newTeamTestConverter(newTeamTestClient(team,
+		octopus.ScopedUserRole{Id: "ScopedUserRoles-1", SpaceId: strutil.StrPointer("Spaces-1"), TeamId: team.Id, UserRoleId: "userroles-1"}))
+
+	if err := converter.ToHclById(team.Id, &dependencies); err != nil {
+		t.Fatal(err)
+	}
+
+	teams := dependencies.GetAllResource("Teams")
+	if len(teams) != 1 {
+		t.Fatalf("expected 1 team, found %d", len(teams))
+	}
+
+	if teams[0].Lookup != "${octopusdeploy_team.team_deployers.id}" {
+		t.Fatalf("unexpected lookup %s", teams[0].Lookup)
+	}
+
+	hcl, err := teams[0].ToHcl()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(hcl, "resource \"octopusdeploy_team\" \"team_deployers\"") {
+		t.Fatalf("unexpected HCL %s", hcl)
+	}
+
+	if roles := dependencies.GetAllResource("ScopedUserRoles"); len(roles) != 1 {
+		t.Fatalf("expected 1 scoped user role, found %d", len(roles))
+	}
+}
+
+func TestSystemTeamIsLookedUp(t *testing.T) {
+	dependencies := data.ResourceDetailsCollection{}
+	team := octopus.Team{
+		Id:           "teams-administrators",
+		Name:         "Octopus Administrators",
+		CanBeDeleted: false,
+	}
+
+	converter := newTeamTestConverter(newTeamTestClient(team))
+
+	if err := converter.ToHclById(team.Id, &dependencies); err != nil {
+		t.Fatal(err)
+	}
+
+	teams := dependencies.GetAllResource("Teams")
+	if len(teams) != 1 {
+		t.Fatalf("expected 1 team, found %d", len(teams))
+	}
+
+	if teams[0].Lookup != "${data.octopusdeploy_teams.team_octopus_administrators.teams[0].id}" {
+		t.Fatalf("unexpected lookup %s", teams[0].Lookup)
+	}
+}
+
+func TestSpaceManagersTeamRolesAreNotExported(t *testing.T) {
+	dependencies := data.ResourceDetailsCollection{}
+	team := octopus.Team{
+		Id:           "teams-spacemanagers-Spaces-1",
+		Name:         "Space Managers",
+		SpaceId:      strutil.StrPointer("Spaces-1"),
+		CanBeDeleted: false,
+	}
+
+	converter := newTeamTestConverter(newTeamTestClient(team,
+		octopus.ScopedUserRole{Id: "ScopedUserRoles-1", SpaceId: strutil.StrPointer("Spaces-1"), TeamId: team.Id, UserRoleId: "userroles-1"}))
+
+	if err := converter.ToHclById(team.Id, &dependencies); err != nil {
+		t.Fatal(err)
+	}
+
+	teams := dependencies.GetAllResource("Teams")
+	if len(teams) != 1 || teams[0].Lookup != "${data.octopusdeploy_teams.team_space_managers.teams[0].id}" {
+		t.Fatalf("expected the team to be looked up, found %v", teams)
+	}
+
+	if roles := dependencies.GetAllResource("ScopedUserRoles"); len(roles) != 0 {
+		t.Fatalf("expected no scoped user roles, found %d", len(roles))
+	}
+}
+
+func TestExcludedTeamIsNotExported(t *testing.T) {
+	dependencies := data.ResourceDetailsCollection{}
+	team := octopus.Team{
+		Id:           "Teams-1",
+		Name:         "Deployers",
+		SpaceId:      strutil.StrPointer("Spaces-1"),
+		CanBeDeleted: true,
+	}
+
+	converter := newTeamTestConverter(newTeamTestClient(team))
+	converter.ExcludeTeams = []string{"Deployers"}
+
+	if err := converter.ToHclById(team.Id, &dependencies); err != nil {
+		t.Fatal(err)
+	}
+
+	if teams := dependencies.GetAllResource("Teams"); len(teams) != 0 {
+		t.Fatalf("expected no teams, found %d", len(teams))
+	}
+}
+
+func TestStatelessTeamRecordsSkippedRoles(t *testing.T) {
+	dependencies := data.ResourceDetailsCollection{}
+	team := octopus.Team{
+		Id:           "Teams-1",
+		Name:         "Deployers",
+		SpaceId:      strutil.StrPointer("Spaces-1"),
+		CanBeDeleted: true,
+	}
+
+	converter := newTeamTestConverter(newTeamTestClient(team,
+		octopus.ScopedUserRole{Id: "ScopedUserRoles-1", SpaceId: strutil.StrPointer("Spaces-1"), TeamId: team.Id, UserRoleId: "userroles-1"}))
+
+	if err := converter.ToHclStatelessById(team.Id, &dependencies); err != nil {
+		t.Fatal(err)
+	}
+
+	if roles := dependencies.GetAllResource("ScopedUserRoles"); len(roles) != 0 {
+		t.Fatalf("expected no scoped user roles, found %d", len(roles))
+	}
+
+	if len(dependencies.LintFindings) != 1 ||
+		dependencies.LintFindings[0].Severity != data.LintSeverityLossy ||
+		dependencies.LintFindings[0].ResourceId != team.Id {
+		t.Fatalf("unexpected lint findings %v", dependencies.LintFindings)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
@@ -24,6 +25,7 @@ import (
 // So this processor can either create common variables when the entire space is serialized, or projects can
 // export tenant common variables as part of the project.
 type TenantCommonVariableProcessor struct {
+	Client                       client.OctopusClient
 	Excluder                     ExcludeByName
 	ExcludeAllProjects           bool
 	ExcludeAllTenantVariables    bool
@@ -58,15 +60,24 @@ func (c TenantCommonVariableProcessor) ConvertTenantCommonVariable(stateless boo
 
 	var count *string = nil
 	if stateless {
-		count = strutil.StrPointer("${length(data." + octopusdeployTenantsDataType + ".tenant_" +
-			sanitizer.SanitizeName(tenantVariable.TenantName) + ".tenants) != 0 ? 0 : 1}")
+		tenantName, err := getResourceLabel(c.Client, dependencies, "Tenants", "tenant_", tenantVariable.TenantId, tenantVariable.TenantName)
+
+		if err != nil {
+			return err
+		}
+
+		count = strutil.StrPointer("${length(data." + octopusdeployTenantsDataType + "." + tenantName + ".tenants) != 0 ? 0 : 1}")
 	}
 
-	variableName := "tenantcommonvariable" + fmt.Sprint(commonVariableIndex) + "_" + sanitizer.SanitizeName(tenantVariable.TenantName)
+	importId := tenantVariable.TenantId + ":" + libraryVariableSet.Id + ":" + tenantVariableId
+	// The label is registered against the import ID, which identifies the tenant and the variable
+	variableName := dependencies.GetResourceLabel(c.GetResourceType(), importId,
+		"tenantcommonvariable"+fmt.Sprint(commonVariableIndex)+"_"+sanitizer.SanitizeName(tenantVariable.TenantName))
 
 	thisResource := data.ResourceDetails{}
 	thisResource.FileName = "space_population/" + variableName + ".tf"
 	thisResource.Id = tenantVariableId
+	thisResource.ImportId = importId
 	thisResource.ResourceType = c.GetResourceType()
 	thisResource.Lookup = "${octopusdeploy_tenant_common_variable." + variableName + ".id}"
 
@@ -86,7 +97,7 @@ func (c TenantCommonVariableProcessor) ConvertTenantCommonVariable(stateless boo
 		if stringValue, ok := tenantVariableValue.(string); ok {
 			fixedValue = strutil.EscapeDollarCurlyPointer(&stringValue)
 		} else {
-			fixedValue = c.TerraformVariableWriter.WriteTerraformVariablesForSecret(c.GetResourceType(), file, &tenantVariable, dependencies)
+			fixedValue = c.TerraformVariableWriter.WriteTerraformVariablesForSecret(c.GetResourceType(), file, &tenantVariable, data.SecretScope{TenantIds: []string{tenantVariable.TenantId}}, dependencies)
 		}
 
 		terraformResource := terraform.TerraformTenantCommonVariable{
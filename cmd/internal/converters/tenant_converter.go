@@ -8,7 +8,6 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
@@ -103,6 +102,7 @@ func (c *TenantConverter) toHclByProjectId(projectId string, stateless bool, dep
 
 	for _, resource := range collection.Items {
 		zap.L().Info("Tenant: " + resource.Id + " " + resource.Name)
+		recordInclusion(dependencies, projectId, "", "tenant \""+resource.Name+"\" linked to the project", resource.Id)
 		err = c.toHcl(resource, true, false, stateless, dependencies)
 		if err != nil {
 			return nil
@@ -144,6 +144,7 @@ func (c *TenantConverter) ToHclLookupByProjectId(projectId string, dependencies
 	}
 
 	for _, tenant := range collection.Items {
+		recordInclusion(dependencies, projectId, "", "tenant \""+tenant.Name+"\" linked to the project", tenant.Id)
 		err = c.toHcl(tenant, false, true, false, dependencies)
 		if err != nil {
 			return nil
@@ -302,6 +303,10 @@ func (c *TenantConverter) toHcl(tenant octopus.Tenant, recursive bool, lookup bo
 		return nil
 	}
 
+	for projectId, environments := range tenant.ProjectEnvironments {
+		recordInclusion(dependencies, tenant.Id, tenant.Name, "ProjectEnvironments["+projectId+"]", environments...)
+	}
+
 	if recursive {
 		// Export the tenant variables
 		err := c.TenantVariableConverter.ToHclByTenantId(tenant.Id, stateless, dependencies)
@@ -349,7 +354,11 @@ func (c *TenantConverter) toHcl(tenant octopus.Tenant, recursive bool, lookup bo
 		return err
 	}
 
-	tenantName := "tenant_" + sanitizer.SanitizeName(tenant.Name)
+	tenantName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "tenant_", tenant.Id, tenant.Name)
+
+	if err != nil {
+		return err
+	}
 
 	if c.GenerateImportScripts && !stateless && !lookup {
 		c.toBashImport(tenantName, tenant.Name, dependencies)
@@ -464,7 +473,9 @@ func (c *TenantConverter) createTenantProjects(tenant octopus.Tenant, dependenci
 			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.Project: %w", err)
 		}
 
-		c.TenantProjectConverter.LinkTenantToProject(tenant, project, environmentId, dependencies)
+		if err := c.TenantProjectConverter.LinkTenantToProject(tenant, project, environmentId, dependencies); err != nil {
+			return err
+		}
 	}
 
 	return nil
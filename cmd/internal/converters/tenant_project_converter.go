@@ -7,13 +7,13 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	"strings"
 )
 
 type TenantProjectConverter struct {
@@ -69,31 +69,46 @@ func (c TenantProjectConverter) allToHcl(stateless bool, dependencies *data.Reso
 
 			zap.L().Info("Tenant: " + resource.Id + " Project: " + projectId)
 
-			c.LinkTenantToProject(resource, project, environmentId, dependencies)
+			if err := c.LinkTenantToProject(resource, project, environmentId, dependencies); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
-func (c TenantProjectConverter) LinkTenantToProject(tenant octopus.Tenant, project octopus.Project, environmentIds []string, dependencies *data.ResourceDetailsCollection) {
+func (c TenantProjectConverter) LinkTenantToProject(tenant octopus.Tenant, project octopus.Project, environmentIds []string, dependencies *data.ResourceDetailsCollection) error {
 	// Ignore excluded tenants
 	if c.Excluder.IsResourceExcludedWithRegex(tenant.Name, c.ExcludeAllTenants, c.ExcludeTenants, c.ExcludeTenantsRegex, c.ExcludeTenantsExcept) {
-		return
+		return nil
 	}
 
 	// Ignore tenants with excluded tags
 	if c.ExcludeTenantsWithTags != nil && tenant.TenantTags != nil && lo.SomeBy(tenant.TenantTags, func(item string) bool {
 		return lo.IndexOf(c.ExcludeTenantsWithTags, item) != -1
 	}) {
-		return
+		return nil
+	}
+
+	tenantLabel, err := getResourceLabel(c.Client, dependencies, "Tenants", "tenant_", tenant.Id, tenant.Name)
+
+	if err != nil {
+		return err
 	}
 
-	resourceName := "tenant_project_" + sanitizer.SanitizeName(tenant.Name) + "_" + sanitizer.SanitizeName(project.Name)
+	projectLabel, err := getProjectLabel(c.Client, dependencies, project.Id, project.Name)
+
+	if err != nil {
+		return err
+	}
+
+	resourceName := "tenant_project_" + strings.TrimPrefix(tenantLabel, "tenant_") + "_" + strings.TrimPrefix(projectLabel, "project_")
 
 	tenantProject := data.ResourceDetails{}
 	tenantProject.FileName = "space_population/" + resourceName + ".tf"
 	tenantProject.Id = tenant.Id + "_" + project.Id
+	tenantProject.ImportId = tenant.Id + ":" + project.Id
 	tenantProject.Name = tenant.Name + " " + project.Name
 	tenantProject.ResourceType = "TenantProject"
 	tenantProject.Lookup = "${" + octopusdeployTenantProjectResourceType + "." + resourceName + ".id}"
@@ -117,6 +132,8 @@ func (c TenantProjectConverter) LinkTenantToProject(tenant octopus.Tenant, proje
 	}
 
 	dependencies.AddResource(tenantProject)
+
+	return nil
 }
 
 // lookupEnvironments resolves the tenant project environments, which can reference regular or parent environments
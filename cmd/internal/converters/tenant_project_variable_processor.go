@@ -3,6 +3,7 @@ package converters
 import (
 	"fmt"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/dummy"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
@@ -10,6 +11,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/naming"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/secrets"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
@@ -18,6 +20,7 @@ import (
 )
 
 type TenantProjectVariableConverter struct {
+	Client                       client.OctopusClient
 	Excluder                     ExcludeByName
 	ExcludeAllProjects           bool
 	ExcludeAllTenantVariables    bool
@@ -26,14 +29,24 @@ type TenantProjectVariableConverter struct {
 	ExcludeTenantVariablesRegex  args.StringSliceArgs
 	DummySecretVariableValues    bool
 	DummySecretGenerator         dummy.DummySecretGenerator
+	SecretProvider               secrets.SecretProvider
 }
 
 func (c TenantProjectVariableConverter) ConvertTenantProjectVariable(stateless bool, tenantVariable octopus.TenantVariable, projectVariable octopus.ProjectVariable, environmentId string, value any, projectVariableIndex int, templateId string, dependencies *data.ResourceDetailsCollection) error {
-	variableName := "tenantprojectvariable_" + fmt.Sprint(projectVariableIndex) + "_" + sanitizer.SanitizeName(tenantVariable.TenantName)
+	importId := tenantVariable.TenantId + ":" + projectVariable.ProjectId + ":" + environmentId + ":" + templateId
+	// The template ID is shared by the tenants and environments, so the label is registered against the import ID
+	variableName := dependencies.GetResourceLabel(c.GetResourceType(), importId,
+		"tenantprojectvariable_"+fmt.Sprint(projectVariableIndex)+"_"+sanitizer.SanitizeName(tenantVariable.TenantName))
+	tenantName, err := getResourceLabel(c.Client, dependencies, "Tenants", "tenant_", tenantVariable.TenantId, tenantVariable.TenantName)
+
+	if err != nil {
+		return err
+	}
 
 	thisResource := data.ResourceDetails{}
 	thisResource.FileName = "space_population/" + variableName + ".tf"
 	thisResource.Id = templateId
+	thisResource.ImportId = importId
 	thisResource.ResourceType = c.GetResourceType()
 	thisResource.Lookup = "${" + octopusdeployTenantProjectVariableResourceType + "." + variableName + ".id}"
 
@@ -41,12 +54,10 @@ func (c TenantProjectVariableConverter) ConvertTenantProjectVariable(stateless b
 	// to test if any of the tenant variables should be created.
 	var count *string = nil
 	if stateless {
-		count = strutil.StrPointer("${length(data." + octopusdeployTenantsDataType + ".tenant_" +
-			sanitizer.SanitizeName(tenantVariable.TenantName) + ".tenants) != 0 ? 0 : 1}")
+		count = strutil.StrPointer("${length(data." + octopusdeployTenantsDataType + "." + tenantName + ".tenants) != 0 ? 0 : 1}")
 	}
 
 	if stateless {
-		tenantName := "tenant_" + sanitizer.SanitizeName(tenantVariable.TenantName)
 		thisResource.Lookup = "${length(data." + octopusdeployTenantsDataType + "." + tenantName + ".tenants) != 0 " +
 			"? '' " +
 			": " + octopusdeployTenantProjectVariableResourceType + "." + variableName + "[0].id}"
@@ -70,15 +81,28 @@ func (c TenantProjectVariableConverter) ConvertTenantProjectVariable(stateless b
 		// Define a secret value with an optional dummy default
 		if _, ok := value.(map[string]any); ok {
 			tenantProjectVariableValue.Name = naming.TenantVariableSecretName(tenantVariable)
+			tenantProjectVariableValue.Sensitive = true
 
-			if c.DummySecretVariableValues {
-				tenantProjectVariableValue.Default = c.DummySecretGenerator.GetDummySecret()
-			}
-			dependencies.AddDummy(data.DummyVariableReference{
+			secretReference := data.SecretVariableReference{
 				VariableName: tenantProjectVariableValue.Name,
-				ResourceName: tenantVariable.TenantName,
 				ResourceType: c.GetResourceType(),
-			})
+				ResourceId:   templateId,
+				ResourceName: tenantVariable.TenantName,
+				Scope: data.SecretScope{
+					ProjectId:      projectVariable.ProjectId,
+					EnvironmentIds: lo.Compact([]string{environmentId}),
+					TenantIds:      []string{tenantVariable.TenantId},
+				},
+			}
+
+			if !secrets.AddSecretVariable(c.SecretProvider, secretReference, dependencies) && c.DummySecretVariableValues {
+				tenantProjectVariableValue.Default = c.DummySecretGenerator.GetDummySecret()
+				dependencies.AddDummy(data.DummyVariableReference{
+					VariableName: tenantProjectVariableValue.Name,
+					ResourceName: tenantVariable.TenantName,
+					ResourceType: c.GetResourceType(),
+				})
+			}
 		}
 
 		// Define the default value
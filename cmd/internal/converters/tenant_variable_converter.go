@@ -3,6 +3,9 @@ package converters
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
@@ -134,10 +137,13 @@ func (c TenantVariableConverter) toHcl(tenant octopus.TenantVariable, _ bool, st
 }
 
 func (c TenantVariableConverter) convertCommonVariables(tenant octopus.TenantVariable, stateless bool, dependencies *data.ResourceDetailsCollection) error {
-	for _, l := range tenant.LibraryVariables {
+	// Map keys are sorted so the variables are numbered the same way by every export
+	for _, libraryVariableId := range slices.Sorted(maps.Keys(tenant.LibraryVariables)) {
+		l := tenant.LibraryVariables[libraryVariableId]
 		commonVariableIndex := 0
 
-		for id, value := range l.Variables {
+		for _, id := range slices.Sorted(maps.Keys(l.Variables)) {
+			value := l.Variables[id]
 
 			libraryVariableSet := octopus.LibraryVariableSet{}
 			_, err := c.Client.GetSpaceResourceById("LibraryVariableSets", l.LibraryVariableSetId, &libraryVariableSet)
@@ -160,7 +166,10 @@ func (c TenantVariableConverter) convertCommonVariables(tenant octopus.TenantVar
 func (c TenantVariableConverter) convertProjectVariables(tenant octopus.TenantVariable, stateless bool, dependencies *data.ResourceDetailsCollection) error {
 	// Don't attempt to link variables from excluded projects
 	var filterErr error = nil
-	filteredProjectVariables := lo.Filter(lo.Values[string, octopus.ProjectVariable](tenant.ProjectVariables), func(item octopus.ProjectVariable, index int) bool {
+	projectVariables := lo.Map(slices.Sorted(maps.Keys(tenant.ProjectVariables)), func(item string, index int) octopus.ProjectVariable {
+		return tenant.ProjectVariables[item]
+	})
+	filteredProjectVariables := lo.Filter(projectVariables, func(item octopus.ProjectVariable, index int) bool {
 		varExcluded, varExcludedErr := c.excludeProject(item.ProjectId)
 		if varExcludedErr != nil {
 			filterErr = errors.Join(filterErr, varExcludedErr)
@@ -178,9 +187,11 @@ func (c TenantVariableConverter) convertProjectVariables(tenant octopus.TenantVa
 
 		projectVariableIndex := 0
 
-		for environmentId, variable := range projectVariable.Variables {
-			for templateId, value := range variable {
-				value := value
+		// Map keys are sorted so the variables are numbered the same way by every export
+		for _, environmentId := range slices.Sorted(maps.Keys(projectVariable.Variables)) {
+			variable := projectVariable.Variables[environmentId]
+			for _, templateId := range slices.Sorted(maps.Keys(variable)) {
+				value := variable[templateId]
 
 				projectVariableIndex++
 				if err := c.TenantProjectVariableConverter.ConvertTenantProjectVariable(
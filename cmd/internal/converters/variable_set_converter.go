@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
 	"strings"
 
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
@@ -47,6 +48,7 @@ type VariableSetConverter struct {
 	ListeningTargetConverter          ConverterAndLookupWithStatelessById
 	OfflineDropTargetConverter        ConverterAndLookupWithStatelessById
 	PollingTargetConverter            ConverterAndLookupWithStatelessById
+	KubernetesAgentTargetConverter    ConverterAndLookupWithStatelessById
 	SshTargetConverter                ConverterAndLookupWithStatelessById
 	AccountConverter                  ConverterAndLookupWithStatelessById
 	FeedConverter                     ConverterAndLookupWithStatelessById
@@ -1226,7 +1228,21 @@ func (c *VariableSetConverter) processImportScript(resourceName string, parentId
 	return nil
 }
 
+// getLabelPrefix returns the start of the labels of the variables in the set. Variables owned by a project start with
+// the label of the project, so projects whose names are sanitized to the same label still have unique variable labels.
+func (c *VariableSetConverter) getLabelPrefix(resource octopus.VariableSet, parentName string, dependencies *data.ResourceDetailsCollection) string {
+	ownerId := strutil.EmptyIfNil(resource.OwnerId)
+
+	if strings.HasPrefix(ownerId, "Projects-") {
+		projectLabel := dependencies.GetResourceLabel("Projects", ownerId, "project_"+sanitizer.SanitizeName(parentName))
+		return strings.TrimPrefix(projectLabel, "project_")
+	}
+
+	return sanitizer.SanitizeName(parentName)
+}
+
 func (c *VariableSetConverter) toHcl(resource octopus.VariableSet, recursive bool, lookup bool, stateless bool, ignoreSecrets bool, parentName string, parentLookup string, parentCount *string, dependencies *data.ResourceDetailsCollection) error {
+	labelPrefix := c.getLabelPrefix(resource, parentName, dependencies)
 	nameCount := map[string]int{}
 	for _, v := range resource.Variables {
 		// Don't import duplicates
@@ -1255,12 +1271,14 @@ func (c *VariableSetConverter) toHcl(resource octopus.VariableSet, recursive boo
 		file := hclwrite.NewEmptyFile()
 		thisResource := data.ResourceDetails{}
 
-		resourceName := sanitizer.SanitizeName(parentName) + "_" + sanitizer.SanitizeName(v.Name) + "_" + fmt.Sprint(nameCount[v.Name])
+		resourceName := labelPrefix + "_" + sanitizer.SanitizeName(v.Name) + "_" + fmt.Sprint(nameCount[v.Name])
 
 		if err := c.processImportScript(resourceName, strutil.EmptyIfNil(resource.OwnerId), v, dependencies); err != nil {
 			return err
 		}
 
+		c.recordInclusions(resource, v, dependencies)
+
 		// Export linked accounts
 		err := c.exportAccounts(recursive, lookup, stateless, v.Value, dependencies)
 		if err != nil {
@@ -1339,6 +1357,12 @@ func (c *VariableSetConverter) toHcl(resource octopus.VariableSet, recursive boo
 			return err
 		}
 
+		// Export kubernetes agent targets
+		err = c.exportKubernetesAgentTargets(recursive, lookup, stateless, &v, dependencies)
+		if err != nil {
+			return err
+		}
+
 		// Export polling targets
 		err = c.exportSshTargets(recursive, lookup, stateless, &v, dependencies)
 		if err != nil {
@@ -1353,6 +1377,7 @@ func (c *VariableSetConverter) toHcl(resource octopus.VariableSet, recursive boo
 		}
 
 		thisResource.Id = v.GetVariableSetId(&resource)
+		thisResource.ImportId = strutil.EmptyIfNil(resource.OwnerId) + ":" + v.Id
 		thisResource.Name = v.Name
 		thisResource.ResourceType = c.GetResourceType()
 		thisResource.Lookup = "${" + octopusdeployVariableResourceType + "." + resourceName + ".id}"
@@ -1393,13 +1418,13 @@ func (c *VariableSetConverter) toHcl(resource octopus.VariableSet, recursive boo
 
 			normalValue := value
 			if !c.InlineVariableValues {
-				normalValue = c.writeTerraformVariablesForString(file, v, value)
+				normalValue = c.writeTerraformVariablesForString(file, v, value, dependencies)
 			}
 
 			var sensitiveValue *string = nil
 			if v.IsSensitive {
 				if !c.InlineVariableValues {
-					sensitiveValue = c.TerraformVariableWriter.WriteTerraformVariablesForSecret(c.GetResourceType(), file, &v, dependencies)
+					sensitiveValue = c.TerraformVariableWriter.WriteTerraformVariablesForSecret(c.GetResourceType(), file, &v, c.secretScope(v, resource), dependencies)
 				} else {
 					sensitiveValue = strutil.StrPointer("\"" + *c.DummySecretGenerator.GetDummySecret() + "\"")
 				}
@@ -1483,7 +1508,7 @@ func (c *VariableSetConverter) toHcl(resource octopus.VariableSet, recursive boo
 	return nil
 }
 
-func (c *VariableSetConverter) writeTerraformVariablesForString(file *hclwrite.File, variable octopus.Variable, value *string) *string {
+func (c *VariableSetConverter) writeTerraformVariablesForString(file *hclwrite.File, variable octopus.Variable, value *string, dependencies *data.ResourceDetailsCollection) *string {
 	if c.ExcludeTerraformVariables {
 		return value
 	}
@@ -1496,13 +1521,20 @@ func (c *VariableSetConverter) writeTerraformVariablesForString(file *hclwrite.F
 		// that are being created by terraform, and these dynamic values can not be used as default
 		// variable values.
 
+		defaultValue := LimitAttributeLength(c.LimitAttributeLength, true, strutil.EmptyIfNil(value))
+
+		if defaultValue != strutil.EmptyIfNil(value) {
+			recordLintFinding(dependencies, data.LintSeverityLossy, c.GetResourceType(), variable.Id, variable.Name,
+				"The value of the variable was truncated to "+fmt.Sprint(c.LimitAttributeLength)+" characters.")
+		}
+
 		regularVariable := terraform.TerraformVariable{
 			Name:        variableName,
 			Type:        "string",
 			Nullable:    true,
 			Sensitive:   false,
 			Description: "The value associated with the variable " + variable.Name,
-			Default:     strutil.StrPointer(LimitAttributeLength(c.LimitAttributeLength, true, strutil.EmptyIfNil(value))),
+			Default:     strutil.StrPointer(defaultValue),
 		}
 
 		block := gohcl.EncodeAsBlock(regularVariable, "variable")
@@ -1652,6 +1684,36 @@ func (c *VariableSetConverter) convertScope(variable octopus.Variable, variableS
 
 }
 
+// recordInclusions records the resources referenced by the variable value and scopes, so the reason each resource
+// was included in the export can be reported.
+// secretScope returns the project, environments and tenant tags that a sensitive variable applies to. Variables in
+// library variable sets have no project.
+func (c *VariableSetConverter) secretScope(variable octopus.Variable, variableSet octopus.VariableSet) data.SecretScope {
+	scope := data.SecretScope{
+		EnvironmentIds: variable.Scope.Environment,
+		TenantTags:     variable.Scope.TenantTag,
+	}
+
+	if strings.HasPrefix(strutil.EmptyIfNil(variableSet.OwnerId), "Projects") {
+		scope.ProjectId = strutil.EmptyIfNil(variableSet.OwnerId)
+	}
+
+	return scope
+}
+
+func (c *VariableSetConverter) recordInclusions(variableSet octopus.VariableSet, variable octopus.Variable, dependencies *data.ResourceDetailsCollection) {
+	variableId := variable.GetVariableSetId(&variableSet)
+
+	recordInclusion(dependencies, strutil.EmptyIfNil(variableSet.OwnerId), "", "variable \""+variable.Name+"\"", variableId)
+
+	for _, regex := range []*regexp.Regexp{regexes.AccountRegex, regexes.FeedRegex, regexes.WorkerPoolsRegex, regexes.CertificatesRegex} {
+		recordInclusion(dependencies, variableId, variable.Name, "value", regex.FindAllString(strutil.EmptyIfNil(variable.Value), -1)...)
+	}
+
+	recordInclusion(dependencies, variableId, variable.Name, "environment scope", variable.Scope.Environment...)
+	recordInclusion(dependencies, variableId, variable.Name, "machine scope", variable.Scope.Machine...)
+}
+
 func (c *VariableSetConverter) getDeploymentProcessFromVariable(variableSet octopus.VariableSet) (string, error) {
 	if strings.HasPrefix(strutil.EmptyIfNil(variableSet.OwnerId), "Projects") {
 		project := octopus.Project{}
@@ -1972,6 +2034,34 @@ func (c *VariableSetConverter) exportPollingTargets(recursive bool, lookup bool,
 	return nil
 }
 
+func (c *VariableSetConverter) exportKubernetesAgentTargets(recursive bool, lookup bool, stateless bool, variable *octopus.Variable, dependencies *data.ResourceDetailsCollection) error {
+	if variable == nil {
+		return nil
+	}
+
+	if recursive && lookup {
+		return errors.New("one, and only one, of recursive and lookup can be true")
+	}
+
+	for _, e := range variable.Scope.Machine {
+		var err error
+		if recursive {
+			if stateless {
+				err = c.KubernetesAgentTargetConverter.ToHclStatelessById(e, dependencies)
+			} else {
+				err = c.KubernetesAgentTargetConverter.ToHclById(e, dependencies)
+			}
+		} else if lookup {
+			err = c.KubernetesAgentTargetConverter.ToHclLookupById(e, dependencies)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (c *VariableSetConverter) exportSshTargets(recursive bool, lookup bool, stateless bool, variable *octopus.Variable, dependencies *data.ResourceDetailsCollection) error {
 	if variable == nil {
 		return nil
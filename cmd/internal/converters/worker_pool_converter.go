@@ -91,23 +91,21 @@ func (c WorkerPoolConverter) toHclById(id string, stateless bool, dependencies *
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		resource := octopus.WorkerPool{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
 
-	resource := octopus.WorkerPool{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &resource)
-
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.WorkerPool: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.WorkerPool: %w", err)
+		}
 
-	if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllWorkerpools, c.ExcludeWorkerpools, c.ExcludeWorkerpoolsRegex, c.ExcludeWorkerpoolsExcept) {
-		return nil
-	}
+		if c.Excluder.IsResourceExcludedWithRegex(resource.Name, c.ExcludeAllWorkerpools, c.ExcludeWorkerpools, c.ExcludeWorkerpoolsRegex, c.ExcludeWorkerpoolsExcept) {
+			return nil
+		}
 
-	zap.L().Info("Worker Pool: " + resource.Id + " " + resource.Name)
-	return c.toHcl(resource, true, false, stateless, dependencies)
+		zap.L().Info("Worker Pool: " + resource.Id + " " + resource.Name)
+		return c.toHcl(resource, true, false, stateless, dependencies)
+	})
 }
 
 func (c WorkerPoolConverter) ToHclLookupById(id string, dependencies *data.ResourceDetailsCollection) error {
@@ -115,22 +113,20 @@ func (c WorkerPoolConverter) ToHclLookupById(id string, dependencies *data.Resou
 		return nil
 	}
 
-	if dependencies.HasResource(id, c.GetResourceType()) {
-		return nil
-	}
-
-	pool := octopus.WorkerPool{}
-	_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &pool)
+	return dependencies.ConvertResource(id, c.GetResourceType(), func() error {
+		pool := octopus.WorkerPool{}
+		_, err := c.Client.GetSpaceResourceById(c.GetResourceType(), id, &pool)
 
-	if err != nil {
-		return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.WorkerPool: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error in OctopusClient.GetSpaceResourceById loading type octopus.WorkerPool: %w", err)
+		}
 
-	if c.Excluder.IsResourceExcludedWithRegex(pool.Name, c.ExcludeAllWorkerpools, c.ExcludeWorkerpools, c.ExcludeWorkerpoolsRegex, c.ExcludeWorkerpoolsExcept) {
-		return nil
-	}
+		if c.Excluder.IsResourceExcludedWithRegex(pool.Name, c.ExcludeAllWorkerpools, c.ExcludeWorkerpools, c.ExcludeWorkerpoolsRegex, c.ExcludeWorkerpoolsExcept) {
+			return nil
+		}
 
-	return c.toHcl(pool, false, true, false, dependencies)
+		return c.toHcl(pool, false, true, false, dependencies)
+	})
 }
 
 func (c WorkerPoolConverter) buildData(resourceName string, resourceDisplayName string) terraform.TerraformWorkerPoolData {
@@ -279,7 +275,11 @@ func (c WorkerPoolConverter) toHcl(pool octopus.WorkerPool, _ bool, lookup bool,
 		return nil
 	}
 
-	resourceName := "workerpool_" + sanitizer.SanitizeName(pool.Name)
+	resourceName, err := getResourceLabel(c.Client, dependencies, c.GetResourceType(), "workerpool_", pool.Id, pool.Name)
+
+	if err != nil {
+		return err
+	}
 
 	thisResource := data.ResourceDetails{}
 	thisResource.FileName = "space_population/" + resourceName + ".tf"
@@ -1,6 +1,14 @@
 package data
 
 import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -11,6 +19,9 @@ import (
 
 type ToHcl func() (string, error)
 
+// labelSuffixChars matches the characters in an ID that can not be used in a Terraform label
+var labelSuffixChars = regexp.MustCompile(`[^a-z0-9]`)
+
 type ResourceParameter struct {
 	VariableName  string
 	Description   string
@@ -75,10 +86,28 @@ type ResourceDetails struct {
 	Count string
 	// FileName is the file contains the exported resource
 	FileName string
+	// ImportId is the ID used to import an existing resource into the Terraform state. This is only required when
+	// the provider uses a composite ID, like "deploymentprocess-Projects-1:<step id>" for a process step. When
+	// empty, the Id field is used.
+	ImportId string
 	// ToHCL is a function that generates the HCL from the Octopus resource
 	ToHcl ToHcl
 	// A collection of any parameters that relate to the resource. These are used when building up a step template.
 	Parameters []ResourceParameter
+	// IncludedBy records the resources that referenced this resource, causing it to be included in the export.
+	// Resources that were exported directly, or as children of their parent, have no inclusion reasons.
+	IncludedBy []InclusionReason
+}
+
+// InclusionReason records why a resource was included in an export.
+type InclusionReason struct {
+	// ParentId is the ID of the resource that referenced the included resource
+	ParentId string
+	// ParentName is the name of the resource that referenced the included resource
+	ParentName string
+	// Field describes where the reference was found, for example "LifecycleId" or
+	// "step \"Deploy\" action \"Deploy\" property \"Octopus.Azure.Account\""
+	Field string
 }
 
 // The DummyVariableReference struct defines the details of a variable that had a dummy value injected into it.
@@ -88,11 +117,295 @@ type DummyVariableReference struct {
 	ResourceType string
 }
 
+// SecretScope describes the project, environments and tenants that a secret applies to. Empty fields mean the
+// secret is not limited by that dimension.
+type SecretScope struct {
+	ProjectId      string
+	EnvironmentIds []string
+	TenantIds      []string
+	TenantTags     []string
+}
+
+// The SecretVariableReference struct defines the details of a sensitive Terraform variable declared by the module.
+// The Octopus API does not return secrets, so each of these variables must be populated when the module is applied.
+type SecretVariableReference struct {
+	VariableName string
+	ResourceType string
+	ResourceId   string
+	ResourceName string
+	Scope        SecretScope
+}
+
+// LintSeverity categorises the issues found while exporting a resource.
+type LintSeverity string
+
+const (
+	// LintSeverityError is an issue that prevents the module from being applied.
+	LintSeverityError LintSeverity = "error"
+	// LintSeverityLossy is a resource, or part of a resource, that was dropped or changed during the export.
+	LintSeverityLossy LintSeverity = "lossy"
+	// LintSeverityWarning is an issue that may need to be resolved manually after the module is applied.
+	LintSeverityWarning LintSeverity = "warning"
+)
+
+// LintFinding records an issue found while exporting a resource.
+type LintFinding struct {
+	Severity     LintSeverity
+	ResourceType string
+	ResourceId   string
+	ResourceName string
+	Message      string
+}
+
+// resourceKey identifies a resource by its type and ID. Resource types are compared case-insensitively, so
+// the type is stored in lower case.
+type resourceKey struct {
+	resourceType string
+	id           string
+}
+
+func newResourceKey(resourceType string, id string) resourceKey {
+	return resourceKey{resourceType: strings.ToLower(resourceType), id: id}
+}
+
+// ResourceDetailsCollection holds the resources that have been exported. Lookups are served from indexes that
+// hold positions in the Resources slice, so the Resources slice must only be appended to, either via AddResource
+// or directly. Any resources appended directly are indexed the next time the collection is queried.
 type ResourceDetailsCollection struct {
 	Resources      []ResourceDetails
 	DummyVariables []DummyVariableReference
+	// SecretVariables lists the sensitive Terraform variables declared by the module
+	SecretVariables []SecretVariableReference
+	// SecretValues maps the names of sensitive Terraform variables to the values supplied by a secret provider
+	SecretValues map[string]string
+	// LintFindings lists the issues found while exporting the resources
+	LintFindings []LintFinding
+	// files holds the files that are not generated from a single resource, like extracted scripts, mapped to the lowest
+	// key they were added with
+	files map[addedFile]string
 	// A mutex to protect lookups
 	mu sync.Mutex
+	// indexedCount is the number of items in Resources that have been added to the indexes
+	indexedCount int
+	// byId maps the resource type and ID to the first matching resource
+	byId map[resourceKey]int
+	// byAlternateId maps the resource type and alternate ID to the first matching resource
+	byAlternateId map[resourceKey]int
+	// byType maps the lower case resource type to the matching resources
+	byType map[string][]int
+	// byParentId maps the parent ID to the matching resources
+	byParentId map[string][]int
+	// byImmediateParentId maps the immediate parent ID to the matching resources
+	byImmediateParentId map[string][]int
+	// conversions holds the resources being converted by ConvertResource
+	conversions map[resourceKey]*resourceConversion
+	// byOctopusId maps the resource ID to the matching resources of any type
+	byOctopusId map[string][]int
+	// inclusions maps the resource ID to the reasons the resource was included in the export
+	inclusions map[string][]InclusionReason
+	// labels maps the resource type and ID to the Terraform label returned by GetResourceLabel
+	labels map[resourceKey]string
+	// labelOwners maps the resource type and Terraform label to the ID of the resource that was given the label
+	labelOwners map[resourceKey]string
+	// labelReservations holds the result of ReserveResourceLabels for each group of resources
+	labelReservations map[string]*labelReservation
+}
+
+// labelReservation loads the labels of a group of resources once.
+type labelReservation struct {
+	once sync.Once
+	err  error
+}
+
+// updateIndexes adds any resources that have not been indexed to the indexes. The mutex must be held by the caller.
+func (c *ResourceDetailsCollection) updateIndexes() {
+	if c.byId == nil || c.indexedCount > len(c.Resources) {
+		c.byId = map[resourceKey]int{}
+		c.byAlternateId = map[resourceKey]int{}
+		c.byType = map[string][]int{}
+		c.byParentId = map[string][]int{}
+		c.byImmediateParentId = map[string][]int{}
+		c.byOctopusId = map[string][]int{}
+		c.indexedCount = 0
+	}
+
+	for ; c.indexedCount < len(c.Resources); c.indexedCount++ {
+		index := c.indexedCount
+		resource := c.Resources[index]
+		key := newResourceKey(resource.ResourceType, resource.Id)
+
+		// Lookups have always returned the first matching resource, so later duplicates are not indexed by ID
+		if _, ok := c.byId[key]; !ok {
+			c.byId[key] = index
+		}
+
+		if resource.AlternateId != "" {
+			alternateKey := newResourceKey(resource.ResourceType, resource.AlternateId)
+			if _, ok := c.byAlternateId[alternateKey]; !ok {
+				c.byAlternateId[alternateKey] = index
+			}
+		}
+
+		c.byType[key.resourceType] = append(c.byType[key.resourceType], index)
+
+		if resource.Id != "" {
+			c.byOctopusId[resource.Id] = append(c.byOctopusId[resource.Id], index)
+		}
+
+		if resource.ParentId != "" {
+			c.byParentId[resource.ParentId] = append(c.byParentId[resource.ParentId], index)
+		}
+
+		if resource.ImmediateParentId != "" {
+			c.byImmediateParentId[resource.ImmediateParentId] = append(c.byImmediateParentId[resource.ImmediateParentId], index)
+		}
+	}
+}
+
+// findById returns the first resource with the supplied type and ID. The mutex must be held by the caller.
+func (c *ResourceDetailsCollection) findById(resourceType string, id string) (ResourceDetails, bool) {
+	c.updateIndexes()
+
+	if index, ok := c.byId[newResourceKey(resourceType, id)]; ok {
+		return c.Resources[index], true
+	}
+
+	return ResourceDetails{}, false
+}
+
+// findByIdOrAlternateId returns the first resource with the supplied type and either an ID or alternate ID
+// matching the supplied ID. The mutex must be held by the caller.
+func (c *ResourceDetailsCollection) findByIdOrAlternateId(resourceType string, id string) (ResourceDetails, bool) {
+	c.updateIndexes()
+
+	key := newResourceKey(resourceType, id)
+	index, found := c.byId[key]
+
+	if alternateIndex, ok := c.byAlternateId[key]; ok && (!found || alternateIndex < index) {
+		index = alternateIndex
+		found = true
+	}
+
+	if found {
+		return c.Resources[index], true
+	}
+
+	return ResourceDetails{}, false
+}
+
+// AddSecretValue records the value of a sensitive Terraform variable
+func (c *ResourceDetailsCollection) AddSecretValue(variableName string, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.SecretValues == nil {
+		c.SecretValues = map[string]string{}
+	}
+
+	c.SecretValues[variableName] = value
+}
+
+// AddSecretVariable records a sensitive Terraform variable declared by the module
+func (c *ResourceDetailsCollection) AddSecretVariable(reference SecretVariableReference) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.SecretVariables = append(c.SecretVariables, reference)
+}
+
+// AddLintFinding records an issue found while exporting a resource. Converters can process a resource more than
+// once, so duplicate findings are ignored.
+func (c *ResourceDetailsCollection) AddLintFinding(finding LintFinding) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if slices.Contains(c.LintFindings, finding) {
+		return
+	}
+
+	c.LintFindings = append(c.LintFindings, finding)
+}
+
+// addedFile is a file recorded by AddFile.
+type addedFile struct {
+	directory string
+	name      string
+	content   string
+}
+
+// placeholder returns the text that is replaced with the name of the file by ResolveFiles.
+func (f addedFile) placeholder() string {
+	hash := sha256.Sum256([]byte(f.directory + "\x00" + f.name + "\x00" + f.content))
+	return "__octoterra_file_" + hex.EncodeToString(hash[:8]) + "__"
+}
+
+/*
+AddFile records a file to be written to the directory alongside the generated HCL, and returns a placeholder that
+ResolveFiles replaces with the name of the file relative to the directory. The key identifies the resource that owns
+the file, like an action ID.
+
+Converters can process a resource more than once, so a file with the same name and content is recorded once. When
+different content is added with the same name, for example because two names sanitize to the same string, the files
+are renamed by adding a number to the end of the name, before the extension. The files are numbered in order of
+their keys by ResolveFiles, so the names do not depend on the order the resources are converted in.
+*/
+func (c *ResourceDetailsCollection) AddFile(directory string, fileName string, key string, content string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.files == nil {
+		c.files = map[addedFile]string{}
+	}
+
+	file := addedFile{directory: directory, name: fileName, content: content}
+	if existing, ok := c.files[file]; !ok || key < existing {
+		c.files[file] = key
+	}
+
+	return file.placeholder()
+}
+
+// ResolveFiles returns the generated files with the placeholders returned by AddFile replaced by the file names, along
+// with the files recorded by AddFile.
+func (c *ResourceDetailsCollection) ResolveFiles(generated map[string]string) map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	files := slices.SortedFunc(maps.Keys(c.files), func(a addedFile, b addedFile) int {
+		return cmp.Or(
+			strings.Compare(a.directory+a.name, b.directory+b.name),
+			strings.Compare(c.files[a], c.files[b]),
+			strings.Compare(a.content, b.content))
+	})
+
+	result := map[string]string{}
+	replacements := []string{}
+
+	for _, file := range files {
+		subdirectory, name := path.Split(file.name)
+		base, extension, _ := strings.Cut(name, ".")
+		if extension != "" {
+			extension = "." + extension
+		}
+
+		uniqueName := file.name
+		for count := 2; ; count++ {
+			if _, ok := result[file.directory+uniqueName]; !ok {
+				break
+			}
+			uniqueName = subdirectory + base + "_" + strconv.Itoa(count) + extension
+		}
+
+		result[file.directory+uniqueName] = file.content
+		replacements = append(replacements, file.placeholder(), uniqueName)
+	}
+
+	replacer := strings.NewReplacer(replacements...)
+	for name, content := range generated {
+		result[name] = replacer.Replace(content)
+	}
+
+	return result
 }
 
 // AddDummy adds a dummy variable reference to the collection
@@ -112,19 +425,238 @@ HasResource returns true if the resource with the id and resourceType exist in t
 While this method is thread-safe, it is not a guarantee that two goroutines are not processing the same resource
 concurrently. If HasResource returns true, it is safe to assume the resource has been processed by other goroutines
 and exit early. If HasResource returns false, the resource should be processed, but the results may be discarded
-by the AddResource method if another goroutine has processed the same resource in the meantime.
+by the AddResource method if another goroutine has processed the same resource in the meantime. Use ConvertResource
+to guarantee that only one goroutine processes a resource.
 */
 func (c *ResourceDetailsCollection) HasResource(id string, resourceType string) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for _, r := range c.Resources {
-		if r.Id == id && strings.EqualFold(r.ResourceType, resourceType) {
-			return true
+	_, found := c.findById(resourceType, id)
+	return found
+}
+
+// resourceConversion is a conversion started by ConvertResource. The done channel is closed once the conversion has
+// finished and err holds its result.
+type resourceConversion struct {
+	done chan struct{}
+	err  error
+}
+
+/*
+ConvertResource runs convert for the resource with the id and resourceType, making sure the resource is converted once
+no matter how many goroutines ask for it. The first goroutine to ask claims the resource and runs convert. Any other
+goroutine asking while the resource is claimed waits for convert to finish and returns the same error, so every caller
+can rely on the resource having been added to the collection once ConvertResource returns without an error.
+
+Converters of different kinds can share a resource type, like the converters of each kind of target, which skip the
+targets they do not convert. So when the conversion a goroutine waited for skipped the resource, the goroutine claims
+the resource and runs its own convert function. Resources that are already in the collection are not converted again.
+
+The by ID conversions of every converter are run through this method. A converter must not ask for a resource it is
+already converting, as it would wait for itself.
+*/
+func (c *ResourceDetailsCollection) ConvertResource(id string, resourceType string, convert func() error) error {
+	key := newResourceKey(resourceType, id)
+
+	for {
+		c.mu.Lock()
+
+		if _, found := c.findById(resourceType, id); found {
+			c.mu.Unlock()
+			return nil
+		}
+
+		conversion, found := c.conversions[key]
+
+		if !found {
+			break
+		}
+
+		c.mu.Unlock()
+		<-conversion.done
+
+		if conversion.err != nil {
+			return conversion.err
+		}
+	}
+
+	if c.conversions == nil {
+		c.conversions = map[resourceKey]*resourceConversion{}
+	}
+
+	conversion := &resourceConversion{done: make(chan struct{})}
+	c.conversions[key] = conversion
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.conversions, key)
+		c.mu.Unlock()
+		close(conversion.done)
+	}()
+
+	conversion.err = convert()
+
+	return conversion.err
+}
+
+/*
+GetResourceLabel returns a unique Terraform label for the resource with the resourceType and id. The label is
+normally the one supplied, which converters build from the sanitized resource name. Different names can be sanitized
+to the same label, for example "Docker Hub" and "docker-hub", so when the label has already been given to another
+resource of the same type, the sanitized ID is appended to it. Labels are remembered by ID, so every converter asking
+for the label of a resource receives the same label.
+
+Resources are converted concurrently, so the resource that keeps a duplicated label depends on the order the labels
+are requested in. Call ReserveResourceLabels first to assign the labels of the resources independently of the order.
+*/
+func (c *ResourceDetailsCollection) GetResourceLabel(resourceType string, id string, label string) string {
+	if id == "" {
+		return label
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.assignResourceLabel(resourceType, id, label)
+}
+
+/*
+ReserveResourceLabels assigns the labels of a group of resources of the resourceType, like every resource of the type
+in the space. The load function returns the label each resource would be given by GetResourceLabel, mapped by ID, and
+is called once for each group no matter how many converters reserve the group. When resources share a label, the
+resource with the lexically lowest ID keeps the label and the IDs are appended to the others, so the labels do not
+depend on the order resources are converted in. Resources that already have a label keep it.
+*/
+func (c *ResourceDetailsCollection) ReserveResourceLabels(resourceType string, group string, load func() (map[string]string, error)) error {
+	c.mu.Lock()
+	if c.labelReservations == nil {
+		c.labelReservations = map[string]*labelReservation{}
+	}
+
+	key := strings.ToLower(resourceType) + "/" + group
+	reservation, ok := c.labelReservations[key]
+	if !ok {
+		reservation = &labelReservation{}
+		c.labelReservations[key] = reservation
+	}
+	c.mu.Unlock()
+
+	// The labels are loaded without holding the lock, as loading them can call the Octopus API
+	reservation.once.Do(func() {
+		labels, err := load()
+
+		if err != nil {
+			reservation.err = err
+			return
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		ids := slices.Sorted(maps.Keys(labels))
+
+		// Give each label to its lowest ID before appending IDs to the duplicates, so an appended ID can not take
+		// the label of another resource
+		duplicates := []string{}
+		for _, id := range ids {
+			if c.labelOwners[newResourceKey(resourceType, labels[id])] == "" {
+				c.assignResourceLabel(resourceType, id, labels[id])
+			} else {
+				duplicates = append(duplicates, id)
+			}
+		}
+
+		for _, id := range duplicates {
+			c.assignResourceLabel(resourceType, id, labels[id])
+		}
+	})
+
+	return reservation.err
+}
+
+// assignResourceLabel returns the label of the resource, assigning a unique label if the resource does not have one.
+// The mutex must be held by the caller.
+func (c *ResourceDetailsCollection) assignResourceLabel(resourceType string, id string, label string) string {
+	if c.labels == nil {
+		c.labels = map[resourceKey]string{}
+		c.labelOwners = map[resourceKey]string{}
+	}
+
+	key := newResourceKey(resourceType, id)
+
+	if existing, ok := c.labels[key]; ok {
+		return existing
+	}
+
+	unique := label
+	suffix := labelSuffixChars.ReplaceAllString(strings.ToLower(id), "_")
+
+	for count := 1; c.labelOwners[newResourceKey(resourceType, unique)] != ""; count++ {
+		unique = label + "_" + suffix
+
+		// IDs are unique, but their sanitized form may not be
+		if count > 1 {
+			unique += "_" + strconv.Itoa(count)
 		}
 	}
 
-	return false
+	c.labels[key] = unique
+	c.labelOwners[newResourceKey(resourceType, unique)] = id
+
+	return unique
+}
+
+/*
+RecordInclusion records that the resource with the id was included in the export because it was referenced by
+another resource. The reason is recorded against the ID alone, as Octopus IDs are unique across resource types, and
+is added to the IncludedBy field of the resource when it is added to the collection. Recording the reason before
+converting the referenced resource means the reason is available however the resource is added.
+*/
+func (c *ResourceDetailsCollection) RecordInclusion(id string, reason InclusionReason) {
+	if id == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.inclusions == nil {
+		c.inclusions = map[string][]InclusionReason{}
+	}
+
+	if slices.Contains(c.inclusions[id], reason) {
+		return
+	}
+
+	c.inclusions[id] = append(c.inclusions[id], reason)
+
+	c.updateIndexes()
+
+	for _, index := range c.byOctopusId[id] {
+		c.Resources[index].IncludedBy = append(slices.Clone(c.Resources[index].IncludedBy), reason)
+	}
+}
+
+// GetInclusionReasons returns the reasons the resource with the id was included in the export.
+func (c *ResourceDetailsCollection) GetInclusionReasons(id string) []InclusionReason {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.inclusions[id])
+}
+
+// GetResourcesByOctopusId returns the resources of any type with the id.
+func (c *ResourceDetailsCollection) GetResourcesByOctopusId(id string) []ResourceDetails {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.updateIndexes()
+
+	return lo.Map(c.byOctopusId[id], func(index int, _ int) ResourceDetails {
+		return c.Resources[index]
+	})
 }
 
 func (c *ResourceDetailsCollection) AddResourcePtr(resources ...*ResourceDetails) {
@@ -148,19 +680,28 @@ func (c *ResourceDetailsCollection) AddResource(resources ...ResourceDetails) {
 		return
 	}
 
-	/*
-		When running with multiple goroutines it is possible to have a race condition where a call to HasResource
-		returns false, indicating that a converter should go ahead and process the resource. But by the time
-		AddResource is called, another goroutine has added the same resource. This check is to ensure that the
-		resource is not added twice.
-	*/
-	fixedResources := lo.Filter(resources, func(resource ResourceDetails, index int) bool {
-		return !lo.ContainsBy(c.Resources, func(existingResource ResourceDetails) bool {
-			return resource.Id != "" && resource.ResourceType != "" && existingResource.Id == resource.Id && strings.EqualFold(existingResource.ResourceType, resource.ResourceType)
-		})
-	})
+	c.updateIndexes()
 
-	c.Resources = append(c.Resources, fixedResources...)
+	for _, resource := range resources {
+		/*
+			When running with multiple goroutines it is possible to have a race condition where a call to HasResource
+			returns false, indicating that a converter should go ahead and process the resource. But by the time
+			AddResource is called, another goroutine has added the same resource. This check is to ensure that the
+			resource is not added twice.
+		*/
+		if resource.Id != "" && resource.ResourceType != "" {
+			if _, found := c.findById(resource.ResourceType, resource.Id); found {
+				continue
+			}
+		}
+
+		if reasons, ok := c.inclusions[resource.Id]; ok && resource.Id != "" {
+			resource.IncludedBy = append(slices.Clone(resource.IncludedBy), reasons...)
+		}
+
+		c.Resources = append(c.Resources, resource)
+		c.updateIndexes()
+	}
 }
 
 // GetAllResource returns a slice of resources in the collection of type resourceType
@@ -168,11 +709,12 @@ func (c *ResourceDetailsCollection) GetAllResource(resourceType string) []Resour
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	resources := make([]ResourceDetails, 0)
-	for _, r := range c.Resources {
-		if strings.EqualFold(r.ResourceType, resourceType) {
-			resources = append(resources, r)
-		}
+	c.updateIndexes()
+
+	indexes := c.byType[strings.ToLower(resourceType)]
+	resources := make([]ResourceDetails, 0, len(indexes))
+	for _, index := range indexes {
+		resources = append(resources, c.Resources[index])
 	}
 
 	return resources
@@ -189,8 +731,20 @@ func (c *ResourceDetailsCollection) GetAllResourceWithLowerSort(resourceType str
 // GetAllResourceWithImmediateParentWithLowerSort returns a slice of resources in the collection of type resourceType that have
 // a lower sort order and who share the same immediate parent ID.
 func (c *ResourceDetailsCollection) GetAllResourceWithImmediateParentWithLowerSort(resourceType string, maxSort int, immediateParentId string) []ResourceDetails {
-	return lo.Filter(c.GetAllResource(resourceType), func(item ResourceDetails, index int) bool {
-		return item.SortOrder < maxSort && item.ImmediateParentId == immediateParentId
+	if immediateParentId == "" {
+		return lo.Filter(c.GetAllResource(resourceType), func(item ResourceDetails, index int) bool {
+			return item.SortOrder < maxSort && item.ImmediateParentId == immediateParentId
+		})
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.updateIndexes()
+
+	return lo.FilterMap(c.byImmediateParentId[immediateParentId], func(index int, _ int) (ResourceDetails, bool) {
+		item := c.Resources[index]
+		return item, item.SortOrder < maxSort && strings.EqualFold(item.ResourceType, resourceType)
 	})
 }
 
@@ -206,10 +760,8 @@ func (c *ResourceDetailsCollection) GetResource(resourceType string, id string)
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for _, r := range c.Resources {
-		if (r.Id == id || r.AlternateId == id) && strings.EqualFold(r.ResourceType, resourceType) {
-			return r.Lookup
-		}
+	if r, found := c.findByIdOrAlternateId(resourceType, id); found {
+		return r.Lookup
 	}
 
 	zap.L().Error("Failed to resolve lookup " + id + " of type " + resourceType)
@@ -228,10 +780,8 @@ func (c *ResourceDetailsCollection) GetResourceCount(resourceType string, id str
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for _, r := range c.Resources {
-		if r.Id == id && strings.EqualFold(r.ResourceType, resourceType) {
-			return r.Count
-		}
+	if r, found := c.findById(resourceType, id); found {
+		return r.Count
 	}
 
 	zap.L().Error("Failed to resolve lookup " + id + " of type " + resourceType)
@@ -250,10 +800,8 @@ func (c *ResourceDetailsCollection) GetResourceName(resourceType string, id stri
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for _, r := range c.Resources {
-		if r.Id == id && strings.EqualFold(r.ResourceType, resourceType) {
-			return r.Name
-		}
+	if r, found := c.findById(resourceType, id); found {
+		return r.Name
 	}
 
 	zap.L().Error("Failed to resolve lookup " + id + " of type " + resourceType)
@@ -270,10 +818,8 @@ func (c *ResourceDetailsCollection) GetResourceVersionLookup(resourceType string
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for _, r := range c.Resources {
-		if r.Id == id && strings.EqualFold(r.ResourceType, resourceType) {
-			return r.VersionLookup
-		}
+	if r, found := c.findById(resourceType, id); found {
+		return r.VersionLookup
 	}
 
 	zap.L().Error("Failed to resolve lookup " + id + " of type " + resourceType)
@@ -290,10 +836,8 @@ func (c *ResourceDetailsCollection) GetResourceVersionCurrent(resourceType strin
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for _, r := range c.Resources {
-		if r.Id == id && strings.EqualFold(r.ResourceType, resourceType) {
-			return r.VersionCurrent
-		}
+	if r, found := c.findById(resourceType, id); found {
+		return r.VersionCurrent
 	}
 
 	zap.L().Error("Failed to resolve lookup " + id + " of type " + resourceType)
@@ -312,11 +856,9 @@ func (c *ResourceDetailsCollection) GetResourceDependency(resourceType string, i
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for _, r := range c.Resources {
-		if (r.Id == id || r.AlternateId == id) && strings.EqualFold(r.ResourceType, resourceType) {
-			// return the dependency field if it was defined, otherwise fall back to the lookup field
-			return strutil.DefaultIfEmpty(r.Dependency, r.Lookup)
-		}
+	if r, found := c.findByIdOrAlternateId(resourceType, id); found {
+		// return the dependency field if it was defined, otherwise fall back to the lookup field
+		return strutil.DefaultIfEmpty(r.Dependency, r.Lookup)
 	}
 
 	zap.L().Error("Failed to resolve dependency " + id + " of type " + resourceType)
@@ -335,11 +877,9 @@ func (c *ResourceDetailsCollection) GetResourceDependencyPointer(resourceType st
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for _, r := range c.Resources {
-		if r.Id == *id && strings.EqualFold(r.ResourceType, resourceType) {
-			// return the dependency field if it was defined, otherwise fall back to the lookup field
-			return strutil.NilIfEmpty(strutil.DefaultIfEmpty(r.Dependency, r.Lookup))
-		}
+	if r, found := c.findById(resourceType, *id); found {
+		// return the dependency field if it was defined, otherwise fall back to the lookup field
+		return strutil.NilIfEmpty(strutil.DefaultIfEmpty(r.Dependency, r.Lookup))
 	}
 
 	zap.L().Error("Failed to resolve dependency " + *id + " of type " + resourceType)
@@ -356,8 +896,11 @@ func (c *ResourceDetailsCollection) GetResourceDependencyFromParent(parentId str
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	return lo.FilterMap(c.Resources, func(item ResourceDetails, index int) (string, bool) {
-		return item.Dependency, item.ParentId == parentId && strings.EqualFold(item.ResourceType, resourceType)
+	c.updateIndexes()
+
+	return lo.FilterMap(c.byParentId[parentId], func(index int, _ int) (string, bool) {
+		item := c.Resources[index]
+		return item.Dependency, strings.EqualFold(item.ResourceType, resourceType)
 	})
 }
 
@@ -368,15 +911,9 @@ func (c *ResourceDetailsCollection) GetResources(resourceType string, ids ...str
 
 	lookups := []string{}
 	for _, i := range ids {
-		found := false
-		for _, r := range c.Resources {
-			if r.Id == i && strings.EqualFold(r.ResourceType, resourceType) {
-				lookups = append(lookups, r.Lookup)
-				found = true
-				continue
-			}
-		}
-		if !found {
+		if r, found := c.findById(resourceType, i); found {
+			lookups = append(lookups, r.Lookup)
+		} else {
 			zap.L().Error("Failed to resolve " + i + " of type " + resourceType)
 		}
 	}
@@ -386,19 +923,12 @@ func (c *ResourceDetailsCollection) GetResources(resourceType string, ids ...str
 
 // GetResourcePointer returns the Terraform reference for a given resource type and id as a string pointer.
 func (c *ResourceDetailsCollection) GetResourcePointer(resourceType string, id *string) *string {
-	if id == nil {
-		empty := ""
-		return &empty
-	}
-
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
 	if id != nil {
-		for _, r := range c.Resources {
-			if r.Id == *id && strings.EqualFold(r.ResourceType, resourceType) {
-				return &r.Lookup
-			}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if r, found := c.findById(resourceType, *id); found {
+			return &r.Lookup
 		}
 
 		zap.L().Error("Failed to resolve " + strutil.EmptyIfNil(id) + " of type " + resourceType)
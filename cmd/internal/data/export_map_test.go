@@ -0,0 +1,355 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const benchmarkResourceCount = 50000
+
+var benchmarkResourceTypes = []string{"Projects", "Variables", "Steps", "Channels", "Runbooks", "Environments", "Tenants", "Feeds", "Accounts", "Lifecycles"}
+
+func syntheticResources(count int) []ResourceDetails {
+	resources := make([]ResourceDetails, 0, count)
+	for i := 0; i < count; i++ {
+		resourceType := benchmarkResourceTypes[i%len(benchmarkResourceTypes)]
+		resources = append(resources, ResourceDetails{
+			Id:                fmt.Sprintf("%s-%d", resourceType, i),
+			ResourceType:      resourceType,
+			ParentId:          fmt.Sprintf("Projects-%d", i%500),
+			ImmediateParentId: fmt.Sprintf("deploymentprocess-Projects-%d", i%500),
+			Lookup:            fmt.Sprintf("${octopusdeploy_thing.thing_%d.id}", i),
+			Dependency:        fmt.Sprintf("${octopusdeploy_thing.thing_%d}", i),
+		})
+	}
+	return resources
+}
+
+func TestResourceLookups(t *testing.T) {
+	collection := ResourceDetailsCollection{}
+	collection.AddResource(
+		ResourceDetails{Id: "Steps-1", AlternateId: "Actions-1", ResourceType: "Steps", ParentId: "Projects-1", ImmediateParentId: "Process-1", SortOrder: 1, Lookup: "step1", Dependency: "step1dep"},
+		ResourceDetails{Id: "Steps-2", ResourceType: "Steps", ParentId: "Projects-1", ImmediateParentId: "Process-1", SortOrder: 2, Lookup: "step2"},
+		ResourceDetails{Id: "Steps-1", ResourceType: "Steps", Lookup: "duplicate"},
+		ResourceDetails{Id: "Channels-1", ResourceType: "Channels", ParentId: "Projects-1", Lookup: "channel1"})
+
+	if len(collection.Resources) != 3 {
+		t.Fatalf("Duplicate resources must not be added, found %d resources", len(collection.Resources))
+	}
+
+	if !collection.HasResource("Steps-1", "steps") {
+		t.Fatalf("Resource types must be compared case-insensitively")
+	}
+
+	if collection.GetResource("Steps", "Actions-1") != "step1" {
+		t.Fatalf("Resources must be found by their alternate ID")
+	}
+
+	if len(collection.GetAllResource("Steps")) != 2 {
+		t.Fatalf("Expected 2 steps")
+	}
+
+	if dependencies := collection.GetResourceDependencyFromParent("Projects-1", "Steps"); len(dependencies) != 2 || dependencies[0] != "step1dep" {
+		t.Fatalf("Unexpected dependencies from parent: %v", dependencies)
+	}
+
+	if steps := collection.GetAllResourceWithImmediateParentWithLowerSort("Steps", 2, "Process-1"); len(steps) != 1 || steps[0].Id != "Steps-1" {
+		t.Fatalf("Unexpected steps with lower sort: %v", steps)
+	}
+
+	// Resources appended directly to the slice must also be found
+	collection.Resources = append(collection.Resources, ResourceDetails{Id: "Feeds-1", ResourceType: "Feeds", Lookup: "feed1"})
+
+	if collection.GetResource("Feeds", "Feeds-1") != "feed1" {
+		t.Fatalf("Resources appended directly to the slice must be indexed")
+	}
+}
+
+func TestConvertResource(t *testing.T) {
+	collection := ResourceDetailsCollection{}
+	conversions := atomic.Int32{}
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := collection.ConvertResource("Projects-1", "Projects", func() error {
+				conversions.Add(1)
+				collection.AddResource(ResourceDetails{Id: "Projects-1", ResourceType: "Projects"})
+				return nil
+			})
+
+			if err != nil {
+				t.Error(err)
+			}
+
+			// Every caller returns once the resource has been converted
+			if !collection.HasResource("Projects-1", "Projects") {
+				t.Errorf("Expected the resource to be converted before ConvertResource returned")
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if conversions.Load() != 1 {
+		t.Fatalf("Expected exactly one goroutine to convert the resource, got %d", conversions.Load())
+	}
+
+	// A resource that was skipped can be converted again
+	_ = collection.ConvertResource("Projects-2", "Projects", func() error { return nil })
+
+	converted := false
+	_ = collection.ConvertResource("Projects-2", "Projects", func() error {
+		converted = true
+		return nil
+	})
+
+	if !converted {
+		t.Fatalf("A skipped resource must be able to be converted again")
+	}
+}
+
+func TestConvertResourceWaitsForResult(t *testing.T) {
+	collection := ResourceDetailsCollection{}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	winner := make(chan error)
+	loser := make(chan error)
+
+	go func() {
+		winner <- collection.ConvertResource("Projects-1", "Projects", func() error {
+			close(started)
+			<-release
+			return errors.New("conversion failed")
+		})
+	}()
+
+	<-started
+
+	go func() {
+		loser <- collection.ConvertResource("Projects-1", "Projects", func() error {
+			return errors.New("the resource must not be converted twice")
+		})
+	}()
+
+	select {
+	case err := <-loser:
+		t.Fatalf("Expected the second caller to wait for the conversion, got %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+
+	for _, result := range []chan error{winner, loser} {
+		if err := <-result; err == nil || err.Error() != "conversion failed" {
+			t.Fatalf("Expected every caller to receive the result of the conversion, got %v", err)
+		}
+	}
+}
+
+func TestRecordInclusion(t *testing.T) {
+	collection := ResourceDetailsCollection{}
+	reason := InclusionReason{ParentId: "Projects-1", ParentName: "My Project", Field: "LifecycleId"}
+
+	// Reasons recorded before the resource is added are copied to the resource
+	collection.RecordInclusion("Lifecycles-1", reason)
+	collection.RecordInclusion("Lifecycles-1", reason)
+	collection.AddResource(ResourceDetails{Id: "Lifecycles-1", ResourceType: "Lifecycles"})
+
+	if len(collection.Resources[0].IncludedBy) != 1 || collection.Resources[0].IncludedBy[0] != reason {
+		t.Fatalf("The inclusion reason was not added to the resource: %v", collection.Resources[0].IncludedBy)
+	}
+
+	// Reasons recorded after the resource is added update the existing resource
+	collection.RecordInclusion("Lifecycles-1", InclusionReason{ParentId: "Projects-2", Field: "LifecycleId"})
+
+	if len(collection.Resources[0].IncludedBy) != 2 || len(collection.GetInclusionReasons("Lifecycles-1")) != 2 {
+		t.Fatalf("The inclusion reason was not added to the existing resource: %v", collection.Resources[0].IncludedBy)
+	}
+
+	if len(collection.GetResourcesByOctopusId("Lifecycles-1")) != 1 {
+		t.Fatalf("The resource was not found by its ID")
+	}
+}
+
+func BenchmarkAddResource(b *testing.B) {
+	resources := syntheticResources(benchmarkResourceCount)
+
+	for n := 0; n < b.N; n++ {
+		collection := ResourceDetailsCollection{}
+		for _, resource := range resources {
+			if !collection.HasResource(resource.Id, resource.ResourceType) {
+				collection.AddResource(resource)
+			}
+		}
+	}
+}
+
+func BenchmarkGetResource(b *testing.B) {
+	resources := syntheticResources(benchmarkResourceCount)
+	collection := ResourceDetailsCollection{}
+	collection.AddResource(resources...)
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		resource := resources[(n*7919)%len(resources)]
+		collection.GetResource(resource.ResourceType, resource.Id)
+		collection.GetResourceDependencyFromParent(resource.ParentId, resource.ResourceType)
+	}
+}
+
+func TestGetResourceLabel(t *testing.T) {
+	collection := ResourceDetailsCollection{}
+
+	if label := collection.GetResourceLabel("Feeds", "Feeds-1", "feed_docker_hub"); label != "feed_docker_hub" {
+		t.Fatalf("The first resource must keep its label, got %s", label)
+	}
+
+	if label := collection.GetResourceLabel("Feeds", "Feeds-2", "feed_docker_hub"); label != "feed_docker_hub_feeds_2" {
+		t.Fatalf("A duplicate label must have the ID appended, got %s", label)
+	}
+
+	if label := collection.GetResourceLabel("feeds", "Feeds-2", "feed_docker_hub"); label != "feed_docker_hub_feeds_2" {
+		t.Fatalf("The same resource must always get the same label, got %s", label)
+	}
+
+	if label := collection.GetResourceLabel("Feeds", "feeds_2", "feed_docker_hub"); label != "feed_docker_hub_feeds_2_2" {
+		t.Fatalf("A duplicate sanitized ID must have a count appended, got %s", label)
+	}
+
+	if label := collection.GetResourceLabel("Accounts", "Accounts-1", "feed_docker_hub"); label != "feed_docker_hub" {
+		t.Fatalf("Labels are unique per resource type, got %s", label)
+	}
+}
+
+func TestReserveResourceLabels(t *testing.T) {
+	collection := ResourceDetailsCollection{}
+	var loads atomic.Int32
+
+	load := func() (map[string]string, error) {
+		loads.Add(1)
+		return map[string]string{
+			"Feeds-2":  "feed_docker_hub",
+			"Feeds-10": "feed_docker_hub",
+			"Feeds-3":  "feed_docker_hub_feeds_2",
+			"Feeds-4":  "feed_npm",
+		}, nil
+	}
+
+	// A label requested before the reservation is kept
+	if label := collection.GetResourceLabel("Feeds", "Feeds-4", "feed_npm"); label != "feed_npm" {
+		t.Fatalf("unexpected label %s", label)
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := collection.ReserveResourceLabels("Feeds", "Feeds", load); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if loads.Load() != 1 {
+		t.Fatalf("The labels must be loaded once, loaded %d times", loads.Load())
+	}
+
+	expected := map[string]string{
+		// The lexically lowest ID keeps the label
+		"Feeds-10": "feed_docker_hub",
+		// The ID appended to a duplicate label must not take the label of another resource
+		"Feeds-2": "feed_docker_hub_feeds_2_2",
+		"Feeds-3": "feed_docker_hub_feeds_2",
+		"Feeds-4": "feed_npm",
+	}
+
+	for id, expectedLabel := range expected {
+		if label := collection.GetResourceLabel("Feeds", id, "ignored"); label != expectedLabel {
+			t.Fatalf("expected %s to have the label %s, got %s", id, expectedLabel, label)
+		}
+	}
+
+	err := collection.ReserveResourceLabels("Accounts", "Accounts", func() (map[string]string, error) {
+		return nil, fmt.Errorf("failed")
+	})
+
+	if err == nil {
+		t.Fatalf("The error from loading the labels must be returned")
+	}
+}
+
+func TestAddFile(t *testing.T) {
+	collection := ResourceDetailsCollection{}
+
+	// The files are added in the opposite order to their keys
+	three := collection.AddFile("scripts/", "project_web/deploy_app.sh", "Actions-3", "echo three")
+	two := collection.AddFile("scripts/", "project_web/deploy_app.sh", "Actions-2", "echo two")
+	one := collection.AddFile("scripts/", "project_web/deploy_app.sh", "Actions-1", "echo one")
+	template := collection.AddFile("scripts/", "project_web/deploy_app.tf.json.tmpl", "Actions-1", "{}")
+
+	// Adding the same content again must return the original file
+	if collection.AddFile("scripts/", "project_web/deploy_app.sh", "Actions-4", "echo one") != one {
+		t.Fatalf("A file with the same content must reuse the name")
+	}
+
+	files := collection.ResolveFiles(map[string]string{"project.tf": one + " " + two + " " + three + " " + template})
+
+	if files["project.tf"] != "project_web/deploy_app.sh project_web/deploy_app_2.sh project_web/deploy_app_3.sh project_web/deploy_app.tf.json.tmpl" {
+		t.Fatalf("The files must be numbered in order of their keys, got %q", files["project.tf"])
+	}
+
+	if len(files) != 5 || files["scripts/project_web/deploy_app.sh"] != "echo one" ||
+		files["scripts/project_web/deploy_app_2.sh"] != "echo two" || files["scripts/project_web/deploy_app_3.sh"] != "echo three" {
+		t.Fatalf("Files must not be overwritten, found %v", files)
+	}
+}
+
+func TestConvertResourceAfterSkippedConversion(t *testing.T) {
+	collection := ResourceDetailsCollection{}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	skipped := make(chan error)
+
+	// The first converter skips the resource, like a polling target converter given a listening target
+	go func() {
+		skipped <- collection.ConvertResource("Machines-1", "Machines", func() error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+
+	<-started
+
+	converted := make(chan error)
+	go func() {
+		converted <- collection.ConvertResource("Machines-1", "Machines", func() error {
+			collection.AddResource(ResourceDetails{Id: "Machines-1", ResourceType: "Machines"})
+			return nil
+		})
+	}()
+
+	close(release)
+
+	if err := <-skipped; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := <-converted; err != nil {
+		t.Fatal(err)
+	}
+
+	if !collection.HasResource("Machines-1", "Machines") {
+		t.Fatalf("Expected the waiting converter to convert the resource skipped by the first converter")
+	}
+}
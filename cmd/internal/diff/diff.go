@@ -0,0 +1,404 @@
+package diff
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/generators"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
+)
+
+const reportFormatVersion = 1
+
+// contentPath is the path reported when a file can not be parsed as HCL and is compared as text.
+const contentPath = "(content)"
+
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// Report describes the differences between two exports. Resources are matched by their type, name and the name of
+// their parent, because the IDs of equivalent resources are different in each space. Added resources only exist in
+// the right export, and removed resources only exist in the left export.
+type Report struct {
+	FormatVersion int                  `json:"formatVersion"`
+	Added         []ResourceDifference `json:"added"`
+	Removed       []ResourceDifference `json:"removed"`
+	Changed       []ResourceDifference `json:"changed"`
+	Unchanged     int                  `json:"unchanged"`
+}
+
+// ResourceDifference describes a resource that was added, removed or changed.
+type ResourceDifference struct {
+	ResourceType string `json:"resourceType"`
+	Name         string `json:"name"`
+	ParentName   string `json:"parentName,omitempty"`
+	LeftId       string `json:"leftId,omitempty"`
+	RightId      string `json:"rightId,omitempty"`
+	// Attributes lists the attributes in the generated HCL that are different
+	Attributes []AttributeDifference `json:"attributes,omitempty"`
+}
+
+// AttributeDifference describes an attribute in the generated HCL that was added, removed or changed.
+type AttributeDifference struct {
+	Path   string `json:"path"`
+	Change string `json:"change"`
+	Left   string `json:"left,omitempty"`
+	Right  string `json:"right,omitempty"`
+}
+
+// matchedResource is a resource with the names used to match it to the equivalent resource in the other export.
+type matchedResource struct {
+	name       string
+	parentName string
+	resource   data.ResourceDetails
+}
+
+// HasDifferences returns true if any resources were added, removed or changed.
+func (r Report) HasDifferences() bool {
+	return len(r.Added) != 0 || len(r.Removed) != 0 || len(r.Changed) != 0
+}
+
+// Json returns the report as JSON. HTML characters are not escaped, so heredocs are readable.
+func (r Report) Json() ([]byte, error) {
+	buffer := bytes.Buffer{}
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(r); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Text returns a human-readable description of the report.
+func (r Report) Text() string {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("%d added, %d removed, %d changed, %d unchanged\n", len(r.Added), len(r.Removed), len(r.Changed), r.Unchanged))
+
+	for _, resource := range r.Added {
+		builder.WriteString("\n+ " + resource.describe() + "\n")
+	}
+
+	for _, resource := range r.Removed {
+		builder.WriteString("\n- " + resource.describe() + "\n")
+	}
+
+	for _, resource := range r.Changed {
+		builder.WriteString("\n~ " + resource.describe() + "\n")
+
+		for _, attribute := range resource.Attributes {
+			switch attribute.Change {
+			case ChangeAdded:
+				builder.WriteString("    + " + attribute.Path + " = " + indent(attribute.Right) + "\n")
+			case ChangeRemoved:
+				builder.WriteString("    - " + attribute.Path + " = " + indent(attribute.Left) + "\n")
+			default:
+				builder.WriteString("    ~ " + attribute.Path + " = " + indent(attribute.Left) + " -> " + indent(attribute.Right) + "\n")
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+func (r ResourceDifference) describe() string {
+	description := r.ResourceType + " \"" + r.Name + "\""
+
+	if r.ParentName != "" {
+		description += " in \"" + r.ParentName + "\""
+	}
+
+	ids := strings.Join(slices.DeleteFunc([]string{r.LeftId, r.RightId}, func(id string) bool {
+		return id == ""
+	}), " -> ")
+
+	if ids != "" {
+		description += " (" + ids + ")"
+	}
+
+	return description
+}
+
+// indent indents the lines after the first line of multiline values, like heredoc scripts, to line up with the
+// attribute differences.
+func indent(value string) string {
+	return strings.ReplaceAll(value, "\n", "\n      ")
+}
+
+// Compare returns the differences between the resources in the left and right collections.
+func Compare(left *data.ResourceDetailsCollection, right *data.ResourceDetailsCollection) (Report, error) {
+	report := Report{
+		FormatVersion: reportFormatVersion,
+		Added:         []ResourceDifference{},
+		Removed:       []ResourceDifference{},
+		Changed:       []ResourceDifference{},
+	}
+
+	leftResources, err := matchResources(left)
+
+	if err != nil {
+		return report, err
+	}
+
+	rightResources, err := matchResources(right)
+
+	if err != nil {
+		return report, err
+	}
+
+	for _, key := range sortedKeys(leftResources) {
+		leftResource := leftResources[key]
+		rightResource, ok := rightResources[key]
+
+		if !ok {
+			report.Removed = append(report.Removed, newResourceDifference(&leftResource, nil))
+			continue
+		}
+
+		attributes, err := compareHcl(leftResource.resource, rightResource.resource)
+
+		if err != nil {
+			return report, err
+		}
+
+		if len(attributes) == 0 {
+			report.Unchanged++
+			continue
+		}
+
+		difference := newResourceDifference(&leftResource, &rightResource)
+		difference.Attributes = attributes
+		report.Changed = append(report.Changed, difference)
+	}
+
+	for _, key := range sortedKeys(rightResources) {
+		if _, ok := leftResources[key]; !ok {
+			rightResource := rightResources[key]
+			report.Added = append(report.Added, newResourceDifference(nil, &rightResource))
+		}
+	}
+
+	return report, nil
+}
+
+func newResourceDifference(left *matchedResource, right *matchedResource) ResourceDifference {
+	matched := left
+	if matched == nil {
+		matched = right
+	}
+
+	difference := ResourceDifference{
+		ResourceType: matched.resource.ResourceType,
+		Name:         matched.name,
+		ParentName:   matched.parentName,
+	}
+
+	if left != nil {
+		difference.LeftId = left.resource.Id
+	}
+
+	if right != nil {
+		difference.RightId = right.resource.Id
+	}
+
+	return difference
+}
+
+// matchResources maps the resources in the collection to the keys used to match them across exports. Resources are
+// identified by their name, or by the address of their Terraform resource if they have no name. Resources with
+// neither can not be matched, and are ignored.
+func matchResources(collection *data.ResourceDetailsCollection) (map[string]matchedResource, error) {
+	groups := map[string][]matchedResource{}
+	manifestGenerator := generators.ManifestGenerator{}
+
+	for _, resource := range collection.Resources {
+		name := resource.Name
+		if name == "" {
+			name = manifestGenerator.Address(resource)
+		}
+
+		if name == "" {
+			continue
+		}
+
+		parentName := ""
+		if resource.ParentId != "" && resource.ParentId != resource.Id {
+			parentName = getParentName(collection, resource.ParentId)
+		}
+
+		key := strings.ToLower(resource.ResourceType) + "\x00" + parentName + "\x00" + name
+
+		groups[key] = append(groups[key], matchedResource{
+			name:       name,
+			parentName: parentName,
+			resource:   resource,
+		})
+	}
+
+	resources := map[string]matchedResource{}
+	for key, group := range groups {
+		if err := sortDuplicates(group); err != nil {
+			return nil, err
+		}
+
+		resources[key] = group[0]
+		for index, resource := range group[1:] {
+			resources[key+"\x00"+fmt.Sprint(index+2)] = resource
+		}
+	}
+
+	return resources, nil
+}
+
+// sortDuplicates sorts resources that can not be distinguished by name, so they are matched in the same order no matter
+// the order they were exported in. Resources are sorted by a hash of their attributes, so identical resources are
+// matched with each other, and then by their ID.
+func sortDuplicates(resources []matchedResource) error {
+	if len(resources) < 2 {
+		return nil
+	}
+
+	hashes := make([]string, len(resources))
+	for index, resource := range resources {
+		hash, err := hashAttributes(resource.resource)
+
+		if err != nil {
+			return err
+		}
+
+		hashes[index] = hash
+	}
+
+	order := make([]int, len(resources))
+	for index := range order {
+		order[index] = index
+	}
+
+	slices.SortFunc(order, func(a int, b int) int {
+		return cmp.Or(
+			strings.Compare(hashes[a], hashes[b]),
+			strings.Compare(resources[a].resource.Id, resources[b].resource.Id))
+	})
+
+	sorted := make([]matchedResource, 0, len(resources))
+	for _, index := range order {
+		sorted = append(sorted, resources[index])
+	}
+	copy(resources, sorted)
+
+	return nil
+}
+
+// hashAttributes returns a hash of the attribute values in the HCL of the resource. The attribute paths are not
+// hashed, because they include the Terraform labels, which are made unique with the IDs of duplicate resources.
+// Resources without HCL have an empty hash.
+func hashAttributes(resource data.ResourceDetails) (string, error) {
+	if resource.ToHcl == nil {
+		return "", nil
+	}
+
+	attributes, err := getAttributes(resource)
+
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	for _, path := range slices.Sorted(maps.Keys(attributes)) {
+		hash.Write([]byte(attributes[path] + "\x00"))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// getParentName returns the name of the parent resource. The parent ID is not returned if the parent was not
+// exported, as IDs are different in each space.
+func getParentName(collection *data.ResourceDetailsCollection, parentId string) string {
+	for _, parent := range collection.GetResourcesByOctopusId(parentId) {
+		if parent.Name != "" {
+			return parent.Name
+		}
+	}
+
+	return ""
+}
+
+// compareHcl returns the differences between the attributes in the HCL of the resources. Resources without HCL,
+// like those loaded from a manifest, are not compared.
+func compareHcl(left data.ResourceDetails, right data.ResourceDetails) ([]AttributeDifference, error) {
+	if left.ToHcl == nil || right.ToHcl == nil {
+		return nil, nil
+	}
+
+	leftAttributes, err := getAttributes(left)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rightAttributes, err := getAttributes(right)
+
+	if err != nil {
+		return nil, err
+	}
+
+	differences := []AttributeDifference{}
+	for _, path := range slices.Sorted(maps.Keys(leftAttributes)) {
+		rightValue, ok := rightAttributes[path]
+
+		if !ok {
+			differences = append(differences, AttributeDifference{Path: path, Change: ChangeRemoved, Left: leftAttributes[path]})
+		} else if rightValue != leftAttributes[path] {
+			differences = append(differences, AttributeDifference{Path: path, Change: ChangeChanged, Left: leftAttributes[path], Right: rightValue})
+		}
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(rightAttributes)) {
+		if _, ok := leftAttributes[path]; !ok {
+			differences = append(differences, AttributeDifference{Path: path, Change: ChangeAdded, Right: rightAttributes[path]})
+		}
+	}
+
+	sort.SliceStable(differences, func(i, j int) bool {
+		return differences[i].Path < differences[j].Path
+	})
+
+	return differences, nil
+}
+
+// getAttributes returns the attributes in the HCL of the resource. Files that are not native HCL, like .tf.json
+// files, are compared as text.
+func getAttributes(resource data.ResourceDetails) (map[string]string, error) {
+	content, err := resource.ToHcl()
+
+	if err != nil {
+		return nil, err
+	}
+
+	attributes, err := hcl.FlattenAttributes(resource.FileName, content)
+
+	if err != nil {
+		return map[string]string{contentPath: strings.TrimSpace(content)}, nil
+	}
+
+	return attributes, nil
+}
+
+func sortedKeys(resources map[string]matchedResource) []string {
+	return slices.Sorted(maps.Keys(resources))
+}
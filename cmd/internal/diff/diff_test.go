@@ -0,0 +1,179 @@
+package diff
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/generators"
+)
+
+func buildManifest(projectId string, environmentIds ...string) generators.ExportManifest {
+	manifest := generators.ExportManifest{
+		Resources: []generators.ExportManifestEntry{
+			{Id: projectId, ResourceType: "Projects", Name: "Web", FileName: "space_population/project_web.tf"},
+			{Id: "Channels-" + projectId, ResourceType: "Channels", Name: "Default", ParentId: projectId, FileName: "space_population/channel_default.tf"},
+		},
+	}
+
+	for _, id := range environmentIds {
+		manifest.Resources = append(manifest.Resources, generators.ExportManifestEntry{
+			Id:           id,
+			ResourceType: "Environments",
+			Name:         "Environment " + id,
+			FileName:     "space_population/environment_" + id + ".tf",
+		})
+	}
+
+	return manifest
+}
+
+func TestCompare(t *testing.T) {
+	left := NewCollection(buildManifest("Projects-1", "Environments-1"), map[string]string{
+		"space_population/project_web.tf":                "resource \"octopusdeploy_project\" \"project_web\" {\n  name = \"Web\"\n  description = \"old\"\n}\n",
+		"space_population/channel_default.tf":            "resource \"octopusdeploy_channel\" \"channel_default\" {\n  name = \"Default\"\n}\n",
+		"space_population/environment_Environments-1.tf": "resource \"octopusdeploy_environment\" \"environment\" {\n  name = \"Environment\"\n}\n",
+	})
+
+	right := NewCollection(buildManifest("Projects-2", "Environments-2"), map[string]string{
+		"space_population/project_web.tf":                "resource \"octopusdeploy_project\" \"project_web\" {\n  name = \"Web\"\n  description = \"new\"\n  tenanted = true\n}\n",
+		"space_population/channel_default.tf":            "resource \"octopusdeploy_channel\" \"channel_default\" {\n  name = \"Default\"\n}\n",
+		"space_population/environment_Environments-2.tf": "resource \"octopusdeploy_environment\" \"environment\" {\n  name = \"Environment\"\n}\n",
+	})
+
+	report, err := Compare(left, right)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !report.HasDifferences() || report.Unchanged != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	if len(report.Added) != 1 || report.Added[0].Name != "Environment Environments-2" || report.Added[0].RightId != "Environments-2" {
+		t.Fatalf("unexpected added resources %+v", report.Added)
+	}
+
+	if len(report.Removed) != 1 || report.Removed[0].Name != "Environment Environments-1" || report.Removed[0].LeftId != "Environments-1" {
+		t.Fatalf("unexpected removed resources %+v", report.Removed)
+	}
+
+	if len(report.Changed) != 1 || report.Changed[0].Name != "Web" || report.Changed[0].LeftId != "Projects-1" || report.Changed[0].RightId != "Projects-2" {
+		t.Fatalf("unexpected changed resources %+v", report.Changed)
+	}
+
+	expected := []AttributeDifference{
+		{Path: "resource.octopusdeploy_project.project_web.description", Change: ChangeChanged, Left: `"old"`, Right: `"new"`},
+		{Path: "resource.octopusdeploy_project.project_web.tenanted", Change: ChangeAdded, Right: "true"},
+	}
+
+	if len(report.Changed[0].Attributes) != len(expected) {
+		t.Fatalf("unexpected attributes %+v", report.Changed[0].Attributes)
+	}
+
+	for index, attribute := range expected {
+		if report.Changed[0].Attributes[index] != attribute {
+			t.Fatalf("unexpected attribute %+v", report.Changed[0].Attributes[index])
+		}
+	}
+
+	text := report.Text()
+
+	if !strings.HasPrefix(text, "1 added, 1 removed, 1 changed, 1 unchanged\n") ||
+		!strings.Contains(text, `~ resource.octopusdeploy_project.project_web.description = "old" -> "new"`) {
+		t.Fatalf("unexpected text report %q", text)
+	}
+}
+
+func TestCompareManifestOnly(t *testing.T) {
+	report, err := Compare(NewCollection(buildManifest("Projects-1"), nil), NewCollection(buildManifest("Projects-2"), nil))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.HasDifferences() || report.Unchanged != 2 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+}
+
+func TestLoadExportDirectory(t *testing.T) {
+	directory := t.TempDir()
+	manifest, err := generators.ManifestGenerator{}.Marshal(buildManifest("Projects-1"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		generators.ManifestFileName:           string(manifest),
+		"space_population/project_web.tf":     "resource \"octopusdeploy_project\" \"project_web\" {\n  name = \"Web\"\n}\n",
+		"space_population/channel_default.tf": "resource \"octopusdeploy_channel\" \"channel_default\" {\n  name = \"Default\"\n}\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(directory, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	collection, err := LoadExportDirectory(directory)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(collection.Resources) != 2 || collection.Resources[0].ToHcl == nil {
+		t.Fatalf("unexpected resources %+v", collection.Resources)
+	}
+
+	hcl, err := collection.Resources[0].ToHcl()
+
+	if err != nil || hcl != files["space_population/project_web.tf"] {
+		t.Fatalf("unexpected HCL %q %v", hcl, err)
+	}
+}
+
+func TestCompareDuplicateNames(t *testing.T) {
+	buildDuplicates := func(ids ...string) generators.ExportManifest {
+		manifest := generators.ExportManifest{}
+		for _, id := range ids {
+			manifest.Resources = append(manifest.Resources, generators.ExportManifestEntry{
+				Id:           id,
+				ResourceType: "Projects",
+				Name:         "Web",
+				FileName:     "space_population/project_" + id + ".tf",
+			})
+		}
+		return manifest
+	}
+
+	left := NewCollection(buildDuplicates("Projects-1", "Projects-2"), map[string]string{
+		"space_population/project_Projects-1.tf": "resource \"octopusdeploy_project\" \"project_web\" {\n  name = \"Web\"\n  description = \"first\"\n}\n",
+		"space_population/project_Projects-2.tf": "resource \"octopusdeploy_project\" \"project_web_2\" {\n  name = \"Web\"\n  description = \"second\"\n}\n",
+	})
+
+	// The duplicates are exported in a different order, and the order of their IDs does not match the left export
+	right := NewCollection(buildDuplicates("Projects-6", "Projects-5"), map[string]string{
+		"space_population/project_Projects-5.tf": "resource \"octopusdeploy_project\" \"project_web_2\" {\n  name = \"Web\"\n  description = \"second\"\n}\n",
+		"space_population/project_Projects-6.tf": "resource \"octopusdeploy_project\" \"project_web\" {\n  name = \"Web\"\n  description = \"first\"\n}\n",
+	})
+
+	report, err := Compare(left, right)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.HasDifferences() || report.Unchanged != 2 {
+		t.Fatalf("expected the identical duplicates to be matched, found %+v", report)
+	}
+}
@@ -0,0 +1,164 @@
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/export"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/generators"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/pkg/octoterra"
+	"go.uber.org/zap"
+)
+
+// LoadSource loads one side of a comparison. The source is one of:
+//   - a directory holding a previous export, including the export_manifest.json file
+//   - an export_manifest.json file, in which case resources can be added or removed, but not changed
+//   - a snapshot archive created with the -recordSnapshot argument, which is exported with the arguments
+//   - the ID of a space, which is exported from the Octopus instance defined in the arguments
+func LoadSource(ctx context.Context, source string, arguments args.Arguments, version string) (*data.ResourceDetailsCollection, error) {
+	info, err := os.Stat(source)
+
+	if err == nil && info.IsDir() {
+		zap.L().Info("Loading the export in " + source)
+		return LoadExportDirectory(source)
+	}
+
+	if err == nil && strings.EqualFold(filepath.Ext(source), ".json") {
+		zap.L().Info("Loading the export manifest " + source)
+		manifest, err := loadManifest(source)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return NewCollection(manifest, nil), nil
+	}
+
+	if err == nil {
+		snapshot, err := client.LoadSnapshot(source)
+
+		if err != nil {
+			return nil, err
+		}
+
+		arguments.ReplaySnapshot = source
+		arguments.Space = snapshot.Space
+	} else if strings.ContainsAny(source, `/\`) {
+		return nil, errors.New("the export " + source + " does not exist")
+	} else if arguments.Url == "" || (arguments.ApiKey == "" && arguments.AccessToken == "") {
+		return nil, errors.New("exporting the space " + source + " requires the -url and -apiKey arguments")
+	} else {
+		arguments.Space = source
+	}
+
+	zap.L().Info("Exporting " + source)
+
+	return exportSource(ctx, arguments, version, nil)
+}
+
+// exportSource exports a space with the client, or with a client created from the arguments if the client is nil.
+func exportSource(ctx context.Context, arguments args.Arguments, version string, octopusClient octoterra.OctopusClient) (*data.ResourceDetailsCollection, error) {
+	if arguments.Stateless {
+		return nil, errors.New("stateless exports can not be compared")
+	}
+
+	// Dummy secrets are generated for every export, so they would be reported as changes
+	arguments.DummySecretVariableValues = false
+	// The comparison is done on the native HCL syntax
+	arguments.OutputFormat = "hcl"
+
+	// The exported files are unescaped the same way as the files saved by an export, so they can be compared with an
+	// export loaded from a directory
+	files, manifest, err := export.Export(ctx, export.Options{
+		Arguments: arguments,
+		Version:   version,
+		Client:    octopusClient,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCollection(manifest, files), nil
+}
+
+// LoadExportDirectory loads an export that was written to a directory.
+func LoadExportDirectory(directory string) (*data.ResourceDetailsCollection, error) {
+	manifest, err := loadManifest(filepath.Join(directory, generators.ManifestFileName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]string{}
+	for _, resource := range manifest.Resources {
+		if resource.FileName == "" {
+			continue
+		}
+
+		if _, ok := files[resource.FileName]; ok {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(directory, filepath.FromSlash(resource.FileName)))
+
+		if err != nil {
+			return nil, err
+		}
+
+		files[resource.FileName] = string(content)
+	}
+
+	return NewCollection(manifest, files), nil
+}
+
+// NewCollection converts an export into a resource collection. The HCL of each resource is the content of the file
+// it was written to. The files may be nil if only the manifest is available, in which case the resources have no HCL.
+func NewCollection(manifest generators.ExportManifest, files map[string]string) *data.ResourceDetailsCollection {
+	collection := data.ResourceDetailsCollection{}
+
+	for _, manifestEntry := range manifest.Resources {
+		resource := data.ResourceDetails{
+			Id:                manifestEntry.Id,
+			AlternateId:       manifestEntry.AlternateId,
+			ParentId:          manifestEntry.ParentId,
+			ImmediateParentId: manifestEntry.ImmediateParentId,
+			Name:              manifestEntry.Name,
+			FileName:          manifestEntry.FileName,
+			ResourceType:      manifestEntry.ResourceType,
+			Lookup:            manifestEntry.Lookup,
+		}
+
+		if content, ok := files[manifestEntry.FileName]; ok {
+			resource.ToHcl = func() (string, error) {
+				return content, nil
+			}
+		}
+
+		collection.AddResource(resource)
+	}
+
+	return &collection
+}
+
+func loadManifest(path string) (generators.ExportManifest, error) {
+	manifest := generators.ExportManifest{}
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return manifest, err
+	}
+
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return manifest, errors.Join(errors.New("failed to parse the export manifest "+path), err)
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,144 @@
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/export"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/pkg/octoterra"
+)
+
+// fakeClient serves a space holding the collections, and returns nothing for any other request.
+type fakeClient struct {
+	collections map[string]string
+}
+
+func (c fakeClient) GetSpaceBaseUrl() (string, error) {
+	return "https://example.octopus.app/api/Spaces-1", nil
+}
+
+func (c fakeClient) GetSpace(resources *octopus.Space) error {
+	resources.Id = "Spaces-1"
+	resources.Name = "Default"
+	return nil
+}
+
+func (c fakeClient) GetSpaces() ([]octopus.Space, error) {
+	return []octopus.Space{{Id: "Spaces-1", Name: "Default"}}, nil
+}
+
+func (c fakeClient) EnsureSpaceDeleted(spaceId string) (bool, error) {
+	return false, nil
+}
+
+func (c fakeClient) GetResource(resourceType string, resources any) (bool, error) {
+	return false, nil
+}
+
+func (c fakeClient) GetResourceById(resourceType string, id string, resources any) error {
+	return nil
+}
+
+func (c fakeClient) GetResourceByName(resourceType string, name string, resources any) (bool, error) {
+	return false, nil
+}
+
+func (c fakeClient) GetSpaceResourceById(resourceType string, id string, resources any) (bool, error) {
+	return false, nil
+}
+
+func (c fakeClient) GetGlobalResourceById(resourceType string, id string, resources any) (bool, error) {
+	return false, nil
+}
+
+func (c fakeClient) GetResourceNameById(resourceType string, id string) (string, error) {
+	return "", nil
+}
+
+func (c fakeClient) GetResourceNamesByIds(resourceType string, id []string) ([]string, error) {
+	return []string{}, nil
+}
+
+func (c fakeClient) GetAllResources(resourceType string, resources any, queryParams ...[]string) error {
+	collection, ok := c.collections[resourceType]
+
+	// Only the first page holds any resources
+	for _, param := range queryParams {
+		if len(param) == 2 && param[0] == "skip" && param[1] != "0" {
+			ok = false
+		}
+	}
+
+	if !ok {
+		return nil
+	}
+
+	return json.Unmarshal([]byte(collection), resources)
+}
+
+func (c fakeClient) GetAllGlobalResources(resourceType string, resources any, queryParams ...[]string) error {
+	return nil
+}
+
+func TestExportSourceMatchesWrittenExport(t *testing.T) {
+	octopusClient := fakeClient{collections: map[string]string{
+		"Environments": `{"Items": [
+			{"Id": "Environments-1", "Name": "Production", "Description": "${var.environment} #{Octopus.Space.Name}"},
+			{"Id": "Environments-2", "Name": "Test", "Description": "${var.environment}"}
+		]}`,
+	}}
+	arguments := args.Arguments{Space: "Spaces-1"}
+
+	live, err := exportSource(context.Background(), arguments, "", octopusClient)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	directory := t.TempDir()
+	if _, _, err := export.Export(context.Background(), export.Options{
+		Arguments: arguments,
+		Client:    octopusClient,
+		Writer:    octoterra.NewFileWriter(directory),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := LoadExportDirectory(directory)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	environments := live.GetResourcesByOctopusId("Environments-2")
+	if len(environments) != 1 || environments[0].ToHcl == nil {
+		t.Fatalf("unexpected environments %+v", live.GetAllResource("Environments"))
+	}
+
+	environment := environments[0]
+
+	hcl, err := environment.ToHcl()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Strings holding only an interpolation are unescaped before the files are written
+	if !strings.Contains(hcl, "\"${var.environment}\"") {
+		t.Fatalf("the live export must be unescaped: %s", hcl)
+	}
+
+	report, err := Compare(written, live)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.HasDifferences() {
+		t.Fatalf("unexpected differences %s", report.Text())
+	}
+}
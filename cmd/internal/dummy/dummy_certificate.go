@@ -0,0 +1,266 @@
+package dummy
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"io"
+	"math/big"
+	"math/rand/v2"
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// octopusDateFormats are the formats used by the Octopus API to serialize the certificate dates
+var octopusDateFormats = []string{
+	"2006-01-02T15:04:05.000Z07:00",
+	time.RFC3339Nano,
+}
+
+// defaultCertificateLifetime is how long a dummy certificate is valid for when the original expiry is not known
+const defaultCertificateLifetime = 365 * 24 * time.Hour
+
+// GetDummyCertificate generates a self-signed certificate with the subject, subject alternative names and
+// validity period of the supplied certificate, protected by a password. Each certificate ID has its own key, so the
+// thumbprints of the dummy certificates are unique.
+//
+// The key, serial number and password are derived from the certificate ID, so exporting the same certificate again
+// generates the same dummy certificate. This means the values can be recreated by anyone who knows the ID, which is
+// acceptable for placeholders that are expected to be replaced with the real certificate.
+func (e DummySecret) GetDummyCertificate(certificate octopus.Certificate) (*string, *string, error) {
+	random := seededRandom(certificate.Id)
+	password := generatePassword(random)
+	data, err := e.generateCertificate(random, certificate, password)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return data, &password, nil
+}
+
+// GetDummyCertificateNoPass generates a self-signed certificate issued to the name, for resources like Azure
+// management certificates that do not accept a password. Like GetDummyCertificate, the certificate is derived from
+// the ID of the resource.
+func (e DummySecret) GetDummyCertificateNoPass(id string, name string) (*string, error) {
+	return e.generateCertificate(seededRandom(id), octopus.Certificate{Id: id, Name: name}, "")
+}
+
+// seededRandom returns a stream of bytes derived from the resource ID.
+func seededRandom(id string) io.Reader {
+	return rand.NewChaCha8(sha256.Sum256([]byte("octoterra dummy certificate\x00" + id)))
+}
+
+// generatePassword returns a password read from the random stream, in the same format as crypto/rand.Text.
+func generatePassword(random io.Reader) string {
+	password := make([]byte, 16)
+	// ChaCha8 never fails to read
+	_, _ = io.ReadFull(random, password)
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(password)
+}
+
+// generateCertificate returns a base64 encoded PKCS#12 file holding a key and a self-signed certificate built from
+// the supplied certificate, protected by the password. Every random value is read from the random stream.
+func (e DummySecret) generateCertificate(random io.Reader, certificate octopus.Certificate, password string) (*string, error) {
+	key, err := generateKey(random)
+
+	if err != nil {
+		return nil, err
+	}
+
+	serialBytes := make([]byte, 16)
+	if _, err := io.ReadFull(random, serialBytes); err != nil {
+		return nil, err
+	}
+	serialNumber := new(big.Int).SetBytes(serialBytes)
+
+	notBefore := parseOctopusDate(certificate.NotBefore, e.getIssuedAt().Add(-time.Hour))
+	notAfter := parseOctopusDate(certificate.NotAfter, notBefore.Add(defaultCertificateLifetime))
+
+	// Expired certificates may not report when they became valid
+	if notAfter.Before(notBefore) {
+		notBefore = notAfter.Add(-defaultCertificateLifetime)
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               getSubject(certificate),
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	addSubjectAlternativeNames(&template, certificate.SubjectAlternativeNames)
+
+	der, err := x509.CreateCertificate(random, &template, &template, &key.PublicKey, key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pfx, err := pkcs12.Modern.WithRand(random).Encode(key, cert, nil, password)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := base64.StdEncoding.EncodeToString(pfx)
+	return &data, nil
+}
+
+// getIssuedAt returns the time the dummy certificates are issued at when the original date is not known.
+func (e DummySecret) getIssuedAt() time.Time {
+	if e.IssuedAt.IsZero() {
+		return time.Now()
+	}
+
+	return e.IssuedAt
+}
+
+// generateKey returns a 2048 bit RSA key built from primes read from the random stream. The rsa package always reads
+// from its own secure source of randomness, so it can not generate the same key twice.
+func generateKey(random io.Reader) (*rsa.PrivateKey, error) {
+	exponent := big.NewInt(65537)
+	one := big.NewInt(1)
+
+	for {
+		p, err := generatePrime(random, 1024, exponent)
+		if err != nil {
+			return nil, err
+		}
+
+		q, err := generatePrime(random, 1024, exponent)
+		if err != nil {
+			return nil, err
+		}
+
+		modulus := new(big.Int).Mul(p, q)
+		if p.Cmp(q) == 0 || modulus.BitLen() != 2048 {
+			continue
+		}
+
+		totient := new(big.Int).Mul(new(big.Int).Sub(p, one), new(big.Int).Sub(q, one))
+		privateExponent := new(big.Int).ModInverse(exponent, totient)
+		if privateExponent == nil {
+			continue
+		}
+
+		key := &rsa.PrivateKey{
+			PublicKey: rsa.PublicKey{N: modulus, E: int(exponent.Int64())},
+			D:         privateExponent,
+			Primes:    []*big.Int{p, q},
+		}
+		key.Precompute()
+
+		if err := key.Validate(); err != nil {
+			return nil, err
+		}
+
+		return key, nil
+	}
+}
+
+// generatePrime returns a prime of the bit length read from the random stream, where the prime minus one is coprime
+// with the exponent.
+func generatePrime(random io.Reader, bits int, exponent *big.Int) (*big.Int, error) {
+	if bits%8 != 0 {
+		return nil, errors.New("the prime length must be a multiple of 8 bits")
+	}
+
+	candidate := make([]byte, bits/8)
+	one := big.NewInt(1)
+
+	for {
+		if _, err := io.ReadFull(random, candidate); err != nil {
+			return nil, err
+		}
+
+		// Setting the top two bits means the product of two primes has the full length, and the prime must be odd
+		candidate[0] |= 0xc0
+		candidate[len(candidate)-1] |= 1
+
+		prime := new(big.Int).SetBytes(candidate)
+		if !prime.ProbablyPrime(20) {
+			continue
+		}
+
+		if new(big.Int).GCD(nil, nil, exponent, new(big.Int).Sub(prime, one)).Cmp(one) == 0 {
+			return prime, nil
+		}
+	}
+}
+
+// getSubject returns the subject of the dummy certificate, falling back to the certificate name if the common name
+// is not known.
+func getSubject(certificate octopus.Certificate) pkix.Name {
+	subject := pkix.Name{
+		CommonName: strings.TrimSpace(certificate.SubjectCommonName),
+	}
+
+	if subject.CommonName == "" {
+		subject.CommonName = certificate.Name
+	}
+
+	if strings.TrimSpace(certificate.SubjectOrganization) != "" {
+		subject.Organization = []string{strings.TrimSpace(certificate.SubjectOrganization)}
+	}
+
+	return subject
+}
+
+// addSubjectAlternativeNames adds the subject alternative names to the certificate template. The Octopus API
+// returns names like "DNS Name=example.org", so any type prefix is removed and the type is determined from the
+// value itself.
+func addSubjectAlternativeNames(template *x509.Certificate, names []string) {
+	for _, name := range names {
+		value := strings.TrimSpace(name)
+
+		if prefix, after, found := strings.Cut(value, "="); found && !strings.ContainsAny(prefix, ":/") {
+			value = strings.TrimSpace(after)
+		}
+
+		if value == "" {
+			continue
+		}
+
+		if ip := net.ParseIP(value); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else if address, err := mail.ParseAddress(value); err == nil && address.Address == value {
+			template.EmailAddresses = append(template.EmailAddresses, value)
+		} else if uri, err := url.Parse(value); err == nil && uri.Scheme != "" && uri.Host != "" {
+			template.URIs = append(template.URIs, uri)
+		} else {
+			template.DNSNames = append(template.DNSNames, value)
+		}
+	}
+}
+
+// parseOctopusDate parses a date returned by the Octopus API, returning the default value if the date is empty or
+// can not be parsed.
+func parseOctopusDate(date string, defaultValue time.Time) time.Time {
+	for _, format := range octopusDateFormats {
+		if parsed, err := time.Parse(format, strings.TrimSpace(date)); err == nil {
+			return parsed
+		}
+	}
+
+	return defaultValue
+}
@@ -0,0 +1,114 @@
+package dummy
+
+import (
+	"encoding/base64"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+func TestGetDummyCertificate(t *testing.T) {
+	certificate := octopus.Certificate{
+		Name:                    "Test",
+		SubjectCommonName:       "example.org",
+		SubjectOrganization:     "Example",
+		SubjectAlternativeNames: []string{"DNS Name=www.example.org", "IP Address=10.0.0.1"},
+		NotAfter:                "2040-01-02T03:04:05.000+00:00",
+	}
+
+	data, password, err := DummySecret{}.GetDummyCertificate(certificate)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pfx, err := base64.StdEncoding.DecodeString(*data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, cert, err := pkcs12.Decode(pfx, *password)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cert.Subject.CommonName != "example.org" || !slices.Equal(cert.Subject.Organization, []string{"Example"}) {
+		t.Fatalf("unexpected subject %s", cert.Subject)
+	}
+
+	if !slices.Equal(cert.DNSNames, []string{"www.example.org"}) || len(cert.IPAddresses) != 1 || cert.IPAddresses[0].String() != "10.0.0.1" {
+		t.Fatalf("unexpected subject alternative names %v %v", cert.DNSNames, cert.IPAddresses)
+	}
+
+	if !cert.NotAfter.Equal(time.Date(2040, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Fatalf("unexpected expiry %s", cert.NotAfter)
+	}
+}
+
+func TestGetDummyCertificateIsUnique(t *testing.T) {
+	firstData, firstPassword, err := DummySecret{}.GetDummyCertificate(octopus.Certificate{Id: "Certificates-1", Name: "Test"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secondData, secondPassword, err := DummySecret{}.GetDummyCertificate(octopus.Certificate{Id: "Certificates-2", Name: "Test"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *firstData == *secondData || *firstPassword == *secondPassword {
+		t.Fatal("expected each dummy certificate to have a unique key and password")
+	}
+}
+
+func TestGetDummyCertificateIsReproducible(t *testing.T) {
+	generator := DummySecret{IssuedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	certificate := octopus.Certificate{Id: "Certificates-1", Name: "Test"}
+
+	firstData, firstPassword, err := generator.GetDummyCertificate(certificate)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secondData, secondPassword, err := generator.GetDummyCertificate(certificate)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *firstData != *secondData || *firstPassword != *secondPassword {
+		t.Fatal("expected the dummy certificate generated for an ID to be the same every time")
+	}
+}
+
+func TestGetDummyCertificateNoPass(t *testing.T) {
+	data, err := DummySecret{}.GetDummyCertificateNoPass("Accounts-1", "Azure Account")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pfx, err := base64.StdEncoding.DecodeString(*data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, cert, err := pkcs12.Decode(pfx, "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cert.Subject.CommonName != "Azure Account" {
+		t.Fatalf("unexpected subject %s", cert.Subject)
+	}
+}
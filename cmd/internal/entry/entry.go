@@ -1,11 +1,16 @@
 package entry
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"maps"
 	"os"
 	"runtime/pprof"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
@@ -14,7 +19,9 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/dummy"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/generators"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/secrets"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/variables"
 	"github.com/samber/lo"
@@ -24,39 +31,53 @@ import (
 
 // Entry takes the arguments, exports the Octopus resources to HCL in strings and returns the strings mapped to file names.
 func Entry(parseArgs args.Arguments, version string) (map[string]string, error) {
+	files, _, err := EntryWithClient(context.Background(), parseArgs, nil, version, nil, nil)
+	return files, err
+}
+
+// EntryWithClient exports the Octopus resources with the supplied client, returning the strings mapped to file names
+// and the manifest describing the exported resources. The manifest is nil for stateless exports. When octopusClient
+// is nil, a client is created from the arguments. Requests to the Octopus API stop once the context is done or the
+// export timeout defined in the arguments is exceeded. The logger, which defaults to the global logger when nil,
+// receives the messages describing the export as a whole.
+func EntryWithClient(ctx context.Context, parseArgs args.Arguments, octopusClient client.OctopusClient, version string, progress ProgressFunc, logger *zap.Logger) (map[string]string, *generators.ExportManifest, error) {
+	if logger == nil {
+		logger = zap.L()
+	}
+
+	if parseArgs.ExportTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(parseArgs.ExportTimeout)*time.Second)
+		defer cancel()
+	}
 
 	if parseArgs.Profiling {
 		f, err := os.Create("octoterra.prof")
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		err = pprof.StartCPUProfile(f)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		defer pprof.StopCPUProfile()
 	}
 
+	octopusClient, snapshot, err := createOctopusClient(ctx, logger, parseArgs, octopusClient, version)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if len(parseArgs.ProjectName) != 0 {
 
 		projectIds := []string{}
 
 		for _, project := range parseArgs.ProjectName {
-			projectId, err := ConvertProjectNameToId(
-				parseArgs.Url,
-				parseArgs.Space,
-				parseArgs.ApiKey,
-				parseArgs.AccessToken,
-				project,
-				version,
-				parseArgs.UseRedirector,
-				parseArgs.RedirectorHost,
-				parseArgs.RedirectorServiceApiKey,
-				parseArgs.RedirecrtorApiKey,
-				parseArgs.RedirectorRedirections)
+			projectId, err := convertProjectNameToIdWithClient(octopusClient, parseArgs.Space, project)
 
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			projectIds = append(projectIds, projectId)
@@ -66,91 +87,297 @@ func Entry(parseArgs args.Arguments, version string) (map[string]string, error)
 	}
 
 	if parseArgs.RunbookName != "" {
-		runbookId, err := ConvertRunbookNameToId(
-			parseArgs.Url,
-			parseArgs.Space,
-			parseArgs.ApiKey,
-			parseArgs.AccessToken,
-			parseArgs.ProjectId[0],
-			parseArgs.RunbookName,
-			version,
-			parseArgs.UseRedirector,
-			parseArgs.RedirectorHost,
-			parseArgs.RedirectorServiceApiKey,
-			parseArgs.RedirecrtorApiKey,
-			parseArgs.RedirectorRedirections)
+		runbookId, err := convertRunbookNameToIdWithClient(octopusClient, parseArgs.Space, parseArgs.ProjectId[0], parseArgs.RunbookName)
 
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		parseArgs.RunbookId = runbookId
 	}
 
-	dependencies, err := getDependencies(parseArgs, version)
+	progress.report(ProgressStageLoading, 0, 0)
+
+	dependencies, err := getDependencies(ctx, logger, parseArgs, octopusClient)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	var files map[string]string
+	var manifest *generators.ExportManifest
+
 	if parseArgs.Stateless {
 		templateGenerator := generators.StepTemplateGenerator{}
+
+		if parseArgs.ExportTimestamp != "" {
+			// Invalid timestamps are rejected by Arguments.Validate
+			templateGenerator.ExportedAt, _ = time.Parse(time.RFC3339, parseArgs.ExportTimestamp)
+		}
+
 		templateContent, err := templateGenerator.Generate(dependencies, parseArgs.StepTemplateName, parseArgs.StepTemplateKey, parseArgs.StepTemplateDescription)
 
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		return map[string]string{"step_template.json": string(templateContent[:])}, nil
+		files = map[string]string{"step_template.json": string(templateContent[:])}
 	} else {
-		files, err := ProcessResources(dependencies.Resources)
+		if parseArgs.GenerateImportBlocks {
+			generators.ImportBlockGenerator{}.AddImportBlocks(dependencies)
+		}
+
+		files, err = ProcessResourcesWithContext(ctx, logger, dependencies.Resources, progress)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Files like extracted scripts are generated alongside the resources
+		files = dependencies.ResolveFiles(files)
+
+		if parseArgs.OutputFormat == "json" {
+			files, err = convertFilesToJson(files)
+
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		manifestGenerator := generators.ManifestGenerator{JsonOutput: parseArgs.OutputFormat == "json"}
+		exportManifest := manifestGenerator.Build(dependencies)
+		manifest = &exportManifest
+
+		manifestJson, err := manifestGenerator.Marshal(exportManifest)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		files[generators.ManifestFileName] = string(manifestJson)
+
+		if len(dependencies.SecretValues) != 0 {
+			files[generators.SecretsFileName] = generators.SecretsGenerator{}.Generate(dependencies)
+		}
+
+		if len(dependencies.SecretVariables) != 0 {
+			inventoryGenerator := generators.SecretsInventoryGenerator{}
+			inventory := inventoryGenerator.Build(dependencies)
+
+			inventoryJson, err := inventoryGenerator.Json(inventory)
+
+			if err != nil {
+				return nil, nil, err
+			}
+
+			inventoryCsv, err := inventoryGenerator.Csv(inventory)
+
+			if err != nil {
+				return nil, nil, err
+			}
+
+			files[generators.SecretsInventoryJsonFileName] = string(inventoryJson)
+			files[generators.SecretsInventoryCsvFileName] = inventoryCsv
+			files[generators.SecretsExampleFileName] = inventoryGenerator.Example(inventory)
+
+			logSecretsInventory(logger, inventory)
+		}
+
+		if len(dependencies.LintFindings) != 0 {
+			lintGenerator := generators.LintReportGenerator{}
+			report := lintGenerator.Build(dependencies)
+
+			reportJson, err := lintGenerator.Json(report)
+
+			if err != nil {
+				return nil, nil, err
+			}
+
+			files[generators.LintReportFileName] = string(reportJson)
+
+			logger.Warn(fmt.Sprintf("The export found %d errors, %d lossy conversions and %d warnings, which are listed in %s",
+				report.Errors, report.Lossy, report.Warnings, generators.LintReportFileName))
+		}
+
+		if parseArgs.Explain != "" {
+			explanation := generators.ExplainGenerator{}.Generate(dependencies, parseArgs.Explain)
+
+			logger.Info(explanation)
+			files[generators.ExplainFileName] = explanation
+		}
+	}
+
+	if parseArgs.Checksums {
+		// The checksums match the files as they are saved, after the dollar signs are unescaped
+		files[generators.ChecksumsFileName] = generators.ChecksumsGenerator{}.Generate(strutil.UnEscapeDollarInMap(maps.Clone(files)))
+	}
+
+	if snapshot != nil {
+		logger.Info("Saving " + fmt.Sprint(snapshot.Len()) + " API responses to the snapshot " + parseArgs.RecordSnapshot)
+		if err := snapshot.Save(parseArgs.RecordSnapshot); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	progress.report(ProgressStageComplete, len(files), len(files))
+
+	return files, manifest, nil
+}
+
+// convertFilesToJson replaces the .tf files with the equivalent .tf.json files. The dollar signs in the HCL are
+// unescaped before the conversion, and the JSON files are excluded from any further unescaping.
+func convertFilesToJson(files map[string]string) (map[string]string, error) {
+	jsonFiles := map[string]string{}
+
+	for filename, contents := range files {
+		if !strings.HasSuffix(filename, ".tf") {
+			jsonFiles[filename] = contents
+			continue
+		}
+
+		jsonContents, err := hcl.ConvertToJson(filename, strutil.UnEscapeDollar(contents))
 
 		if err != nil {
 			return nil, err
 		}
 
-		dummyLogs := logDummyValues(dependencies)
+		jsonFiles[filename+".json"] = jsonContents
+	}
 
-		zap.L().Info(dummyLogs)
-		files["dummy_values.txt"] = dummyLogs
+	return jsonFiles, nil
+}
 
-		return files, nil
+// NewOctopusClient creates a client that queries the Octopus API.
+func NewOctopusClient(args args.Arguments, version string) *client.OctopusApiClient {
+	return &client.OctopusApiClient{
+		Url:                     args.Url,
+		ApiKey:                  args.ApiKey,
+		AccessToken:             args.AccessToken,
+		Space:                   args.Space,
+		Version:                 version,
+		UseRedirector:           args.UseRedirector,
+		RedirectorHost:          args.RedirectorHost,
+		RedirectorServiceApiKey: args.RedirectorServiceApiKey,
+		RedirecrtorApiKey:       args.RedirecrtorApiKey,
+		RedirectorRedirections:  args.RedirectorRedirections,
+		IgnoreUnauthorized:      args.IgnoreUnauthorized,
+		IgnoreServerError:       args.IgnoreServerError,
+		RequestTimeout:          time.Duration(args.RequestTimeout) * time.Second,
+	}
+}
+
+// createOctopusClient returns the client used by an export. When replaying a snapshot, the client serves the
+// responses from the snapshot archive. When recording a snapshot, the returned snapshot captures the API responses
+// and must be saved once the export is complete. Otherwise, the supplied client is used, or a new client is created
+// from the arguments if no client was supplied. The returned client stops making requests once the context is done.
+func createOctopusClient(ctx context.Context, logger *zap.Logger, parseArgs args.Arguments, octopusClient client.OctopusClient, version string) (client.OctopusClient, *client.Snapshot, error) {
+	if parseArgs.ReplaySnapshot != "" {
+		snapshot, err := client.LoadSnapshot(parseArgs.ReplaySnapshot)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		logger.Info("Replaying " + fmt.Sprint(snapshot.Len()) + " API responses from the snapshot " + parseArgs.ReplaySnapshot)
+
+		return &client.ContextOctopusClient{Client: &client.ReplayOctopusClient{Snapshot: snapshot}, Context: ctx}, nil, nil
+	}
+
+	if octopusClient == nil {
+		apiClient := NewOctopusClient(parseArgs, version)
+		apiClient.Context = ctx
+		octopusClient = apiClient
+	}
+
+	if parseArgs.RecordSnapshot != "" {
+		snapshot := client.NewSnapshot(parseArgs.Space)
+		return &client.ContextOctopusClient{
+			Client: &client.RecordingOctopusClient{
+				Client:   octopusClient,
+				Snapshot: snapshot,
+			},
+			Context: ctx,
+		}, snapshot, nil
 	}
+
+	return &client.ContextOctopusClient{Client: octopusClient, Context: ctx}, nil, nil
 }
 
-func logDummyValues(dependencies *data.ResourceDetailsCollection) string {
-	if len(dependencies.DummyVariables) == 0 {
-		return ""
+// createDummySecretGenerator returns the generator of dummy secret values. Dummy certificates are issued at the export
+// timestamp when one is supplied, so exports of the same resources are identical.
+func createDummySecretGenerator(args args.Arguments) dummy.DummySecret {
+	generator := dummy.DummySecret{}
+
+	if args.ExportTimestamp != "" {
+		// Invalid timestamps are rejected by Arguments.Validate
+		generator.IssuedAt, _ = time.Parse(time.RFC3339, args.ExportTimestamp)
+	}
+
+	return generator
+}
+
+// createSecretProvider returns the provider that supplies the values of sensitive variables, or nil if no source of
+// secrets was defined. The secrets file takes precedence over the environment variables.
+func createSecretProvider(args args.Arguments) (secrets.SecretProvider, error) {
+	providers := secrets.MultiSecretProvider{}
+
+	if args.SecretsFile != "" {
+		fileProvider, err := secrets.NewFileSecretProvider(args.SecretsFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		providers = append(providers, fileProvider)
 	}
 
-	message := strutil.StripMultilineWhitespace(`The follow dummy values were defined for sensitive values and certificates.
-		These values must be defined when applying the module, or manually updated after the module is applied`)
+	if args.SecretsEnvironmentPrefix != "" {
+		providers = append(providers, secrets.EnvironmentSecretProvider{Prefix: args.SecretsEnvironmentPrefix})
+	}
+
+	if len(providers) == 0 {
+		return nil, nil
+	}
+
+	return providers, nil
+}
 
-	for _, dummy := range dependencies.DummyVariables {
-		message += "Dummy value defined for variable " + dummy.VariableName + " associated with " + dummy.ResourceType + " called " + dummy.ResourceName + "\n"
+// logSecretsInventory logs the secrets that must be populated when applying the module
+func logSecretsInventory(logger *zap.Logger, inventory generators.SecretsInventory) {
+	for _, secret := range inventory.Secrets {
+		if secret.ValueSupplied {
+			continue
+		}
+
+		message := "The sensitive variable " + secret.VariableName + " associated with " + secret.ResourceType + " called " + secret.ResourceName + " must be defined when applying the module"
+		if secret.Dummy {
+			message += ", or manually updated after the module is applied, as it has a dummy value"
+		}
+		logger.Info(message)
 	}
 
-	return message
+	logger.Info("The sensitive variables are listed in " + generators.SecretsInventoryJsonFileName + ", " +
+		generators.SecretsInventoryCsvFileName + " and " + generators.SecretsExampleFileName)
 }
 
-func getDependencies(parseArgs args.Arguments, version string) (*data.ResourceDetailsCollection, error) {
+func getDependencies(ctx context.Context, logger *zap.Logger, parseArgs args.Arguments, octopusClient client.OctopusClient) (*data.ResourceDetailsCollection, error) {
 	if parseArgs.RunbookId != "" {
-		zap.L().Info("Exporting runbook " + parseArgs.RunbookId + " from project " + lo.Ternary(len(parseArgs.ProjectId) != 0, parseArgs.ProjectId[0], "undefined") + " in space " + parseArgs.Space)
-		files, err := ConvertRunbookToTerraform(parseArgs, version)
+		logger.Info("Exporting runbook " + parseArgs.RunbookId + " from project " + lo.Ternary(len(parseArgs.ProjectId) != 0, parseArgs.ProjectId[0], "undefined") + " in space " + parseArgs.Space)
+		files, err := ConvertRunbookToTerraformWithClient(parseArgs, octopusClient)
 		if err != nil {
 			return nil, err
 		}
 		return files, nil
 	} else if len(parseArgs.ProjectId) != 0 {
-		zap.L().Info("Exporting project(s) " + strings.Join(parseArgs.ProjectId, ", ") + " in space " + parseArgs.Space)
-		files, err := ConvertProjectToTerraform(parseArgs, version)
+		logger.Info("Exporting project(s) " + strings.Join(parseArgs.ProjectId, ", ") + " in space " + parseArgs.Space)
+		files, err := ConvertProjectToTerraformWithClient(parseArgs, octopusClient)
 		if err != nil {
 			return nil, err
 		}
 		return files, nil
 	} else {
-		zap.L().Info("Exporting space " + parseArgs.Space)
-		files, err := ConvertSpaceToTerraform(parseArgs, version)
+		logger.Info("Exporting space " + parseArgs.Space)
+		files, err := ConvertSpaceToTerraformWithClient(ctx, parseArgs, octopusClient)
 		if err != nil {
 			return nil, err
 		}
@@ -184,6 +411,10 @@ func ConvertProjectNameToId(
 		RedirectorRedirections:  redirectorRedirections,
 	}
 
+	return convertProjectNameToIdWithClient(&octopusClient, space, name)
+}
+
+func convertProjectNameToIdWithClient(octopusClient client.OctopusClient, space string, name string) (string, error) {
 	collection := octopus.GeneralCollection[octopus.Project]{}
 	err := octopusClient.GetAllResources("Projects", &collection, []string{"name", name})
 
@@ -231,6 +462,10 @@ func ConvertRunbookNameToId(url string,
 		RedirectorRedirections:  redirectorRedirections,
 	}
 
+	return convertRunbookNameToIdWithClient(&octopusClient, space, projectId, runbookName)
+}
+
+func convertRunbookNameToIdWithClient(octopusClient client.OctopusClient, space string, projectId string, runbookName string) (string, error) {
 	collection := octopus.GeneralCollection[octopus.Runbook]{}
 	err := octopusClient.GetAllResources("Projects/"+projectId+"/runbooks", &collection)
 
@@ -252,37 +487,39 @@ func ConvertRunbookNameToId(url string,
 	return "", errors.New("did not find runbook with name " + runbookName + " for the project " + projectId + " in space " + space)
 }
 
+// ConvertSpaceToTerraform exports a space using a client that queries the Octopus API.
 func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.ResourceDetailsCollection, error) {
-	group := errgroup.Group{}
+	return ConvertSpaceToTerraformWithClient(context.Background(), args, NewOctopusClient(args, version))
+}
+
+// ConvertSpaceToTerraformWithClient exports a space using the supplied client, which may be recording or replaying
+// the API responses. The resources are converted concurrently, and once any converter fails, or the context is done,
+// the remaining converters stop making requests to the Octopus API.
+func ConvertSpaceToTerraformWithClient(ctx context.Context, args args.Arguments, octopusClient client.OctopusClient) (*data.ResourceDetailsCollection, error) {
+	group, groupCtx := errgroup.WithContext(ctx)
 	group.SetLimit(10)
 
-	octopusClient := client.OctopusApiClient{
-		Url:                     args.Url,
-		ApiKey:                  args.ApiKey,
-		AccessToken:             args.AccessToken,
-		Space:                   args.Space,
-		Version:                 version,
-		UseRedirector:           args.UseRedirector,
-		RedirectorHost:          args.RedirectorHost,
-		RedirectorServiceApiKey: args.RedirectorServiceApiKey,
-		RedirecrtorApiKey:       args.RedirecrtorApiKey,
-		RedirectorRedirections:  args.RedirectorRedirections,
-		IgnoreUnauthorized:      args.IgnoreUnauthorized,
-		IgnoreServerError:       args.IgnoreServerError,
-	}
+	octopusClient = &client.ContextOctopusClient{Client: octopusClient, Context: groupCtx}
 
 	dependencies := data.ResourceDetailsCollection{}
 
-	dummySecretGenerator := dummy.DummySecret{}
+	dummySecretGenerator := createDummySecretGenerator(args)
+	secretProvider, err := createSecretProvider(args)
+
+	if err != nil {
+		return nil, err
+	}
 
 	terraformVariableWriter := variables.DefaultTerraformVariableWriter{
 		ExcludeTerraformVariables:   args.ExcludeTerraformVariables,
 		DummySecretVariableValues:   args.DummySecretVariableValues,
 		DefaultSecretVariableValues: args.DefaultSecretVariableValues,
 		DummySecretGenerator:        dummySecretGenerator,
+		SecretProvider:              secretProvider,
 	}
 
 	tenantCommonVariableProcessor := converters.TenantCommonVariableProcessor{
+		Client:                       octopusClient,
 		Excluder:                     converters.DefaultExcluder{},
 		ExcludeAllProjects:           args.ExcludeAllProjects,
 		ExcludeAllTenantVariables:    args.ExcludeAllTenantVariables,
@@ -293,6 +530,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 	}
 
 	tenantProjectVariableConverter := converters.TenantProjectVariableConverter{
+		Client:                       octopusClient,
 		Excluder:                     converters.DefaultExcluder{},
 		ExcludeAllProjects:           args.ExcludeAllProjects,
 		ExcludeAllTenantVariables:    args.ExcludeAllTenantVariables,
@@ -301,11 +539,12 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		ExcludeTenantVariablesRegex:  args.ExcludeTenantVariablesRegex,
 		DummySecretVariableValues:    args.DummySecretVariableValues,
 		DummySecretGenerator:         dummySecretGenerator,
+		SecretProvider:               secretProvider,
 	}
 
 	tenantProjectConverter := converters.TenantProjectConverter{
 		IncludeSpaceInPopulation: args.IncludeSpaceInPopulation,
-		ErrGroup:                 &group,
+		ErrGroup:                 group,
 		ExcludeTenantTagSets:     args.ExcludeTenantTagSets,
 		ExcludeTenantTags:        args.ExcludeTenantTags,
 		ExcludeTenants:           args.ExcludeTenants,
@@ -318,12 +557,12 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		ExcludeProjectsRegex:     args.ExcludeProjectsRegex,
 		ExcludeAllProjects:       args.ExcludeAllProjects,
 		Excluder:                 converters.DefaultExcluder{},
-		Client:                   &octopusClient,
+		Client:                   octopusClient,
 	}
 
 	stepTemplateConverter := converters.StepTemplateConverter{
-		ErrGroup:                   &group,
-		Client:                     &octopusClient,
+		ErrGroup:                   group,
+		Client:                     octopusClient,
 		ExcludeAllStepTemplates:    args.ExcludeAllStepTemplates,
 		ExcludeStepTemplates:       args.ExcludeStepTemplates,
 		ExcludeStepTemplatesRegex:  args.ExcludeStepTemplatesRegex,
@@ -357,8 +596,8 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 	}
 
 	machinePolicyConverter := converters.MachinePolicyConverter{
-		Client:                       &octopusClient,
-		ErrGroup:                     &group,
+		Client:                       octopusClient,
+		ErrGroup:                     group,
 		ExcludeMachinePolicies:       args.ExcludeMachinePolicies,
 		ExcludeMachinePoliciesRegex:  args.ExcludeMachinePoliciesRegex,
 		ExcludeMachinePoliciesExcept: args.ExcludeMachinePoliciesExcept,
@@ -368,35 +607,36 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		IncludeIds:                   args.IncludeIds,
 		IncludeSpaceInPopulation:     args.IncludeSpaceInPopulation,
 		GenerateImportScripts:        args.GenerateImportScripts,
+		ExtractScripts:               args.ExtractScripts,
 	}
 	environmentConverter := converters.EnvironmentConverter{
-		Client:                    &octopusClient,
+		Client:                    octopusClient,
 		ExcludeEnvironments:       args.ExcludeEnvironments,
 		ExcludeAllEnvironments:    args.ExcludeAllEnvironments,
 		ExcludeEnvironmentsExcept: args.ExcludeEnvironmentsExcept,
 		ExcludeEnvironmentsRegex:  args.ExcludeEnvironmentsRegex,
 		Excluder:                  converters.DefaultExcluder{},
-		ErrGroup:                  &group,
+		ErrGroup:                  group,
 		IncludeIds:                args.IncludeIds,
 		LimitResourceCount:        args.LimitResourceCount,
 		IncludeSpaceInPopulation:  args.IncludeSpaceInPopulation,
 		GenerateImportScripts:     args.GenerateImportScripts,
 	}
 	parentEnvironmentConverter := converters.ParentEnvironmentConverter{
-		Client:                    &octopusClient,
+		Client:                    octopusClient,
 		ExcludeEnvironments:       args.ExcludeEnvironments,
 		ExcludeAllEnvironments:    args.ExcludeAllEnvironments,
 		ExcludeEnvironmentsExcept: args.ExcludeEnvironmentsExcept,
 		ExcludeEnvironmentsRegex:  args.ExcludeEnvironmentsRegex,
 		Excluder:                  converters.DefaultExcluder{},
-		ErrGroup:                  &group,
+		ErrGroup:                  group,
 		IncludeIds:                args.IncludeIds,
 		LimitResourceCount:        args.LimitResourceCount,
 		IncludeSpaceInPopulation:  args.IncludeSpaceInPopulation,
 		GenerateImportScripts:     args.GenerateImportScripts,
 	}
 	tenantVariableConverter := converters.TenantVariableConverter{
-		Client:                         &octopusClient,
+		Client:                         octopusClient,
 		ExcludeTenants:                 args.ExcludeTenants,
 		ExcludeTenantsWithTags:         args.ExcludeTenantsWithTags,
 		ExcludeAllTenants:              args.ExcludeAllTenants,
@@ -408,7 +648,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		ExcludeProjectsRegex:           args.ExcludeProjectsRegex,
 		ExcludeAllProjects:             args.ExcludeAllProjects,
 		ExcludeProjectsExcept:          args.ExcludeProjectsExcept,
-		ErrGroup:                       &group,
+		ErrGroup:                       group,
 		ExcludeAllTenantVariables:      args.ExcludeAllTenantVariables,
 		ExcludeTenantVariables:         args.ExcludeTenantVariables,
 		ExcludeTenantVariablesExcept:   args.ExcludeTenantVariablesExcept,
@@ -417,11 +657,11 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		TenantProjectVariableConverter: tenantProjectVariableConverter,
 	}
 	tagsetConverter := converters.TagSetConverter{
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 		Excluder:                   converters.DefaultExcluder{},
 		ExcludeTenantTags:          args.ExcludeTenantTags,
 		ExcludeTenantTagSets:       args.ExcludeTenantTagSets,
-		ErrGroup:                   &group,
+		ErrGroup:                   group,
 		ExcludeTenantTagSetsRegex:  args.ExcludeTenantTagSetsRegex,
 		ExcludeTenantTagSetsExcept: args.ExcludeTenantTagSetsExcept,
 		ExcludeAllTenantTagSets:    args.ExcludeAllTenantTagSets,
@@ -429,7 +669,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		GenerateImportScripts:      args.GenerateImportScripts,
 	}
 	tenantConverter := converters.TenantConverter{
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 		TenantVariableConverter:    tenantVariableConverter,
 		EnvironmentConverter:       environmentConverter,
 		ParentEnvironmentConverter: parentEnvironmentConverter,
@@ -446,7 +686,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		ExcludeTenantTags:          args.ExcludeTenantTags,
 		ExcludeTenantTagSets:       args.ExcludeTenantTagSets,
 		ExcludeProjectsExcept:      args.ExcludeProjectsExcept,
-		ErrGroup:                   &group,
+		ErrGroup:                   group,
 		IncludeIds:                 args.IncludeIds,
 		LimitResourceCount:         args.LimitResourceCount,
 		IncludeSpaceInPopulation:   args.IncludeSpaceInPopulation,
@@ -454,17 +694,18 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		TenantProjectConverter:     tenantProjectConverter,
 	}
 	accountConverter := converters.AccountConverter{
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 		EnvironmentConverter:       machinePolicyConverter,
 		ParentEnvironmentConverter: parentEnvironmentConverter,
 		TenantConverter:            &tenantConverter,
 		DummySecretVariableValues:  args.DummySecretVariableValues,
 		DummySecretGenerator:       dummySecretGenerator,
+		SecretProvider:             secretProvider,
 		ExcludeTenantTags:          args.ExcludeTenantTags,
 		ExcludeTenantTagSets:       args.ExcludeTenantTagSets,
 		Excluder:                   converters.DefaultExcluder{},
 		TagSetConverter:            &tagsetConverter,
-		ErrGroup:                   &group,
+		ErrGroup:                   group,
 		ExcludeAccounts:            args.ExcludeAccounts,
 		ExcludeAccountsRegex:       args.ExcludeAccountsRegex,
 		ExcludeAccountsExcept:      args.ExcludeAccountsExcept,
@@ -476,9 +717,9 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 	}
 
 	lifecycleConverter := converters.LifecycleConverter{
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 		EnvironmentConverter:       environmentConverter,
-		ErrGroup:                   &group,
+		ErrGroup:                   group,
 		ParentEnvironmentConverter: parentEnvironmentConverter,
 		ExcludeLifecycles:          args.ExcludeLifecycles,
 		ExcludeLifecyclesRegex:     args.ExcludeLifecyclesRegex,
@@ -491,24 +732,25 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		GenerateImportScripts:      args.GenerateImportScripts,
 	}
 	gitCredentialsConverter := converters.GitCredentialsConverter{
-		Client:                    &octopusClient,
+		Client:                    octopusClient,
 		DummySecretVariableValues: args.DummySecretVariableValues,
 		DummySecretGenerator:      dummySecretGenerator,
+		SecretProvider:            secretProvider,
 		ExcludeAllGitCredentials:  args.ExcludeAllGitCredentials,
-		ErrGroup:                  &group,
+		ErrGroup:                  group,
 		IncludeIds:                args.IncludeIds,
 		LimitResourceCount:        args.LimitResourceCount,
 		IncludeSpaceInPopulation:  args.IncludeSpaceInPopulation,
 		GenerateImportScripts:     args.GenerateImportScripts,
 	}
 	channelConverter := converters.ChannelConverter{
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 		LifecycleConverter:         lifecycleConverter,
 		ParentEnvironmentConverter: parentEnvironmentConverter,
 		ExcludeTenantTags:          args.ExcludeTenantTags,
 		ExcludeTenantTagSets:       args.ExcludeTenantTagSets,
 		Excluder:                   converters.DefaultExcluder{},
-		ErrGroup:                   &group,
+		ErrGroup:                   group,
 		IncludeIds:                 args.IncludeIds,
 		LimitResourceCount:         args.LimitResourceCount,
 		IncludeDefaultChannel:      args.IncludeDefaultChannel,
@@ -523,8 +765,8 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 	}
 
 	projectGroupConverter := converters.ProjectGroupConverter{
-		Client:                     &octopusClient,
-		ErrGroup:                   &group,
+		Client:                     octopusClient,
+		ErrGroup:                   group,
 		ExcludeProjectGroups:       args.ExcludeProjectGroups,
 		ExcludeProjectGroupsRegex:  args.ExcludeProjectGroupsRegex,
 		ExcludeProjectGroupsExcept: args.ExcludeProjectGroupsExcept,
@@ -537,8 +779,8 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 	}
 
 	machineProxyConverter := converters.MachineProxyConverter{
-		Client:                      &octopusClient,
-		ErrGroup:                    &group,
+		Client:                      octopusClient,
+		ErrGroup:                    group,
 		ExcludeMachineProxies:       args.ExcludeMachineProxies,
 		ExcludeMachineProxiesRegex:  args.ExcludeMachineProxiesRegex,
 		ExcludeMachineProxiesExcept: args.ExcludeMachineProxiesExcept,
@@ -550,17 +792,19 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		GenerateImportScripts:       args.GenerateImportScripts,
 		DummySecretVariableValues:   args.DummySecretVariableValues,
 		DummySecretGenerator:        dummySecretGenerator,
+		SecretProvider:              secretProvider,
 	}
 
 	certificateConverter := converters.CertificateConverter{
-		Client:                    &octopusClient,
+		Client:                    octopusClient,
 		DummySecretVariableValues: args.DummySecretVariableValues,
 		DummySecretGenerator:      dummySecretGenerator,
+		SecretProvider:            secretProvider,
 		ExcludeTenantTags:         args.ExcludeTenantTags,
 		ExcludeTenantTagSets:      args.ExcludeTenantTagSets,
 		Excluder:                  converters.DefaultExcluder{},
 		TagSetConverter:           &tagsetConverter,
-		ErrGroup:                  &group,
+		ErrGroup:                  group,
 		ExcludeCertificates:       args.ExcludeCertificates,
 		ExcludeCertificatesRegex:  args.ExcludeCertificatesRegex,
 		ExcludeCertificatesExcept: args.ExcludeCertificatesExcept,
@@ -573,8 +817,8 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 
 	sshWorkerConverter := converters.SshWorkerConverter{
 		BaseWorkerConverter: converters.BaseWorkerConverter{
-			Client:                   &octopusClient,
-			ErrGroup:                 &group,
+			Client:                   octopusClient,
+			ErrGroup:                 group,
 			Excluder:                 converters.DefaultExcluder{},
 			MachinePolicyConverter:   machinePolicyConverter,
 			ExcludeAllWorkers:        args.ExcludeAllWorkers,
@@ -591,8 +835,8 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 
 	listeningWorkerConverter := converters.ListeningWorkerConverter{
 		BaseWorkerConverter: converters.BaseWorkerConverter{
-			Client:                   &octopusClient,
-			ErrGroup:                 &group,
+			Client:                   octopusClient,
+			ErrGroup:                 group,
 			Excluder:                 converters.DefaultExcluder{},
 			MachinePolicyConverter:   machinePolicyConverter,
 			ExcludeAllWorkers:        args.ExcludeAllWorkers,
@@ -608,8 +852,8 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 
 	k8sAgentWorkerConverter := converters.KubernetesAgentWorkerConverter{
 		BaseWorkerConverter: converters.BaseWorkerConverter{
-			Client:                   &octopusClient,
-			ErrGroup:                 &group,
+			Client:                   octopusClient,
+			ErrGroup:                 group,
 			Excluder:                 converters.DefaultExcluder{},
 			MachinePolicyConverter:   machinePolicyConverter,
 			ExcludeAllWorkers:        args.ExcludeAllWorkers,
@@ -624,8 +868,8 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 	}
 
 	workerPoolConverter := converters.WorkerPoolConverter{
-		Client:                   &octopusClient,
-		ErrGroup:                 &group,
+		Client:                   octopusClient,
+		ErrGroup:                 group,
 		ExcludeWorkerpools:       args.ExcludeWorkerpools,
 		ExcludeWorkerpoolsRegex:  args.ExcludeWorkerpoolsRegex,
 		ExcludeWorkerpoolsExcept: args.ExcludeWorkerpoolsExcept,
@@ -638,10 +882,11 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 	}
 
 	feedConverter := converters.FeedConverter{
-		Client:                    &octopusClient,
+		Client:                    octopusClient,
 		DummySecretVariableValues: args.DummySecretVariableValues,
 		DummySecretGenerator:      dummySecretGenerator,
-		ErrGroup:                  &group,
+		SecretProvider:            secretProvider,
+		ErrGroup:                  group,
 		ExcludeFeeds:              args.ExcludeFeeds,
 		ExcludeFeedsRegex:         args.ExcludeFeedsRegex,
 		ExcludeFeedsExcept:        args.ExcludeFeedsExcept,
@@ -655,7 +900,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 
 	kubernetesTargetConverter := converters.KubernetesTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -675,7 +920,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		ExcludeTargets:             args.ExcludeTargets,
 		ExcludeTargetsRegex:        args.ExcludeTargetsRegex,
 		ExcludeTargetsExcept:       args.ExcludeTargetsExcept,
-		ErrGroup:                   &group,
+		ErrGroup:                   group,
 		LimitResourceCount:         args.LimitResourceCount,
 		IncludeIds:                 args.IncludeIds,
 		IncludeSpaceInPopulation:   args.IncludeSpaceInPopulation,
@@ -684,7 +929,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 
 	sshTargetConverter := converters.SshTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -703,7 +948,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		ExcludeTargets:             args.ExcludeTargets,
 		ExcludeTargetsRegex:        args.ExcludeTargetsRegex,
 		ExcludeTargetsExcept:       args.ExcludeTargetsExcept,
-		ErrGroup:                   &group,
+		ErrGroup:                   group,
 		IncludeIds:                 args.IncludeIds,
 		LimitResourceCount:         args.LimitResourceCount,
 		IncludeSpaceInPopulation:   args.IncludeSpaceInPopulation,
@@ -712,7 +957,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 
 	listeningTargetConverter := converters.ListeningTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -730,7 +975,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		ExcludeTargets:             args.ExcludeTargets,
 		ExcludeTargetsRegex:        args.ExcludeTargetsRegex,
 		ExcludeTargetsExcept:       args.ExcludeTargetsExcept,
-		ErrGroup:                   &group,
+		ErrGroup:                   group,
 		IncludeIds:                 args.IncludeIds,
 		LimitResourceCount:         args.LimitResourceCount,
 		IncludeSpaceInPopulation:   args.IncludeSpaceInPopulation,
@@ -739,7 +984,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 
 	pollingTargetConverter := converters.PollingTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -747,26 +992,57 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 			ExcludeAllEnvironments:           args.ExcludeAllEnvironments,
 			ExcludeTargetsWithNoEnvironments: args.ExcludeTargetsWithNoEnvironments,
 		},
-		MachinePolicyConverter:     machinePolicyConverter,
-		EnvironmentConverter:       environmentConverter,
-		ParentEnvironmentConverter: parentEnvironmentConverter,
-		ExcludeAllTargets:          args.ExcludeAllTargets,
-		ExcludeTenantTags:          args.ExcludeTenantTags,
-		ExcludeTenantTagSets:       args.ExcludeTenantTagSets,
-		TagSetConverter:            &tagsetConverter,
-		ExcludeTargets:             args.ExcludeTargets,
-		ExcludeTargetsRegex:        args.ExcludeTargetsRegex,
-		ExcludeTargetsExcept:       args.ExcludeTargetsExcept,
-		ErrGroup:                   &group,
-		IncludeIds:                 args.IncludeIds,
-		LimitResourceCount:         args.LimitResourceCount,
-		IncludeSpaceInPopulation:   args.IncludeSpaceInPopulation,
-		GenerateImportScripts:      args.GenerateImportScripts,
+		TargetDependencyConverters: converters.TargetDependencyConverters{
+			MachinePolicyConverter:     machinePolicyConverter,
+			EnvironmentConverter:       environmentConverter,
+			ParentEnvironmentConverter: parentEnvironmentConverter,
+		},
+		ExcludeAllTargets:        args.ExcludeAllTargets,
+		ExcludeTenantTags:        args.ExcludeTenantTags,
+		ExcludeTenantTagSets:     args.ExcludeTenantTagSets,
+		TagSetConverter:          &tagsetConverter,
+		ExcludeTargets:           args.ExcludeTargets,
+		ExcludeTargetsRegex:      args.ExcludeTargetsRegex,
+		ExcludeTargetsExcept:     args.ExcludeTargetsExcept,
+		ErrGroup:                 group,
+		IncludeIds:               args.IncludeIds,
+		LimitResourceCount:       args.LimitResourceCount,
+		IncludeSpaceInPopulation: args.IncludeSpaceInPopulation,
+		GenerateImportScripts:    args.GenerateImportScripts,
+	}
+
+	kubernetesAgentTargetConverter := converters.KubernetesAgentTargetConverter{
+		TargetConverter: converters.TargetConverter{
+			Client:                           octopusClient,
+			Excluder:                         converters.DefaultExcluder{},
+			ExcludeEnvironments:              args.ExcludeEnvironments,
+			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
+			ExcludeEnvironmentsExcept:        args.ExcludeEnvironmentsExcept,
+			ExcludeAllEnvironments:           args.ExcludeAllEnvironments,
+			ExcludeTargetsWithNoEnvironments: args.ExcludeTargetsWithNoEnvironments,
+		},
+		TargetDependencyConverters: converters.TargetDependencyConverters{
+			MachinePolicyConverter:     machinePolicyConverter,
+			EnvironmentConverter:       environmentConverter,
+			ParentEnvironmentConverter: parentEnvironmentConverter,
+		},
+		ExcludeAllTargets:        args.ExcludeAllTargets,
+		ExcludeTenantTags:        args.ExcludeTenantTags,
+		ExcludeTenantTagSets:     args.ExcludeTenantTagSets,
+		TagSetConverter:          &tagsetConverter,
+		ExcludeTargets:           args.ExcludeTargets,
+		ExcludeTargetsRegex:      args.ExcludeTargetsRegex,
+		ExcludeTargetsExcept:     args.ExcludeTargetsExcept,
+		ErrGroup:                 group,
+		IncludeIds:               args.IncludeIds,
+		LimitResourceCount:       args.LimitResourceCount,
+		IncludeSpaceInPopulation: args.IncludeSpaceInPopulation,
+		GenerateImportScripts:    args.GenerateImportScripts,
 	}
 
 	cloudRegionTargetConverter := converters.CloudRegionTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -786,7 +1062,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		ExcludeTargets:           args.ExcludeTargets,
 		ExcludeTargetsRegex:      args.ExcludeTargetsRegex,
 		ExcludeTargetsExcept:     args.ExcludeTargetsExcept,
-		ErrGroup:                 &group,
+		ErrGroup:                 group,
 		IncludeIds:               args.IncludeIds,
 		LimitResourceCount:       args.LimitResourceCount,
 		IncludeSpaceInPopulation: args.IncludeSpaceInPopulation,
@@ -795,7 +1071,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 
 	offlineDropTargetConverter := converters.OfflineDropTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -815,7 +1091,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		ExcludeTargets:             args.ExcludeTargets,
 		ExcludeTargetsRegex:        args.ExcludeTargetsRegex,
 		ExcludeTargetsExcept:       args.ExcludeTargetsExcept,
-		ErrGroup:                   &group,
+		ErrGroup:                   group,
 		IncludeIds:                 args.IncludeIds,
 		LimitResourceCount:         args.LimitResourceCount,
 		IncludeSpaceInPopulation:   args.IncludeSpaceInPopulation,
@@ -824,7 +1100,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 
 	azureCloudServiceTargetConverter := converters.AzureCloudServiceTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -843,7 +1119,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		ExcludeTargets:             args.ExcludeTargets,
 		ExcludeTargetsRegex:        args.ExcludeTargetsRegex,
 		ExcludeTargetsExcept:       args.ExcludeTargetsExcept,
-		ErrGroup:                   &group,
+		ErrGroup:                   group,
 		IncludeIds:                 args.IncludeIds,
 		LimitResourceCount:         args.LimitResourceCount,
 		IncludeSpaceInPopulation:   args.IncludeSpaceInPopulation,
@@ -852,7 +1128,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 
 	azureServiceFabricTargetConverter := converters.AzureServiceFabricTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -872,7 +1148,8 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		ExcludeTargetsExcept:       args.ExcludeTargetsExcept,
 		DummySecretVariableValues:  args.DummySecretVariableValues,
 		DummySecretGenerator:       dummySecretGenerator,
-		ErrGroup:                   &group,
+		SecretProvider:             secretProvider,
+		ErrGroup:                   group,
 		IncludeIds:                 args.IncludeIds,
 		LimitResourceCount:         args.LimitResourceCount,
 		IncludeSpaceInPopulation:   args.IncludeSpaceInPopulation,
@@ -881,7 +1158,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 
 	azureWebAppTargetConverter := converters.AzureWebAppTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -900,7 +1177,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		ExcludeTargets:             args.ExcludeTargets,
 		ExcludeTargetsRegex:        args.ExcludeTargetsRegex,
 		ExcludeTargetsExcept:       args.ExcludeTargetsExcept,
-		ErrGroup:                   &group,
+		ErrGroup:                   group,
 		IncludeIds:                 args.IncludeIds,
 		LimitResourceCount:         args.LimitResourceCount,
 		IncludeSpaceInPopulation:   args.IncludeSpaceInPopulation,
@@ -908,7 +1185,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 	}
 
 	variableSetConverter := converters.VariableSetConverter{
-		Client:                            &octopusClient,
+		Client:                            octopusClient,
 		ChannelConverter:                  channelConverter,
 		EnvironmentConverter:              environmentConverter,
 		ParentEnvironmentConverter:        parentEnvironmentConverter,
@@ -921,6 +1198,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		ListeningTargetConverter:          listeningTargetConverter,
 		OfflineDropTargetConverter:        offlineDropTargetConverter,
 		PollingTargetConverter:            pollingTargetConverter,
+		KubernetesAgentTargetConverter:    kubernetesAgentTargetConverter,
 		SshTargetConverter:                sshTargetConverter,
 		AccountConverter:                  accountConverter,
 		FeedConverter:                     feedConverter,
@@ -938,13 +1216,13 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		IgnoreProjectChanges:              args.IgnoreProjectChanges || args.IgnoreProjectVariableChanges,
 		DummySecretGenerator:              dummySecretGenerator,
 		Excluder:                          converters.DefaultExcluder{},
-		ErrGroup:                          &group,
+		ErrGroup:                          group,
 		ExcludeTerraformVariables:         args.ExcludeTerraformVariables,
 		LimitAttributeLength:              args.LimitAttributeLength,
 		StatelessAdditionalParams:         args.StatelessAdditionalParams,
 		GenerateImportScripts:             args.GenerateImportScripts,
 		EnvironmentFilter: converters.EnvironmentFilter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			ExcludeVariableEnvironmentScopes: args.ExcludeVariableEnvironmentScopes,
 		},
 		IgnoreCacErrors:         args.IgnoreCacErrors,
@@ -952,7 +1230,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		TerraformVariableWriter: &terraformVariableWriter,
 	}
 	libraryVariableSetConverter := converters.LibraryVariableSetConverter{
-		Client:                           &octopusClient,
+		Client:                           octopusClient,
 		VariableSetConverter:             &variableSetConverter,
 		Excluded:                         args.ExcludeLibraryVariableSets,
 		ExcludeLibraryVariableSetsRegex:  args.ExcludeLibraryVariableSetsRegex,
@@ -961,24 +1239,25 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		DummySecretVariableValues:        args.DummySecretVariableValues,
 		DummySecretGenerator:             dummySecretGenerator,
 		Excluder:                         converters.DefaultExcluder{},
-		ErrGroup:                         &group,
+		ErrGroup:                         group,
 		LimitResourceCount:               args.LimitResourceCount,
 		GenerateImportScripts:            args.GenerateImportScripts,
+		ExtractScripts:                   args.ExtractScripts,
 	}
 
 	workerPoolProcessor := converters.OctopusWorkerPoolProcessor{
 		WorkerPoolConverter:     workerPoolConverter,
 		LookupDefaultWorkerPool: args.LookUpDefaultWorkerPools,
-		Client:                  &octopusClient,
-		ErrGroup:                &group,
+		Client:                  octopusClient,
+		ErrGroup:                group,
 	}
 
 	runbookConverter := converters.RunbookConverter{
-		Client: &octopusClient,
+		Client: octopusClient,
 		RunbookProcessConverter: &converters.RunbookProcessConverter{
 			DeploymentProcessConverterBase: converters.DeploymentProcessConverterBase{
 				ResourceType:               "RunbookProcesses",
-				Client:                     &octopusClient,
+				Client:                     octopusClient,
 				OctopusActionProcessor:     nil,
 				IgnoreProjectChanges:       args.IgnoreProjectChanges,
 				WorkerPoolProcessor:        workerPoolProcessor,
@@ -994,10 +1273,12 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 				ExcludeStepsExcept:         args.ExcludeStepsExcept,
 				IgnoreInvalidExcludeExcept: args.IgnoreInvalidExcludeExcept,
 				DummySecretGenerator:       dummySecretGenerator,
+				SecretProvider:             secretProvider,
 				DummySecretVariableValues:  args.DummySecretVariableValues,
 				IgnoreCacErrors:            args.IgnoreCacErrors,
 				DetachProjectTemplates:     args.DetachProjectTemplates,
 				GenerateImportScripts:      args.GenerateImportScripts,
+				ExtractScripts:             args.ExtractScripts,
 			},
 		},
 		EnvironmentConverter:       environmentConverter,
@@ -1009,7 +1290,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		ExcludeAllRunbooks:         false,
 		ProjectConverter:           nil,
 		IgnoreProjectChanges:       false,
-		ErrGroup:                   &group,
+		ErrGroup:                   group,
 		LimitResourceCount:         args.LimitResourceCount,
 		IncludeSpaceInPopulation:   args.IncludeSpaceInPopulation,
 		IncludeIds:                 args.IncludeIds,
@@ -1019,7 +1300,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 	}
 
 	projectConverter := &converters.ProjectConverter{
-		Client:                      &octopusClient,
+		Client:                      octopusClient,
 		LifecycleConverter:          lifecycleConverter,
 		GitCredentialsConverter:     gitCredentialsConverter,
 		LibraryVariableSetConverter: &libraryVariableSetConverter,
@@ -1028,7 +1309,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		DeploymentProcessConverter: &converters.DeploymentProcessConverter{
 			DeploymentProcessConverterBase: converters.DeploymentProcessConverterBase{
 				ResourceType:               "DeploymentProcesses",
-				Client:                     &octopusClient,
+				Client:                     octopusClient,
 				OctopusActionProcessor:     nil,
 				IgnoreProjectChanges:       args.IgnoreProjectChanges,
 				WorkerPoolProcessor:        workerPoolProcessor,
@@ -1044,15 +1325,17 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 				ExcludeStepsExcept:         args.ExcludeStepsExcept,
 				IgnoreInvalidExcludeExcept: args.IgnoreInvalidExcludeExcept,
 				DummySecretGenerator:       dummySecretGenerator,
+				SecretProvider:             secretProvider,
 				DummySecretVariableValues:  args.DummySecretVariableValues,
 				IgnoreCacErrors:            args.IgnoreCacErrors,
 				DetachProjectTemplates:     args.DetachProjectTemplates,
 				GenerateImportScripts:      args.GenerateImportScripts,
+				ExtractScripts:             args.ExtractScripts,
 			},
 		},
 		TenantConverter: &tenantConverter,
 		ProjectTriggerConverter: converters.ProjectTriggerConverter{
-			Client:                     &octopusClient,
+			Client:                     octopusClient,
 			LimitResourceCount:         args.LimitResourceCount,
 			GenerateImportScripts:      args.GenerateImportScripts,
 			EnvironmentConverter:       environmentConverter,
@@ -1079,9 +1362,10 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		ExcludeAllProjects:         args.ExcludeAllProjects,
 		DummySecretVariableValues:  args.DummySecretVariableValues,
 		DummySecretGenerator:       dummySecretGenerator,
+		SecretProvider:             secretProvider,
 		Excluder:                   converters.DefaultExcluder{},
 		LookupOnlyMode:             false,
-		ErrGroup:                   &group,
+		ErrGroup:                   group,
 		ExcludeTerraformVariables:  args.ExcludeTerraformVariables,
 		IncludeIds:                 args.IncludeIds,
 		LimitResourceCount:         args.LimitResourceCount,
@@ -1102,8 +1386,8 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 	}
 
 	deploymentFreezeConverter := converters.DeploymentFreezeConverter{
-		Client:                         &octopusClient,
-		ErrGroup:                       &group,
+		Client:                         octopusClient,
+		ErrGroup:                       group,
 		ExcludeDeploymentFreezes:       args.ExcludeDeploymentFreezes,
 		ExcludeDeploymentFreezesRegex:  args.ExcludeDeploymentFreezesRegex,
 		ExcludeDeploymentFreezesExcept: args.ExcludeDeploymentFreezesExcept,
@@ -1114,16 +1398,34 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		GenerateImportScripts:          args.GenerateImportScripts,
 	}
 
+	teamConverter := converters.TeamConverter{
+		Client:   octopusClient,
+		ErrGroup: group,
+		ScopedUserRoleConverter: converters.ScopedUserRoleConverter{
+			Client:     octopusClient,
+			IncludeIds: args.IncludeIds,
+		},
+		ExcludeTeams:             args.ExcludeTeams,
+		ExcludeTeamsRegex:        args.ExcludeTeamsRegex,
+		ExcludeTeamsExcept:       args.ExcludeTeamsExcept,
+		ExcludeAllTeams:          args.ExcludeAllTeams,
+		Excluder:                 converters.DefaultExcluder{},
+		LimitResourceCount:       args.LimitResourceCount,
+		IncludeIds:               args.IncludeIds,
+		IncludeSpaceInPopulation: args.IncludeSpaceInPopulation,
+	}
+
 	platformHubConverter := converters.PlatformHubConverter{
-		Client:                           &octopusClient,
-		ErrGroup:                         &group,
+		Client:                           octopusClient,
+		ErrGroup:                         group,
 		DummySecretVariableValues:        args.DummySecretVariableValues,
 		DummySecretGenerator:             dummySecretGenerator,
+		SecretProvider:                   secretProvider,
 		ExcludePlatformHubVersionControl: args.ExcludePlatformHubVersionControl,
 	}
 
 	spaceConverter := converters.SpaceConverter{
-		Client:                      &octopusClient,
+		Client:                      octopusClient,
 		ExcludeSpaceCreation:        args.ExcludeSpaceCreation,
 		IncludeOctopusOutputVars:    args.IncludeOctopusOutputVars,
 		AccountConverter:            accountConverter,
@@ -1136,10 +1438,10 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		GitCredentialsConverter:     gitCredentialsConverter,
 		ProjectGroupConverter:       projectGroupConverter,
 		SpacePopulateConverter: converters.SpacePopulateConverter{
-			Client:                   &octopusClient,
+			Client:                   octopusClient,
 			IncludeSpaceInPopulation: args.IncludeSpaceInPopulation,
 			IncludeIds:               args.IncludeIds,
-			ErrGroup:                 &group,
+			ErrGroup:                 group,
 		},
 		ProjectConverter:                  projectConverter,
 		TenantConverter:                   &tenantConverter,
@@ -1150,13 +1452,14 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		SshTargetConverter:                sshTargetConverter,
 		ListeningTargetConverter:          listeningTargetConverter,
 		PollingTargetConverter:            pollingTargetConverter,
+		KubernetesAgentTargetConverter:    kubernetesAgentTargetConverter,
 		CloudRegionTargetConverter:        cloudRegionTargetConverter,
 		OfflineDropTargetConverter:        offlineDropTargetConverter,
 		AzureCloudServiceTargetConverter:  azureCloudServiceTargetConverter,
 		AzureServiceFabricTargetConverter: azureServiceFabricTargetConverter,
 		AzureWebAppTargetConverter:        azureWebAppTargetConverter,
 		FeedConverter:                     feedConverter,
-		ErrGroup:                          &group,
+		ErrGroup:                          group,
 		StepTemplateConverter:             stepTemplateConverter,
 		TenantProjectConverter:            tenantProjectConverter,
 		DeploymentFreezeConverter:         deploymentFreezeConverter,
@@ -1166,6 +1469,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		MachineProxyConverter:             machineProxyConverter,
 		Stateless:                         args.Stateless,
 		PlatformHubConverter:              platformHubConverter,
+		TeamConverter:                     teamConverter,
 	}
 
 	octopusActionProcessor := converters.OctopusActionProcessor{
@@ -1179,7 +1483,7 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 		GitCredentialsConverter:    gitCredentialsConverter,
 		StepTemplateConverter:      stepTemplateConverter,
 		ProjectExporter:            projectConverter,
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 	}
 
 	// Projects and runbooks have circular references to other projects. For example, a project can have
@@ -1196,33 +1500,32 @@ func ConvertSpaceToTerraform(args args.Arguments, version string) (*data.Resourc
 	return &dependencies, nil
 }
 
+// ConvertRunbookToTerraform exports a runbook using a client that queries the Octopus API.
 func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.ResourceDetailsCollection, error) {
+	return ConvertRunbookToTerraformWithClient(args, NewOctopusClient(args, version))
+}
 
-	octopusClient := client.OctopusApiClient{
-		Url:                     args.Url,
-		ApiKey:                  args.ApiKey,
-		AccessToken:             args.AccessToken,
-		Space:                   args.Space,
-		Version:                 version,
-		UseRedirector:           args.UseRedirector,
-		RedirectorHost:          args.RedirectorHost,
-		RedirectorServiceApiKey: args.RedirectorServiceApiKey,
-		RedirecrtorApiKey:       args.RedirecrtorApiKey,
-		RedirectorRedirections:  args.RedirectorRedirections,
-		IgnoreUnauthorized:      args.IgnoreUnauthorized,
-		IgnoreServerError:       args.IgnoreServerError,
-	}
+// ConvertRunbookToTerraformWithClient exports a runbook using the supplied client, which may be recording or replaying
+// the API responses.
+func ConvertRunbookToTerraformWithClient(args args.Arguments, octopusClient client.OctopusClient) (*data.ResourceDetailsCollection, error) {
 
-	dummySecretGenerator := dummy.DummySecret{}
+	dummySecretGenerator := createDummySecretGenerator(args)
+	secretProvider, err := createSecretProvider(args)
+
+	if err != nil {
+		return nil, err
+	}
 
 	terraformVariableWriter := variables.DefaultTerraformVariableWriter{
 		ExcludeTerraformVariables:   args.ExcludeTerraformVariables,
 		DummySecretVariableValues:   args.DummySecretVariableValues,
 		DefaultSecretVariableValues: args.DefaultSecretVariableValues,
 		DummySecretGenerator:        dummySecretGenerator,
+		SecretProvider:              secretProvider,
 	}
 
 	tenantCommonVariableProcessor := converters.TenantCommonVariableProcessor{
+		Client:                       octopusClient,
 		Excluder:                     converters.DefaultExcluder{},
 		ExcludeAllProjects:           args.ExcludeAllProjects,
 		ExcludeAllTenantVariables:    args.ExcludeAllTenantVariables,
@@ -1233,6 +1536,7 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 	}
 
 	tenantProjectVariableConverter := converters.TenantProjectVariableConverter{
+		Client:                       octopusClient,
 		Excluder:                     converters.DefaultExcluder{},
 		ExcludeAllProjects:           args.ExcludeAllProjects,
 		ExcludeAllTenantVariables:    args.ExcludeAllTenantVariables,
@@ -1241,6 +1545,7 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 		ExcludeTenantVariablesRegex:  args.ExcludeTenantVariablesRegex,
 		DummySecretVariableValues:    args.DummySecretVariableValues,
 		DummySecretGenerator:         dummySecretGenerator,
+		SecretProvider:               secretProvider,
 	}
 
 	tenantProjectConverter := converters.TenantProjectConverter{
@@ -1258,14 +1563,14 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 		ExcludeProjectsRegex:     args.ExcludeProjectsRegex,
 		ExcludeAllProjects:       args.ExcludeAllProjects,
 		Excluder:                 converters.DefaultExcluder{},
-		Client:                   &octopusClient,
+		Client:                   octopusClient,
 	}
 
 	dependencies := data.ResourceDetailsCollection{}
 
 	stepTemplateConverter := converters.StepTemplateConverter{
 		ErrGroup:                   nil,
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 		ExcludeAllStepTemplates:    args.ExcludeAllStepTemplates,
 		ExcludeStepTemplates:       args.ExcludeStepTemplates,
 		ExcludeStepTemplatesRegex:  args.ExcludeStepTemplatesRegex,
@@ -1289,7 +1594,7 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 	}.ToHcl("space_population", true, args.IncludeProviderServerDetails, &dependencies)
 
 	environmentConverter := converters.EnvironmentConverter{
-		Client:                    &octopusClient,
+		Client:                    octopusClient,
 		ExcludeEnvironments:       args.ExcludeEnvironments,
 		ExcludeAllEnvironments:    args.ExcludeAllEnvironments,
 		ExcludeEnvironmentsExcept: args.ExcludeEnvironmentsExcept,
@@ -1302,7 +1607,7 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 		ErrGroup:                  nil,
 	}
 	parentEnvironmentConverter := converters.ParentEnvironmentConverter{
-		Client:                    &octopusClient,
+		Client:                    octopusClient,
 		ExcludeEnvironments:       args.ExcludeEnvironments,
 		ExcludeAllEnvironments:    args.ExcludeAllEnvironments,
 		ExcludeEnvironmentsExcept: args.ExcludeEnvironmentsExcept,
@@ -1315,9 +1620,10 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 		ErrGroup:                  nil,
 	}
 	gitCredentialsConverter := converters.GitCredentialsConverter{
-		Client:                    &octopusClient,
+		Client:                    octopusClient,
 		DummySecretVariableValues: args.DummySecretVariableValues,
 		DummySecretGenerator:      dummySecretGenerator,
+		SecretProvider:            secretProvider,
 		ExcludeAllGitCredentials:  args.ExcludeAllGitCredentials,
 		ErrGroup:                  nil,
 		IncludeIds:                args.IncludeIds,
@@ -1326,7 +1632,7 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 		GenerateImportScripts:     args.GenerateImportScripts,
 	}
 	tagsetConverter := converters.TagSetConverter{
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 		Excluder:                   converters.DefaultExcluder{},
 		ExcludeTenantTags:          args.ExcludeTenantTags,
 		ExcludeTenantTagSets:       args.ExcludeTenantTagSets,
@@ -1339,7 +1645,7 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 	}
 
 	tenantVariableConverter := converters.TenantVariableConverter{
-		Client:                         &octopusClient,
+		Client:                         octopusClient,
 		ExcludeTenants:                 args.ExcludeTenants,
 		ExcludeTenantsWithTags:         nil,
 		ExcludeTenantsExcept:           args.ExcludeTenantsExcept,
@@ -1360,7 +1666,7 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 		TenantProjectVariableConverter: tenantProjectVariableConverter,
 	}
 	tenantConverter := converters.TenantConverter{
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 		TenantVariableConverter:    tenantVariableConverter,
 		EnvironmentConverter:       environmentConverter,
 		ParentEnvironmentConverter: parentEnvironmentConverter,
@@ -1386,12 +1692,13 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 	}
 
 	accountConverter := converters.AccountConverter{
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 		EnvironmentConverter:       environmentConverter,
 		ParentEnvironmentConverter: parentEnvironmentConverter,
 		TenantConverter:            &tenantConverter,
 		DummySecretVariableValues:  args.DummySecretVariableValues,
 		DummySecretGenerator:       dummySecretGenerator,
+		SecretProvider:             secretProvider,
 		ExcludeTenantTags:          args.ExcludeTenantTags,
 		ExcludeTenantTagSets:       args.ExcludeTenantTagSets,
 		Excluder:                   converters.DefaultExcluder{},
@@ -1408,9 +1715,10 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 	}
 
 	feedConverter := converters.FeedConverter{
-		Client:                    &octopusClient,
+		Client:                    octopusClient,
 		DummySecretVariableValues: args.DummySecretVariableValues,
 		DummySecretGenerator:      dummySecretGenerator,
+		SecretProvider:            secretProvider,
 		ExcludeFeeds:              args.ExcludeFeeds,
 		ExcludeFeedsRegex:         args.ExcludeFeedsRegex,
 		ExcludeFeedsExcept:        args.ExcludeFeedsExcept,
@@ -1423,7 +1731,7 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 		ErrGroup:                  nil,
 	}
 	workerPoolConverter := converters.WorkerPoolConverter{
-		Client:                   &octopusClient,
+		Client:                   octopusClient,
 		ErrGroup:                 nil,
 		ExcludeWorkerpools:       args.ExcludeWorkerpools,
 		ExcludeWorkerpoolsRegex:  args.ExcludeWorkerpoolsRegex,
@@ -1439,11 +1747,11 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 	workerPoolProcessor := converters.OctopusWorkerPoolProcessor{
 		WorkerPoolConverter:     workerPoolConverter,
 		LookupDefaultWorkerPool: args.LookUpDefaultWorkerPools,
-		Client:                  &octopusClient,
+		Client:                  octopusClient,
 	}
 
 	projectConverter := &converters.ProjectConverter{
-		Client:                      &octopusClient,
+		Client:                      octopusClient,
 		LifecycleConverter:          nil,
 		GitCredentialsConverter:     nil,
 		LibraryVariableSetConverter: nil,
@@ -1490,11 +1798,11 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 	}
 
 	runbookConverter := converters.RunbookConverter{
-		Client: &octopusClient,
+		Client: octopusClient,
 		RunbookProcessConverter: &converters.RunbookProcessConverter{
 			DeploymentProcessConverterBase: converters.DeploymentProcessConverterBase{
 				ResourceType:               "RunbookProcesses",
-				Client:                     &octopusClient,
+				Client:                     octopusClient,
 				OctopusActionProcessor:     nil,
 				IgnoreProjectChanges:       args.IgnoreProjectChanges,
 				WorkerPoolProcessor:        workerPoolProcessor,
@@ -1510,10 +1818,12 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 				ExcludeStepsExcept:         args.ExcludeStepsExcept,
 				IgnoreInvalidExcludeExcept: args.IgnoreInvalidExcludeExcept,
 				DummySecretGenerator:       dummySecretGenerator,
+				SecretProvider:             secretProvider,
 				DummySecretVariableValues:  args.DummySecretVariableValues,
 				IgnoreCacErrors:            args.IgnoreCacErrors,
 				DetachProjectTemplates:     args.DetachProjectTemplates,
 				GenerateImportScripts:      args.GenerateImportScripts,
+				ExtractScripts:             args.ExtractScripts,
 			},
 		},
 		EnvironmentConverter:       environmentConverter,
@@ -1549,7 +1859,7 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 		GitCredentialsConverter:    gitCredentialsConverter,
 		StepTemplateConverter:      stepTemplateConverter,
 		ProjectExporter:            projectConverter,
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 	}
 
 	runbookConverter.RunbookProcessConverter.SetActionProcessor(&octopusActionProcessor)
@@ -1561,33 +1871,32 @@ func ConvertRunbookToTerraform(args args.Arguments, version string) (*data.Resou
 	return &dependencies, nil
 }
 
+// ConvertProjectToTerraform exports a project using a client that queries the Octopus API.
 func ConvertProjectToTerraform(args args.Arguments, version string) (*data.ResourceDetailsCollection, error) {
+	return ConvertProjectToTerraformWithClient(args, NewOctopusClient(args, version))
+}
 
-	octopusClient := client.OctopusApiClient{
-		Url:                     args.Url,
-		ApiKey:                  args.ApiKey,
-		AccessToken:             args.AccessToken,
-		Space:                   args.Space,
-		Version:                 version,
-		UseRedirector:           args.UseRedirector,
-		RedirectorHost:          args.RedirectorHost,
-		RedirectorServiceApiKey: args.RedirectorServiceApiKey,
-		RedirecrtorApiKey:       args.RedirecrtorApiKey,
-		RedirectorRedirections:  args.RedirectorRedirections,
-		IgnoreUnauthorized:      args.IgnoreUnauthorized,
-		IgnoreServerError:       args.IgnoreServerError,
-	}
+// ConvertProjectToTerraformWithClient exports a project using the supplied client, which may be recording or replaying
+// the API responses.
+func ConvertProjectToTerraformWithClient(args args.Arguments, octopusClient client.OctopusClient) (*data.ResourceDetailsCollection, error) {
 
-	dummySecretGenerator := dummy.DummySecret{}
+	dummySecretGenerator := createDummySecretGenerator(args)
+	secretProvider, err := createSecretProvider(args)
+
+	if err != nil {
+		return nil, err
+	}
 
 	terraformVariableWriter := variables.DefaultTerraformVariableWriter{
 		ExcludeTerraformVariables:   args.ExcludeTerraformVariables,
 		DummySecretVariableValues:   args.DummySecretVariableValues,
 		DefaultSecretVariableValues: args.DefaultSecretVariableValues,
 		DummySecretGenerator:        dummySecretGenerator,
+		SecretProvider:              secretProvider,
 	}
 
 	tenantCommonVariableProcessor := converters.TenantCommonVariableProcessor{
+		Client:                       octopusClient,
 		Excluder:                     converters.DefaultExcluder{},
 		ExcludeAllProjects:           args.ExcludeAllProjects,
 		ExcludeAllTenantVariables:    args.ExcludeAllTenantVariables,
@@ -1598,6 +1907,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 	}
 
 	tenantProjectVariableConverter := converters.TenantProjectVariableConverter{
+		Client:                       octopusClient,
 		Excluder:                     converters.DefaultExcluder{},
 		ExcludeAllProjects:           args.ExcludeAllProjects,
 		ExcludeAllTenantVariables:    args.ExcludeAllTenantVariables,
@@ -1606,6 +1916,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		ExcludeTenantVariablesRegex:  args.ExcludeTenantVariablesRegex,
 		DummySecretVariableValues:    args.DummySecretVariableValues,
 		DummySecretGenerator:         dummySecretGenerator,
+		SecretProvider:               secretProvider,
 	}
 
 	tenantProjectConverter := converters.TenantProjectConverter{
@@ -1623,14 +1934,14 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		ExcludeProjectsRegex:     args.ExcludeProjectsRegex,
 		ExcludeAllProjects:       args.ExcludeAllProjects,
 		Excluder:                 converters.DefaultExcluder{},
-		Client:                   &octopusClient,
+		Client:                   octopusClient,
 	}
 
 	dependencies := data.ResourceDetailsCollection{}
 
 	stepTemplateConverter := converters.StepTemplateConverter{
 		ErrGroup:                   nil,
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 		ExcludeAllStepTemplates:    args.ExcludeAllStepTemplates,
 		ExcludeStepTemplates:       args.ExcludeStepTemplates,
 		ExcludeStepTemplatesRegex:  args.ExcludeStepTemplatesRegex,
@@ -1654,7 +1965,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 	}.ToHcl("space_population", true, args.IncludeProviderServerDetails, &dependencies)
 
 	environmentConverter := converters.EnvironmentConverter{
-		Client:                    &octopusClient,
+		Client:                    octopusClient,
 		ExcludeEnvironments:       args.ExcludeEnvironments,
 		ExcludeAllEnvironments:    args.ExcludeAllEnvironments,
 		ExcludeEnvironmentsExcept: args.ExcludeEnvironmentsExcept,
@@ -1667,7 +1978,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		ErrGroup:                  nil,
 	}
 	parentEnvironmentConverter := converters.ParentEnvironmentConverter{
-		Client:                    &octopusClient,
+		Client:                    octopusClient,
 		ExcludeEnvironments:       args.ExcludeEnvironments,
 		ExcludeAllEnvironments:    args.ExcludeAllEnvironments,
 		ExcludeEnvironmentsExcept: args.ExcludeEnvironmentsExcept,
@@ -1680,7 +1991,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		ErrGroup:                  nil,
 	}
 	lifecycleConverter := converters.LifecycleConverter{
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 		EnvironmentConverter:       environmentConverter,
 		ErrGroup:                   nil,
 		ParentEnvironmentConverter: parentEnvironmentConverter,
@@ -1695,9 +2006,10 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		GenerateImportScripts:      args.GenerateImportScripts,
 	}
 	gitCredentialsConverter := converters.GitCredentialsConverter{
-		Client:                    &octopusClient,
+		Client:                    octopusClient,
 		DummySecretVariableValues: args.DummySecretVariableValues,
 		DummySecretGenerator:      dummySecretGenerator,
+		SecretProvider:            secretProvider,
 		ExcludeAllGitCredentials:  args.ExcludeAllGitCredentials,
 		ErrGroup:                  nil,
 		IncludeIds:                args.IncludeIds,
@@ -1706,7 +2018,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		GenerateImportScripts:     args.GenerateImportScripts,
 	}
 	tagsetConverter := converters.TagSetConverter{
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 		ExcludeTenantTags:          args.ExcludeTenantTags,
 		ExcludeTenantTagSets:       args.ExcludeTenantTagSets,
 		ExcludeTenantTagSetsRegex:  args.ExcludeTenantTagSetsRegex,
@@ -1718,7 +2030,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		GenerateImportScripts:      args.GenerateImportScripts,
 	}
 	channelConverter := converters.ChannelConverter{
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 		LifecycleConverter:         lifecycleConverter,
 		ParentEnvironmentConverter: parentEnvironmentConverter,
 		ExcludeTenantTags:          args.ExcludeTenantTags,
@@ -1739,7 +2051,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 	}
 
 	projectGroupConverter := converters.ProjectGroupConverter{
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 		ErrGroup:                   nil,
 		ExcludeProjectGroups:       args.ExcludeProjectGroups,
 		ExcludeProjectGroupsRegex:  args.ExcludeProjectGroupsRegex,
@@ -1752,7 +2064,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		GenerateImportScripts:      args.GenerateImportScripts,
 	}
 	tenantVariableConverter := converters.TenantVariableConverter{
-		Client:                         &octopusClient,
+		Client:                         octopusClient,
 		ExcludeTenants:                 args.ExcludeTenants,
 		ExcludeTenantsWithTags:         args.ExcludeTenantsWithTags,
 		ExcludeTenantsExcept:           args.ExcludeTenantsExcept,
@@ -1773,7 +2085,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		TenantProjectVariableConverter: tenantProjectVariableConverter,
 	}
 	tenantConverter := converters.TenantConverter{
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 		TenantVariableConverter:    tenantVariableConverter,
 		EnvironmentConverter:       environmentConverter,
 		ParentEnvironmentConverter: parentEnvironmentConverter,
@@ -1799,7 +2111,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 	}
 
 	machinePolicyConverter := converters.MachinePolicyConverter{
-		Client:                       &octopusClient,
+		Client:                       octopusClient,
 		ExcludeMachinePolicies:       args.ExcludeMachinePolicies,
 		ExcludeMachinePoliciesRegex:  args.ExcludeMachinePoliciesRegex,
 		ExcludeMachinePoliciesExcept: args.ExcludeMachinePoliciesExcept,
@@ -1809,15 +2121,17 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		IncludeIds:                   args.IncludeIds,
 		IncludeSpaceInPopulation:     args.IncludeSpaceInPopulation,
 		GenerateImportScripts:        args.GenerateImportScripts,
+		ExtractScripts:               args.ExtractScripts,
 		ErrGroup:                     nil,
 	}
 	accountConverter := converters.AccountConverter{
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 		EnvironmentConverter:       environmentConverter,
 		ParentEnvironmentConverter: parentEnvironmentConverter,
 		TenantConverter:            &tenantConverter,
 		DummySecretVariableValues:  args.DummySecretVariableValues,
 		DummySecretGenerator:       dummySecretGenerator,
+		SecretProvider:             secretProvider,
 		ExcludeTenantTags:          args.ExcludeTenantTags,
 		ExcludeTenantTagSets:       args.ExcludeTenantTagSets,
 		Excluder:                   converters.DefaultExcluder{},
@@ -1833,9 +2147,10 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		ErrGroup:                   nil,
 	}
 	certificateConverter := converters.CertificateConverter{
-		Client:                    &octopusClient,
+		Client:                    octopusClient,
 		DummySecretVariableValues: args.DummySecretVariableValues,
 		DummySecretGenerator:      dummySecretGenerator,
+		SecretProvider:            secretProvider,
 		ExcludeTenantTags:         args.ExcludeTenantTags,
 		ExcludeTenantTagSets:      args.ExcludeTenantTagSets,
 		Excluder:                  converters.DefaultExcluder{},
@@ -1853,7 +2168,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 
 	kubernetesTargetConverter := converters.KubernetesTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -1882,7 +2197,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 
 	sshTargetConverter := converters.SshTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -1910,7 +2225,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 
 	listeningTargetConverter := converters.ListeningTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -1937,7 +2252,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 
 	pollingTargetConverter := converters.PollingTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -1945,26 +2260,57 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 			ExcludeAllEnvironments:           args.ExcludeAllEnvironments,
 			ExcludeTargetsWithNoEnvironments: args.ExcludeTargetsWithNoEnvironments,
 		},
-		MachinePolicyConverter:     machinePolicyConverter,
-		EnvironmentConverter:       environmentConverter,
-		ParentEnvironmentConverter: parentEnvironmentConverter,
-		ExcludeAllTargets:          args.ExcludeAllTargets,
-		ExcludeTenantTags:          args.ExcludeTenantTags,
-		ExcludeTenantTagSets:       args.ExcludeTenantTagSets,
-		TagSetConverter:            &tagsetConverter,
-		ExcludeTargets:             args.ExcludeTargets,
-		ExcludeTargetsRegex:        args.ExcludeTargetsRegex,
-		ExcludeTargetsExcept:       args.ExcludeTargetsExcept,
-		IncludeIds:                 args.IncludeIds,
-		LimitResourceCount:         args.LimitResourceCount,
-		IncludeSpaceInPopulation:   args.IncludeSpaceInPopulation,
-		GenerateImportScripts:      args.GenerateImportScripts,
-		ErrGroup:                   nil,
+		TargetDependencyConverters: converters.TargetDependencyConverters{
+			MachinePolicyConverter:     machinePolicyConverter,
+			EnvironmentConverter:       environmentConverter,
+			ParentEnvironmentConverter: parentEnvironmentConverter,
+		},
+		ExcludeAllTargets:        args.ExcludeAllTargets,
+		ExcludeTenantTags:        args.ExcludeTenantTags,
+		ExcludeTenantTagSets:     args.ExcludeTenantTagSets,
+		TagSetConverter:          &tagsetConverter,
+		ExcludeTargets:           args.ExcludeTargets,
+		ExcludeTargetsRegex:      args.ExcludeTargetsRegex,
+		ExcludeTargetsExcept:     args.ExcludeTargetsExcept,
+		IncludeIds:               args.IncludeIds,
+		LimitResourceCount:       args.LimitResourceCount,
+		IncludeSpaceInPopulation: args.IncludeSpaceInPopulation,
+		GenerateImportScripts:    args.GenerateImportScripts,
+		ErrGroup:                 nil,
+	}
+
+	kubernetesAgentTargetConverter := converters.KubernetesAgentTargetConverter{
+		TargetConverter: converters.TargetConverter{
+			Client:                           octopusClient,
+			Excluder:                         converters.DefaultExcluder{},
+			ExcludeEnvironments:              args.ExcludeEnvironments,
+			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
+			ExcludeEnvironmentsExcept:        args.ExcludeEnvironmentsExcept,
+			ExcludeAllEnvironments:           args.ExcludeAllEnvironments,
+			ExcludeTargetsWithNoEnvironments: args.ExcludeTargetsWithNoEnvironments,
+		},
+		TargetDependencyConverters: converters.TargetDependencyConverters{
+			MachinePolicyConverter:     machinePolicyConverter,
+			EnvironmentConverter:       environmentConverter,
+			ParentEnvironmentConverter: parentEnvironmentConverter,
+		},
+		ExcludeAllTargets:        args.ExcludeAllTargets,
+		ExcludeTenantTags:        args.ExcludeTenantTags,
+		ExcludeTenantTagSets:     args.ExcludeTenantTagSets,
+		TagSetConverter:          &tagsetConverter,
+		ExcludeTargets:           args.ExcludeTargets,
+		ExcludeTargetsRegex:      args.ExcludeTargetsRegex,
+		ExcludeTargetsExcept:     args.ExcludeTargetsExcept,
+		IncludeIds:               args.IncludeIds,
+		LimitResourceCount:       args.LimitResourceCount,
+		IncludeSpaceInPopulation: args.IncludeSpaceInPopulation,
+		GenerateImportScripts:    args.GenerateImportScripts,
+		ErrGroup:                 nil,
 	}
 
 	cloudRegionTargetConverter := converters.CloudRegionTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -1991,7 +2337,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 
 	offlineDropTargetConverter := converters.OfflineDropTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -2020,7 +2366,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 
 	azureCloudServiceTargetConverter := converters.AzureCloudServiceTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -2048,7 +2394,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 
 	azureServiceFabricTargetConverter := converters.AzureServiceFabricTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -2062,6 +2408,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		ExcludeAllTargets:          args.ExcludeAllTargets,
 		DummySecretVariableValues:  args.DummySecretVariableValues,
 		DummySecretGenerator:       dummySecretGenerator,
+		SecretProvider:             secretProvider,
 		ExcludeTenantTags:          args.ExcludeTenantTags,
 		ExcludeTenantTagSets:       args.ExcludeTenantTagSets,
 		TagSetConverter:            &tagsetConverter,
@@ -2077,7 +2424,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 
 	azureWebAppTargetConverter := converters.AzureWebAppTargetConverter{
 		TargetConverter: converters.TargetConverter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			Excluder:                         converters.DefaultExcluder{},
 			ExcludeEnvironments:              args.ExcludeEnvironments,
 			ExcludeEnvironmentsRegex:         args.ExcludeEnvironmentsRegex,
@@ -2104,9 +2451,10 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 	}
 
 	feedConverter := converters.FeedConverter{
-		Client:                    &octopusClient,
+		Client:                    octopusClient,
 		DummySecretVariableValues: args.DummySecretVariableValues,
 		DummySecretGenerator:      dummySecretGenerator,
+		SecretProvider:            secretProvider,
 		ExcludeFeeds:              args.ExcludeFeeds,
 		ExcludeFeedsRegex:         args.ExcludeFeedsRegex,
 		ExcludeFeedsExcept:        args.ExcludeFeedsExcept,
@@ -2119,7 +2467,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		ErrGroup:                  nil,
 	}
 	workerPoolConverter := converters.WorkerPoolConverter{
-		Client:                   &octopusClient,
+		Client:                   octopusClient,
 		ErrGroup:                 nil,
 		ExcludeWorkerpools:       args.ExcludeWorkerpools,
 		ExcludeWorkerpoolsRegex:  args.ExcludeWorkerpoolsRegex,
@@ -2133,7 +2481,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 	}
 
 	variableSetConverter := converters.VariableSetConverter{
-		Client:                            &octopusClient,
+		Client:                            octopusClient,
 		ChannelConverter:                  channelConverter,
 		EnvironmentConverter:              environmentConverter,
 		ParentEnvironmentConverter:        parentEnvironmentConverter,
@@ -2146,6 +2494,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		ListeningTargetConverter:          listeningTargetConverter,
 		OfflineDropTargetConverter:        offlineDropTargetConverter,
 		PollingTargetConverter:            pollingTargetConverter,
+		KubernetesAgentTargetConverter:    kubernetesAgentTargetConverter,
 		SshTargetConverter:                sshTargetConverter,
 		AccountConverter:                  accountConverter,
 		FeedConverter:                     feedConverter,
@@ -2169,7 +2518,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		StatelessAdditionalParams:         args.StatelessAdditionalParams,
 		GenerateImportScripts:             args.GenerateImportScripts,
 		EnvironmentFilter: converters.EnvironmentFilter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			ExcludeVariableEnvironmentScopes: args.ExcludeVariableEnvironmentScopes,
 		},
 		IgnoreCacErrors:         args.IgnoreCacErrors,
@@ -2178,7 +2527,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 	}
 
 	variableSetConverterForLibrary := converters.VariableSetConverter{
-		Client:                            &octopusClient,
+		Client:                            octopusClient,
 		ChannelConverter:                  channelConverter,
 		EnvironmentConverter:              environmentConverter,
 		ParentEnvironmentConverter:        parentEnvironmentConverter,
@@ -2191,6 +2540,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		ListeningTargetConverter:          listeningTargetConverter,
 		OfflineDropTargetConverter:        offlineDropTargetConverter,
 		PollingTargetConverter:            pollingTargetConverter,
+		KubernetesAgentTargetConverter:    kubernetesAgentTargetConverter,
 		SshTargetConverter:                sshTargetConverter,
 		AccountConverter:                  accountConverter,
 		FeedConverter:                     feedConverter,
@@ -2215,7 +2565,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		StatelessAdditionalParams:         args.StatelessAdditionalParams,
 		GenerateImportScripts:             args.GenerateImportScripts,
 		EnvironmentFilter: converters.EnvironmentFilter{
-			Client:                           &octopusClient,
+			Client:                           octopusClient,
 			ExcludeVariableEnvironmentScopes: args.ExcludeVariableEnvironmentScopes,
 		},
 		IgnoreCacErrors:      args.IgnoreCacErrors,
@@ -2223,7 +2573,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 	}
 
 	libraryVariableSetConverter := converters.LibraryVariableSetConverter{
-		Client:                           &octopusClient,
+		Client:                           octopusClient,
 		VariableSetConverter:             &variableSetConverterForLibrary,
 		Excluded:                         args.ExcludeLibraryVariableSets,
 		ExcludeLibraryVariableSetsRegex:  args.ExcludeLibraryVariableSetsRegex,
@@ -2234,22 +2584,23 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		Excluder:                         converters.DefaultExcluder{},
 		LimitResourceCount:               args.LimitResourceCount,
 		GenerateImportScripts:            args.GenerateImportScripts,
+		ExtractScripts:                   args.ExtractScripts,
 		ErrGroup:                         nil,
 	}
 
 	workerPoolProcessor := converters.OctopusWorkerPoolProcessor{
 		WorkerPoolConverter:     workerPoolConverter,
 		LookupDefaultWorkerPool: args.LookUpDefaultWorkerPools,
-		Client:                  &octopusClient,
+		Client:                  octopusClient,
 		ErrGroup:                nil,
 	}
 
 	runbookConverter := converters.RunbookConverter{
-		Client: &octopusClient,
+		Client: octopusClient,
 		RunbookProcessConverter: &converters.RunbookProcessConverter{
 			DeploymentProcessConverterBase: converters.DeploymentProcessConverterBase{
 				ResourceType:               "RunbookProcesses",
-				Client:                     &octopusClient,
+				Client:                     octopusClient,
 				OctopusActionProcessor:     nil,
 				IgnoreProjectChanges:       args.IgnoreProjectChanges,
 				WorkerPoolProcessor:        workerPoolProcessor,
@@ -2265,9 +2616,11 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 				ExcludeStepsExcept:         args.ExcludeStepsExcept,
 				IgnoreInvalidExcludeExcept: args.IgnoreInvalidExcludeExcept,
 				DummySecretGenerator:       dummySecretGenerator,
+				SecretProvider:             secretProvider,
 				DummySecretVariableValues:  args.DummySecretVariableValues,
 				IgnoreCacErrors:            args.IgnoreCacErrors,
 				GenerateImportScripts:      args.GenerateImportScripts,
+				ExtractScripts:             args.ExtractScripts,
 			},
 		},
 		EnvironmentConverter:       environmentConverter,
@@ -2289,7 +2642,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 	}
 
 	projectConverter := converters.ProjectConverter{
-		Client:                      &octopusClient,
+		Client:                      octopusClient,
 		LifecycleConverter:          lifecycleConverter,
 		GitCredentialsConverter:     gitCredentialsConverter,
 		LibraryVariableSetConverter: &libraryVariableSetConverter,
@@ -2297,7 +2650,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		DeploymentProcessConverter: &converters.DeploymentProcessConverter{
 			DeploymentProcessConverterBase: converters.DeploymentProcessConverterBase{
 				ResourceType:               "DeploymentProcesses",
-				Client:                     &octopusClient,
+				Client:                     octopusClient,
 				OctopusActionProcessor:     nil,
 				IgnoreProjectChanges:       args.IgnoreProjectChanges,
 				WorkerPoolProcessor:        workerPoolProcessor,
@@ -2313,15 +2666,17 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 				ExcludeStepsExcept:         args.ExcludeStepsExcept,
 				IgnoreInvalidExcludeExcept: args.IgnoreInvalidExcludeExcept,
 				DummySecretGenerator:       dummySecretGenerator,
+				SecretProvider:             secretProvider,
 				DummySecretVariableValues:  args.DummySecretVariableValues,
 				IgnoreCacErrors:            args.IgnoreCacErrors,
 				DetachProjectTemplates:     args.DetachProjectTemplates,
 				GenerateImportScripts:      args.GenerateImportScripts,
+				ExtractScripts:             args.ExtractScripts,
 			},
 		},
 		TenantConverter: &tenantConverter,
 		ProjectTriggerConverter: converters.ProjectTriggerConverter{
-			Client:                     &octopusClient,
+			Client:                     octopusClient,
 			LimitResourceCount:         args.LimitResourceCount,
 			IncludeIds:                 false,
 			GenerateImportScripts:      args.GenerateImportScripts,
@@ -2349,6 +2704,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		ExcludeAllProjects:         false,
 		DummySecretVariableValues:  args.DummySecretVariableValues,
 		DummySecretGenerator:       dummySecretGenerator,
+		SecretProvider:             secretProvider,
 		Excluder:                   converters.DefaultExcluder{},
 		LookupOnlyMode:             false,
 		ErrGroup:                   nil,
@@ -2385,7 +2741,7 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 		GitCredentialsConverter:    gitCredentialsConverter,
 		StepTemplateConverter:      stepTemplateConverter,
 		ProjectExporter:            &projectConverter,
-		Client:                     &octopusClient,
+		Client:                     octopusClient,
 	}
 
 	// Projects and runbooks have circular references to other projects. For example, a project can have
@@ -2404,13 +2760,26 @@ func ConvertProjectToTerraform(args args.Arguments, version string) (*data.Resou
 
 // ProcessResources creates a map of file names to file content
 func ProcessResources(resources []data.ResourceDetails) (map[string]string, error) {
-	zap.L().Info("Generating HCL (this can take a little while)")
-	defer zap.L().Info("Done Generating HCL")
+	return ProcessResourcesWithContext(context.Background(), zap.L(), resources, nil)
+}
+
+// ProcessResourcesWithContext generates the HCL for the resources, returning the context error if the context is
+// done before all the HCL is generated. The progress function, which may be nil, is called as each resource is
+// processed.
+func ProcessResourcesWithContext(ctx context.Context, logger *zap.Logger, resources []data.ResourceDetails, progress ProgressFunc) (map[string]string, error) {
+	logger.Info("Generating HCL (this can take a little while)")
+	defer logger.Info("Done Generating HCL")
 
 	var wg sync.WaitGroup
 	var fileMap sync.Map
+	var completed atomic.Int64
 	hclErrors := collections.SafeErrorSlice{}
 
+	total := len(lo.Filter(resources, func(item data.ResourceDetails, index int) bool {
+		return item.ToHcl != nil
+	}))
+	progress.report(ProgressStageGenerating, 0, total)
+
 	for _, r := range resources {
 		// Some resources are already resolved by their parent, but exist in the resource details map as a lookup.
 		// In these cases, ToHclByProjectId is nil.
@@ -2418,11 +2787,20 @@ func ProcessResources(resources []data.ResourceDetails) (map[string]string, erro
 			continue
 		}
 
+		// Stop generating HCL once the export has been cancelled
+		if ctx.Err() != nil {
+			break
+		}
+
 		wg.Add(1)
 
 		resource := r
 		go func() {
 			defer wg.Done()
+			defer func() {
+				progress.report(ProgressStageGenerating, int(completed.Add(1)), total)
+			}()
+
 			hcl, err := resource.ToHcl()
 
 			if err != nil {
@@ -2436,6 +2814,11 @@ func ProcessResources(resources []data.ResourceDetails) (map[string]string, erro
 	}
 
 	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if len(hclErrors.GetCopy()) != 0 {
 		return nil, errors.Join(hclErrors.GetCopy()...)
 	}
@@ -0,0 +1,30 @@
+package entry
+
+const (
+	// ProgressStageLoading is reported while the resources are loaded from the Octopus API
+	ProgressStageLoading = "loading"
+	// ProgressStageGenerating is reported as the files are generated from the loaded resources
+	ProgressStageGenerating = "generating"
+	// ProgressStageComplete is reported once the export is complete
+	ProgressStageComplete = "complete"
+)
+
+// Progress describes how far an export has progressed.
+type Progress struct {
+	// Stage is one of the ProgressStage constants
+	Stage string `json:"stage"`
+	// Completed is the number of items processed in the current stage
+	Completed int `json:"completed"`
+	// Total is the number of items to process in the current stage, or 0 if it is not known
+	Total int `json:"total"`
+}
+
+// ProgressFunc receives the progress of an export. It may be called from multiple goroutines.
+type ProgressFunc func(progress Progress)
+
+// report calls the function if it is defined.
+func (f ProgressFunc) report(stage string, completed int, total int) {
+	if f != nil {
+		f(Progress{Stage: stage, Completed: completed, Total: total})
+	}
+}
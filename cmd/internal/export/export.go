@@ -0,0 +1,112 @@
+// Package export runs an export defined by a complete set of octoterra arguments. It is used by the octoterra
+// applications, which build the arguments themselves, and by the public pkg/octoterra package.
+package export
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/entry"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/generators"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+	"go.uber.org/zap"
+)
+
+// Files maps the names of the exported files to their contents.
+type Files map[string]string
+
+// String returns the contents of every file, ordered by file name.
+func (f Files) String() string {
+	var sb strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(f)) {
+		sb.WriteString(f[name] + "\n\n")
+	}
+	return sb.String()
+}
+
+// Writer saves the exported files, returning a description of where the files were written.
+type Writer interface {
+	Write(files map[string]string) (string, error)
+}
+
+// Options defines the export.
+type Options struct {
+	// Arguments define the export, including the Octopus server and credentials
+	Arguments args.Arguments
+	// Version is the version of the calling application, which is included in the user agent of API requests
+	Version string
+	// Client queries the Octopus API. When nil, a client is created from the arguments.
+	Client client.OctopusClient
+	// Logger receives the messages describing the export as a whole. When nil, the global zap logger is used.
+	Logger *zap.Logger
+	// Writer saves the exported files. When nil, the files are only returned.
+	Writer Writer
+	// Progress is called as the export progresses. It may be called from multiple goroutines.
+	Progress func(progress entry.Progress)
+}
+
+// Export exports the Octopus resources defined by the options. It returns the exported files, which are ready to
+// be saved without further processing, and the manifest describing the exported resources. The manifest is empty
+// for stateless step template exports.
+func Export(ctx context.Context, options Options) (Files, generators.ExportManifest, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, generators.ExportManifest{}, err
+	}
+
+	arguments, err := validateArguments(options.Arguments, options.Client != nil)
+
+	if err != nil {
+		return nil, generators.ExportManifest{}, err
+	}
+
+	files, manifest, err := entry.EntryWithClient(ctx, arguments, options.Client, options.Version, options.Progress, options.Logger)
+
+	if err != nil {
+		return nil, generators.ExportManifest{}, err
+	}
+
+	exported := Files(strutil.UnEscapeDollarInMap(files))
+
+	if options.Writer != nil {
+		if _, err := options.Writer.Write(exported); err != nil {
+			return nil, generators.ExportManifest{}, err
+		}
+	}
+
+	if manifest == nil {
+		return exported, generators.ExportManifest{}, nil
+	}
+
+	return exported, *manifest, nil
+}
+
+// validateArguments returns an error if the arguments do not define a valid export, and otherwise returns the
+// arguments with the settings that do not apply to the export removed.
+func validateArguments(arguments args.Arguments, hasClient bool) (args.Arguments, error) {
+	// Replaying a snapshot or supplying a client does not require the details of the Octopus server
+	if !hasClient && arguments.ReplaySnapshot == "" {
+		if arguments.Url == "" {
+			return args.Arguments{}, errors.New("the Octopus URL must be defined")
+		}
+
+		if arguments.ApiKey == "" && arguments.AccessToken == "" {
+			return args.Arguments{}, errors.New("the Octopus API key or access token must be defined")
+		}
+	}
+
+	if err := arguments.Validate(); err != nil {
+		return args.Arguments{}, err
+	}
+
+	// Stateless exports do not generate scripts
+	if arguments.Stateless {
+		arguments.GenerateImportScripts = false
+	}
+
+	return arguments, nil
+}
@@ -0,0 +1,34 @@
+package generators
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ChecksumsFileName is the name of the file holding the checksums of the exported files.
+const ChecksumsFileName = "checksums.txt"
+
+// ChecksumsGenerator lists the SHA-256 checksum of each exported file. The output uses the format written by
+// sha256sum, so the files can be verified with "sha256sum -c checksums.txt".
+type ChecksumsGenerator struct {
+}
+
+// Generate returns the contents of the checksums file, with the files sorted by name. Any existing checksums file is
+// excluded.
+func (g ChecksumsGenerator) Generate(files map[string]string) string {
+	builder := strings.Builder{}
+
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		if name == ChecksumsFileName {
+			continue
+		}
+
+		checksum := sha256.Sum256([]byte(files[name]))
+		builder.WriteString(hex.EncodeToString(checksum[:]) + "  " + name + "\n")
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,20 @@
+package generators
+
+import (
+	"testing"
+)
+
+func TestChecksumsGenerator(t *testing.T) {
+	checksums := ChecksumsGenerator{}.Generate(map[string]string{
+		"space_population/b.tf": "",
+		"a.tf":                  "hello",
+		ChecksumsFileName:       "ignored",
+	})
+
+	expected := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824  a.tf\n" +
+		"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  space_population/b.tf\n"
+
+	if checksums != expected {
+		t.Fatalf("unexpected checksums %q", checksums)
+	}
+}
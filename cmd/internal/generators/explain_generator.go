@@ -0,0 +1,115 @@
+package generators
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/samber/lo"
+)
+
+// ExplainFileName is the name of the file holding the explanation of why a resource was included in an export.
+const ExplainFileName = "explain.txt"
+
+// ExplainGenerator reports the chain of references that caused a resource to be included in an export.
+type ExplainGenerator struct {
+}
+
+// Generate returns a description of why the resources matching the name or ID were included in the export. Each
+// reason is followed by the reasons its parent was included, so the full chain back to a directly exported resource
+// is displayed.
+func (g ExplainGenerator) Generate(collection *data.ResourceDetailsCollection, nameOrId string) string {
+	matches := g.findResources(collection, nameOrId)
+
+	if len(matches) == 0 {
+		return "No exported resource has the name or ID \"" + nameOrId + "\"\n"
+	}
+
+	builder := strings.Builder{}
+
+	for _, resource := range matches {
+		builder.WriteString(g.describe(resource.Id, resource.ResourceType, resource.Name) + "\n")
+		g.explain(collection, resource.Id, resource.ParentId, resource.ImmediateParentId, 1, map[string]bool{resource.Id: true}, &builder)
+	}
+
+	return builder.String()
+}
+
+// findResources returns the resources whose ID matches exactly, or whose name matches case-insensitively. Resources
+// that share an ID, like a resource and the data source looking it up, are reported once.
+func (g ExplainGenerator) findResources(collection *data.ResourceDetailsCollection, nameOrId string) []data.ResourceDetails {
+	matches := lo.Filter(collection.Resources, func(item data.ResourceDetails, index int) bool {
+		return item.Id != "" && (item.Id == nameOrId || strings.EqualFold(item.Name, nameOrId))
+	})
+
+	matches = lo.UniqBy(matches, func(item data.ResourceDetails) string {
+		return item.Id
+	})
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Id < matches[j].Id
+	})
+
+	return matches
+}
+
+func (g ExplainGenerator) explain(collection *data.ResourceDetailsCollection, id string, parentId string, immediateParentId string, depth int, visited map[string]bool, builder *strings.Builder) {
+	indent := strings.Repeat("  ", depth)
+	reasons := collection.GetInclusionReasons(id)
+
+	if len(reasons) == 0 {
+		// Resources like steps and variables are exported as children of their parent rather than being referenced
+		parent := immediateParentId
+		if parent == "" || parent == id {
+			parent = parentId
+		}
+
+		if parent == "" || parent == id {
+			builder.WriteString(indent + "was exported directly\n")
+			return
+		}
+
+		reasons = []data.InclusionReason{{ParentId: parent, Field: "child resource"}}
+	}
+
+	for _, reason := range reasons {
+		parents := collection.GetResourcesByOctopusId(reason.ParentId)
+		parentType := ""
+		parentName := reason.ParentName
+		grandparentId := ""
+		immediateGrandparentId := ""
+
+		if len(parents) != 0 {
+			parentType = parents[0].ResourceType
+			parentName = lo.Ternary(parentName == "", parents[0].Name, parentName)
+			grandparentId = parents[0].ParentId
+			immediateGrandparentId = parents[0].ImmediateParentId
+		}
+
+		builder.WriteString(indent + "was included by " + g.describe(reason.ParentId, parentType, parentName) +
+			" through " + reason.Field + "\n")
+
+		if visited[reason.ParentId] {
+			builder.WriteString(indent + "  (circular reference)\n")
+			continue
+		}
+
+		visited[reason.ParentId] = true
+		g.explain(collection, reason.ParentId, grandparentId, immediateGrandparentId, depth+1, visited, builder)
+		delete(visited, reason.ParentId)
+	}
+}
+
+func (g ExplainGenerator) describe(id string, resourceType string, name string) string {
+	description := id
+
+	if name != "" {
+		description = "\"" + name + "\" (" + id + ")"
+	}
+
+	if resourceType != "" {
+		description = resourceType + " " + description
+	}
+
+	return description
+}
@@ -0,0 +1,56 @@
+package generators
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+)
+
+func TestExplainGenerator(t *testing.T) {
+	collection := data.ResourceDetailsCollection{}
+
+	collection.AddResource(
+		data.ResourceDetails{Id: "Projects-1", ResourceType: "Projects", Name: "My Project"},
+		data.ResourceDetails{Id: "deploymentprocess-Projects-1", ResourceType: "DeploymentProcesses", ParentId: "Projects-1"})
+
+	collection.RecordInclusion("deploymentprocess-Projects-1", data.InclusionReason{ParentId: "Projects-1", ParentName: "My Project", Field: "process"})
+	collection.RecordInclusion("Accounts-1", data.InclusionReason{ParentId: "deploymentprocess-Projects-1", Field: "step \"Deploy\" action \"Deploy\" property \"Octopus.Action.Azure.AccountId\""})
+
+	collection.AddResource(data.ResourceDetails{Id: "Accounts-1", ResourceType: "Accounts", Name: "Azure"})
+
+	explanation := ExplainGenerator{}.Generate(&collection, "azure")
+	lines := strings.Split(strings.TrimSpace(explanation), "\n")
+
+	expected := []string{
+		"Accounts \"Azure\" (Accounts-1)",
+		"  was included by DeploymentProcesses deploymentprocess-Projects-1 through step \"Deploy\" action \"Deploy\" property \"Octopus.Action.Azure.AccountId\"",
+		"    was included by Projects \"My Project\" (Projects-1) through process",
+		"      was exported directly",
+	}
+
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Unexpected explanation:\n%s", explanation)
+	}
+
+	if !strings.HasPrefix(ExplainGenerator{}.Generate(&collection, "Missing"), "No exported resource") {
+		t.Fatalf("Missing resources must be reported")
+	}
+}
+
+func TestExplainGeneratorCircularReference(t *testing.T) {
+	collection := data.ResourceDetailsCollection{}
+
+	collection.AddResource(
+		data.ResourceDetails{Id: "Projects-1", ResourceType: "Projects", Name: "First"},
+		data.ResourceDetails{Id: "Projects-2", ResourceType: "Projects", Name: "Second"})
+
+	collection.RecordInclusion("Projects-1", data.InclusionReason{ParentId: "Projects-2", Field: "project reference"})
+	collection.RecordInclusion("Projects-2", data.InclusionReason{ParentId: "Projects-1", Field: "project reference"})
+
+	explanation := ExplainGenerator{}.Generate(&collection, "Projects-1")
+
+	if !strings.Contains(explanation, "(circular reference)") {
+		t.Fatalf("Circular references must be reported:\n%s", explanation)
+	}
+}
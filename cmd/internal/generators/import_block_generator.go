@@ -0,0 +1,69 @@
+package generators
+
+import (
+	"regexp"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hcl"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+	"github.com/hashicorp/hcl2/gohcl"
+	"github.com/hashicorp/hcl2/hclwrite"
+)
+
+// resourceLookupRegex matches the lookup of a managed resource, like "${octopusdeploy_project.my_project.id}".
+// Data sources and resources with a count (which are created by stateless modules) do not match.
+var resourceLookupRegex = regexp.MustCompile(`^\$\{(octopusdeploy_[a-z0-9_]+\.[A-Za-z0-9_\-]+)\.id}$`)
+
+// ImportBlockGenerator adds Terraform import blocks alongside each exported resource. This is an alternative to
+// the Bash and Powershell import scripts: the IDs of the existing resources are known at export time, so
+// adopting the existing resources in a space only requires a "terraform plan" and "terraform apply".
+type ImportBlockGenerator struct {
+}
+
+// AddImportBlocks wraps the ToHcl function of each managed resource to append an import block to the
+// generated HCL.
+func (s ImportBlockGenerator) AddImportBlocks(collection *data.ResourceDetailsCollection) {
+	for i := range collection.Resources {
+		resource := &collection.Resources[i]
+
+		if resource.ToHcl == nil {
+			continue
+		}
+
+		importId := strutil.DefaultIfEmpty(resource.ImportId, resource.Id)
+
+		if importId == "" {
+			continue
+		}
+
+		matches := resourceLookupRegex.FindStringSubmatch(resource.Lookup)
+
+		if matches == nil {
+			continue
+		}
+
+		address := matches[1]
+		toHcl := resource.ToHcl
+
+		resource.ToHcl = func() (string, error) {
+			resourceHcl, err := toHcl()
+
+			if err != nil {
+				return "", err
+			}
+
+			return resourceHcl + s.generateImportBlock(address, importId), nil
+		}
+	}
+}
+
+func (s ImportBlockGenerator) generateImportBlock(address string, importId string) string {
+	file := hclwrite.NewEmptyFile()
+	block := gohcl.EncodeAsBlock(terraform.TerraformImport{To: address, Id: importId}, "import")
+	// The address of the resource is a reference rather than a string
+	hcl.WriteUnquotedAttribute(block, "to", address)
+	file.Body().AppendBlock(block)
+
+	return "\n" + string(file.Bytes())
+}
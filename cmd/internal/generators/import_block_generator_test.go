@@ -0,0 +1,94 @@
+package generators
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+)
+
+func TestAddImportBlocks(t *testing.T) {
+	collection := data.ResourceDetailsCollection{}
+	collection.AddResource(
+		data.ResourceDetails{
+			Id:           "Projects-1",
+			ResourceType: "Projects",
+			Lookup:       "${octopusdeploy_project.project_my_project.id}",
+			ToHcl: func() (string, error) {
+				return "resource \"octopusdeploy_project\" \"project_my_project\" {}\n", nil
+			},
+		},
+		data.ResourceDetails{
+			Id:           "Environments-1",
+			ResourceType: "Environments",
+			Lookup:       "${octopusdeploy_environment.environment_dev[0].id}",
+			ToHcl: func() (string, error) {
+				return "resource \"octopusdeploy_environment\" \"environment_dev\" {}\n", nil
+			},
+		},
+		data.ResourceDetails{
+			Id:           "Feeds-1",
+			ResourceType: "Feeds",
+			Lookup:       "${data.octopusdeploy_feeds.feed_docker.feeds[0].id}",
+			ToHcl: func() (string, error) {
+				return "data \"octopusdeploy_feeds\" \"feed_docker\" {}\n", nil
+			},
+		})
+
+	ImportBlockGenerator{}.AddImportBlocks(&collection)
+
+	projectHcl, err := collection.Resources[0].ToHcl()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(projectHcl, "to = octopusdeploy_project.project_my_project") || !strings.Contains(projectHcl, "id = \"Projects-1\"") {
+		t.Fatalf("The project import block was not generated: %s", projectHcl)
+	}
+
+	environmentHcl, err := collection.Resources[1].ToHcl()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(environmentHcl, "import") {
+		t.Fatalf("Resources with a count must not be imported: %s", environmentHcl)
+	}
+
+	feedHcl, err := collection.Resources[2].ToHcl()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(feedHcl, "import") {
+		t.Fatalf("Data sources must not be imported: %s", feedHcl)
+	}
+}
+
+func TestAddImportBlocksWithCompositeId(t *testing.T) {
+	collection := data.ResourceDetailsCollection{}
+	collection.AddResource(data.ResourceDetails{
+		Id:           "Projects-1/deploymentprocess-Projects-1/step-1",
+		ImportId:     "deploymentprocess-Projects-1:step-1",
+		ResourceType: "DeploymentProcesses/Steps",
+		Lookup:       "${octopusdeploy_process_step.process_step_my_project_step.id}",
+		ToHcl: func() (string, error) {
+			return "resource \"octopusdeploy_process_step\" \"process_step_my_project_step\" {}\n", nil
+		},
+	})
+
+	ImportBlockGenerator{}.AddImportBlocks(&collection)
+
+	stepHcl, err := collection.Resources[0].ToHcl()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(stepHcl, "id = \"deploymentprocess-Projects-1:step-1\"") {
+		t.Fatalf("The step import block did not use the composite ID: %s", stepHcl)
+	}
+}
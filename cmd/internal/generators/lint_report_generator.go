@@ -0,0 +1,160 @@
+package generators
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+)
+
+const LintReportFileName = "lint_report.json"
+
+const lintReportFormatVersion = 1
+
+// lintSeverityOrder sorts the findings of a resource from the most to the least severe.
+var lintSeverityOrder = []data.LintSeverity{data.LintSeverityError, data.LintSeverityLossy, data.LintSeverityWarning}
+
+// LintReport lists the issues found while exporting the resources, grouped by resource.
+type LintReport struct {
+	FormatVersion int                  `json:"formatVersion"`
+	Errors        int                  `json:"errors"`
+	Lossy         int                  `json:"lossy"`
+	Warnings      int                  `json:"warnings"`
+	Resources     []LintReportResource `json:"resources"`
+}
+
+// LintReportResource lists the issues found while exporting a resource.
+type LintReportResource struct {
+	ResourceType string              `json:"resourceType"`
+	ResourceId   string              `json:"resourceId,omitempty"`
+	ResourceName string              `json:"resourceName,omitempty"`
+	Findings     []LintReportFinding `json:"findings"`
+}
+
+// LintReportFinding is an issue found while exporting a resource.
+type LintReportFinding struct {
+	Severity data.LintSeverity `json:"severity"`
+	Message  string            `json:"message"`
+}
+
+// HasSeverity returns true if the report has any findings with the severity, or a more severe one.
+func (r LintReport) HasSeverity(severity data.LintSeverity) bool {
+	switch severity {
+	case data.LintSeverityError:
+		return r.Errors != 0
+	case data.LintSeverityLossy:
+		return r.Errors+r.Lossy != 0
+	default:
+		return r.Errors+r.Lossy+r.Warnings != 0
+	}
+}
+
+// LintReportGenerator builds the lint report from the findings collected by the converters.
+type LintReportGenerator struct {
+}
+
+// Build returns the report of the findings in the collection. Resources are sorted by type, name and ID, and the
+// findings of each resource are sorted by severity.
+func (g LintReportGenerator) Build(collection *data.ResourceDetailsCollection) LintReport {
+	report := LintReport{
+		FormatVersion: lintReportFormatVersion,
+		Resources:     []LintReportResource{},
+	}
+
+	resources := map[string]*LintReportResource{}
+
+	for _, finding := range collection.LintFindings {
+		key := finding.ResourceType + "/" + finding.ResourceId
+
+		resource, ok := resources[key]
+		if !ok {
+			resource = &LintReportResource{
+				ResourceType: finding.ResourceType,
+				ResourceId:   finding.ResourceId,
+				ResourceName: finding.ResourceName,
+				Findings:     []LintReportFinding{},
+			}
+			resources[key] = resource
+		}
+
+		resource.Findings = append(resource.Findings, LintReportFinding{
+			Severity: finding.Severity,
+			Message:  finding.Message,
+		})
+
+		switch finding.Severity {
+		case data.LintSeverityError:
+			report.Errors++
+		case data.LintSeverityLossy:
+			report.Lossy++
+		default:
+			report.Warnings++
+		}
+	}
+
+	for _, resource := range resources {
+		sort.SliceStable(resource.Findings, func(i, j int) bool {
+			left := slices.Index(lintSeverityOrder, resource.Findings[i].Severity)
+			right := slices.Index(lintSeverityOrder, resource.Findings[j].Severity)
+
+			if left != right {
+				return left < right
+			}
+
+			return resource.Findings[i].Message < resource.Findings[j].Message
+		})
+
+		report.Resources = append(report.Resources, *resource)
+	}
+
+	sort.SliceStable(report.Resources, func(i, j int) bool {
+		left := report.Resources[i]
+		right := report.Resources[j]
+
+		if left.ResourceType != right.ResourceType {
+			return left.ResourceType < right.ResourceType
+		}
+
+		if left.ResourceName != right.ResourceName {
+			return left.ResourceName < right.ResourceName
+		}
+
+		return left.ResourceId < right.ResourceId
+	})
+
+	return report
+}
+
+// Json returns the report as JSON.
+func (g LintReportGenerator) Json(report LintReport) ([]byte, error) {
+	return json.MarshalIndent(report, "", "  ")
+}
+
+// Text returns the report as a summary line followed by the findings of each resource.
+func (g LintReportGenerator) Text(report LintReport) string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("%d errors, %d lossy conversions, %d warnings\n", report.Errors, report.Lossy, report.Warnings))
+
+	for _, resource := range report.Resources {
+		builder.WriteString("\n" + resource.ResourceType)
+
+		if resource.ResourceName != "" {
+			builder.WriteString(" \"" + resource.ResourceName + "\"")
+		}
+
+		if resource.ResourceId != "" {
+			builder.WriteString(" (" + resource.ResourceId + ")")
+		}
+
+		builder.WriteString("\n")
+
+		for _, finding := range resource.Findings {
+			builder.WriteString("  " + string(finding.Severity) + ": " + finding.Message + "\n")
+		}
+	}
+
+	return builder.String()
+}
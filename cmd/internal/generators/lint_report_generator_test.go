@@ -0,0 +1,54 @@
+package generators
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+)
+
+func TestLintReportGenerator(t *testing.T) {
+	collection := data.ResourceDetailsCollection{}
+	collection.AddLintFinding(data.LintFinding{Severity: data.LintSeverityWarning, ResourceType: "DeploymentProcesses", ResourceId: "Projects-1", ResourceName: "Web", Message: "The action \"Deploy\" is from the step framework."})
+	collection.AddLintFinding(data.LintFinding{Severity: data.LintSeverityLossy, ResourceType: "DeploymentProcesses", ResourceId: "Projects-1", ResourceName: "Web", Message: "The step \"Empty\" has no actions, so it was not exported."})
+	collection.AddLintFinding(data.LintFinding{Severity: data.LintSeverityError, ResourceType: "Channels", ResourceId: "Channels-1", ResourceName: "Hotfix", Message: "The channel is invalid."})
+	// Duplicate findings are ignored
+	collection.AddLintFinding(data.LintFinding{Severity: data.LintSeverityError, ResourceType: "Channels", ResourceId: "Channels-1", ResourceName: "Hotfix", Message: "The channel is invalid."})
+
+	generator := LintReportGenerator{}
+	report := generator.Build(&collection)
+
+	if report.Errors != 1 || report.Lossy != 1 || report.Warnings != 1 {
+		t.Fatalf("unexpected counts %+v", report)
+	}
+
+	if len(report.Resources) != 2 || report.Resources[0].ResourceType != "Channels" || report.Resources[1].ResourceType != "DeploymentProcesses" {
+		t.Fatalf("unexpected resources %+v", report.Resources)
+	}
+
+	if len(report.Resources[1].Findings) != 2 || report.Resources[1].Findings[0].Severity != data.LintSeverityLossy {
+		t.Fatalf("findings were not sorted by severity %+v", report.Resources[1].Findings)
+	}
+
+	if !report.HasSeverity(data.LintSeverityError) {
+		t.Fatalf("the report must have errors")
+	}
+
+	text := generator.Text(report)
+
+	if !strings.HasPrefix(text, "1 errors, 1 lossy conversions, 1 warnings\n") ||
+		!strings.Contains(text, "Channels \"Hotfix\" (Channels-1)\n  error: The channel is invalid.\n") {
+		t.Fatalf("unexpected text report %q", text)
+	}
+}
+
+func TestLintReportSeverity(t *testing.T) {
+	collection := data.ResourceDetailsCollection{}
+	collection.AddLintFinding(data.LintFinding{Severity: data.LintSeverityLossy, ResourceType: "ProjectTriggers", ResourceId: "ProjectTriggers-1", Message: "The trigger was not exported."})
+
+	report := LintReportGenerator{}.Build(&collection)
+
+	if report.HasSeverity(data.LintSeverityError) || !report.HasSeverity(data.LintSeverityLossy) || !report.HasSeverity(data.LintSeverityWarning) {
+		t.Fatalf("unexpected severity checks for %+v", report)
+	}
+}
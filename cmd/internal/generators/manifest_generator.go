@@ -0,0 +1,208 @@
+package generators
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/samber/lo"
+)
+
+const ManifestFileName = "export_manifest.json"
+const manifestFormatVersion = 1
+
+// ManifestKindResource is a resource created by the exported module.
+const ManifestKindResource = "resource"
+
+// ManifestKindData is an existing resource looked up by a data source.
+const ManifestKindData = "data"
+
+// ManifestKindStateless is a resource that is created only if a data source fails to find an existing resource.
+const ManifestKindStateless = "stateless"
+
+// ManifestKindOther is a resource that is referenced by a variable or a hard coded value.
+const ManifestKindOther = "other"
+
+// terraformAddressRegex matches the addresses of managed resources and data sources in a lookup, optionally
+// followed by an index like "[0]".
+var terraformAddressRegex = regexp.MustCompile(`(data\.)?(octopusdeploy_[a-z0-9_]+\.[A-Za-z0-9_\-]+)(\[\d+])?`)
+
+// ExportManifest describes the resources included in an export. It is used by tooling that needs to correlate
+// the resources in the source space with the resources created by the Terraform module.
+type ExportManifest struct {
+	FormatVersion  int                   `json:"formatVersion"`
+	Resources      []ExportManifestEntry `json:"resources"`
+	DummyVariables []ExportManifestDummy `json:"dummyVariables"`
+}
+
+// ExportManifestEntry maps an Octopus resource to the Terraform that creates or looks up the resource.
+type ExportManifestEntry struct {
+	Id                string   `json:"id"`
+	AlternateId       string   `json:"alternateId,omitempty"`
+	ResourceType      string   `json:"resourceType"`
+	Name              string   `json:"name,omitempty"`
+	Kind              string   `json:"kind"`
+	Address           string   `json:"address,omitempty"`
+	DataAddress       string   `json:"dataAddress,omitempty"`
+	Lookup            string   `json:"lookup,omitempty"`
+	FileName          string   `json:"fileName,omitempty"`
+	ParentId          string   `json:"parentId,omitempty"`
+	ImmediateParentId string   `json:"immediateParentId,omitempty"`
+	Children          []string `json:"children,omitempty"`
+	// IncludedBy records the resources that referenced this resource, causing it to be included in the export
+	IncludedBy []ExportManifestInclusion `json:"includedBy,omitempty"`
+}
+
+// ExportManifestInclusion records a reference that caused a resource to be included in the export.
+type ExportManifestInclusion struct {
+	ParentId   string `json:"parentId"`
+	ParentName string `json:"parentName,omitempty"`
+	Field      string `json:"field"`
+}
+
+// ExportManifestDummy records a variable that was assigned a dummy value.
+type ExportManifestDummy struct {
+	VariableName string `json:"variableName"`
+	ResourceName string `json:"resourceName"`
+	ResourceType string `json:"resourceType"`
+}
+
+// ManifestGenerator builds the export manifest from the resources collected by the converters.
+type ManifestGenerator struct {
+	// JsonOutput indicates that the Terraform files are written in the JSON syntax, with the .tf.json extension
+	JsonOutput bool
+}
+
+// Generate returns the JSON manifest describing every resource in the collection.
+func (g ManifestGenerator) Generate(collection *data.ResourceDetailsCollection) ([]byte, error) {
+	return g.Marshal(g.Build(collection))
+}
+
+// Marshal returns the manifest as JSON.
+func (g ManifestGenerator) Marshal(manifest ExportManifest) ([]byte, error) {
+	return json.MarshalIndent(manifest, "", "  ")
+}
+
+// Build returns the manifest describing every resource in the collection.
+func (g ManifestGenerator) Build(collection *data.ResourceDetailsCollection) ExportManifest {
+	children := map[string][]string{}
+	for _, resource := range collection.Resources {
+		if resource.Id == "" {
+			continue
+		}
+
+		parentIds := lo.Uniq(lo.Filter([]string{resource.ParentId, resource.ImmediateParentId}, func(item string, index int) bool {
+			return item != "" && item != resource.Id
+		}))
+
+		for _, parentId := range parentIds {
+			children[parentId] = append(children[parentId], resource.Id)
+		}
+	}
+
+	entries := []ExportManifestEntry{}
+	for _, resource := range collection.Resources {
+		if resource.Id == "" {
+			continue
+		}
+
+		kind, address, dataAddress := g.getKind(resource)
+
+		entries = append(entries, ExportManifestEntry{
+			Id:                resource.Id,
+			AlternateId:       resource.AlternateId,
+			ResourceType:      resource.ResourceType,
+			Name:              resource.Name,
+			Kind:              kind,
+			Address:           address,
+			DataAddress:       dataAddress,
+			Lookup:            resource.Lookup,
+			FileName:          g.getFileName(resource),
+			ParentId:          resource.ParentId,
+			ImmediateParentId: resource.ImmediateParentId,
+			Children:          lo.Uniq(children[resource.Id]),
+			IncludedBy: lo.Map(resource.IncludedBy, func(item data.InclusionReason, index int) ExportManifestInclusion {
+				return ExportManifestInclusion{
+					ParentId:   item.ParentId,
+					ParentName: item.ParentName,
+					Field:      item.Field,
+				}
+			}),
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].ResourceType != entries[j].ResourceType {
+			return entries[i].ResourceType < entries[j].ResourceType
+		}
+		return entries[i].Id < entries[j].Id
+	})
+
+	dummies := lo.Map(collection.DummyVariables, func(item data.DummyVariableReference, index int) ExportManifestDummy {
+		return ExportManifestDummy{
+			VariableName: item.VariableName,
+			ResourceName: item.ResourceName,
+			ResourceType: item.ResourceType,
+		}
+	})
+
+	return ExportManifest{
+		FormatVersion:  manifestFormatVersion,
+		Resources:      entries,
+		DummyVariables: dummies,
+	}
+}
+
+// Address returns the address of the managed resource referenced by the lookup of the resource, or the address of
+// the data source if the resource is only looked up.
+func (g ManifestGenerator) Address(resource data.ResourceDetails) string {
+	_, address, dataAddress := g.getKind(resource)
+	return lo.Ternary(address != "", address, dataAddress)
+}
+
+// getKind determines how the resource is represented in the Terraform module from the lookup and count, and
+// returns the addresses of the managed resource and data source.
+func (g ManifestGenerator) getKind(resource data.ResourceDetails) (string, string, string) {
+	address := ""
+	dataAddress := ""
+	indexed := false
+
+	for _, match := range terraformAddressRegex.FindAllStringSubmatch(resource.Lookup, -1) {
+		if match[1] != "" {
+			if dataAddress == "" {
+				dataAddress = match[1] + match[2]
+			}
+		} else if address == "" {
+			address = match[2]
+			indexed = match[3] != ""
+		}
+	}
+
+	if address != "" && (resource.Count != "" || indexed) {
+		return ManifestKindStateless, address, dataAddress
+	}
+
+	if address != "" {
+		return ManifestKindResource, address, dataAddress
+	}
+
+	if dataAddress != "" {
+		return ManifestKindData, address, dataAddress
+	}
+
+	return ManifestKindOther, address, dataAddress
+}
+
+func (g ManifestGenerator) getFileName(resource data.ResourceDetails) string {
+	if resource.ToHcl == nil {
+		return ""
+	}
+
+	if g.JsonOutput && strings.HasSuffix(resource.FileName, ".tf") {
+		return resource.FileName + ".json"
+	}
+
+	return resource.FileName
+}
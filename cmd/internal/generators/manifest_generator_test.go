@@ -0,0 +1,87 @@
+package generators
+
+import (
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+)
+
+func TestManifestGenerator(t *testing.T) {
+	collection := data.ResourceDetailsCollection{}
+	toHcl := func() (string, error) { return "", nil }
+
+	collection.AddResource(
+		data.ResourceDetails{
+			Id:           "Projects-1",
+			ResourceType: "Projects",
+			Name:         "My Project",
+			FileName:     "space_population/project_my_project.tf",
+			Lookup:       "${octopusdeploy_project.project_my_project.id}",
+			ToHcl:        toHcl,
+		},
+		data.ResourceDetails{
+			Id:                "Steps-1",
+			ResourceType:      "Steps",
+			ParentId:          "Projects-1",
+			ImmediateParentId: "deploymentprocess-Projects-1",
+			FileName:          "space_population/process_my_project.tf",
+			Lookup:            "${length(data.octopusdeploy_projects.project_my_project.projects) != 0 ? null : octopusdeploy_process_step.process_step_my_project_run[0].id}",
+			Count:             "${length(data.octopusdeploy_projects.project_my_project.projects) != 0 ? 0 : 1}",
+			ToHcl:             toHcl,
+		},
+		data.ResourceDetails{
+			Id:           "Environments-1",
+			ResourceType: "Environments",
+			FileName:     "space_population/environment_dev.tf",
+			Lookup:       "${data.octopusdeploy_environments.environment_dev.environments[0].id}",
+			ToHcl:        toHcl,
+		},
+		data.ResourceDetails{
+			Id:           "Spaces-1",
+			ResourceType: "Spaces",
+			Lookup:       "${trimspace(var.octopus_space_id)}",
+		})
+
+	collection.AddDummy(data.DummyVariableReference{VariableName: "account_password", ResourceName: "Account", ResourceType: "Accounts"})
+
+	manifest := ManifestGenerator{JsonOutput: true}.Build(&collection)
+
+	if len(manifest.Resources) != 4 || len(manifest.DummyVariables) != 1 {
+		t.Fatalf("Unexpected manifest contents: %v", manifest)
+	}
+
+	expected := map[string]struct {
+		kind    string
+		address string
+	}{
+		"Projects-1":     {ManifestKindResource, "octopusdeploy_project.project_my_project"},
+		"Steps-1":        {ManifestKindStateless, "octopusdeploy_process_step.process_step_my_project_run"},
+		"Environments-1": {ManifestKindData, ""},
+		"Spaces-1":       {ManifestKindOther, ""},
+	}
+
+	for _, entry := range manifest.Resources {
+		if entry.Kind != expected[entry.Id].kind || entry.Address != expected[entry.Id].address {
+			t.Fatalf("Unexpected kind %s or address %s for %s", entry.Kind, entry.Address, entry.Id)
+		}
+
+		switch entry.Id {
+		case "Projects-1":
+			if len(entry.Children) != 1 || entry.Children[0] != "Steps-1" {
+				t.Fatalf("Expected the project to have the step as a child")
+			}
+
+			if entry.FileName != "space_population/project_my_project.tf.json" {
+				t.Fatalf("Expected the file name to have the JSON extension")
+			}
+		case "Environments-1":
+			if entry.DataAddress != "data.octopusdeploy_environments.environment_dev" {
+				t.Fatalf("Unexpected data address %s", entry.DataAddress)
+			}
+		case "Steps-1":
+			if entry.DataAddress != "data.octopusdeploy_projects.project_my_project" {
+				t.Fatalf("Unexpected data address %s", entry.DataAddress)
+			}
+		}
+	}
+}
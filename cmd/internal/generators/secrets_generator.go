@@ -0,0 +1,33 @@
+package generators
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+	"github.com/hashicorp/hcl2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SecretsFileName is the name of the file holding the values of the sensitive variables. Terraform loads files
+// ending in .auto.tfvars automatically, so the secrets are applied without any additional arguments.
+// The file is written by hclwrite, which escapes any interpolation in the values, so it is not unescaped like the
+// other HCL files.
+const SecretsFileName = strutil.SecretsFileName
+
+// SecretsGenerator writes the values supplied by a secret provider to a tfvars file. The values are kept out of the
+// .tf files so the module can be shared or committed without the secrets.
+type SecretsGenerator struct {
+}
+
+// Generate returns the contents of the tfvars file, with the variables sorted by name.
+func (g SecretsGenerator) Generate(collection *data.ResourceDetailsCollection) string {
+	file := hclwrite.NewEmptyFile()
+
+	for _, name := range slices.Sorted(maps.Keys(collection.SecretValues)) {
+		file.Body().SetAttributeValue(name, cty.StringVal(collection.SecretValues[name]))
+	}
+
+	return string(file.Bytes())
+}
@@ -0,0 +1,52 @@
+package generators
+
+import (
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+)
+
+func TestSecretsGenerator(t *testing.T) {
+	collection := data.ResourceDetailsCollection{}
+	collection.AddSecretValue("feed_docker_password", "pa\"ss")
+	collection.AddSecretValue("account_aws", "secret")
+
+	tfvars := SecretsGenerator{}.Generate(&collection)
+	expected := "account_aws          = \"secret\"\nfeed_docker_password = \"pa\\\"ss\"\n"
+
+	if tfvars != expected {
+		t.Fatalf("unexpected tfvars %q", tfvars)
+	}
+}
+
+func TestSecretsGeneratorRoundTrip(t *testing.T) {
+	collection := data.ResourceDetailsCollection{}
+	collection.AddSecretValue("account_aws", "${password}")
+	collection.AddSecretValue("feed_docker_password", "pa${ss} %{x} $${y}")
+
+	files := strutil.UnEscapeDollarInMap(map[string]string{SecretsFileName: SecretsGenerator{}.Generate(&collection)})
+
+	file, diags := hclsyntax.ParseConfig([]byte(files[SecretsFileName]), SecretsFileName, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	attributes, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	for name, expected := range collection.SecretValues {
+		value, diags := attributes[name].Expr.Value(nil)
+		if diags.HasErrors() {
+			t.Fatal(diags.Error())
+		}
+
+		if value.AsString() != expected {
+			t.Fatalf("unexpected secret %q", value.AsString())
+		}
+	}
+}
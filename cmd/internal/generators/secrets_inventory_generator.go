@@ -0,0 +1,238 @@
+package generators
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+	"github.com/samber/lo"
+)
+
+const SecretsInventoryJsonFileName = "secrets_inventory.json"
+const SecretsInventoryCsvFileName = "secrets_inventory.csv"
+
+// SecretsExampleFileName is the name of the example tfvars file listing every sensitive variable. Terraform ignores
+// the file, so it can be copied to secrets.auto.tfvars and populated.
+const SecretsExampleFileName = "space_population/secrets.auto.tfvars.example"
+
+const secretsInventoryFormatVersion = 1
+
+// SecretsInventory lists the sensitive Terraform variables declared by the exported module. The Octopus API does not
+// return secrets, so each of these variables must be populated when the module is applied.
+type SecretsInventory struct {
+	FormatVersion int                     `json:"formatVersion"`
+	Secrets       []SecretsInventoryEntry `json:"secrets"`
+}
+
+// SecretsInventoryEntry describes a sensitive Terraform variable and the Octopus resource that uses it.
+type SecretsInventoryEntry struct {
+	VariableName     string   `json:"variableName"`
+	ResourceType     string   `json:"resourceType"`
+	ResourceId       string   `json:"resourceId,omitempty"`
+	ResourceName     string   `json:"resourceName,omitempty"`
+	ProjectId        string   `json:"projectId,omitempty"`
+	ProjectName      string   `json:"projectName,omitempty"`
+	EnvironmentIds   []string `json:"environmentIds,omitempty"`
+	EnvironmentNames []string `json:"environmentNames,omitempty"`
+	TenantIds        []string `json:"tenantIds,omitempty"`
+	TenantNames      []string `json:"tenantNames,omitempty"`
+	TenantTags       []string `json:"tenantTags,omitempty"`
+	// Dummy is true if the variable was given a dummy default value. The value itself is never recorded.
+	Dummy bool `json:"dummy"`
+	// ValueSupplied is true if a secret provider supplied the value, which is written to secrets.auto.tfvars
+	ValueSupplied bool `json:"valueSupplied"`
+}
+
+// SecretsInventoryGenerator builds the secrets inventory from the secret variables collected by the converters.
+type SecretsInventoryGenerator struct {
+}
+
+// Build returns the inventory of the secret variables in the collection, sorted by variable name.
+func (g SecretsInventoryGenerator) Build(collection *data.ResourceDetailsCollection) SecretsInventory {
+	dummies := map[string]data.DummyVariableReference{}
+	for _, dummy := range collection.DummyVariables {
+		dummies[dummy.VariableName] = dummy
+	}
+
+	// The converters run concurrently, so the references are sorted to keep the inventory consistent between exports
+	sorted := slices.Clone(collection.SecretVariables)
+	slices.SortStableFunc(sorted, func(a, b data.SecretVariableReference) int {
+		return cmp.Or(cmp.Compare(a.VariableName, b.VariableName), cmp.Compare(a.ResourceId, b.ResourceId))
+	})
+
+	// A variable may be recorded more than once if it is shared by resources, so only the first reference is kept
+	references := lo.UniqBy(sorted, func(item data.SecretVariableReference) string {
+		return item.VariableName
+	})
+
+	entries := lo.Map(references, func(item data.SecretVariableReference, index int) SecretsInventoryEntry {
+		_, isDummy := dummies[item.VariableName]
+		_, supplied := collection.SecretValues[item.VariableName]
+
+		return SecretsInventoryEntry{
+			VariableName:     item.VariableName,
+			ResourceType:     item.ResourceType,
+			ResourceId:       item.ResourceId,
+			ResourceName:     item.ResourceName,
+			ProjectId:        item.Scope.ProjectId,
+			ProjectName:      g.getName(collection, "Projects", item.Scope.ProjectId),
+			EnvironmentIds:   item.Scope.EnvironmentIds,
+			EnvironmentNames: g.getNames(collection, "Environments", item.Scope.EnvironmentIds),
+			TenantIds:        item.Scope.TenantIds,
+			TenantNames:      g.getNames(collection, "Tenants", item.Scope.TenantIds),
+			TenantTags:       item.Scope.TenantTags,
+			Dummy:            isDummy,
+			ValueSupplied:    supplied,
+		}
+	})
+
+	return SecretsInventory{
+		FormatVersion: secretsInventoryFormatVersion,
+		Secrets:       entries,
+	}
+}
+
+// Json returns the inventory as JSON.
+func (g SecretsInventoryGenerator) Json(inventory SecretsInventory) ([]byte, error) {
+	return json.MarshalIndent(inventory, "", "  ")
+}
+
+// Csv returns the inventory as CSV with a header row. Lists are separated with semicolons.
+func (g SecretsInventoryGenerator) Csv(inventory SecretsInventory) (string, error) {
+	buffer := bytes.Buffer{}
+	writer := csv.NewWriter(&buffer)
+
+	records := [][]string{{
+		"variable_name",
+		"resource_type",
+		"resource_id",
+		"resource_name",
+		"project_id",
+		"project_name",
+		"environment_ids",
+		"environment_names",
+		"tenant_ids",
+		"tenant_names",
+		"tenant_tags",
+		"dummy",
+		"value_supplied",
+	}}
+
+	for _, entry := range inventory.Secrets {
+		records = append(records, []string{
+			entry.VariableName,
+			entry.ResourceType,
+			entry.ResourceId,
+			entry.ResourceName,
+			entry.ProjectId,
+			entry.ProjectName,
+			strings.Join(entry.EnvironmentIds, ";"),
+			strings.Join(entry.EnvironmentNames, ";"),
+			strings.Join(entry.TenantIds, ";"),
+			strings.Join(entry.TenantNames, ";"),
+			strings.Join(entry.TenantTags, ";"),
+			strconv.FormatBool(entry.Dummy),
+			strconv.FormatBool(entry.ValueSupplied),
+		})
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// Example returns a tfvars file assigning an empty string to every secret variable, with a comment describing the
+// resource that uses the secret.
+func (g SecretsInventoryGenerator) Example(inventory SecretsInventory) string {
+	builder := strings.Builder{}
+
+	for index, entry := range inventory.Secrets {
+		if index != 0 {
+			builder.WriteString("\n")
+		}
+
+		builder.WriteString("# " + g.describe(entry) + "\n")
+		builder.WriteString(entry.VariableName + " = \"\"\n")
+	}
+
+	return builder.String()
+}
+
+// describe returns a single line describing the resource and scope of the secret.
+func (g SecretsInventoryGenerator) describe(entry SecretsInventoryEntry) string {
+	description := entry.ResourceType
+
+	if entry.ResourceName != "" {
+		description += " \"" + entry.ResourceName + "\""
+	}
+
+	if entry.ResourceId != "" {
+		description += " (" + entry.ResourceId + ")"
+	}
+
+	scopes := []string{}
+
+	if entry.ProjectName != "" {
+		scopes = append(scopes, "project "+entry.ProjectName)
+	} else if entry.ProjectId != "" {
+		scopes = append(scopes, "project "+entry.ProjectId)
+	}
+
+	if len(entry.EnvironmentIds) != 0 {
+		scopes = append(scopes, "environments "+strings.Join(g.namesOrIds(entry.EnvironmentNames, entry.EnvironmentIds), ", "))
+	}
+
+	if len(entry.TenantIds) != 0 {
+		scopes = append(scopes, "tenants "+strings.Join(g.namesOrIds(entry.TenantNames, entry.TenantIds), ", "))
+	}
+
+	if len(entry.TenantTags) != 0 {
+		scopes = append(scopes, "tenant tags "+strings.Join(entry.TenantTags, ", "))
+	}
+
+	if len(scopes) != 0 {
+		description += " scoped to " + strings.Join(scopes, "; ")
+	}
+
+	return description
+}
+
+// namesOrIds returns the names if every ID was resolved to a name, and the IDs otherwise.
+func (g SecretsInventoryGenerator) namesOrIds(names []string, ids []string) []string {
+	if len(names) == len(ids) {
+		return names
+	}
+
+	return ids
+}
+
+// getName returns the name of the exported resource, or an empty string if the resource was not exported.
+func (g SecretsInventoryGenerator) getName(collection *data.ResourceDetailsCollection, resourceType string, id string) string {
+	if id == "" {
+		return ""
+	}
+
+	for _, resource := range collection.GetResourcesByOctopusId(id) {
+		if strings.EqualFold(resource.ResourceType, resourceType) && resource.Name != "" {
+			return resource.Name
+		}
+	}
+
+	return ""
+}
+
+// getNames returns the names of the exported resources, skipping any that were not exported.
+func (g SecretsInventoryGenerator) getNames(collection *data.ResourceDetailsCollection, resourceType string, ids []string) []string {
+	return lo.Filter(lo.Map(ids, func(item string, index int) string {
+		return g.getName(collection, resourceType, item)
+	}), func(item string, index int) bool {
+		return item != ""
+	})
+}
@@ -0,0 +1,124 @@
+package generators
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+)
+
+func buildSecretsInventoryCollection() *data.ResourceDetailsCollection {
+	collection := data.ResourceDetailsCollection{}
+	collection.AddResource(
+		data.ResourceDetails{Id: "Projects-1", ResourceType: "Projects", Name: "Web"},
+		data.ResourceDetails{Id: "Environments-1", ResourceType: "Environments", Name: "Production"},
+	)
+	collection.AddSecretVariable(data.SecretVariableReference{
+		VariableName: "variable_abc_sensitive_value",
+		ResourceType: "Variables",
+		ResourceId:   "Variables-1",
+		ResourceName: "Password",
+		Scope: data.SecretScope{
+			ProjectId:      "Projects-1",
+			EnvironmentIds: []string{"Environments-1"},
+			TenantIds:      []string{"Tenants-1"},
+		},
+	})
+	collection.AddSecretVariable(data.SecretVariableReference{
+		VariableName: "feed_docker_password",
+		ResourceType: "Feeds",
+		ResourceId:   "Feeds-1",
+		ResourceName: "Docker",
+	})
+	collection.AddSecretVariable(data.SecretVariableReference{
+		VariableName: "feed_docker_password",
+		ResourceType: "Feeds",
+		ResourceId:   "Feeds-1",
+		ResourceName: "Docker",
+	})
+	collection.AddSecretValue("feed_docker_password", "secret")
+	collection.AddDummy(data.DummyVariableReference{
+		VariableName: "variable_abc_sensitive_value",
+		ResourceName: "Password",
+		ResourceType: "Variables",
+	})
+
+	return &collection
+}
+
+func TestSecretsInventoryGeneratorBuild(t *testing.T) {
+	inventory := SecretsInventoryGenerator{}.Build(buildSecretsInventoryCollection())
+
+	if len(inventory.Secrets) != 2 {
+		t.Fatalf("expected duplicate secrets to be removed, got %v", inventory.Secrets)
+	}
+
+	feed := inventory.Secrets[0]
+	if feed.VariableName != "feed_docker_password" || !feed.ValueSupplied || feed.Dummy {
+		t.Fatalf("unexpected feed secret %+v", feed)
+	}
+
+	variable := inventory.Secrets[1]
+	if variable.ProjectName != "Web" || !slices.Equal(variable.EnvironmentNames, []string{"Production"}) || !variable.Dummy || variable.ValueSupplied {
+		t.Fatalf("unexpected variable secret %+v", variable)
+	}
+
+	// The tenant was not exported, so its name can not be resolved
+	if len(variable.TenantNames) != 0 || !slices.Equal(variable.TenantIds, []string{"Tenants-1"}) {
+		t.Fatalf("unexpected tenants %+v", variable)
+	}
+}
+
+func TestSecretsInventoryGeneratorCsv(t *testing.T) {
+	generator := SecretsInventoryGenerator{}
+	csv, err := generator.Csv(generator.Build(buildSecretsInventoryCollection()))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(csv), "\n")
+
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "variable_name,resource_type,") {
+		t.Fatalf("unexpected csv %q", csv)
+	}
+
+	if lines[2] != "variable_abc_sensitive_value,Variables,Variables-1,Password,Projects-1,Web,Environments-1,Production,Tenants-1,,,true,false" {
+		t.Fatalf("unexpected csv row %q", lines[2])
+	}
+}
+
+func TestSecretsInventoryGeneratorExample(t *testing.T) {
+	generator := SecretsInventoryGenerator{}
+	example := generator.Example(generator.Build(buildSecretsInventoryCollection()))
+	expected := "# Feeds \"Docker\" (Feeds-1)\n" +
+		"feed_docker_password = \"\"\n" +
+		"\n" +
+		"# Variables \"Password\" (Variables-1) scoped to project Web; environments Production; tenants Tenants-1\n" +
+		"variable_abc_sensitive_value = \"\"\n"
+
+	if example != expected {
+		t.Fatalf("unexpected example %q", example)
+	}
+}
+
+func TestSecretsInventoryGeneratorKeepsLowestResourceId(t *testing.T) {
+	collection := data.ResourceDetailsCollection{}
+	collection.AddSecretVariable(data.SecretVariableReference{
+		VariableName: "account_shared",
+		ResourceType: "Accounts",
+		ResourceId:   "Accounts-2",
+	})
+	collection.AddSecretVariable(data.SecretVariableReference{
+		VariableName: "account_shared",
+		ResourceType: "Accounts",
+		ResourceId:   "Accounts-1",
+	})
+
+	inventory := SecretsInventoryGenerator{}.Build(&collection)
+
+	if len(inventory.Secrets) != 1 || inventory.Secrets[0].ResourceId != "Accounts-1" {
+		t.Fatalf("expected the reference with the lowest resource ID to be kept, got %v", inventory.Secrets)
+	}
+}
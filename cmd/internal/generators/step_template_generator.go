@@ -7,11 +7,15 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 	"github.com/google/uuid"
 	"github.com/zeebo/xxh3"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 )
 
 type StepTemplateGenerator struct {
+	// ExportedAt is the time recorded in the step template. The current time is used when it is zero.
+	ExportedAt time.Time
 }
 
 func (s StepTemplateGenerator) Generate(collection *data.ResourceDetailsCollection, name string, stepKey string, description string) ([]byte, error) {
@@ -67,7 +71,7 @@ func (s StepTemplateGenerator) Generate(collection *data.ResourceDetailsCollecti
 		Parameters:     stepTemplateParams,
 		Version:        1,
 		Meta: steptemplate.StepTemplateMeta{
-			ExportedAt:     time.Now().Format(time.RFC3339),
+			ExportedAt:     s.getExportedAt().Format(time.RFC3339),
 			OctopusVersion: "2024.1.10177",
 			Type:           "ActionTemplate",
 		},
@@ -76,6 +80,34 @@ func (s StepTemplateGenerator) Generate(collection *data.ResourceDetailsCollecti
 	return json.MarshalIndent(template, "", "\t")
 }
 
+func (s StepTemplateGenerator) getExportedAt() time.Time {
+	if s.ExportedAt.IsZero() {
+		return time.Now()
+	}
+
+	return s.ExportedAt
+}
+
+// sortResources returns the resources sorted by file name, type and ID. Resources are added to the collection by
+// concurrent converters, so sorting them ensures every export generates the same step template.
+func (s StepTemplateGenerator) sortResources(collection *data.ResourceDetailsCollection) []data.ResourceDetails {
+	resources := slices.Clone(collection.Resources)
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		if resources[i].FileName != resources[j].FileName {
+			return resources[i].FileName < resources[j].FileName
+		}
+
+		if resources[i].ResourceType != resources[j].ResourceType {
+			return resources[i].ResourceType < resources[j].ResourceType
+		}
+
+		return resources[i].Id < resources[j].Id
+	})
+
+	return resources
+}
+
 func (s StepTemplateGenerator) createStableGuid(name string) string {
 	h := xxh3.HashString128(name).Bytes()
 	guid, _ := uuid.FromBytes(h[:])
@@ -84,7 +116,7 @@ func (s StepTemplateGenerator) createStableGuid(name string) string {
 
 func (s StepTemplateGenerator) createTemplate(collection *data.ResourceDetailsCollection) (string, error) {
 	sb := strings.Builder{}
-	for _, resource := range collection.Resources {
+	for _, resource := range s.sortResources(collection) {
 		// Some resources are already resolved by their parent, but exist in the resource details map as a lookup.
 		// In these cases, ToHcl is nil.
 		if resource.ToHcl == nil {
@@ -117,7 +149,7 @@ func (s StepTemplateGenerator) createTerraformTemplateParameters(collection *dat
 	parameters["octopus_apikey"] = "#{ReferenceArchitecture." + stepKey + ".Octopus.ApiKey}"
 	parameters["octopus_space_id"] = "#{ReferenceArchitecture." + stepKey + ".Octopus.SpaceId}"
 
-	for _, resource := range collection.Resources {
+	for _, resource := range s.sortResources(collection) {
 		for _, parameter := range resource.Parameters {
 			name := "ReferenceArchitecture." + stepKey + "." + resource.ResourceType + "." + parameter.ResourceName + "." + parameter.ParameterType
 			parameters[parameter.VariableName] = "#{" + name + "}"
@@ -163,7 +195,7 @@ func (s StepTemplateGenerator) createStepTemplateParameters(collection *data.Res
 		},
 	})
 
-	for _, resource := range collection.Resources {
+	for _, resource := range s.sortResources(collection) {
 		for _, parameter := range resource.Parameters {
 			name := "ReferenceArchitecture." + stepKey + "." + resource.ResourceType + "." + parameter.ResourceName + "." + parameter.ParameterType
 			parameters = append(parameters, steptemplate.StepTemplateParameters{
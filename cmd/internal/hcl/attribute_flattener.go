@@ -0,0 +1,53 @@
+package hcl
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+)
+
+// FlattenAttributes parses a Terraform configuration file written in the native HCL syntax and returns the source of
+// every attribute expression mapped to the path of the attribute. Paths are made up of the block types and labels,
+// like "resource.octopusdeploy_project.project_web.name". Nested blocks without labels are identified by their
+// position among the blocks of the same type, like "resource.octopusdeploy_process_step.step.execution_properties[0]".
+func FlattenAttributes(filename string, hclText string) (map[string]string, error) {
+	src := []byte(hclText)
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.Pos{Line: 1, Column: 1})
+
+	if diags.HasErrors() {
+		return nil, errors.New("failed to parse " + filename + ": " + diags.Error())
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+
+	if !ok {
+		return nil, errors.New("failed to parse " + filename + " because the body was not native HCL")
+	}
+
+	attributes := map[string]string{}
+	flattenBody(src, body, "", attributes)
+
+	return attributes, nil
+}
+
+func flattenBody(src []byte, body *hclsyntax.Body, prefix string, attributes map[string]string) {
+	for name, attribute := range body.Attributes {
+		attributes[prefix+name] = strings.TrimSpace(string(attribute.Expr.Range().SliceBytes(src)))
+	}
+
+	positions := map[string]int{}
+	for _, block := range body.Blocks {
+		path := prefix + strings.Join(append([]string{block.Type}, block.Labels...), ".")
+
+		if len(block.Labels) == 0 {
+			position := positions[path]
+			positions[path]++
+			path += "[" + strconv.Itoa(position) + "]"
+		}
+
+		flattenBody(src, block.Body, path+".", attributes)
+	}
+}
@@ -0,0 +1,48 @@
+package hcl
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestFlattenAttributes(t *testing.T) {
+	attributes, err := FlattenAttributes("test.tf", `resource "octopusdeploy_project" "project_web" {
+  name = "${var.project_web_name}"
+  tags = ["a", "b"]
+
+  connectivity_policy {
+    allow_deployments_to_no_targets = true
+  }
+
+  template {
+    name = "first"
+  }
+
+  template {
+    name = "second"
+  }
+}
+`)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"resource.octopusdeploy_project.project_web.name":                                                   `"${var.project_web_name}"`,
+		"resource.octopusdeploy_project.project_web.tags":                                                   `["a", "b"]`,
+		"resource.octopusdeploy_project.project_web.connectivity_policy[0].allow_deployments_to_no_targets": "true",
+		"resource.octopusdeploy_project.project_web.template[0].name":                                       `"first"`,
+		"resource.octopusdeploy_project.project_web.template[1].name":                                       `"second"`,
+	}
+
+	if !maps.Equal(attributes, expected) {
+		t.Fatalf("unexpected attributes %v", attributes)
+	}
+}
+
+func TestFlattenAttributesInvalid(t *testing.T) {
+	if _, err := FlattenAttributes("test.tf", `resource "octopusdeploy_project" {`); err == nil {
+		t.Fatal("expected an error for invalid HCL")
+	}
+}
@@ -0,0 +1,66 @@
+package hcl
+
+import "strings"
+
+// ReplaceInterpolations calls replace with each "${...}" interpolation in the template, which is escaped to be placed
+// in a HCL string, and returns the template with the interpolations replaced by the results. Escaped "$${" and "%%{"
+// sequences are not interpolations. An interpolation ends at the brace that closes it, so expressions can hold
+// object literals and strings that include braces. An interpolation that is never closed is left unchanged.
+func ReplaceInterpolations(template string, replace func(interpolation string) string) string {
+	builder := strings.Builder{}
+
+	for index := 0; index < len(template); {
+		if strings.HasPrefix(template[index:], "$${") || strings.HasPrefix(template[index:], "%%{") {
+			builder.WriteString(template[index : index+3])
+			index += 3
+			continue
+		}
+
+		if strings.HasPrefix(template[index:], "${") {
+			if end := interpolationEnd(template, index); end != -1 {
+				builder.WriteString(replace(template[index:end]))
+				index = end
+				continue
+			}
+		}
+
+		builder.WriteByte(template[index])
+		index++
+	}
+
+	return builder.String()
+}
+
+// interpolationEnd returns the index after the brace that closes the interpolation starting at the index, or -1 if
+// the interpolation is not closed. Each entry in the stack is true for a quoted string, and false for an expression.
+func interpolationEnd(template string, start int) int {
+	stack := []bool{false}
+
+	for index := start + 2; index < len(template); index++ {
+		inString := stack[len(stack)-1]
+		remaining := template[index:]
+
+		switch {
+		case inString && remaining[0] == '\\':
+			index++
+		case inString && remaining[0] == '"':
+			stack = stack[:len(stack)-1]
+		case inString && (strings.HasPrefix(remaining, "$${") || strings.HasPrefix(remaining, "%%{")):
+			index += 2
+		case inString && (strings.HasPrefix(remaining, "${") || strings.HasPrefix(remaining, "%{")):
+			stack = append(stack, false)
+			index++
+		case !inString && remaining[0] == '"':
+			stack = append(stack, true)
+		case !inString && remaining[0] == '{':
+			stack = append(stack, false)
+		case !inString && remaining[0] == '}':
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return index + 1
+			}
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,27 @@
+package hcl
+
+import (
+	"testing"
+)
+
+func TestReplaceInterpolations(t *testing.T) {
+	tests := map[string]string{
+		"echo ${a.b.id}":                         "echo [a.b.id]",
+		"echo $${HOME} %%{x} ${a.b.id}":          "echo $${HOME} %%{x} [a.b.id]",
+		`${lookup({ a = "}" }, "a")} done`:       `[lookup({ a = "}" }, "a")] done`,
+		`${format("%s", "${var.x}")}`:            `[format("%s", "${var.x}")]`,
+		`${jsonencode("\"}")} ${b}`:              `[jsonencode("\"}")] [b]`,
+		"unclosed ${a.b.id":                      "unclosed ${a.b.id",
+		"${trimspace(var.octopus_space_id)}-{x}": "[trimspace(var.octopus_space_id)]-{x}",
+	}
+
+	for template, expected := range tests {
+		actual := ReplaceInterpolations(template, func(interpolation string) string {
+			return "[" + interpolation[2:len(interpolation)-1] + "]"
+		})
+
+		if actual != expected {
+			t.Errorf("expected %q to be replaced with %q, got %q", template, expected, actual)
+		}
+	}
+}
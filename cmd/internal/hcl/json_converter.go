@@ -0,0 +1,384 @@
+package hcl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+)
+
+// jsonObject is a JSON object that retains the order of the keys, so the generated JSON follows the order of
+// the attributes and blocks in the source HCL.
+type jsonObject struct {
+	keys   []string
+	values map[string]any
+}
+
+func newJsonObject() *jsonObject {
+	return &jsonObject{values: map[string]any{}}
+}
+
+func (o *jsonObject) get(key string) (any, bool) {
+	value, ok := o.values[key]
+	return value, ok
+}
+
+func (o *jsonObject) set(key string, value any) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	buffer := bytes.Buffer{}
+	buffer.WriteString("{")
+	for i, key := range o.keys {
+		if i != 0 {
+			buffer.WriteString(",")
+		}
+
+		keyJson, err := marshalJson(key)
+		if err != nil {
+			return nil, err
+		}
+
+		valueJson, err := marshalJson(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(keyJson)
+		buffer.WriteString(":")
+		buffer.Write(valueJson)
+	}
+	buffer.WriteString("}")
+	return buffer.Bytes(), nil
+}
+
+// ConvertToJson converts a Terraform configuration file written in the native HCL syntax to the equivalent
+// JSON syntax described at https://developer.hashicorp.com/terraform/language/syntax/json.
+// Expressions like references, function calls and conditionals are written as "${...}" interpolations, so
+// they are evaluated by Terraform exactly as they were in the native syntax.
+func ConvertToJson(filename string, hclText string) (string, error) {
+	src := []byte(hclText)
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.Pos{Line: 1, Column: 1})
+
+	if diags.HasErrors() {
+		return "", errors.New("failed to parse " + filename + " to convert it to JSON: " + diags.Error())
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+
+	if !ok {
+		return "", errors.New("failed to convert " + filename + " to JSON because the body was not native HCL")
+	}
+
+	converter := jsonConverter{src: src}
+	root, err := converter.bodyToJson(body, "")
+
+	if err != nil {
+		return "", errors.New("failed to convert " + filename + " to JSON: " + err.Error())
+	}
+
+	buffer := bytes.Buffer{}
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(root); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+type jsonConverter struct {
+	src []byte
+}
+
+// bodyToJson converts the attributes and blocks in a body to a JSON object. The blockType identifies the
+// block that holds the body, which determines if an attribute is treated as an expression or as a raw
+// reference.
+func (c jsonConverter) bodyToJson(body *hclsyntax.Body, blockType string) (*jsonObject, error) {
+	type item struct {
+		start     int
+		attribute *hclsyntax.Attribute
+		block     *hclsyntax.Block
+	}
+
+	items := []item{}
+	for _, attribute := range body.Attributes {
+		items = append(items, item{start: attribute.SrcRange.Start.Byte, attribute: attribute})
+	}
+	for _, block := range body.Blocks {
+		items = append(items, item{start: block.TypeRange.Start.Byte, block: block})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].start < items[j].start
+	})
+
+	object := newJsonObject()
+
+	for _, i := range items {
+		if i.attribute != nil {
+			value, err := c.attributeToJson(i.attribute, blockType)
+			if err != nil {
+				return nil, err
+			}
+			object.set(i.attribute.Name, value)
+		} else {
+			if err := c.addBlock(object, i.block); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return object, nil
+}
+
+// addBlock adds a block to the parent object. Labels are represented as nested objects, and repeated blocks
+// with the same type and labels are represented as an array of objects.
+func (c jsonConverter) addBlock(parent *jsonObject, block *hclsyntax.Block) error {
+	body, err := c.bodyToJson(block.Body, block.Type)
+
+	if err != nil {
+		return err
+	}
+
+	keys := append([]string{block.Type}, block.Labels...)
+	target := parent
+
+	for _, key := range keys[:len(keys)-1] {
+		existing, ok := target.get(key)
+		if !ok {
+			existing = newJsonObject()
+			target.set(key, existing)
+		}
+
+		nested, ok := existing.(*jsonObject)
+		if !ok {
+			return errors.New("the block " + strings.Join(keys, ".") + " conflicts with an existing attribute or block")
+		}
+
+		target = nested
+	}
+
+	lastKey := keys[len(keys)-1]
+	existing, ok := target.get(lastKey)
+
+	if !ok {
+		target.set(lastKey, body)
+	} else if existingArray, ok := existing.([]any); ok {
+		target.set(lastKey, append(existingArray, body))
+	} else {
+		target.set(lastKey, []any{existing, body})
+	}
+
+	return nil
+}
+
+func (c jsonConverter) attributeToJson(attribute *hclsyntax.Attribute, blockType string) (any, error) {
+	switch {
+	// Meta-arguments that reference other objects are written as strings without interpolation
+	case attribute.Name == "depends_on" || attribute.Name == "ignore_changes" || attribute.Name == "replace_triggered_by":
+		return c.referencesToJson(attribute.Expr), nil
+	case attribute.Name == "provider" && blockType != "":
+		return c.source(attribute.Expr), nil
+	case attribute.Name == "to" && (blockType == "import" || blockType == "moved"), attribute.Name == "from" && blockType == "moved":
+		return c.source(attribute.Expr), nil
+	// Variable types are written as a string containing the type expression
+	case attribute.Name == "type" && blockType == "variable":
+		return c.source(attribute.Expr), nil
+	// Variable defaults are literal values rather than expressions
+	case attribute.Name == "default" && blockType == "variable":
+		return c.expressionToJson(attribute.Expr, true), nil
+	}
+
+	return c.expressionToJson(attribute.Expr, false), nil
+}
+
+// referencesToJson converts a list of references to an array of strings.
+func (c jsonConverter) referencesToJson(expr hclsyntax.Expression) any {
+	if tuple, ok := expr.(*hclsyntax.TupleConsExpr); ok {
+		references := []any{}
+		for _, item := range tuple.Exprs {
+			references = append(references, c.source(item))
+		}
+		return references
+	}
+
+	return c.source(expr)
+}
+
+// expressionToJson converts an expression to a JSON value. When literal is true, strings are written without
+// escaping template sequences, as the value is not evaluated as a template by Terraform.
+func (c jsonConverter) expressionToJson(expr hclsyntax.Expression, literal bool) any {
+	source := c.source(expr)
+
+	switch typedExpr := expr.(type) {
+	case *hclsyntax.TupleConsExpr:
+		array := []any{}
+		for _, item := range typedExpr.Exprs {
+			array = append(array, c.expressionToJson(item, literal))
+		}
+		return array
+	case *hclsyntax.ObjectConsExpr:
+		object := newJsonObject()
+		for _, item := range typedExpr.Items {
+			key, ok := c.objectKey(item.KeyExpr)
+			if !ok {
+				return c.interpolate(source, literal)
+			}
+			object.set(key, c.expressionToJson(item.ValueExpr, literal))
+		}
+		return object
+	case *hclsyntax.LiteralValueExpr:
+		if value, ok := literalToJson(source); ok {
+			return value
+		}
+	case *hclsyntax.TemplateExpr, *hclsyntax.TemplateWrapExpr:
+		if template, ok := templateSource(source); ok {
+			if literal {
+				return unescapeTemplate(template)
+			}
+			return template
+		}
+	}
+
+	if literal {
+		if value, ok := literalToJson(source); ok {
+			return value
+		}
+	}
+
+	return c.interpolate(source, literal)
+}
+
+func (c jsonConverter) interpolate(source string, literal bool) any {
+	if literal {
+		return source
+	}
+
+	return "${" + source + "}"
+}
+
+// objectKey returns the name of a key in an object constructor, which is either a bare identifier or a string.
+func (c jsonConverter) objectKey(expr hclsyntax.Expression) (string, bool) {
+	if keyExpr, ok := expr.(*hclsyntax.ObjectConsKeyExpr); ok {
+		expr = keyExpr.Wrapped
+	}
+
+	source := c.source(expr)
+
+	if _, ok := expr.(*hclsyntax.ScopeTraversalExpr); ok && hclsyntax.ValidIdentifier(source) {
+		return source, true
+	}
+
+	if _, ok := expr.(*hclsyntax.TemplateExpr); ok {
+		if template, ok := templateSource(source); ok && !strings.Contains(template, "${") && !strings.Contains(template, "%{") {
+			return unescapeTemplate(template), true
+		}
+	}
+
+	return "", false
+}
+
+func (c jsonConverter) source(expr hclsyntax.Expression) string {
+	return strings.TrimSpace(string(expr.Range().SliceBytes(c.src)))
+}
+
+// literalToJson converts the source of a number, bool or null literal to a JSON value.
+func literalToJson(source string) (any, bool) {
+	switch source {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	case "null":
+		return nil, true
+	}
+
+	if _, err := strconv.ParseFloat(source, 64); err == nil {
+		return json.Number(source), true
+	}
+
+	return nil, false
+}
+
+// templateSource returns the template text from a quoted string or a heredoc. JSON strings are evaluated as
+// templates with the same syntax, so the template text can be used as is.
+func templateSource(source string) (string, bool) {
+	if strings.HasPrefix(source, "\"") {
+		template, err := strconv.Unquote(source)
+		return template, err == nil
+	}
+
+	if strings.HasPrefix(source, "<<") {
+		return heredocSource(source)
+	}
+
+	return "", false
+}
+
+func heredocSource(source string) (string, bool) {
+	header, content, found := strings.Cut(source, "\n")
+
+	if !found {
+		return "", false
+	}
+
+	marker := strings.TrimPrefix(header, "<<")
+	indented := strings.HasPrefix(marker, "-")
+	marker = strings.TrimSpace(strings.TrimPrefix(marker, "-"))
+
+	lines := strings.Split(content, "\n")
+
+	if len(lines) == 0 || strings.TrimSpace(lines[len(lines)-1]) != marker {
+		return "", false
+	}
+
+	lines = lines[:len(lines)-1]
+
+	if indented {
+		indent := -1
+		for _, line := range lines {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			lineIndent := len(line) - len(strings.TrimLeft(line, " \t"))
+			if indent == -1 || lineIndent < indent {
+				indent = lineIndent
+			}
+		}
+
+		for i, line := range lines {
+			if len(line) >= indent && indent > 0 {
+				lines[i] = line[indent:]
+			}
+		}
+	}
+
+	return strings.Join(lines, "\n") + "\n", true
+}
+
+func unescapeTemplate(template string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(template, "$${", "${"), "%%{", "%{")
+}
+
+func marshalJson(value any) ([]byte, error) {
+	buffer := bytes.Buffer{}
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,135 @@
+package hcl
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const jsonConverterTestHcl = `terraform {
+  required_providers {
+    octopusdeploy = { source = "OctopusDeployLabs/octopusdeploy", version = "0.43.0" }
+  }
+  required_version = ">= 1.6.0"
+}
+
+provider "octopusdeploy" {
+  address  = "${var.octopus_server}"
+  api_key  = "${var.octopus_apikey}"
+  space_id = "${trimspace(var.octopus_space_id)}"
+}
+
+variable "project_my_project_name" {
+  type        = string
+  nullable    = false
+  sensitive   = true
+  description = "The name of the project exported from My Project"
+  default     = "My $${Project}"
+}
+
+data "octopusdeploy_projects" "project_my_project" {
+  partial_name = "${var.project_my_project_name}"
+  skip         = 0
+  take         = 1
+}
+
+resource "octopusdeploy_project" "project_my_project" {
+  count       = "${length(data.octopusdeploy_projects.project_my_project.projects) != 0 ? 0 : 1}"
+  name        = "${var.project_my_project_name}"
+  description = "Uses #{Octopus.Action[Run].Output} and $${Bash}"
+  tags        = ["a", "b"]
+  script      = <<EOT
+echo "hi"
+echo $${HOME}
+EOT
+  settings    = jsonencode({ "Key" = "Value" })
+  depends_on  = [octopusdeploy_lifecycle.lifecycle_default]
+
+  versioning_strategy {
+    template = "#{Octopus.Version.LastMajor}"
+  }
+
+  connectivity_policy {
+    allow_deployments_to_no_targets = true
+  }
+
+  connectivity_policy {
+    allow_deployments_to_no_targets = false
+  }
+
+  lifecycle {
+    ignore_changes  = [description]
+    prevent_destroy = true
+  }
+}
+
+output "octopus_project_id" {
+  value = "${length(data.octopusdeploy_projects.project_my_project.projects) != 0 ? data.octopusdeploy_projects.project_my_project.projects[0].id : octopusdeploy_project.project_my_project[0].id}"
+}
+
+import {
+  to = octopusdeploy_project.project_my_project
+  id = "Projects-1"
+}
+`
+
+func TestConvertToJson(t *testing.T) {
+	result, err := ConvertToJson("project.tf", jsonConverterTestHcl)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed := map[string]any{}
+	if err := json.Unmarshal([]byte(result), &parsed); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(value any, keys ...any) any {
+		for _, key := range keys {
+			switch typedKey := key.(type) {
+			case string:
+				value = value.(map[string]any)[typedKey]
+			case int:
+				value = value.([]any)[typedKey]
+			}
+		}
+		return value
+	}
+
+	expected := []struct {
+		path  []any
+		value any
+	}{
+		{[]any{"terraform", "required_providers", "octopusdeploy", "source"}, "OctopusDeployLabs/octopusdeploy"},
+		{[]any{"terraform", "required_version"}, ">= 1.6.0"},
+		{[]any{"provider", "octopusdeploy", "space_id"}, "${trimspace(var.octopus_space_id)}"},
+		{[]any{"variable", "project_my_project_name", "type"}, "string"},
+		{[]any{"variable", "project_my_project_name", "sensitive"}, true},
+		{[]any{"variable", "project_my_project_name", "default"}, "My ${Project}"},
+		{[]any{"data", "octopusdeploy_projects", "project_my_project", "take"}, float64(1)},
+		{[]any{"resource", "octopusdeploy_project", "project_my_project", "count"}, "${length(data.octopusdeploy_projects.project_my_project.projects) != 0 ? 0 : 1}"},
+		{[]any{"resource", "octopusdeploy_project", "project_my_project", "description"}, "Uses #{Octopus.Action[Run].Output} and $${Bash}"},
+		{[]any{"resource", "octopusdeploy_project", "project_my_project", "tags", 1}, "b"},
+		{[]any{"resource", "octopusdeploy_project", "project_my_project", "script"}, "echo \"hi\"\necho $${HOME}\n"},
+		{[]any{"resource", "octopusdeploy_project", "project_my_project", "settings"}, "${jsonencode({ \"Key\" = \"Value\" })}"},
+		{[]any{"resource", "octopusdeploy_project", "project_my_project", "depends_on", 0}, "octopusdeploy_lifecycle.lifecycle_default"},
+		{[]any{"resource", "octopusdeploy_project", "project_my_project", "versioning_strategy", "template"}, "#{Octopus.Version.LastMajor}"},
+		{[]any{"resource", "octopusdeploy_project", "project_my_project", "connectivity_policy", 1, "allow_deployments_to_no_targets"}, false},
+		{[]any{"resource", "octopusdeploy_project", "project_my_project", "lifecycle", "ignore_changes", 0}, "description"},
+		{[]any{"output", "octopus_project_id", "value"}, "${length(data.octopusdeploy_projects.project_my_project.projects) != 0 ? data.octopusdeploy_projects.project_my_project.projects[0].id : octopusdeploy_project.project_my_project[0].id}"},
+		{[]any{"import", "to"}, "octopusdeploy_project.project_my_project"},
+		{[]any{"import", "id"}, "Projects-1"},
+	}
+
+	for _, e := range expected {
+		if actual := get(parsed, e.path...); actual != e.value {
+			t.Fatalf("Expected %v to be %v, but was %v\n%s", e.path, e.value, actual, result)
+		}
+	}
+}
+
+func TestConvertToJsonInvalidHcl(t *testing.T) {
+	if _, err := ConvertToJson("invalid.tf", "resource \"a\" {"); err == nil {
+		t.Fatal("Invalid HCL must return an error")
+	}
+}
@@ -3,7 +3,11 @@ package hcl
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"reflect"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
@@ -11,6 +15,7 @@ import (
 	"github.com/hashicorp/hcl2/hcl"
 	"github.com/hashicorp/hcl2/hcl/hclsyntax"
 	"github.com/hashicorp/hcl2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // WriteUnquotedAttribute uses the example from https://github.com/hashicorp/hcl/issues/442
@@ -98,8 +103,14 @@ func WriteActionProperties(block *hclwrite.Block, stepName string, actionName st
 // WriteStepProperties is used to pretty print the properties of a v1 step, writing a multiline map for the properties,
 // and extracting JSON blobs as maps for easy reading.
 func WriteStepProperties(propertyName string, block *hclwrite.Block, properties map[string]string) {
+	WriteStepPropertiesWithExpressions(propertyName, block, properties, nil)
+}
+
+// WriteStepPropertiesWithExpressions writes the properties like WriteStepProperties, along with properties whose
+// values are HCL expressions that are written as they are, like a call to the file() function.
+func WriteStepPropertiesWithExpressions(propertyName string, block *hclwrite.Block, properties map[string]string, expressions map[string]string) {
 	block.Body().SetAttributeTraversal(propertyName, hcl.Traversal{
-		hcl.TraverseRoot{Name: extractJsonAsMap(properties)},
+		hcl.TraverseRoot{Name: extractJsonAsMapWithExpressions(properties, expressions)},
 	})
 }
 
@@ -114,10 +125,23 @@ func getAttributeValue(tokens hclwrite.Tokens) string {
 }
 
 func extractJsonAsMap(properties map[string]string) string {
+	return extractJsonAsMapWithExpressions(properties, nil)
+}
+
+// extractJsonAsMapWithExpressions builds the HCL map of the properties and expressions. The keys are sorted so the
+// generated HCL is stable.
+func extractJsonAsMapWithExpressions(properties map[string]string, expressions map[string]string) string {
 	output := "{"
 
-	for key, value := range properties {
-		output += "\n        \"" + key + "\" = " + jsonStringToHcl(value)
+	keys := slices.Concat(slices.Collect(maps.Keys(properties)), slices.Collect(maps.Keys(expressions)))
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		if expression, ok := expressions[key]; ok {
+			output += "\n        \"" + key + "\" = " + expression
+		} else {
+			output += "\n        \"" + key + "\" = " + jsonStringToHcl(properties[key])
+		}
 	}
 
 	output += "\n      }"
@@ -126,12 +150,6 @@ func extractJsonAsMap(properties map[string]string) string {
 }
 
 func jsonStringToHcl(value string) string {
-	jsonMap := map[string]any{}
-	jsonMapError := json.Unmarshal([]byte(value), &jsonMap)
-
-	jsonArray := []any{}
-	jsonArrayError := json.Unmarshal([]byte(value), &jsonArray)
-
 	isMultilineString := strings.Contains(value, "\n")
 	// Don't use heredocs for strings containing ${ or $${ because heredocs are template strings
 	// in Terraform, and $${VARIABLE} would be unescaped to ${VARIABLE} by Terraform's template processing.
@@ -139,10 +157,8 @@ func jsonStringToHcl(value string) string {
 	// not raw heredoc content.
 	containsDollarCurly := strings.Contains(value, "${") || strings.Contains(value, "%{")
 
-	if jsonMapError == nil {
-		return "jsonencode(" + mapToHclMap(jsonMap) + ")"
-	} else if jsonArrayError == nil {
-		return "jsonencode(" + arrayToHclMap(jsonArray) + ")"
+	if expression, ok := jsonToHclExpression(value); ok {
+		return expression
 	} else if isMultilineString && !containsDollarCurly {
 		return encodeMultilineString(value)
 	} else {
@@ -150,6 +166,148 @@ func jsonStringToHcl(value string) string {
 	}
 }
 
+// jsonToHclExpression returns a jsonencode() call building the JSON object or array in the value. The value may
+// include interpolations like ${octopusdeploy_feed.feed.id} and the escaped $${ and %%{ sequences. The expression is
+// only returned if evaluating it produces JSON that is semantically equal to the value, so documents that can not be
+// represented faithfully as HCL are written as strings.
+func jsonToHclExpression(value string) (string, bool) {
+	if strings.Contains(value, jsonReferencePlaceholderPrefix) {
+		return "", false
+	}
+
+	// Interpolations can not be evaluated without Terraform, so they are replaced with placeholders while the
+	// expression is built and checked
+	document, references := replaceInterpolations(value)
+
+	if !json.Valid([]byte(document)) {
+		return "", false
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.UseNumber()
+
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return "", false
+	}
+
+	expression := ""
+	if mapItem, ok := decoded.(map[string]any); ok {
+		expression = mapToHclMap(mapItem)
+	} else if arrayItem, ok := decoded.([]any); ok {
+		expression = arrayToHclMap(arrayItem)
+	} else {
+		return "", false
+	}
+
+	if !jsonExpressionRoundTrips(expression, document) {
+		return "", false
+	}
+
+	for index, reference := range references {
+		expression = strings.ReplaceAll(expression, jsonReferencePlaceholder(index), reference)
+	}
+
+	return "jsonencode(" + expression + ")", true
+}
+
+const jsonReferencePlaceholderPrefix = "__octoterra_reference_"
+
+func jsonReferencePlaceholder(index int) string {
+	return jsonReferencePlaceholderPrefix + strconv.Itoa(index) + "__"
+}
+
+// replaceInterpolations replaces the interpolations in the value with placeholders, returning the new value and the
+// interpolations that were replaced.
+func replaceInterpolations(value string) (string, []string) {
+	references := []string{}
+
+	document := ReplaceInterpolations(value, func(interpolation string) string {
+		references = append(references, interpolation)
+		return jsonReferencePlaceholder(len(references) - 1)
+	})
+
+	return document, references
+}
+
+// jsonExpressionRoundTrips evaluates the HCL expression and returns true if the result matches the JSON document.
+// The document is escaped to be placed in a HCL string, so the escaped $${ and %%{ sequences are unescaped before
+// the values are compared.
+func jsonExpressionRoundTrips(expression string, document string) bool {
+	parsed, diags := hclsyntax.ParseExpression([]byte(expression), "properties", hcl.Pos{Line: 1, Column: 1})
+
+	if diags.HasErrors() {
+		return false
+	}
+
+	evaluated, diags := parsed.Value(nil)
+
+	if diags.HasErrors() {
+		return false
+	}
+
+	actual, ok := ctyToAny(evaluated)
+
+	if !ok {
+		return false
+	}
+
+	var expected any
+	unescaped := strings.ReplaceAll(strings.ReplaceAll(document, "$${", "${"), "%%{", "%{")
+	if err := json.Unmarshal([]byte(unescaped), &expected); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(actual, expected)
+}
+
+// ctyToAny converts a value to the types produced by json.Unmarshal.
+func ctyToAny(value cty.Value) (any, bool) {
+	if value.IsNull() {
+		return nil, true
+	}
+
+	if !value.IsKnown() {
+		return nil, false
+	}
+
+	valueType := value.Type()
+
+	switch {
+	case valueType == cty.String:
+		return value.AsString(), true
+	case valueType == cty.Bool:
+		return value.True(), true
+	case valueType == cty.Number:
+		number, _ := value.AsBigFloat().Float64()
+		return number, true
+	case valueType.IsObjectType() || valueType.IsMapType():
+		result := map[string]any{}
+		for iterator := value.ElementIterator(); iterator.Next(); {
+			key, element := iterator.Element()
+			converted, ok := ctyToAny(element)
+			if !ok {
+				return nil, false
+			}
+			result[key.AsString()] = converted
+		}
+		return result, true
+	case valueType.IsTupleType() || valueType.IsListType():
+		result := []any{}
+		for iterator := value.ElementIterator(); iterator.Next(); {
+			_, element := iterator.Element()
+			converted, ok := ctyToAny(element)
+			if !ok {
+				return nil, false
+			}
+			result = append(result, converted)
+		}
+		return result, true
+	}
+
+	return nil, false
+}
+
 func anyToHcl(value any) string {
 	if value == nil {
 		return "null"
@@ -159,16 +317,21 @@ func anyToHcl(value any) string {
 		return mapToHclMap(mapItem)
 	} else if arrayItem, ok := value.([]any); ok {
 		return arrayToHclMap(arrayItem)
+	} else if number, ok := value.(json.Number); ok {
+		// Numbers and booleans keep their type, so jsonencode() writes the same JSON document
+		return number.String()
+	} else if boolean, ok := value.(bool); ok {
+		return strconv.FormatBool(boolean)
 	} else {
 		return encodeString(fmt.Sprint(value))
 	}
 }
 
-// mapToHclMap builds a nicely indented HCL map
+// mapToHclMap builds a nicely indented HCL map. The keys are sorted so the generated HCL is stable.
 func mapToHclMap(jsonMap map[string]any) string {
 	output := "{"
-	for k, v := range jsonMap {
-		output += "\n        \"" + k + "\" = " + anyToHcl(v)
+	for _, k := range slices.Sorted(maps.Keys(jsonMap)) {
+		output += "\n        " + encodeString(k) + " = " + anyToHcl(jsonMap[k])
 	}
 	if len(jsonMap) != 0 {
 		output += "\n        "
@@ -1,6 +1,9 @@
 package hcl
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestIsInterpolation(t *testing.T) {
 	if IsInterpolation("$${hi}") {
@@ -41,3 +44,48 @@ func TestRemoveInterpolation(t *testing.T) {
 		t.Fatal("Interpolation removal failed")
 	}
 }
+
+func TestJsonStringToHcl(t *testing.T) {
+	expression := jsonStringToHcl(`{"Name":"web","Ports":[{"port":80,"enabled":true}],"Feed":"${octopusdeploy_feed.feed.id}","Env":"$${HOME}","Image":null}`)
+
+	expected := `jsonencode({
+        "Env" = "$${HOME}"
+        "Feed" = "${octopusdeploy_feed.feed.id}"
+        "Image" = null
+        "Name" = "web"
+        "Ports" = [
+        {
+        "enabled" = true
+        "port" = 80
+                },
+        ]
+                })`
+
+	if expression != expected {
+		t.Fatalf("unexpected expression %s", expression)
+	}
+}
+
+func TestJsonStringToHclFallback(t *testing.T) {
+	// Scalar JSON values are written as strings
+	if jsonStringToHcl("80") != `"80"` {
+		t.Fatal("scalar values must be written as strings")
+	}
+
+	// Invalid interpolations and template directives fail the round trip check
+	if !strings.HasPrefix(jsonStringToHcl(`{"a":"${"}`), `"`) || !strings.HasPrefix(jsonStringToHcl(`{"a":"%{ if }"}`), `"`) {
+		t.Fatal("documents that do not round trip must be written as strings")
+	}
+}
+
+func TestExtractJsonAsMapWithExpressionsSorted(t *testing.T) {
+	output := extractJsonAsMapWithExpressions(
+		map[string]string{"c": "3", "a": "1"},
+		map[string]string{"b": "file(\"b.sh\")"})
+
+	expected := "{\n        \"a\" = \"1\"\n        \"b\" = file(\"b.sh\")\n        \"c\" = \"3\"\n      }"
+
+	if output != expected {
+		t.Fatalf("unexpected output %q", output)
+	}
+}
@@ -70,6 +70,7 @@ type Action struct {
 	Condition                     *string
 	Properties                    map[string]any
 	Inputs                        map[string]any
+	StepPackageVersion            *string
 	GitDependencies               []GitDependency
 }
 
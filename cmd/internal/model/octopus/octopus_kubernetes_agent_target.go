@@ -0,0 +1,28 @@
+package octopus
+
+// KubernetesAgentTarget is a deployment target running the Octopus Kubernetes agent
+type KubernetesAgentTarget struct {
+	Target
+
+	Id                              string
+	Name                            string
+	Roles                           []string
+	TenantIds                       []string
+	TenantTags                      []string
+	TenantedDeploymentParticipation string
+	Thumbprint                      string
+	Uri                             *string
+	IsDisabled                      bool
+	MachinePolicyId                 string
+	HealthStatus                    string
+	HasLatestCalamari               bool
+	StatusSummary                   string
+	IsInProcess                     bool
+	OperatingSystem                 string
+	ShellName                       string
+	ShellVersion                    string
+	Architecture                    string
+	Slug                            string
+	SkipInitialHealthCheck          bool
+	Endpoint                        KubernetesAgentWorkerEndpoint
+}
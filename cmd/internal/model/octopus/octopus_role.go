@@ -4,10 +4,10 @@ type Role struct {
 	Id                           string
 	Name                         string
 	Description                  string
-	SupportedRestrictions        *string
-	SpacePermissionDescriptions  map[int]string
-	SystemPermissionDescriptions map[int]string
-	GrantedSpacePermissions      map[int]string
-	GrantedSystemPermissions     map[int]string
+	SupportedRestrictions        []string
+	SpacePermissionDescriptions  []string
+	SystemPermissionDescriptions []string
+	GrantedSpacePermissions      []string
+	GrantedSystemPermissions     []string
 	CanBeDeleted                 bool
 }
@@ -0,0 +1,14 @@
+package octopus
+
+// ScopedUserRole links a team to a user role, optionally limiting the role to a subset of the
+// environments, projects, project groups and tenants in a space.
+type ScopedUserRole struct {
+	Id              string
+	SpaceId         *string
+	TeamId          string
+	UserRoleId      string
+	EnvironmentIds  []string
+	ProjectIds      []string
+	ProjectGroupIds []string
+	TenantIds       []string
+}
@@ -3,8 +3,8 @@ package octopus
 type Team struct {
 	Id                     string
 	Name                   string
-	MemberUserIds          map[int]string
-	ExternalSecurityGroups []string
+	MemberUserIds          []string
+	ExternalSecurityGroups []TeamExternalSecurityGroup
 	CanBeDeleted           bool
 	CanBeRenamed           bool
 	CanChangeRoles         bool
@@ -13,3 +13,9 @@ type Team struct {
 	Slug                   string
 	Description            string
 }
+
+type TeamExternalSecurityGroup struct {
+	Id               string
+	DisplayName      string
+	DisplayIdAndName bool
+}
@@ -99,3 +99,58 @@ type TerraformS3Feed struct {
 	SecretKey             *string `hcl:"secret_key"`
 	Username              *string `hcl:"username"`
 }
+
+type TerraformOciRegistryFeed struct {
+	Type         string  `hcl:"type,label"`
+	Name         string  `hcl:"name,label"`
+	Id           *string `hcl:"id"`
+	Count        *string `hcl:"count"`
+	ResourceName string  `hcl:"name"`
+	FeedUri      *string `hcl:"feed_uri"`
+	Username     *string `hcl:"username"`
+	Password     *string `hcl:"password"`
+	SpaceId      *string `hcl:"space_id"`
+}
+
+type TerraformAzureContainerRegistryFeed struct {
+	Type         string  `hcl:"type,label"`
+	Name         string  `hcl:"name,label"`
+	Id           *string `hcl:"id"`
+	Count        *string `hcl:"count"`
+	ResourceName string  `hcl:"name"`
+	FeedUri      *string `hcl:"feed_uri"`
+	RegistryPath *string `hcl:"registry_path"`
+	ApiVersion   *string `hcl:"api_version"`
+	Username     *string `hcl:"username"`
+	Password     *string `hcl:"password"`
+	SpaceId      *string `hcl:"space_id"`
+}
+
+type TerraformGoogleContainerRegistryFeed struct {
+	Type         string  `hcl:"type,label"`
+	Name         string  `hcl:"name,label"`
+	Id           *string `hcl:"id"`
+	Count        *string `hcl:"count"`
+	ResourceName string  `hcl:"name"`
+	FeedUri      *string `hcl:"feed_uri"`
+	RegistryPath *string `hcl:"registry_path"`
+	ApiVersion   *string `hcl:"api_version"`
+	Username     *string `hcl:"username"`
+	Password     *string `hcl:"password"`
+	SpaceId      *string `hcl:"space_id"`
+}
+
+type TerraformNpmFeed struct {
+	Type                              string   `hcl:"type,label"`
+	Name                              string   `hcl:"name,label"`
+	Id                                *string  `hcl:"id"`
+	Count                             *string  `hcl:"count"`
+	ResourceName                      string   `hcl:"name"`
+	FeedUri                           *string  `hcl:"feed_uri"`
+	Username                          *string  `hcl:"username"`
+	Password                          *string  `hcl:"password"`
+	SpaceId                           *string  `hcl:"space_id"`
+	PackageAcquisitionLocationOptions []string `hcl:"package_acquisition_location_options"`
+	DownloadAttempts                  *int     `hcl:"download_attempts"`
+	DownloadRetryBackoffSeconds       *int     `hcl:"download_retry_backoff_seconds"`
+}
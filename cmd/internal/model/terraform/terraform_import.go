@@ -0,0 +1,7 @@
+package terraform
+
+// TerraformImport represents an import block, which imports an existing resource into the Terraform state.
+type TerraformImport struct {
+	To string `hcl:"to"`
+	Id string `hcl:"id"`
+}
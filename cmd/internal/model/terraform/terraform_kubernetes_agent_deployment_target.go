@@ -0,0 +1,22 @@
+package terraform
+
+type TerraformKubernetesAgentDeploymentTarget struct {
+	Type                            string   `hcl:"type,label"`
+	Name                            string   `hcl:"name,label"`
+	Count                           *string  `hcl:"count"`
+	Id                              *string  `hcl:"id"`
+	SpaceId                         *string  `hcl:"space_id"`
+	ResourceName                    string   `hcl:"name"`
+	Environments                    []string `hcl:"environments"`
+	Roles                           []string `hcl:"roles"`
+	Thumbprint                      string   `hcl:"thumbprint"`
+	Uri                             string   `hcl:"uri"`
+	CommunicationMode               *string  `hcl:"communication_mode"`
+	DefaultNamespace                *string  `hcl:"default_namespace"`
+	IsDisabled                      *bool    `hcl:"is_disabled"`
+	MachinePolicyId                 *string  `hcl:"machine_policy_id"`
+	TenantTags                      []string `hcl:"tenant_tags"`
+	TenantedDeploymentParticipation *string  `hcl:"tenanted_deployment_participation"`
+	Tenants                         []string `hcl:"tenants"`
+	UpgradeLocked                   *bool    `hcl:"upgrade_locked"`
+}
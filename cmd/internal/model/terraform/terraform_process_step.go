@@ -20,20 +20,24 @@ type TerraformProcessStep struct {
 	// ExecutionProperties are properties associated with the step.
 	ExecutionProperties *map[string]string                              `hcl:"execution_properties"`
 	GitDependencies     *map[string]TerraformProcessStepGitDependencies `hcl:"git_dependencies"`
-	IsDisabled          *bool                                           `hcl:"is_disabled"`
-	IsRequired          *bool                                           `hcl:"is_required"`
-	Notes               *string                                         `hcl:"notes"`
-	PackageRequirement  *string                                         `hcl:"package_requirement"`
-	Packages            *map[string]TerraformProcessStepPackage         `hcl:"packages"`
-	PrimaryPackage      *TerraformProcessStepPackage                    `hcl:"primary_package"`
+	// Inputs are the JSON encoded inputs of a step from the step framework.
+	Inputs             *string                                 `hcl:"inputs"`
+	IsDisabled         *bool                                   `hcl:"is_disabled"`
+	IsRequired         *bool                                   `hcl:"is_required"`
+	Notes              *string                                 `hcl:"notes"`
+	PackageRequirement *string                                 `hcl:"package_requirement"`
+	Packages           *map[string]TerraformProcessStepPackage `hcl:"packages"`
+	PrimaryPackage     *TerraformProcessStepPackage            `hcl:"primary_package"`
 	// Properties are properties associated with the first action.
-	Properties         *map[string]string `hcl:"properties"`
-	Slug               *string            `hcl:"slug"`
-	SpaceId            *string            `hcl:"space_id"`
-	StartTrigger       *string            `hcl:"start_trigger"`
-	TenantTags         []string           `hcl:"tenant_tags"`
-	WorkerPoolId       *string            `hcl:"worker_pool_id"`
-	WorkerPoolVariable *string            `hcl:"worker_pool_variable"`
+	Properties   *map[string]string `hcl:"properties"`
+	Slug         *string            `hcl:"slug"`
+	SpaceId      *string            `hcl:"space_id"`
+	StartTrigger *string            `hcl:"start_trigger"`
+	// StepPackageVersion is the version of the step package used by a step from the step framework.
+	StepPackageVersion *string  `hcl:"step_package_version"`
+	TenantTags         []string `hcl:"tenant_tags"`
+	WorkerPoolId       *string  `hcl:"worker_pool_id"`
+	WorkerPoolVariable *string  `hcl:"worker_pool_variable"`
 }
 
 func (a *TerraformProcessStep) SetWorkerPoolId(workerPool string) {
@@ -2,6 +2,9 @@ package terraform
 
 import "github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 
+// DefaultOctopusProviderVersion is the version of the Octopus Terraform provider used when no version is specified
+const DefaultOctopusProviderVersion = "1.19.2"
+
 type TerraformConfig struct {
 	RequiredProviders RequiredProviders `hcl:"required_providers,block"`
 	Backend           *Backend          `hcl:"backend,block"`
@@ -45,7 +48,7 @@ func (c TerraformConfig) CreateTerraformConfig(backend string, version string) T
 		RequiredProviders: RequiredProviders{
 			OctopusProvider: ProviderDefinition{
 				Source:  "OctopusDeploy/octopusdeploy",
-				Version: strutil.DefaultIfEmpty(version, "1.19.2"),
+				Version: strutil.DefaultIfEmpty(version, DefaultOctopusProviderVersion),
 			},
 		},
 		RequiredVersion: strutil.StrPointer(">= 1.6.0"),
@@ -0,0 +1,15 @@
+package terraform
+
+type TerraformScopedUserRole struct {
+	Type            string   `hcl:"type,label"`
+	Name            string   `hcl:"name,label"`
+	Count           *string  `hcl:"count"`
+	Id              *string  `hcl:"id"`
+	SpaceId         *string  `hcl:"space_id"`
+	TeamId          string   `hcl:"team_id"`
+	UserRoleId      string   `hcl:"user_role_id"`
+	EnvironmentIds  []string `hcl:"environment_ids"`
+	ProjectIds      []string `hcl:"project_ids"`
+	ProjectGroupIds []string `hcl:"project_group_ids"`
+	TenantIds       []string `hcl:"tenant_ids"`
+}
@@ -0,0 +1,18 @@
+package terraform
+
+type TerraformTeam struct {
+	Type                  string                               `hcl:"type,label"`
+	Name                  string                               `hcl:"name,label"`
+	Count                 *string                              `hcl:"count"`
+	Id                    *string                              `hcl:"id"`
+	SpaceId               *string                              `hcl:"space_id"`
+	ResourceName          string                               `hcl:"name"`
+	Description           *string                              `hcl:"description"`
+	ExternalSecurityGroup []TerraformTeamExternalSecurityGroup `hcl:"external_security_group,block"`
+}
+
+type TerraformTeamExternalSecurityGroup struct {
+	Id               string `hcl:"id"`
+	DisplayName      string `hcl:"display_name"`
+	DisplayIdAndName bool   `hcl:"display_id_and_name"`
+}
@@ -0,0 +1,12 @@
+package terraform
+
+type TerraformTeamData struct {
+	Type          string                          `hcl:"type,label"`
+	Name          string                          `hcl:"name,label"`
+	Ids           []string                        `hcl:"ids"`
+	PartialName   string                          `hcl:"partial_name"`
+	IncludeSystem bool                            `hcl:"include_system"`
+	Skip          int                             `hcl:"skip"`
+	Take          int                             `hcl:"take"`
+	Lifecycle     *TerraformLifecycleMetaArgument `hcl:"lifecycle,block"`
+}
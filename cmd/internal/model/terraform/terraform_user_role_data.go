@@ -0,0 +1,11 @@
+package terraform
+
+type TerraformUserRoleData struct {
+	Type        string                          `hcl:"type,label"`
+	Name        string                          `hcl:"name,label"`
+	Ids         []string                        `hcl:"ids"`
+	PartialName string                          `hcl:"partial_name"`
+	Skip        int                             `hcl:"skip"`
+	Take        int                             `hcl:"take"`
+	Lifecycle   *TerraformLifecycleMetaArgument `hcl:"lifecycle,block"`
+}
@@ -3,7 +3,6 @@ package naming
 import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hash"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/sanitizer"
 )
 
 /*
@@ -37,30 +36,37 @@ func DeploymentProcessPropertySecretName(named octopus.NamedResource, action oct
 	return "action_" + hash.Sha256Hash(named.GetId()+"_"+action.GetId()+"_"+property) + "_sensitive_value"
 }
 
-func GitCredentialSecretName(gitCredentials octopus.GitCredentials) string {
-	return "gitcredential_" + sanitizer.SanitizeName(gitCredentials.Name) + "_sensitive_value"
+// GitCredentialSecretName is built from the label of the git credential resource, which is unique, so two git
+// credentials never share a variable.
+func GitCredentialSecretName(gitCredentialsName string) string {
+	return gitCredentialsName + "_sensitive_value"
 }
 
-func CertificateDataName(certificate octopus.Certificate) string {
-	return "certificate_" + sanitizer.SanitizeName(certificate.Name) + "_data"
+// CertificateDataName is built from the label of the certificate resource.
+func CertificateDataName(certificateName string) string {
+	return certificateName + "_data"
 }
 
-func CertificatePasswordName(certificate octopus.Certificate) string {
-	return "certificate_" + sanitizer.SanitizeName(certificate.Name) + "_password"
+// CertificatePasswordName is built from the label of the certificate resource.
+func CertificatePasswordName(certificateName string) string {
+	return certificateName + "_password"
 }
 
-func FeedSecretName(resource octopus.Feed) string {
-	return "feed_" + sanitizer.SanitizeName(resource.Name) + "_password"
+// FeedSecretName is built from the label of the feed resource.
+func FeedSecretName(feedName string) string {
+	return feedName + "_password"
 }
 
-func FeedSecretKeyName(resource octopus.Feed) string {
-	return "feed_" + sanitizer.SanitizeName(resource.Name) + "_secretkey"
+// FeedSecretKeyName is built from the label of the feed resource.
+func FeedSecretKeyName(feedName string) string {
+	return feedName + "_secretkey"
 }
 
 func StepTemplateParameterSecretName(template octopus.StepTemplate, parameter octopus.StepTemplateParameters) string {
 	return "steptemplate_" + hash.Sha256Hash(template.Id+"_"+parameter.Id) + "_sensitive_value"
 }
 
-func MachineProxyPassword(machine octopus.MachineProxy) string {
-	return "machine_proxy_" + sanitizer.SanitizeName(machine.Name) + "_password"
+// MachineProxyPassword is built from the label of the machine proxy resource.
+func MachineProxyPassword(machineProxyName string) string {
+	return machineProxyName + "_password"
 }
@@ -54,45 +54,40 @@ func TestDeploymentProcessPropertySecretName(t *testing.T) {
 }
 
 func TestGitCredentialSecretName(t *testing.T) {
-	gitCredentials := octopus.GitCredentials{Name: "test-name"}
 	expected := "gitcredential_test_name_sensitive_value"
-	result := GitCredentialSecretName(gitCredentials)
+	result := GitCredentialSecretName("gitcredential_test_name")
 	if result != expected {
 		t.Errorf("expected %s, got %s", expected, result)
 	}
 }
 
 func TestCertificateDataName(t *testing.T) {
-	certificate := octopus.Certificate{Name: "test-cert"}
 	expected := "certificate_test_cert_data"
-	result := CertificateDataName(certificate)
+	result := CertificateDataName("certificate_test_cert")
 	if result != expected {
 		t.Errorf("expected %s, got %s", expected, result)
 	}
 }
 
 func TestCertificatePasswordName(t *testing.T) {
-	certificate := octopus.Certificate{Name: "test-cert"}
 	expected := "certificate_test_cert_password"
-	result := CertificatePasswordName(certificate)
+	result := CertificatePasswordName("certificate_test_cert")
 	if result != expected {
 		t.Errorf("expected %s, got %s", expected, result)
 	}
 }
 
 func TestFeedSecretName(t *testing.T) {
-	resource := octopus.Feed{Name: "test-feed"}
 	expected := "feed_test_feed_password"
-	result := FeedSecretName(resource)
+	result := FeedSecretName("feed_test_feed")
 	if result != expected {
 		t.Errorf("expected %s, got %s", expected, result)
 	}
 }
 
 func TestFeedSecretKeyName(t *testing.T) {
-	resource := octopus.Feed{Name: "test-feed"}
 	expected := "feed_test_feed_secretkey"
-	result := FeedSecretKeyName(resource)
+	result := FeedSecretKeyName("feed_test_feed")
 	if result != expected {
 		t.Errorf("expected %s, got %s", expected, result)
 	}
@@ -109,9 +104,8 @@ func TestStepTemplateParameterSecretName(t *testing.T) {
 }
 
 func TestMachineProxyPassword(t *testing.T) {
-	machine := octopus.MachineProxy{Name: "test-machine"}
 	expected := "machine_proxy_test_machine_password"
-	result := MachineProxyPassword(machine)
+	result := MachineProxyPassword("machine_proxy_test_machine")
 	if result != expected {
 		t.Errorf("expected %s, got %s", expected, result)
 	}
@@ -1,15 +1,19 @@
 package output
 
 import (
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
 )
 
+// WriteString returns the contents of every file, ordered by file name.
 func WriteString(files map[string]string) string {
 	var sb strings.Builder
-	for _, str := range strutil.UnEscapeDollarInMap(files) {
-		sb.WriteString(str + "\n\n")
+	unescaped := strutil.UnEscapeDollarInMap(files)
+	for _, name := range slices.Sorted(maps.Keys(unescaped)) {
+		sb.WriteString(unescaped[name] + "\n\n")
 	}
 	return sb.String()
 }
@@ -0,0 +1,16 @@
+package secrets
+
+import (
+	"os"
+)
+
+// EnvironmentSecretProvider reads the secrets from environment variables named after the Terraform variables with
+// a prefix. For example, with the prefix "OCTOTERRA_SECRET_", the value of the Terraform variable
+// "account_aws_account" is read from the environment variable "OCTOTERRA_SECRET_account_aws_account".
+type EnvironmentSecretProvider struct {
+	Prefix string
+}
+
+func (e EnvironmentSecretProvider) GetSecret(variableName string) (string, bool) {
+	return os.LookupEnv(e.Prefix + variableName)
+}
@@ -0,0 +1,49 @@
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileSecretProvider reads the secrets from a JSON or YAML file holding an object that maps the Terraform variable
+// names to the secret values.
+type FileSecretProvider struct {
+	secrets map[string]string
+}
+
+// NewFileSecretProvider loads the secrets from the file. The format is determined by the file extension, which must
+// be .json, .yaml or .yml.
+func NewFileSecretProvider(path string) (*FileSecretProvider, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := map[string]string{}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(content, &secrets)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &secrets)
+	default:
+		return nil, errors.New("the secrets file " + path + " must have a .json, .yaml or .yml extension")
+	}
+
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to parse the secrets file "+path), err)
+	}
+
+	return &FileSecretProvider{secrets: secrets}, nil
+}
+
+func (f *FileSecretProvider) GetSecret(variableName string) (string, bool) {
+	value, ok := f.secrets[variableName]
+	return value, ok
+}
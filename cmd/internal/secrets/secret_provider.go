@@ -0,0 +1,47 @@
+package secrets
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+)
+
+// SecretProvider supplies the values of sensitive values at export time. The Octopus API never returns secrets, so
+// without a provider every secret is exposed as a Terraform variable that must be defined when the module is applied.
+// Secrets are identified by the name of the Terraform variable, which is generated by the functions in the naming
+// package.
+type SecretProvider interface {
+	// GetSecret returns the value of the secret exposed by the Terraform variable, and whether the value was found.
+	GetSecret(variableName string) (string, bool)
+}
+
+// MultiSecretProvider returns the value from the first provider that has a value for the secret.
+type MultiSecretProvider []SecretProvider
+
+func (m MultiSecretProvider) GetSecret(variableName string) (string, bool) {
+	for _, provider := range m {
+		if value, ok := provider.GetSecret(variableName); ok {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// AddSecretVariable records the sensitive Terraform variable in the dependencies, along with its value if the
+// provider has one. It returns true if the value was found, meaning a dummy value is not required. The provider may
+// be nil.
+func AddSecretVariable(provider SecretProvider, reference data.SecretVariableReference, dependencies *data.ResourceDetailsCollection) bool {
+	dependencies.AddSecretVariable(reference)
+
+	if provider == nil {
+		return false
+	}
+
+	value, ok := provider.GetSecret(reference.VariableName)
+
+	if !ok {
+		return false
+	}
+
+	dependencies.AddSecretValue(reference.VariableName, value)
+	return true
+}
@@ -0,0 +1,97 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
+)
+
+func TestFileSecretProvider(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"secrets.json": `{"feed_docker_password": "json secret"}`,
+		"secrets.yaml": "feed_docker_password: yaml secret\n",
+	}
+
+	expected := map[string]string{
+		"secrets.json": "json secret",
+		"secrets.yaml": "yaml secret",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		provider, err := NewFileSecretProvider(path)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if value, ok := provider.GetSecret("feed_docker_password"); !ok || value != expected[name] {
+			t.Fatalf("unexpected secret %s from %s", value, name)
+		}
+
+		if _, ok := provider.GetSecret("missing"); ok {
+			t.Fatalf("expected the missing secret to not be found in %s", name)
+		}
+	}
+}
+
+func TestFileSecretProviderRejectsUnknownExtension(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.txt")
+
+	if err := os.WriteFile(path, []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFileSecretProvider(path); err == nil {
+		t.Fatal("expected an error for the unknown file extension")
+	}
+}
+
+func TestMultiSecretProvider(t *testing.T) {
+	t.Setenv("TEST_SECRET_first", "environment first")
+	t.Setenv("TEST_SECRET_second", "environment second")
+
+	path := filepath.Join(t.TempDir(), "secrets.json")
+
+	if err := os.WriteFile(path, []byte(`{"first": "file first"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	fileProvider, err := NewFileSecretProvider(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := MultiSecretProvider{fileProvider, EnvironmentSecretProvider{Prefix: "TEST_SECRET_"}}
+	dependencies := data.ResourceDetailsCollection{}
+
+	if !AddSecretVariable(provider, data.SecretVariableReference{VariableName: "first"}, &dependencies) || !AddSecretVariable(provider, data.SecretVariableReference{VariableName: "second"}, &dependencies) {
+		t.Fatal("expected the secrets to be found")
+	}
+
+	if AddSecretVariable(provider, data.SecretVariableReference{VariableName: "third"}, &dependencies) || AddSecretVariable(nil, data.SecretVariableReference{VariableName: "first"}, &dependencies) {
+		t.Fatal("expected the secrets to not be found")
+	}
+
+	if dependencies.SecretValues["first"] != "file first" || dependencies.SecretValues["second"] != "environment second" {
+		t.Fatalf("unexpected secret values %v", dependencies.SecretValues)
+	}
+
+	if len(dependencies.SecretValues) != 2 {
+		t.Fatalf("expected only the found secrets to be recorded, got %v", dependencies.SecretValues)
+	}
+
+	if len(dependencies.SecretVariables) != 4 {
+		t.Fatalf("expected every secret variable to be recorded, got %v", dependencies.SecretVariables)
+	}
+}
@@ -0,0 +1,444 @@
+// Package server exposes exports as asynchronous jobs over HTTP. An export is started with a POST request, its
+// status is polled while it runs, and the result is downloaded once it is complete. This allows large spaces to be
+// exported without holding a HTTP request open for the duration of the export.
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/export"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/hash"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/pkg/octoterra"
+	"go.uber.org/zap"
+)
+
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusFailed    = "failed"
+	JobStatusCancelled = "cancelled"
+)
+
+const (
+	ApiKeyHeader      = "X-Octopus-ApiKey"
+	AccessTokenHeader = "X-Octopus-AccessToken"
+	UrlHeader         = "X-Octopus-Url"
+)
+
+// ExportFunc runs an export. It is octoterra.Export outside of tests.
+type ExportFunc func(ctx context.Context, options export.Options) (octoterra.Files, octoterra.Manifest, error)
+
+// JobStatus is the response returned when an export is started and when its status is queried.
+type JobStatus struct {
+	Id        string             `json:"id"`
+	Status    string             `json:"status"`
+	Progress  octoterra.Progress `json:"progress"`
+	Error     string             `json:"error,omitempty"`
+	Created   time.Time          `json:"created"`
+	Completed *time.Time         `json:"completed,omitempty"`
+}
+
+type job struct {
+	status JobStatus
+	// owner is a hash of the credentials that started the export. Only requests with the same credentials can
+	// read the status or result of the export.
+	owner  string
+	cancel context.CancelFunc
+	files  octoterra.Files
+}
+
+// Server runs exports as asynchronous jobs.
+type Server struct {
+	// Export runs the export. When nil, octoterra.Export is used.
+	Export ExportFunc
+	// MaxConcurrentExports is the number of exports that run at the same time. Additional exports are queued.
+	// Defaults to 1.
+	MaxConcurrentExports int
+	// MaxQueuedExports is the number of exports that can wait for a free slot. Requests to start an export are
+	// rejected when the queue is full. 0 means the queue is unbounded.
+	MaxQueuedExports int
+	// ResultExpiry is how long a finished export is kept. Defaults to one hour.
+	ResultExpiry time.Duration
+	// Version is passed to each export
+	Version string
+
+	mu      sync.Mutex
+	once    sync.Once
+	jobs    map[string]*job
+	slots   chan struct{}
+	waiting int
+}
+
+func (s *Server) init() {
+	s.once.Do(func() {
+		s.jobs = map[string]*job{}
+		s.slots = make(chan struct{}, max(s.MaxConcurrentExports, 1))
+
+		if s.Export == nil {
+			s.Export = export.Export
+		}
+
+		if s.ResultExpiry <= 0 {
+			s.ResultExpiry = time.Hour
+		}
+	})
+}
+
+// Handler returns the HTTP handler exposing the export endpoints:
+//
+//	POST   /exports             starts an export, returning the job status
+//	GET    /exports/{id}        returns the status and progress of an export
+//	GET    /exports/{id}/result returns the exported files as a JSON map, or as a zip file with ?format=zip
+//	DELETE /exports/{id}        cancels an export and discards the result
+func (s *Server) Handler() http.Handler {
+	s.init()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /exports", s.startExport)
+	mux.HandleFunc("GET /exports/{id}", s.getStatus)
+	mux.HandleFunc("GET /exports/{id}/result", s.getResult)
+	mux.HandleFunc("DELETE /exports/{id}", s.deleteExport)
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte("Healthy"))
+	})
+	return mux
+}
+
+func (s *Server) startExport(w http.ResponseWriter, r *http.Request) {
+	url := r.Header.Get(UrlHeader)
+	apiKey := r.Header.Get(ApiKeyHeader)
+	accessToken := r.Header.Get(AccessTokenHeader)
+
+	if url == "" || (apiKey == "" && accessToken == "") {
+		writeError(w, http.StatusUnauthorized, errors.New("the "+UrlHeader+" header, and either the "+ApiKeyHeader+
+			" or "+AccessTokenHeader+" header, must be defined"))
+		return
+	}
+
+	arguments, err := parseBody(r.Body)
+
+	if err == nil {
+		err = arguments.Validate()
+	}
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	arguments.Url = url
+	arguments.ApiKey = apiKey
+	arguments.AccessToken = accessToken
+
+	options := export.Options{
+		Arguments: arguments,
+		Version:   s.Version,
+	}
+
+	id, err := newJobId()
+
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// The export must outlive the request that started it
+	ctx, cancel := context.WithCancel(context.Background())
+
+	newJob := &job{
+		status: JobStatus{
+			Id:      id,
+			Status:  JobStatusQueued,
+			Created: time.Now().UTC(),
+		},
+		owner:  requestOwner(r),
+		cancel: cancel,
+	}
+
+	s.mu.Lock()
+	if s.MaxQueuedExports > 0 && s.waiting >= s.MaxQueuedExports {
+		s.mu.Unlock()
+		cancel()
+		writeError(w, http.StatusTooManyRequests, errors.New("too many exports are queued, try again later"))
+		return
+	}
+	s.waiting++
+	s.jobs[id] = newJob
+	status := newJob.status
+	s.mu.Unlock()
+
+	go s.run(ctx, newJob, options)
+
+	zap.L().Info("Queued export " + id)
+
+	w.Header().Set("Location", "/exports/"+id)
+	writeJson(w, http.StatusAccepted, status)
+}
+
+// run waits for a free slot and then runs the export.
+func (s *Server) run(ctx context.Context, exportJob *job, options export.Options) {
+	defer exportJob.cancel()
+
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	case <-ctx.Done():
+		s.mu.Lock()
+		s.waiting--
+		s.mu.Unlock()
+		s.finish(exportJob, nil, ctx.Err())
+		return
+	}
+
+	s.mu.Lock()
+	s.waiting--
+	exportJob.status.Status = JobStatusRunning
+	s.mu.Unlock()
+
+	options.Progress = func(progress octoterra.Progress) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		// Files are generated concurrently, so a late progress report must not move the count backwards
+		if progress.Stage == exportJob.status.Progress.Stage && progress.Completed < exportJob.status.Progress.Completed {
+			return
+		}
+		exportJob.status.Progress = progress
+	}
+
+	zap.L().Info("Running export " + exportJob.status.Id)
+
+	files, _, err := s.Export(ctx, options)
+
+	s.finish(exportJob, files, err)
+}
+
+// finish records the result of the export and schedules it to be removed.
+func (s *Server) finish(exportJob *job, files octoterra.Files, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	completed := time.Now().UTC()
+	exportJob.status.Completed = &completed
+
+	if errors.Is(err, context.Canceled) {
+		exportJob.status.Status = JobStatusCancelled
+	} else if err != nil {
+		exportJob.status.Status = JobStatusFailed
+		exportJob.status.Error = err.Error()
+	} else {
+		exportJob.status.Status = JobStatusCompleted
+		exportJob.files = files
+	}
+
+	zap.L().Info("Export " + exportJob.status.Id + " " + exportJob.status.Status)
+
+	id := exportJob.status.Id
+	time.AfterFunc(s.ResultExpiry, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.jobs, id)
+	})
+}
+
+func (s *Server) getStatus(w http.ResponseWriter, r *http.Request) {
+	exportJob := s.getJob(r)
+
+	if exportJob == nil {
+		writeError(w, http.StatusNotFound, errors.New("the export was not found or has expired"))
+		return
+	}
+
+	s.mu.Lock()
+	status := exportJob.status
+	s.mu.Unlock()
+
+	writeJson(w, http.StatusOK, status)
+}
+
+func (s *Server) getResult(w http.ResponseWriter, r *http.Request) {
+	exportJob := s.getJob(r)
+
+	if exportJob == nil {
+		writeError(w, http.StatusNotFound, errors.New("the export was not found or has expired"))
+		return
+	}
+
+	s.mu.Lock()
+	status := exportJob.status.Status
+	files := exportJob.files
+	s.mu.Unlock()
+
+	if status != JobStatusCompleted {
+		writeError(w, http.StatusConflict, errors.New("the export is "+status))
+		return
+	}
+
+	switch r.URL.Query().Get("format") {
+	case "", "json":
+		writeJson(w, http.StatusOK, files)
+	case "zip":
+		archive, err := zipFiles(files)
+
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", "attachment; filename=\""+r.PathValue("id")+".zip\"")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(archive); err != nil {
+			zap.L().Error(err.Error())
+		}
+	default:
+		writeError(w, http.StatusBadRequest, errors.New("the format must be json or zip"))
+	}
+}
+
+func (s *Server) deleteExport(w http.ResponseWriter, r *http.Request) {
+	exportJob := s.getJob(r)
+
+	if exportJob == nil {
+		writeError(w, http.StatusNotFound, errors.New("the export was not found or has expired"))
+		return
+	}
+
+	exportJob.cancel()
+
+	s.mu.Lock()
+	delete(s.jobs, exportJob.status.Id)
+	s.mu.Unlock()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getJob returns the job identified in the request path, or nil if the job does not exist or was started with
+// different credentials.
+func (s *Server) getJob(r *http.Request) *job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exportJob, ok := s.jobs[r.PathValue("id")]
+
+	if !ok || exportJob.owner != requestOwner(r) {
+		return nil
+	}
+
+	return exportJob
+}
+
+// parseBody reads the export arguments from the request body. The body is a JSON object using the same names as
+// the octoterra config file. Arguments that are not defined in the body keep their default values. The config file
+// and environment variables of the server are never read, so they do not change the exports of remote callers.
+func parseBody(body io.Reader) (args.Arguments, error) {
+	arguments := args.DefaultArguments()
+
+	// Some local arguments, like the config file name, have default values, which are cleared so the export does not
+	// read from or write to the file system of the server
+	arguments.ClearLocalArguments()
+
+	content, err := io.ReadAll(body)
+
+	if err != nil {
+		return args.Arguments{}, err
+	}
+
+	if len(bytes.TrimSpace(content)) == 0 {
+		return arguments, nil
+	}
+
+	config := map[string]any{}
+	if err := json.Unmarshal(content, &config); err != nil {
+		return args.Arguments{}, err
+	}
+
+	// Only arguments that shape the export are accepted. Credentials are only accepted as headers.
+	for name := range config {
+		if !args.IsRemoteArg(name) {
+			delete(config, name)
+		}
+	}
+
+	sanitized, err := json.Marshal(config)
+
+	if err != nil {
+		return args.Arguments{}, err
+	}
+
+	if err := json.Unmarshal(sanitized, &arguments); err != nil {
+		return args.Arguments{}, err
+	}
+
+	return arguments, nil
+}
+
+func requestOwner(r *http.Request) string {
+	return hash.Sha256Hash(r.Header.Get(UrlHeader) + "\n" + r.Header.Get(ApiKeyHeader) + "\n" + r.Header.Get(AccessTokenHeader))
+}
+
+func newJobId() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+func zipFiles(files octoterra.Files) ([]byte, error) {
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		file, err := archive.Create(name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := file.Write([]byte(files[name])); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func writeJson(w http.ResponseWriter, status int, body any) {
+	content, err := json.Marshal(body)
+
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(content); err != nil {
+		zap.L().Error(err.Error())
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	zap.L().Error(err.Error())
+	writeJson(w, status, map[string]string{"error": err.Error()})
+}
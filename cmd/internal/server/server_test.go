@@ -0,0 +1,294 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/export"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/pkg/octoterra"
+)
+
+func newRequest(method string, url string, body string, apiKey string) *http.Request {
+	request := httptest.NewRequest(method, url, strings.NewReader(body))
+	request.Header.Set(UrlHeader, "https://example.octopus.app")
+	request.Header.Set(ApiKeyHeader, apiKey)
+	return request
+}
+
+func startExport(t *testing.T, handler http.Handler, body string) JobStatus {
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, newRequest(http.MethodPost, "/exports", body, "API-TEST"))
+
+	if response.Code != http.StatusAccepted {
+		t.Fatalf("expected status 202, got %d: %s", response.Code, response.Body.String())
+	}
+
+	status := JobStatus{}
+	if err := json.Unmarshal(response.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+
+	return status
+}
+
+func waitForStatus(t *testing.T, handler http.Handler, id string, expected string) JobStatus {
+	for i := 0; i < 100; i++ {
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, newRequest(http.MethodGet, "/exports/"+id, "", "API-TEST"))
+
+		status := JobStatus{}
+		if err := json.Unmarshal(response.Body.Bytes(), &status); err != nil {
+			t.Fatal(err)
+		}
+
+		if status.Status == expected {
+			return status
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("export %s did not reach the status %s", id, expected)
+	return JobStatus{}
+}
+
+func TestExportResults(t *testing.T) {
+	exportServer := Server{
+		Export: func(ctx context.Context, options export.Options) (octoterra.Files, octoterra.Manifest, error) {
+			if options.Arguments.ApiKey != "API-TEST" {
+				t.Errorf("expected the API key from the header, got %s", options.Arguments.ApiKey)
+			}
+
+			if !options.Arguments.ExcludeAllTargets {
+				t.Errorf("expected the arguments from the body, got %v", options.Arguments)
+			}
+
+			options.Progress(octoterra.Progress{Stage: octoterra.ProgressStageComplete, Completed: 1, Total: 1})
+			return octoterra.Files{"space_population/project.tf": "resource"}, octoterra.Manifest{}, nil
+		},
+	}
+	handler := exportServer.Handler()
+
+	started := startExport(t, handler, `{"excludeAllTargets": true, "apiKey": "API-BODY"}`)
+	status := waitForStatus(t, handler, started.Id, JobStatusCompleted)
+
+	if status.Progress.Stage != octoterra.ProgressStageComplete || status.Progress.Completed != 1 {
+		t.Fatalf("unexpected progress %v", status.Progress)
+	}
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, newRequest(http.MethodGet, "/exports/"+started.Id+"/result", "", "API-TEST"))
+
+	files := map[string]string{}
+	if err := json.Unmarshal(response.Body.Bytes(), &files); err != nil {
+		t.Fatal(err)
+	}
+
+	if files["space_population/project.tf"] != "resource" {
+		t.Fatalf("unexpected files %v", files)
+	}
+
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, newRequest(http.MethodGet, "/exports/"+started.Id+"/result?format=zip", "", "API-TEST"))
+
+	archive, err := zip.NewReader(bytes.NewReader(response.Body.Bytes()), int64(response.Body.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(archive.File) != 1 || archive.File[0].Name != "space_population/project.tf" {
+		t.Fatalf("unexpected zip contents %v", archive.File)
+	}
+
+	file, err := archive.File[0].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(contents) != "resource" {
+		t.Fatalf("unexpected zip file contents %s", contents)
+	}
+}
+
+func TestExportRequiresCredentials(t *testing.T) {
+	exportServer := Server{}
+	handler := exportServer.Handler()
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/exports", strings.NewReader("{}")))
+
+	if response.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", response.Code)
+	}
+}
+
+func TestExportIsHiddenFromOtherCredentials(t *testing.T) {
+	exportServer := Server{
+		Export: func(ctx context.Context, options export.Options) (octoterra.Files, octoterra.Manifest, error) {
+			return octoterra.Files{}, octoterra.Manifest{}, nil
+		},
+	}
+	handler := exportServer.Handler()
+
+	started := startExport(t, handler, "")
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, newRequest(http.MethodGet, "/exports/"+started.Id, "", "API-OTHER"))
+
+	if response.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", response.Code)
+	}
+}
+
+func TestExportQueueLimit(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	exportServer := Server{
+		MaxConcurrentExports: 1,
+		MaxQueuedExports:     1,
+		Export: func(ctx context.Context, options export.Options) (octoterra.Files, octoterra.Manifest, error) {
+			<-release
+			return octoterra.Files{}, octoterra.Manifest{}, nil
+		},
+	}
+	handler := exportServer.Handler()
+
+	running := startExport(t, handler, "")
+	waitForStatus(t, handler, running.Id, JobStatusRunning)
+	startExport(t, handler, "")
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, newRequest(http.MethodPost, "/exports", "", "API-TEST"))
+
+	if response.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", response.Code)
+	}
+}
+
+func TestDeleteCancelsExport(t *testing.T) {
+	cancelled := make(chan struct{})
+
+	exportServer := Server{
+		Export: func(ctx context.Context, options export.Options) (octoterra.Files, octoterra.Manifest, error) {
+			<-ctx.Done()
+			close(cancelled)
+			return nil, octoterra.Manifest{}, ctx.Err()
+		},
+	}
+	handler := exportServer.Handler()
+
+	started := startExport(t, handler, "")
+	waitForStatus(t, handler, started.Id, JobStatusRunning)
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, newRequest(http.MethodDelete, "/exports/"+started.Id, "", "API-TEST"))
+
+	if response.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", response.Code)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("the export was not cancelled")
+	}
+
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, newRequest(http.MethodGet, "/exports/"+started.Id, "", "API-TEST"))
+
+	if response.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", response.Code)
+	}
+}
+
+func TestResultsExpire(t *testing.T) {
+	exportServer := Server{
+		ResultExpiry: 200 * time.Millisecond,
+		Export: func(ctx context.Context, options export.Options) (octoterra.Files, octoterra.Manifest, error) {
+			return octoterra.Files{"space_population/project.tf": "resource"}, octoterra.Manifest{}, nil
+		},
+	}
+	handler := exportServer.Handler()
+
+	started := startExport(t, handler, "")
+
+	// The expiry is long enough for the completed result to be retrieved before it is removed
+	waitForStatus(t, handler, started.Id, JobStatusCompleted)
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, newRequest(http.MethodGet, "/exports/"+started.Id+"/result", "", "API-TEST"))
+
+	if response.Code != http.StatusOK {
+		t.Fatalf("expected the result to be retrieved before it expired, got status %d", response.Code)
+	}
+
+	files := map[string]string{}
+	if err := json.Unmarshal(response.Body.Bytes(), &files); err != nil {
+		t.Fatal(err)
+	}
+
+	if files["space_population/project.tf"] != "resource" {
+		t.Fatalf("unexpected files %v", files)
+	}
+
+	for i := 0; i < 100; i++ {
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, newRequest(http.MethodGet, "/exports/"+started.Id, "", "API-TEST"))
+
+		if response.Code == http.StatusNotFound {
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatal("the export result did not expire")
+}
+
+func TestExportIgnoresLocalArguments(t *testing.T) {
+	for _, name := range []string{"recordSnapshot", "replaySnapshot", "configFile", "configPath", "profiling", "secretsFile", "secretsEnvironmentPrefix", "dest", "console", "version", "apiKey", "url"} {
+		t.Run(name, func(t *testing.T) {
+			var value any = "/tmp/" + name
+			if name == "profiling" || name == "console" || name == "version" {
+				value = true
+			}
+
+			body, err := json.Marshal(map[string]any{name: value, "space": "Spaces-2"})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			arguments, err := parseBody(bytes.NewReader(body))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if arguments.Space != "Spaces-2" {
+				t.Fatalf("expected the space to be accepted, got %s", arguments.Space)
+			}
+
+			if arguments.RecordSnapshot != "" || arguments.ReplaySnapshot != "" || arguments.ConfigFile != "" ||
+				arguments.ConfigPath != "" || arguments.Profiling || arguments.SecretsFile != "" ||
+				arguments.SecretsEnvironmentPrefix != "" || arguments.Destination != "" || arguments.Console ||
+				arguments.Version || arguments.ApiKey != "" || arguments.Url != "" {
+				t.Fatalf("expected %s to be ignored", name)
+			}
+		})
+	}
+}
@@ -2,15 +2,20 @@ package steps
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/dummy"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/naming"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/secrets"
 )
 
 type MapSanitizer struct {
 	DummySecretGenerator      dummy.DummySecretGenerator
+	SecretProvider            secrets.SecretProvider
 	DummySecretVariableValues bool
 }
 
@@ -18,7 +23,9 @@ type MapSanitizer struct {
 func (c MapSanitizer) SanitizeMap(parent octopus.NamedResource, action octopus.NamedResource, input map[string]any, dependencies *data.ResourceDetailsCollection) (map[string]string, []terraform.TerraformVariable) {
 	variables := []terraform.TerraformVariable{}
 	fixedMap := map[string]string{}
-	for k, v := range input {
+	// Keys are sorted so the variables are generated in the same order by every export
+	for _, k := range slices.Sorted(maps.Keys(input)) {
+		v := input[k]
 		if _, ok := v.(string); ok {
 			fixedMap[k] = fmt.Sprintf("%v", v)
 		} else {
@@ -28,7 +35,19 @@ func (c MapSanitizer) SanitizeMap(parent octopus.NamedResource, action octopus.N
 
 			var defaultValue *string = nil
 
-			if c.DummySecretVariableValues {
+			secretReference := data.SecretVariableReference{
+				VariableName: variableName,
+				ResourceType: "DeploymentProcesses",
+				ResourceId:   parent.GetId(),
+				ResourceName: parent.GetName(),
+			}
+
+			// Projects and runbooks expose the project that owns the process
+			if owner, ok := parent.(octopus.NameIdParentResource); ok {
+				secretReference.Scope.ProjectId = owner.GetUltimateParent()
+			}
+
+			if !secrets.AddSecretVariable(c.SecretProvider, secretReference, dependencies) && c.DummySecretVariableValues {
 				defaultValue = c.DummySecretGenerator.GetDummySecret()
 				dependencies.AddDummy(data.DummyVariableReference{
 					VariableName: variableName,
@@ -1,11 +1,13 @@
 package steps
 
 import (
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/data"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"testing"
 )
 
 func TestMapSanitizer(t *testing.T) {
+	dependencies := data.ResourceDetailsCollection{}
 	sanitizedMap, _ := MapSanitizer{}.SanitizeMap(octopus.NameId{
 		Id:      "parent",
 		SpaceId: "",
@@ -17,7 +19,7 @@ func TestMapSanitizer(t *testing.T) {
 		map[string]any{
 			"input":  "test",
 			"input2": octopus.Variable{},
-		}, nil)
+		}, &dependencies)
 
 	if sanitizedMap["input"] != "test" {
 		t.Fatal("String should be passed through with no changes")
@@ -26,4 +28,8 @@ func TestMapSanitizer(t *testing.T) {
 	if sanitizedMap["input2"] != "${var.action_4481fe3a58f14368f78761e4acd1bbcaabcb3e35e596829aec0a37c086d52df8_sensitive_value}" {
 		t.Fatal("Object should be replaced with placeholder")
 	}
+
+	if len(dependencies.SecretVariables) != 1 || dependencies.SecretVariables[0].VariableName != "action_4481fe3a58f14368f78761e4acd1bbcaabcb3e35e596829aec0a37c086d52df8_sensitive_value" {
+		t.Fatal("Secret variable should be recorded")
+	}
 }
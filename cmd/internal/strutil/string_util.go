@@ -9,6 +9,12 @@ import (
 	"github.com/samber/lo"
 )
 
+// ScriptsDirectory is the directory that scripts extracted from the HCL are written to
+const ScriptsDirectory = "space_population/scripts/"
+
+// SecretsFileName is the tfvars file holding the values of the sensitive variables
+const SecretsFileName = "space_population/secrets.auto.tfvars"
+
 var regex = regexp.MustCompile(`"\$\$\{([^}]*)}"`)
 var dollarCurlyRegex = regexp.MustCompile(`\$\{`)
 var dollarCurlyEscapedRegex = regexp.MustCompile(`\$\$\{\\"\$\\"}\{`)
@@ -179,13 +185,18 @@ func ParseBoolPointer(input *string) *bool {
 	return &value
 }
 
-// UnEscapeDollarInMap is a naive way of unescaping strings that assumes any string whose entire
-// contents is two dollar signs, an opening curly bracket, some content, and a closing curly bracket
+// UnEscapeDollarInMap is a naive way of unescaping the dollar signs in the HCL files that assumes any string whose
+// entire contents is two dollar signs, an opening curly bracket, some content, and a closing curly bracket
 // was meant to be a HCL interpolated string.
 // Where this assumption doesn't hold, converters must write attributes manually rather than rely on
 // this method. See ProjectConverter for an example where the description field is written out manually.
+// Terraform JSON files are generated from HCL that has already been unescaped, extracted scripts are read by
+// Terraform as they are, and the secrets file holds values escaped by hclwrite, so they are left unchanged.
 func UnEscapeDollarInMap(fileMap map[string]string) map[string]string {
 	for k, v := range fileMap {
+		if strings.HasSuffix(k, ".tf.json") || strings.HasPrefix(k, ScriptsDirectory) || k == SecretsFileName {
+			continue
+		}
 		fileMap[k] = UnEscapeDollar(v)
 	}
 
@@ -0,0 +1,149 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// ProviderSchemas is the output of the "terraform providers schema -json" command.
+type ProviderSchemas struct {
+	FormatVersion   string                    `json:"format_version"`
+	ProviderSchemas map[string]ProviderSchema `json:"provider_schemas"`
+}
+
+// ProviderSchema describes the resources and data sources of a provider.
+type ProviderSchema struct {
+	ResourceSchemas   map[string]Schema `json:"resource_schemas"`
+	DataSourceSchemas map[string]Schema `json:"data_source_schemas"`
+}
+
+// Schema describes a resource or data source.
+type Schema struct {
+	Version int         `json:"version"`
+	Block   SchemaBlock `json:"block"`
+}
+
+// SchemaBlock describes the attributes and nested blocks of a block.
+type SchemaBlock struct {
+	Attributes map[string]SchemaAttribute `json:"attributes,omitempty"`
+	BlockTypes map[string]SchemaBlockType `json:"block_types,omitempty"`
+}
+
+// SchemaAttribute describes an attribute. Attributes have either a type, or a nested type for attributes that hold
+// objects with their own attributes.
+type SchemaAttribute struct {
+	Type       json.RawMessage   `json:"type"`
+	NestedType *SchemaNestedType `json:"nested_type,omitempty"`
+	Required   bool              `json:"required,omitempty"`
+	Optional   bool              `json:"optional,omitempty"`
+	Computed   bool              `json:"computed,omitempty"`
+}
+
+// SchemaNestedType describes the objects held by a nested attribute.
+type SchemaNestedType struct {
+	Attributes  map[string]SchemaAttribute `json:"attributes"`
+	NestingMode string                     `json:"nesting_mode"`
+}
+
+// SchemaBlockType describes a nested block.
+type SchemaBlockType struct {
+	NestingMode string      `json:"nesting_mode"`
+	Block       SchemaBlock `json:"block"`
+	MinItems    int         `json:"min_items,omitempty"`
+	MaxItems    int         `json:"max_items,omitempty"`
+}
+
+// LoadProviderSchemas reads a schema file created with the "terraform providers schema -json" command.
+func LoadProviderSchemas(path string) (ProviderSchemas, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return ProviderSchemas{}, err
+	}
+
+	return parseProviderSchemas(path, content)
+}
+
+func parseProviderSchemas(name string, content []byte) (ProviderSchemas, error) {
+	schemas := ProviderSchemas{}
+
+	if err := json.Unmarshal(content, &schemas); err != nil {
+		return ProviderSchemas{}, errors.Join(errors.New("failed to parse the provider schema "+name), err)
+	}
+
+	if len(schemas.ProviderSchemas) == 0 {
+		return ProviderSchemas{}, errors.New("the provider schema " + name + " does not define any providers")
+	}
+
+	return schemas, nil
+}
+
+// findResource returns the schema of the resource or data source type from any of the providers.
+func (p ProviderSchemas) findResource(blockType string, resourceType string) (Schema, bool) {
+	for _, provider := range p.ProviderSchemas {
+		schemas := provider.ResourceSchemas
+		if blockType == "data" {
+			schemas = provider.DataSourceSchemas
+		}
+
+		if schema, ok := schemas[resourceType]; ok {
+			return schema, true
+		}
+	}
+
+	return Schema{}, false
+}
+
+// hasProvider returns true if the resource type belongs to one of the providers. Resource types are prefixed with
+// the name of their provider, which is the last part of the provider source address.
+func (p ProviderSchemas) hasProvider(resourceType string) bool {
+	for source := range p.ProviderSchemas {
+		if strings.HasPrefix(resourceType, path.Base(source)+"_") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ctyType returns the type of the values that can be assigned to the attribute.
+func (a SchemaAttribute) ctyType() (cty.Type, error) {
+	if a.NestedType == nil {
+		return ctyjson.UnmarshalType(a.Type)
+	}
+
+	attributeTypes := map[string]cty.Type{}
+	optional := []string{}
+	for name, attribute := range a.NestedType.Attributes {
+		attributeType, err := attribute.ctyType()
+
+		if err != nil {
+			return cty.NilType, err
+		}
+
+		attributeTypes[name] = attributeType
+
+		if !attribute.Required {
+			optional = append(optional, name)
+		}
+	}
+
+	objectType := cty.ObjectWithOptionalAttrs(attributeTypes, optional)
+
+	switch a.NestedType.NestingMode {
+	case "list":
+		return cty.List(objectType), nil
+	case "set":
+		return cty.Set(objectType), nil
+	case "map":
+		return cty.Map(objectType), nil
+	default:
+		return objectType, nil
+	}
+}
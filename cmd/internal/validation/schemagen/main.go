@@ -0,0 +1,305 @@
+// Command schemagen writes a provider schema in the format of "terraform providers schema -json", which the
+// validation tests use as a fixture. The schema is derived from the hcl and cty tags of the Terraform model structs,
+// so it describes the attributes octoterra writes rather than every attribute supported by the provider, and it is
+// not a substitute for the schema of the real provider. Regenerate the fixture when the model changes:
+//
+//	go run ./cmd/internal/validation/schemagen > cmd/internal/validation/testdata/generated_schema.json
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/validation"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// providerSource is the source address of the Octopus provider.
+const providerSource = "registry.terraform.io/octopusdeploylabs/octopusdeploy"
+
+// metaArguments are written to the model structs, but are defined by Terraform rather than the provider.
+var metaArguments = []string{"count", "for_each", "depends_on", "provider", "lifecycle"}
+
+// attributeTypes holds the types of attributes whose fields are strings because octoterra assigns them an
+// interpolated expression, rather than a literal value of the type expected by the provider.
+var attributeTypes = map[string]cty.Type{
+	"protected_branches": cty.Set(cty.String),
+}
+
+// resources maps the resource types written by the converters to the structs that hold them.
+var resources = map[string]any{
+	"octopusdeploy_artifactory_generic_feed":                                terraform.TerraformArtifactoryFeed{},
+	"octopusdeploy_aws_account":                                             terraform.TerraformAwsAccount{},
+	"octopusdeploy_aws_elastic_container_registry":                          terraform.TerraformEcrFeed{},
+	"octopusdeploy_aws_openid_connect_account":                              terraform.TerraformAwsOidcAccount{},
+	"octopusdeploy_azure_cloud_service_deployment_target":                   terraform.TerraformAzureCloudServiceDeploymentTarget{},
+	"octopusdeploy_azure_container_registry":                                terraform.TerraformAzureContainerRegistryFeed{},
+	"octopusdeploy_azure_openid_connect":                                    terraform.TerraformAzureOidcSubscription{},
+	"octopusdeploy_azure_service_fabric_cluster_deployment_target":          terraform.TerraformAzureServiceFabricClusterDeploymentTarget{},
+	"octopusdeploy_azure_service_principal":                                 terraform.TerraformAzureServicePrincipal{},
+	"octopusdeploy_azure_subscription_account":                              terraform.TerraformAzureSubscription{},
+	"octopusdeploy_azure_web_app_deployment_target":                         terraform.TerraformAzureWebAppDeploymentTarget{},
+	"octopusdeploy_built_in_trigger":                                        terraform.TerraformBuiltInTrigger{},
+	"octopusdeploy_certificate":                                             terraform.TerraformCertificate{},
+	"octopusdeploy_channel":                                                 terraform.TerraformChannel{},
+	"octopusdeploy_cloud_region_deployment_target":                          terraform.TerraformCloudRegionDeploymentTarget{},
+	"octopusdeploy_community_step_template":                                 terraform.TerraformCommunityStepTemplate{},
+	"octopusdeploy_deployment_freeze":                                       terraform.TerraformDeploymentFreeze{},
+	"octopusdeploy_deployment_freeze_project":                               terraform.TerraformDeploymentFreezeProject{},
+	"octopusdeploy_deployment_freeze_tenant":                                terraform.TerraformDeploymentFreezeTenant{},
+	"octopusdeploy_docker_container_registry":                               terraform.TerraformDockerFeed{},
+	"octopusdeploy_dynamic_worker_pool":                                     terraform.TerraformWorkerPool{},
+	"octopusdeploy_environment":                                             terraform.TerraformEnvironment{},
+	"octopusdeploy_external_feed_create_release_trigger":                    terraform.TerraformProjectFeedTrigger{},
+	"octopusdeploy_gcp_account":                                             terraform.TerraformGcpAccount{},
+	"octopusdeploy_generic_oidc_account":                                    terraform.TerraformGenericOicdAccount{},
+	"octopusdeploy_git_credential":                                          terraform.TerraformGitCredentials{},
+	"octopusdeploy_git_trigger":                                             terraform.TerraformGitTrigger{},
+	"octopusdeploy_github_repository_feed":                                  terraform.TerraformGitHubRepoFeed{},
+	"octopusdeploy_google_container_registry":                               terraform.TerraformGoogleContainerRegistryFeed{},
+	"octopusdeploy_helm_feed":                                               terraform.TerraformHelmFeed{},
+	"octopusdeploy_kubernetes_agent_deployment_target":                      terraform.TerraformKubernetesAgentDeploymentTarget{},
+	"octopusdeploy_kubernetes_agent_worker":                                 terraform.TerraformKubernetesAgentTarget{},
+	"octopusdeploy_kubernetes_cluster_deployment_target":                    terraform.TerraformKubernetesEndpointResource{},
+	"octopusdeploy_library_variable_set":                                    terraform.TerraformLibraryVariableSet{},
+	"octopusdeploy_lifecycle":                                               terraform.TerraformLifecycle{},
+	"octopusdeploy_listening_tentacle_deployment_target":                    terraform.TerraformListeningTentacleDeploymentTarget{},
+	"octopusdeploy_listening_tentacle_worker":                               terraform.TerraformListeningWorker{},
+	"octopusdeploy_machine_policy":                                          terraform.TerraformMachinePolicy{},
+	"octopusdeploy_machine_proxy":                                           terraform.TerraformMachineProxy{},
+	"octopusdeploy_maven_feed":                                              terraform.TerraformMavenFeed{},
+	"octopusdeploy_npm_feed":                                                terraform.TerraformNpmFeed{},
+	"octopusdeploy_nuget_feed":                                              terraform.TerraformNuGetFeed{},
+	"octopusdeploy_oci_registry_feed":                                       terraform.TerraformOciRegistryFeed{},
+	"octopusdeploy_offline_package_drop_deployment_target":                  terraform.TerraformOfflineDropDeploymentTarget{},
+	"octopusdeploy_parent_environment":                                      terraform.TerraformParentEnvironment{},
+	"octopusdeploy_platform_hub_version_control_anonymous_settings":         terraform.TerraformPlatformHubVersionControlAnonymousSettings{},
+	"octopusdeploy_platform_hub_version_control_username_password_settings": terraform.TerraformPlatformHubVersionControlUsernamePasswordSetting{},
+	"octopusdeploy_polling_tentacle_deployment_target":                      terraform.TerraformPollingTentacleDeploymentTarget{},
+	"octopusdeploy_process":                                                 terraform.TerraformProcess{},
+	"octopusdeploy_process_child_step":                                      terraform.TerraformProcessStep{},
+	"octopusdeploy_process_child_steps_order":                               terraform.TerraformProcessChildStepsOrder{},
+	"octopusdeploy_process_step":                                            terraform.TerraformProcessStep{},
+	"octopusdeploy_process_steps_order":                                     terraform.TerraformProcessStepsOrder{},
+	"octopusdeploy_process_templated_child_step":                            terraform.TerraformProcessTemplatedStep{},
+	"octopusdeploy_process_templated_step":                                  terraform.TerraformProcessTemplatedStep{},
+	"octopusdeploy_project":                                                 terraform.TerraformProject{},
+	"octopusdeploy_project_deployment_target_trigger":                       terraform.TerraformProjectTrigger{},
+	"octopusdeploy_project_group":                                           terraform.TerraformProjectGroup{},
+	"octopusdeploy_project_scheduled_trigger":                               terraform.TerraformProjectScheduledTrigger{},
+	"octopusdeploy_project_versioning_strategy":                             terraform.TerraformProjectVersioningStrategy{},
+	"octopusdeploy_runbook":                                                 terraform.TerraformRunbook{},
+	"octopusdeploy_s3_feed":                                                 terraform.TerraformS3Feed{},
+	"octopusdeploy_scoped_user_role":                                        terraform.TerraformScopedUserRole{},
+	"octopusdeploy_script_module":                                           terraform.TerraformScriptModule{},
+	"octopusdeploy_space":                                                   terraform.TerraformSpace{},
+	"octopusdeploy_ssh_connection_deployment_target":                        terraform.TerraformSshConnectionDeploymentTarget{},
+	"octopusdeploy_ssh_connection_worker":                                   terraform.TerraformSshWorker{},
+	"octopusdeploy_ssh_key_account":                                         terraform.TerraformSshAccount{},
+	"octopusdeploy_static_worker_pool":                                      terraform.TerraformWorkerPool{},
+	"octopusdeploy_step_template":                                           terraform.TerraformStepTemplate{},
+	"octopusdeploy_tag":                                                     terraform.TerraformTag{},
+	"octopusdeploy_tag_set":                                                 terraform.TerraformTagSet{},
+	"octopusdeploy_team":                                                    terraform.TerraformTeam{},
+	"octopusdeploy_tenant":                                                  terraform.TerraformTenant{},
+	"octopusdeploy_tenant_common_variable":                                  terraform.TerraformTenantCommonVariable{},
+	"octopusdeploy_tenant_project":                                          terraform.TerraformTenantProjectEnvironment{},
+	"octopusdeploy_tenant_project_variable":                                 terraform.TerraformTenantProjectVariable{},
+	"octopusdeploy_token_account":                                           terraform.TerraformTokenAccount{},
+	"octopusdeploy_username_password_account":                               terraform.TerraformUsernamePasswordAccount{},
+	"octopusdeploy_variable":                                                terraform.TerraformProjectVariable{},
+}
+
+// dataSources maps the data source types written by the converters to the structs that hold them.
+var dataSources = map[string]any{
+	"octopusdeploy_accounts":                terraform.TerraformAccountData{},
+	"octopusdeploy_certificates":            terraform.TerraformCertificateData{},
+	"octopusdeploy_channels":                terraform.TerraformChannelData{},
+	"octopusdeploy_community_step_template": terraform.TerraformCommunityStepTemplateData{},
+	"octopusdeploy_deployment_freezes":      terraform.TerraformDeploymentFreezeData{},
+	"octopusdeploy_deployment_targets":      terraform.TerraformDeploymentTargetsData{},
+	"octopusdeploy_environments":            terraform.TerraformEnvironmentData{},
+	"octopusdeploy_feeds":                   terraform.TerraformFeedData{},
+	"octopusdeploy_git_credentials":         terraform.TerraformGitCredentialData{},
+	"octopusdeploy_library_variable_sets":   terraform.TerraformLibraryVariableSetData{},
+	"octopusdeploy_lifecycles":              terraform.TerraformLifecycleData{},
+	"octopusdeploy_machine_policies":        terraform.TerraformMachinePolicyData{},
+	"octopusdeploy_machine_proxies":         terraform.TerraformProjectGroupData{},
+	"octopusdeploy_parent_environments":     terraform.TerraformParentEnvironmentData{},
+	"octopusdeploy_project_groups":          terraform.TerraformProjectGroupData{},
+	"octopusdeploy_projects":                terraform.TerraformProjectData{},
+	"octopusdeploy_spaces":                  terraform.TerraformSpaceData{},
+	"octopusdeploy_step_template":           terraform.TerraformStepTemplateData{},
+	"octopusdeploy_tag_sets":                terraform.TerraformTagSetData{},
+	"octopusdeploy_teams":                   terraform.TerraformTeamData{},
+	"octopusdeploy_tenants":                 terraform.TerraformTenantData{},
+	"octopusdeploy_user_roles":              terraform.TerraformUserRoleData{},
+	"octopusdeploy_worker_pools":            terraform.TerraformWorkerPoolData{},
+	"octopusdeploy_workers":                 terraform.TerraformWorkersData{},
+}
+
+func main() {
+	content, err := generate()
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	os.Stdout.Write(content)
+}
+
+// generate returns the provider schema in the format of the "terraform providers schema -json" command.
+func generate() ([]byte, error) {
+	provider := validation.ProviderSchema{
+		ResourceSchemas:   map[string]validation.Schema{},
+		DataSourceSchemas: map[string]validation.Schema{},
+	}
+
+	for resourceType, model := range resources {
+		block, err := structBlock(reflect.TypeOf(model), true, true)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to build the schema of %s: %w", resourceType, err)
+		}
+
+		provider.ResourceSchemas[resourceType] = validation.Schema{Block: block}
+	}
+
+	for dataSourceType, model := range dataSources {
+		block, err := structBlock(reflect.TypeOf(model), true, false)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to build the schema of %s: %w", dataSourceType, err)
+		}
+
+		provider.DataSourceSchemas[dataSourceType] = validation.Schema{Block: block}
+	}
+
+	content, err := json.MarshalIndent(validation.ProviderSchemas{
+		FormatVersion:   "1.0",
+		ProviderSchemas: map[string]validation.ProviderSchema{providerSource: provider},
+	}, "", "  ")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(content, '\n'), nil
+}
+
+// structBlock returns the schema of a block from the hcl tags of a struct. The provider schema does not record
+// whether an attribute is required, so the required attributes of resources are assumed to be the string fields that
+// are not pointers. The attributes of data sources are all optional.
+func structBlock(structType reflect.Type, topLevel bool, resource bool) (validation.SchemaBlock, error) {
+	block := validation.SchemaBlock{
+		Attributes: map[string]validation.SchemaAttribute{},
+		BlockTypes: map[string]validation.SchemaBlockType{},
+	}
+
+	for _, field := range reflect.VisibleFields(structType) {
+		name, kind, _ := strings.Cut(field.Tag.Get("hcl"), ",")
+
+		if name == "" || kind == "label" || (topLevel && slices.Contains(metaArguments, name)) {
+			continue
+		}
+
+		if kind == "block" {
+			blockType, err := nestedBlock(field.Type, resource)
+
+			if err != nil {
+				return validation.SchemaBlock{}, err
+			}
+
+			block.BlockTypes[name] = blockType
+			continue
+		}
+
+		fieldType, ok := attributeTypes[name]
+		if !ok {
+			fieldType = ctyType(field.Type)
+		}
+
+		attributeType, err := fieldType.MarshalJSON()
+
+		if err != nil {
+			return validation.SchemaBlock{}, err
+		}
+
+		required := resource && field.Type.Kind() == reflect.String && fieldType == cty.String
+		block.Attributes[name] = validation.SchemaAttribute{
+			Type:     attributeType,
+			Required: required,
+			Optional: !required,
+		}
+	}
+
+	return block, nil
+}
+
+// nestedBlock returns the schema of a nested block. A slice holds any number of blocks, while a pointer or struct
+// holds at most one block. Blocks held by a struct are always written by octoterra, but the provider may not require
+// them, so no block is required.
+func nestedBlock(fieldType reflect.Type, resource bool) (validation.SchemaBlockType, error) {
+	blockType := validation.SchemaBlockType{NestingMode: "list", MaxItems: 1}
+
+	for fieldType.Kind() == reflect.Pointer || fieldType.Kind() == reflect.Slice {
+		if fieldType.Kind() == reflect.Slice {
+			blockType.MaxItems = 0
+		}
+		fieldType = fieldType.Elem()
+	}
+
+	block, err := structBlock(fieldType, false, resource)
+
+	if err != nil {
+		return validation.SchemaBlockType{}, err
+	}
+
+	blockType.Block = block
+	return blockType, nil
+}
+
+// ctyType returns the type of the values that can be assigned to a field. The attributes of objects are read from
+// the cty tags used to encode the struct, and are all optional.
+func ctyType(fieldType reflect.Type) cty.Type {
+	switch fieldType.Kind() {
+	case reflect.Pointer:
+		return ctyType(fieldType.Elem())
+	case reflect.String:
+		return cty.String
+	case reflect.Bool:
+		return cty.Bool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return cty.Number
+	case reflect.Slice, reflect.Array:
+		return cty.List(ctyType(fieldType.Elem()))
+	case reflect.Map:
+		return cty.Map(ctyType(fieldType.Elem()))
+	case reflect.Struct:
+		attributes := map[string]cty.Type{}
+		optional := []string{}
+
+		for _, field := range reflect.VisibleFields(fieldType) {
+			name, _, _ := strings.Cut(field.Tag.Get("cty"), ",")
+
+			if name == "" {
+				continue
+			}
+
+			attributes[name] = ctyType(field.Type)
+			optional = append(optional, name)
+		}
+
+		return cty.ObjectWithOptionalAttrs(attributes, optional)
+	default:
+		return cty.DynamicPseudoType
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGeneratedSchemaIsCurrent fails when the Terraform model changes without regenerating the schema fixture.
+func TestGeneratedSchemaIsCurrent(t *testing.T) {
+	generated, err := generate()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixture, err := os.ReadFile(filepath.Join("..", "testdata", "generated_schema.json"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(generated) != string(fixture) {
+		t.Fatal("the schema fixture is out of date, regenerate it with: go run ./cmd/internal/validation/schemagen > cmd/internal/validation/testdata/generated_schema.json")
+	}
+}
@@ -0,0 +1,289 @@
+package validation
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	hcljson "github.com/hashicorp/hcl2/hcl/json"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// topLevelSchema matches the blocks in a Terraform configuration file. Only resources and data sources are validated.
+var topLevelSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+		{Type: "data", LabelNames: []string{"type", "name"}},
+	},
+}
+
+// metaArguments are the arguments Terraform accepts in every resource and data source.
+var metaArguments = []string{"count", "for_each", "depends_on", "provider"}
+
+// metaBlocks are the blocks Terraform accepts in every resource. Their contents are not validated.
+var metaBlocks = []hcl.BlockHeaderSchema{
+	{Type: "lifecycle"},
+	{Type: "connection"},
+	{Type: "provisioner", LabelNames: []string{"type"}},
+}
+
+// Problem is an error found in a Terraform configuration file.
+type Problem struct {
+	FileName string `json:"fileName"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Message  string `json:"message"`
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", p.FileName, p.Line, p.Column, p.Message)
+}
+
+// Validator checks the resources and data sources in Terraform configuration files against a provider schema. It
+// finds unknown attributes and blocks, missing required attributes, the wrong number of nested blocks, and literal
+// values that can not be converted to the type of their attribute. Expressions that reference variables or other
+// resources can only be evaluated by Terraform, so their types are not checked.
+type Validator struct {
+	Schemas ProviderSchemas
+}
+
+// ValidateDirectory validates the .tf and .tf.json files in the directory and its subdirectories.
+func (v Validator) ValidateDirectory(directory string) ([]Problem, error) {
+	files := map[string]string{}
+
+	err := filepath.WalkDir(directory, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Skip the providers and modules downloaded by terraform init
+		if entry.IsDir() && entry.Name() == ".terraform" {
+			return filepath.SkipDir
+		}
+
+		if entry.IsDir() || !(strings.HasSuffix(path, ".tf") || strings.HasSuffix(path, ".tf.json")) {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+
+		if err != nil {
+			return err
+		}
+
+		name, err := filepath.Rel(directory, path)
+
+		if err != nil {
+			return err
+		}
+
+		files[filepath.ToSlash(name)] = string(content)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return v.ValidateFiles(files), nil
+}
+
+// ValidateFiles validates the .tf and .tf.json files in the map of file names to file contents. Other files are
+// ignored. The problems are sorted by file name and position.
+func (v Validator) ValidateFiles(files map[string]string) []Problem {
+	problems := []Problem{}
+
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		if strings.HasSuffix(name, ".tf") || strings.HasSuffix(name, ".tf.json") {
+			problems = append(problems, v.validateFile(name, files[name])...)
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].FileName != problems[j].FileName {
+			return problems[i].FileName < problems[j].FileName
+		}
+
+		if problems[i].Line != problems[j].Line {
+			return problems[i].Line < problems[j].Line
+		}
+
+		return problems[i].Column < problems[j].Column
+	})
+
+	return problems
+}
+
+func (v Validator) validateFile(name string, content string) []Problem {
+	var file *hcl.File
+	var diags hcl.Diagnostics
+
+	if strings.HasSuffix(name, ".tf.json") {
+		file, diags = hcljson.Parse([]byte(content), name)
+	} else {
+		file, diags = hclsyntax.ParseConfig([]byte(content), name, hcl.Pos{Line: 1, Column: 1})
+	}
+
+	if diags.HasErrors() {
+		return diagnosticsToProblems(diags)
+	}
+
+	body, _, diags := file.Body.PartialContent(topLevelSchema)
+	problems := diagnosticsToProblems(diags)
+
+	for _, block := range body.Blocks {
+		resourceType := block.Labels[0]
+		schema, ok := v.Schemas.findResource(block.Type, resourceType)
+
+		if !ok {
+			if v.Schemas.hasProvider(resourceType) {
+				problems = append(problems, newProblem(block.LabelRanges[0], "Invalid "+block.Type+" type: the provider does not support the "+block.Type+" type \""+resourceType+"\""))
+			}
+			continue
+		}
+
+		problems = append(problems, v.validateBody(block.Body, schema.Block, true)...)
+	}
+
+	return problems
+}
+
+// validateBody validates the attributes and nested blocks of a body. The meta-arguments are only accepted by the
+// body of a resource or data source.
+func (v Validator) validateBody(body hcl.Body, schema SchemaBlock, topLevel bool) []Problem {
+	bodySchema := &hcl.BodySchema{}
+
+	for _, name := range slices.Sorted(maps.Keys(schema.Attributes)) {
+		bodySchema.Attributes = append(bodySchema.Attributes, hcl.AttributeSchema{Name: name, Required: schema.Attributes[name].Required})
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(schema.BlockTypes)) {
+		header := hcl.BlockHeaderSchema{Type: name}
+		if schema.BlockTypes[name].NestingMode == "map" {
+			header.LabelNames = []string{"key"}
+		}
+		bodySchema.Blocks = append(bodySchema.Blocks, header)
+	}
+
+	// Dynamic blocks generate nested blocks, and are accepted in any body
+	bodySchema.Blocks = append(bodySchema.Blocks, hcl.BlockHeaderSchema{Type: "dynamic", LabelNames: []string{"type"}})
+
+	if topLevel {
+		for _, name := range metaArguments {
+			bodySchema.Attributes = append(bodySchema.Attributes, hcl.AttributeSchema{Name: name})
+		}
+		bodySchema.Blocks = append(bodySchema.Blocks, metaBlocks...)
+	}
+
+	content, diags := body.Content(bodySchema)
+	problems := diagnosticsToProblems(diags)
+
+	for _, name := range slices.Sorted(maps.Keys(content.Attributes)) {
+		attribute := content.Attributes[name]
+		attributeSchema, ok := schema.Attributes[name]
+
+		if !ok {
+			continue
+		}
+
+		if attributeSchema.Computed && !attributeSchema.Optional && !attributeSchema.Required {
+			problems = append(problems, newProblem(attribute.NameRange, "Invalid attribute: the attribute \""+name+"\" is computed by the provider and can not be set"))
+			continue
+		}
+
+		problems = append(problems, checkType(attribute, attributeSchema)...)
+	}
+
+	blocks := content.Blocks.ByType()
+	dynamicTypes := map[string]bool{}
+	for _, block := range blocks["dynamic"] {
+		dynamicTypes[block.Labels[0]] = true
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(schema.BlockTypes)) {
+		blockType := schema.BlockTypes[name]
+		nested := blocks[name]
+
+		// The number of blocks generated by a dynamic block is not known until the plan
+		if !dynamicTypes[name] && len(nested) < blockType.MinItems {
+			problems = append(problems, newProblem(body.MissingItemRange(), fmt.Sprintf("Insufficient %s blocks: at least %d required", name, blockType.MinItems)))
+		}
+
+		if blockType.MaxItems > 0 && len(nested) > blockType.MaxItems {
+			problems = append(problems, newProblem(nested[blockType.MaxItems].DefRange, fmt.Sprintf("Too many %s blocks: no more than %d allowed", name, blockType.MaxItems)))
+		}
+
+		for _, block := range nested {
+			problems = append(problems, v.validateBody(block.Body, blockType.Block, false)...)
+		}
+	}
+
+	return problems
+}
+
+// checkType reports a problem if the value of the attribute can not be converted to the type defined in the schema.
+// Only literal values are checked.
+func checkType(attribute *hcl.Attribute, schema SchemaAttribute) []Problem {
+	attributeType, err := schema.ctyType()
+
+	if err != nil || attributeType == cty.DynamicPseudoType {
+		return nil
+	}
+
+	if len(attribute.Expr.Variables()) != 0 {
+		return nil
+	}
+
+	value, diags := attribute.Expr.Value(nil)
+
+	// Expressions like function calls can not be evaluated without Terraform
+	if diags.HasErrors() {
+		return nil
+	}
+
+	if _, err := convert.Convert(value, attributeType); err != nil {
+		return []Problem{newProblem(attribute.Expr.Range(), "Incorrect attribute value type: the attribute \""+attribute.Name+"\" requires "+attributeType.FriendlyName()+": "+err.Error())}
+	}
+
+	return nil
+}
+
+func newProblem(rng hcl.Range, message string) Problem {
+	return Problem{
+		FileName: rng.Filename,
+		Line:     rng.Start.Line,
+		Column:   rng.Start.Column,
+		Message:  message,
+	}
+}
+
+func diagnosticsToProblems(diags hcl.Diagnostics) []Problem {
+	problems := []Problem{}
+
+	for _, diag := range diags {
+		if diag.Severity != hcl.DiagError {
+			continue
+		}
+
+		message := diag.Summary
+		if diag.Detail != "" {
+			message += ": " + diag.Detail
+		}
+
+		if diag.Subject == nil {
+			problems = append(problems, Problem{Message: message})
+		} else {
+			problems = append(problems, newProblem(*diag.Subject, message))
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,250 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSchema = `{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/octopusdeploylabs/octopusdeploy": {
+      "resource_schemas": {
+        "octopusdeploy_environment": {
+          "version": 0,
+          "block": {
+            "attributes": {
+              "id": {"type": "string", "optional": true, "computed": true},
+              "name": {"type": "string", "required": true},
+              "sort_order": {"type": "number", "optional": true},
+              "space_id": {"type": "string", "computed": true}
+            },
+            "block_types": {
+              "jira_extension_settings": {
+                "nesting_mode": "list",
+                "max_items": 1,
+                "block": {
+                  "attributes": {
+                    "environment_type": {"type": "string", "required": true}
+                  }
+                }
+              }
+            }
+          }
+        }
+      },
+      "data_source_schemas": {
+        "octopusdeploy_environments": {
+          "version": 0,
+          "block": {
+            "attributes": {
+              "partial_name": {"type": "string", "optional": true},
+              "skip": {"type": "number", "optional": true}
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+func validate(t *testing.T, content string) []Problem {
+	schemas, err := parseProviderSchemas("schema.json", []byte(testSchema))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return Validator{Schemas: schemas}.ValidateFiles(map[string]string{"environment.tf": content})
+}
+
+func TestValidateFilesValid(t *testing.T) {
+	problems := validate(t, `
+resource "octopusdeploy_environment" "environment_dev" {
+  name       = "Dev"
+  sort_order = "1"
+  count      = var.create ? 1 : 0
+
+  jira_extension_settings {
+    environment_type = "development"
+  }
+
+  lifecycle {
+    ignore_changes = [sort_order]
+  }
+}
+
+data "octopusdeploy_environments" "dev" {
+  partial_name = var.name
+  skip         = 0
+}
+
+resource "random_password" "password" {
+  length = 16
+}`)
+
+	if len(problems) != 0 {
+		t.Fatalf("unexpected problems %v", problems)
+	}
+}
+
+func TestValidateFilesProblems(t *testing.T) {
+	problems := validate(t, `resource "octopusdeploy_environment" "environment_dev" {
+  description = "Dev"
+  sort_order  = "first"
+  space_id    = "Spaces-1"
+
+  jira_extension_settings {
+    environment_type = "development"
+  }
+
+  jira_extension_settings {
+    environment_type = "production"
+  }
+}
+
+data "octopusdeploy_environments" "dev" {
+  skip = [1]
+}
+
+resource "octopusdeploy_unknown" "unknown" {
+}`)
+
+	expected := []string{
+		"environment.tf:1:56: Missing required argument",
+		"environment.tf:2:3: Unsupported argument",
+		"environment.tf:3:17: Incorrect attribute value type",
+		"environment.tf:4:3: Invalid attribute",
+		"environment.tf:10:3: Too many jira_extension_settings blocks",
+		"environment.tf:16:10: Incorrect attribute value type",
+		"environment.tf:19:10: Invalid resource type",
+	}
+
+	if len(problems) != len(expected) {
+		t.Fatalf("unexpected problems %v", problems)
+	}
+
+	for index, prefix := range expected {
+		if !strings.HasPrefix(problems[index].String(), prefix) {
+			t.Fatalf("expected %q to start with %q", problems[index].String(), prefix)
+		}
+	}
+}
+
+func TestValidateFilesJson(t *testing.T) {
+	schemas, err := parseProviderSchemas("schema.json", []byte(testSchema))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	problems := Validator{Schemas: schemas}.ValidateFiles(map[string]string{
+		"environment.tf.json": `{"resource": {"octopusdeploy_environment": {"environment_dev": {"name": "Dev", "color": "red"}}}}`,
+		"README.md":           "not terraform",
+	})
+
+	if len(problems) != 1 || problems[0].FileName != "environment.tf.json" || !strings.HasPrefix(problems[0].Message, "Extraneous JSON object property") {
+		t.Fatalf("unexpected problems %v", problems)
+	}
+}
+
+func TestValidateDirectory(t *testing.T) {
+	directory := t.TempDir()
+	path := filepath.Join(directory, "space_population", "environment.tf")
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte("resource \"octopusdeploy_environment\" \"environment_dev\" {\n  name = \"Dev\"\n  color = \"red\"\n}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	schemas, err := parseProviderSchemas("schema.json", []byte(testSchema))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := Validator{Schemas: schemas}.ValidateDirectory(directory)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(problems) != 1 || problems[0].String() != "space_population/environment.tf:3:3: Unsupported argument: An argument named \"color\" is not expected here." {
+		t.Fatalf("unexpected problems %v", problems)
+	}
+}
+
+// TestValidateWithGeneratedSchema validates a module with the schema generated by schemagen, which describes the
+// attributes octoterra writes.
+func TestValidateWithGeneratedSchema(t *testing.T) {
+	schemas, err := LoadProviderSchemas("testdata/generated_schema.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	module := `data "octopusdeploy_lifecycles" "lifecycle_default_lifecycle" {
+  ids          = null
+  partial_name = "Default Lifecycle"
+  skip         = 0
+  take         = 1
+}
+
+resource "octopusdeploy_environment" "environment_development" {
+  name                         = "Development"
+  description                  = ""
+  allow_dynamic_infrastructure = true
+  use_guided_failure           = false
+  sort_order                   = 0
+
+  jira_extension_settings {
+    environment_type = "development"
+  }
+}
+
+resource "octopusdeploy_project_group" "project_group_web" {
+  name        = "Web"
+  description = "Web projects"
+}
+
+resource "octopusdeploy_project" "project_web" {
+  name                                 = "Web"
+  auto_create_release                  = false
+  default_guided_failure_mode          = "EnvironmentDefault"
+  default_to_skip_if_already_installed = false
+  is_disabled                          = false
+  is_version_controlled                = false
+  lifecycle_id                         = data.octopusdeploy_lifecycles.lifecycle_default_lifecycle.lifecycles[0].id
+  project_group_id                     = octopusdeploy_project_group.project_group_web.id
+  included_library_variable_sets       = []
+  tenanted_deployment_participation    = "Untenanted"
+
+  connectivity_policy {
+    allow_deployments_to_no_targets = true
+    exclude_unhealthy_targets       = false
+    skip_machine_behavior           = "None"
+  }
+}
+`
+
+	problems := Validator{Schemas: schemas}.ValidateFiles(map[string]string{"space_population/main.tf": module})
+
+	if len(problems) != 0 {
+		t.Fatalf("unexpected problems %v", problems)
+	}
+
+	// The generated schema must also find problems
+	problems = Validator{Schemas: schemas}.ValidateFiles(map[string]string{"space_population/main.tf": `resource "octopusdeploy_project_group" "project_group_web" {
+  description = "Web projects"
+  colour      = "red"
+}`})
+
+	if len(problems) != 2 || !strings.HasPrefix(problems[0].Message, "Missing required argument") || !strings.HasPrefix(problems[1].Message, "Unsupported argument") {
+		t.Fatalf("unexpected problems %v", problems)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/terraform"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/naming"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/secrets"
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclwrite"
 )
@@ -16,9 +17,10 @@ type DefaultTerraformVariableWriter struct {
 	DummySecretVariableValues   bool
 	DefaultSecretVariableValues bool
 	DummySecretGenerator        dummy.DummySecretGenerator
+	SecretProvider              secrets.SecretProvider
 }
 
-func (c *DefaultTerraformVariableWriter) WriteTerraformVariablesForSecret(resourceType string, file *hclwrite.File, variable octopus.NamedResource, dependencies *data.ResourceDetailsCollection) *string {
+func (c *DefaultTerraformVariableWriter) WriteTerraformVariablesForSecret(resourceType string, file *hclwrite.File, variable octopus.NamedResource, scope data.SecretScope, dependencies *data.ResourceDetailsCollection) *string {
 	// We don't know the value of secrets, so the value is just nil
 	if c.ExcludeTerraformVariables {
 		return nil
@@ -30,7 +32,15 @@ func (c *DefaultTerraformVariableWriter) WriteTerraformVariablesForSecret(resour
 
 	// Dummy values are used if we are not also replacing the variable with an octostache template
 	// with the DefaultSecretVariableValues option.
-	if c.DummySecretVariableValues && !c.DefaultSecretVariableValues {
+	secretReference := data.SecretVariableReference{
+		VariableName: variableName,
+		ResourceType: resourceType,
+		ResourceId:   variable.GetId(),
+		ResourceName: variable.GetName(),
+		Scope:        scope,
+	}
+
+	if !secrets.AddSecretVariable(c.SecretProvider, secretReference, dependencies) && c.DummySecretVariableValues && !c.DefaultSecretVariableValues {
 		defaultValue = c.DummySecretGenerator.GetDummySecret()
 		dependencies.AddDummy(data.DummyVariableReference{
 			VariableName: variableName,
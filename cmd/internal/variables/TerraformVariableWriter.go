@@ -8,5 +8,8 @@ import (
 
 // TerraformVariableWriter provides functions to create Terraform variables for sensitive values in Octopus.
 type TerraformVariableWriter interface {
-	WriteTerraformVariablesForSecret(resourceType string, file *hclwrite.File, variable octopus.NamedResource, dependencies *data.ResourceDetailsCollection) *string
+	// WriteTerraformVariablesForSecret writes a sensitive Terraform variable for the variable, and returns the
+	// expression that references it. The scope describes where the secret applies, and is recorded in the
+	// secrets inventory.
+	WriteTerraformVariablesForSecret(resourceType string, file *hclwrite.File, variable octopus.NamedResource, scope data.SecretScope, dependencies *data.ResourceDetailsCollection) *string
 }
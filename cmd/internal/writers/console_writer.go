@@ -1,13 +1,18 @@
 package writers
 
-import "fmt"
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
 
 type ConsoleWriter struct {
 }
 
+// Write prints the files ordered by file name.
 func (c ConsoleWriter) Write(files map[string]string) (string, error) {
-	for _, v := range files {
-		fmt.Println(v)
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		fmt.Println(files[name])
 	}
 
 	return "", nil
@@ -1,10 +1,13 @@
 package writers
 
 import (
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
-	"github.com/google/uuid"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+	"github.com/google/uuid"
 )
 
 type FileWriter struct {
@@ -28,8 +31,8 @@ func NewFileWriter(dest string) *FileWriter {
 }
 
 func (c FileWriter) Write(files map[string]string) (string, error) {
-	for k, v := range files {
-		if err := c.write(k, v); err != nil {
+	for _, k := range slices.Sorted(maps.Keys(files)) {
+		if err := c.write(k, files[k]); err != nil {
 			return "", err
 		}
 	}
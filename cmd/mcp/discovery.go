@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/entry"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/export"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/pkg/octoterra"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type SpaceInput struct {
+	Space string `json:"space" jsonschema:"the name or ID of the space"`
+}
+
+type ProjectInput struct {
+	Space       string `json:"space" jsonschema:"the name or ID of the space containing the project"`
+	ProjectId   string `json:"projectId,omitempty" jsonschema:"the ID of the project"`
+	ProjectName string `json:"projectName,omitempty" jsonschema:"the name of the project"`
+}
+
+type NamedResource struct {
+	Id   string `json:"id" jsonschema:"the ID of the resource"`
+	Name string `json:"name" jsonschema:"the name of the resource"`
+}
+
+type SpacesOutput struct {
+	Spaces []NamedResource `json:"spaces" jsonschema:"the spaces"`
+}
+
+type ProjectsOutput struct {
+	Projects []NamedResource `json:"projects" jsonschema:"the projects in the space"`
+}
+
+type RunbooksOutput struct {
+	Runbooks []NamedResource `json:"runbooks" jsonschema:"the runbooks in the project"`
+}
+
+type Dependency struct {
+	Id           string   `json:"id" jsonschema:"the ID of the resource"`
+	Name         string   `json:"name,omitempty" jsonschema:"the name of the resource"`
+	ResourceType string   `json:"resourceType" jsonschema:"the Octopus API resource type, like Environments or Feeds"`
+	IncludedBy   []string `json:"includedBy,omitempty" jsonschema:"the references that made the project depend on the resource"`
+}
+
+type DependenciesOutput struct {
+	Dependencies []Dependency `json:"dependencies" jsonschema:"the space level resources the project depends on"`
+}
+
+// newClient returns a client for the Octopus server defined by the server environment variables.
+func newClient(ctx context.Context, space string) *client.OctopusApiClient {
+	octopusClient := entry.NewOctopusClient(args.Arguments{
+		Url:    os.Getenv("OCTOPUS_CLI_SERVER"),
+		ApiKey: os.Getenv("OCTOPUS_CLI_API_KEY"),
+		Space:  space,
+	}, "")
+	octopusClient.Context = ctx
+	return octopusClient
+}
+
+func listSpaces(ctx context.Context, req *mcp.CallToolRequest, input struct{}) (*mcp.CallToolResult, SpacesOutput, error) {
+	spaces, err := newClient(ctx, "").GetSpaces()
+
+	if err != nil {
+		return nil, SpacesOutput{}, err
+	}
+
+	output := SpacesOutput{Spaces: []NamedResource{}}
+	for _, space := range spaces {
+		output.Spaces = append(output.Spaces, NamedResource{Id: space.Id, Name: space.Name})
+	}
+
+	return nil, output, nil
+}
+
+func listProjects(ctx context.Context, req *mcp.CallToolRequest, input SpaceInput) (*mcp.CallToolResult, ProjectsOutput, error) {
+	projects, err := getAll[octopus.Project](newClient(ctx, input.Space), "Projects")
+
+	if err != nil {
+		return nil, ProjectsOutput{}, err
+	}
+
+	output := ProjectsOutput{Projects: []NamedResource{}}
+	for _, project := range projects {
+		output.Projects = append(output.Projects, NamedResource{Id: project.Id, Name: project.Name})
+	}
+
+	return nil, output, nil
+}
+
+func listRunbooks(ctx context.Context, req *mcp.CallToolRequest, input ProjectInput) (*mcp.CallToolResult, RunbooksOutput, error) {
+	octopusClient := newClient(ctx, input.Space)
+	projectId, err := getProjectId(octopusClient, input)
+
+	if err != nil {
+		return nil, RunbooksOutput{}, err
+	}
+
+	runbooks, err := getAll[octopus.Runbook](octopusClient, "Projects/"+projectId+"/Runbooks")
+
+	if err != nil {
+		return nil, RunbooksOutput{}, err
+	}
+
+	output := RunbooksOutput{Runbooks: []NamedResource{}}
+	for _, runbook := range runbooks {
+		output.Runbooks = append(output.Runbooks, NamedResource{Id: runbook.Id, Name: runbook.Name})
+	}
+
+	return nil, output, nil
+}
+
+// listProjectDependencies exports the project with its dependencies looked up by data sources, and returns the
+// looked up resources from the export manifest.
+func listProjectDependencies(ctx context.Context, req *mcp.CallToolRequest, input ProjectInput) (*mcp.CallToolResult, DependenciesOutput, error) {
+	projectId, err := getProjectId(newClient(ctx, input.Space), input)
+
+	if err != nil {
+		return nil, DependenciesOutput{}, err
+	}
+
+	arguments := args.Arguments{
+		Space:                     input.Space,
+		ProjectId:                 args.StringSliceArgs{projectId},
+		LookupProjectDependencies: true,
+	}
+	prepareArguments(&arguments)
+
+	_, manifest, err := export.Export(ctx, export.Options{
+		Arguments: arguments,
+		Progress:  progressNotifier(ctx, req),
+	})
+
+	if err != nil {
+		return nil, DependenciesOutput{}, err
+	}
+
+	output := DependenciesOutput{Dependencies: []Dependency{}}
+	for _, resource := range manifest.Resources {
+		if resource.Kind != octoterra.ManifestKindData || resource.Id == "" || resource.Id == projectId {
+			continue
+		}
+
+		dependency := Dependency{
+			Id:           resource.Id,
+			Name:         resource.Name,
+			ResourceType: resource.ResourceType,
+		}
+
+		for _, inclusion := range resource.IncludedBy {
+			dependency.IncludedBy = append(dependency.IncludedBy, inclusion.Field+" of "+inclusion.ParentId)
+		}
+
+		output.Dependencies = append(output.Dependencies, dependency)
+	}
+
+	return nil, output, nil
+}
+
+// getProjectId returns the ID of the project identified by its ID or name.
+func getProjectId(octopusClient client.OctopusClient, input ProjectInput) (string, error) {
+	if input.ProjectId != "" {
+		return input.ProjectId, nil
+	}
+
+	if input.ProjectName == "" {
+		return "", errors.New("the projectId or projectName must be defined")
+	}
+
+	project := octopus.Project{}
+	exists, err := octopusClient.GetResourceByName("Projects", input.ProjectName, &project)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !exists {
+		return "", errors.New("the project \"" + input.ProjectName + "\" was not found")
+	}
+
+	return project.Id, nil
+}
+
+// getAll returns every resource of the type, loaded in batches.
+func getAll[T any](octopusClient client.OctopusClient, resourceType string) ([]T, error) {
+	batchClient := client.BatchingOctopusApiClient[T]{
+		Client: octopusClient,
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	resources := []T{}
+	for resourceWrapper := range batchClient.GetAllResourcesBatch(done, resourceType) {
+		if resourceWrapper.Err != nil {
+			return nil, resourceWrapper.Err
+		}
+		resources = append(resources, resourceWrapper.Res)
+	}
+
+	return resources, nil
+}
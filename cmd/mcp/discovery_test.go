@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newOctopusServer returns a server that responds to the API requests made by the discovery tools. The space
+// Spaces-1 contains the project Projects-1, which contains the runbook Runbooks-1.
+func newOctopusServer(t *testing.T) *httptest.Server {
+	responses := map[string]any{
+		"/api/Spaces":                                map[string]any{"Items": []map[string]any{{"Id": "Spaces-1", "Name": "Default"}}},
+		"/api/Spaces-1/Projects":                     map[string]any{"Items": []map[string]any{{"Id": "Projects-1", "Name": "Web App"}}},
+		"/api/Spaces-1/Projects/Projects-1":          map[string]any{"Id": "Projects-1", "Name": "Web App"},
+		"/api/Spaces-1/Projects/Projects-1/Runbooks": map[string]any{"Items": []map[string]any{{"Id": "Runbooks-1", "Name": "Backup"}}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Octopus-ApiKey") != "API-TEST" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		response, ok := responses[r.URL.Path]
+
+		// Any page after the first is empty
+		if !ok || strings.Contains(r.URL.RawQuery, "skip=30") {
+			response = map[string]any{"Items": []any{}}
+		}
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Error(err)
+		}
+	}))
+
+	t.Cleanup(server.Close)
+	t.Setenv("OCTOPUS_CLI_SERVER", server.URL)
+	t.Setenv("OCTOPUS_CLI_API_KEY", "API-TEST")
+
+	return server
+}
+
+func TestListSpaces(t *testing.T) {
+	newOctopusServer(t)
+
+	_, output, err := listSpaces(t.Context(), nil, struct{}{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Spaces) != 1 || output.Spaces[0].Id != "Spaces-1" || output.Spaces[0].Name != "Default" {
+		t.Fatalf("unexpected spaces %v", output.Spaces)
+	}
+}
+
+func TestListProjects(t *testing.T) {
+	newOctopusServer(t)
+
+	_, output, err := listProjects(t.Context(), nil, SpaceInput{Space: "Default"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Projects) != 1 || output.Projects[0].Id != "Projects-1" || output.Projects[0].Name != "Web App" {
+		t.Fatalf("unexpected projects %v", output.Projects)
+	}
+}
+
+func TestListRunbooksByProjectName(t *testing.T) {
+	newOctopusServer(t)
+
+	_, output, err := listRunbooks(t.Context(), nil, ProjectInput{Space: "Spaces-1", ProjectName: "Web App"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Runbooks) != 1 || output.Runbooks[0].Id != "Runbooks-1" || output.Runbooks[0].Name != "Backup" {
+		t.Fatalf("unexpected runbooks %v", output.Runbooks)
+	}
+}
+
+func TestListRunbooksRequiresProject(t *testing.T) {
+	newOctopusServer(t)
+
+	if _, _, err := listRunbooks(t.Context(), nil, ProjectInput{Space: "Spaces-1"}); err == nil {
+		t.Fatalf("listing runbooks without a project must fail")
+	}
+
+	if _, _, err := listRunbooks(t.Context(), nil, ProjectInput{Space: "Spaces-1", ProjectName: "Missing"}); err == nil {
+		t.Fatalf("listing the runbooks of a missing project must fail")
+	}
+}
@@ -2,19 +2,34 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
+	"maps"
 	"os"
 	"reflect"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/entry"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/output"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/export"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/pkg/octoterra"
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-type Output struct {
-	Terraform string `json:"terraform" jsonschema:"the generated Terraform configuration"`
+// insecureTls is set with the -insecureTls argument when the server is started. It applies to every request made
+// to the Octopus server, so it is not exposed as a tool input.
+var insecureTls bool
+
+// RunbookInput defines the runbook exported by the exportRunbook tool.
+type RunbookInput struct {
+	Space                     string `json:"space" jsonschema:"the name or ID of the space containing the runbook"`
+	ProjectId                 string `json:"projectId,omitempty" jsonschema:"the ID of the project containing the runbook"`
+	ProjectName               string `json:"projectName,omitempty" jsonschema:"the name of the project containing the runbook"`
+	RunbookId                 string `json:"runbookId,omitempty" jsonschema:"the ID of the runbook to export"`
+	RunbookName               string `json:"runbookName,omitempty" jsonschema:"the name of the runbook to export"`
+	LookupProjectDependencies bool   `json:"lookupProjectDependencies,omitempty" jsonschema:"reference the space level resources used by the runbook with data sources rather than exporting them"`
 }
 
 // buildInputSchema generates a JSON schema for args.Arguments with all fields
@@ -42,6 +57,13 @@ func buildInputSchema() (*jsonschema.Schema, error) {
 }
 
 func main() {
+	flag.BoolVar(&insecureTls, "insecureTls", false, "Ignore certificate errors when connecting to the Octopus server.")
+	flag.Parse()
+
+	if err := (&args.Arguments{InsecureTls: insecureTls}).ConfigureGlobalSettings(); err != nil {
+		log.Fatal(err)
+	}
+
 	server := mcp.NewServer(&mcp.Implementation{Name: "Octoterra", Version: "v1.0.0"}, nil)
 	schema, err := buildInputSchema()
 
@@ -52,10 +74,35 @@ func main() {
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "convertOctopusToTerraform",
-		Description: "Convert Octopus space or project to Terraform configuration",
+		Description: "Convert Octopus space or project to Terraform configuration. Each generated file is returned as a separate resource.",
 		InputSchema: schema,
 	}, convert)
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "exportRunbook",
+		Description: "Convert a single Octopus runbook to Terraform configuration. Each generated file is returned as a separate resource.",
+	}, exportRunbook)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "listSpaces",
+		Description: "List the Octopus spaces with their IDs",
+	}, listSpaces)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "listProjects",
+		Description: "List the projects in an Octopus space with their IDs",
+	}, listProjects)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "listRunbooks",
+		Description: "List the runbooks in an Octopus project with their IDs",
+	}, listRunbooks)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "listProjectDependencies",
+		Description: "List the space level resources, like environments, feeds, accounts and library variable sets, that an Octopus project depends on",
+	}, listProjectDependencies)
+
 	if err := server.Run(context.Background(), &mcp.StdioTransport{}); err != nil {
 		log.Fatal(err)
 	}
@@ -63,46 +110,172 @@ func main() {
 
 func convert(ctx context.Context, req *mcp.CallToolRequest, input args.Arguments) (
 	*mcp.CallToolResult,
-	Output,
+	any,
 	error,
 ) {
+	prepareArguments(&input)
+
+	// Ignore things that look like empty arrays
+	if input.RunbookName == "[]" {
+		input.RunbookName = ""
+	}
+
+	if input.RunbookId == "[]" {
+		input.RunbookId = ""
+	}
+
+	return exportFiles(ctx, req, input)
+}
+
+func exportRunbook(ctx context.Context, req *mcp.CallToolRequest, input RunbookInput) (
+	*mcp.CallToolResult,
+	any,
+	error,
+) {
+	arguments := args.Arguments{
+		Space:                     input.Space,
+		RunbookId:                 input.RunbookId,
+		RunbookName:               input.RunbookName,
+		LookupProjectDependencies: input.LookupProjectDependencies,
+	}
+
+	if input.ProjectId != "" {
+		arguments.ProjectId = args.StringSliceArgs{input.ProjectId}
+	}
+
+	if input.ProjectName != "" {
+		arguments.ProjectName = args.StringSliceArgs{input.ProjectName}
+	}
+
+	prepareArguments(&arguments)
+
+	return exportFiles(ctx, req, arguments)
+}
+
+// prepareArguments applies the settings defined by the server, overriding anything supplied by the tool input.
+func prepareArguments(input *args.Arguments) {
 	// These arguments don't make sense or can have default values
 	input.ApiKey = os.Getenv("OCTOPUS_CLI_API_KEY")
 	input.AccessToken = ""
 	input.Url = os.Getenv("OCTOPUS_CLI_SERVER")
 	input.UseRedirector = false
+	// Tool inputs must not be able to read or write files or environment variables on the local machine
+	input.ClearLocalArguments()
 	input.Console = true
-	input.ConfigFile = ""
-	input.ConfigPath = ""
-	input.Version = false
-	input.Profiling = false
 	input.ExcludeSpaceCreation = true
-	input.InsecureTls = true
+	input.InsecureTls = insecureTls
+}
 
-	// Ignore things that look like empty arrays
-	if input.RunbookName == "[]" {
-		input.RunbookName = ""
-	}
+// exportFiles runs the export, returning each file as a separate embedded resource.
+func exportFiles(ctx context.Context, req *mcp.CallToolRequest, input args.Arguments) (*mcp.CallToolResult, any, error) {
+	files, _, err := export.Export(ctx, export.Options{
+		Arguments: input,
+		Progress:  progressNotifier(ctx, req),
+	})
 
-	if input.RunbookId == "[]" {
-		input.RunbookId = ""
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if input.Destination == "" {
-		dir, err := os.MkdirTemp("", "octoterra*")
-		if err != nil {
-			return nil, Output{}, err
+	return &mcp.CallToolResult{Content: filesToContent(files)}, nil, nil
+}
+
+// filesToContent returns a content item for each file, ordered by file name.
+func filesToContent(files octoterra.Files) []mcp.Content {
+	content := []mcp.Content{}
+
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		mimeType := "text/plain"
+		if strings.HasSuffix(name, ".json") {
+			mimeType = "application/json"
 		}
-		input.Destination = dir
+
+		content = append(content, &mcp.EmbeddedResource{
+			Resource: &mcp.ResourceContents{
+				URI:      "octoterra:///" + name,
+				MIMEType: mimeType,
+				Text:     files[name],
+			},
+		})
 	}
 
-	files, err := entry.Entry(input, "")
+	return content
+}
+
+// progressStages orders the stages of an export, so progress from an earlier stage that arrives late is dropped.
+var progressStages = []string{octoterra.ProgressStageLoading, octoterra.ProgressStageGenerating, octoterra.ProgressStageComplete}
 
-	if err != nil {
-		return nil, Output{}, err
+// progressMessages describes each stage of an export to the client.
+var progressMessages = map[string]string{
+	octoterra.ProgressStageLoading:    "Loading resources from Octopus",
+	octoterra.ProgressStageGenerating: "Generating Terraform files",
+	octoterra.ProgressStageComplete:   "Export complete",
+}
+
+// progressNotifier returns a function that sends the progress of an export to the client. Notifications are only
+// sent if the client supplied a progress token. Nil is returned if the client did not ask for progress notifications.
+func progressNotifier(ctx context.Context, req *mcp.CallToolRequest) func(octoterra.Progress) {
+	if req == nil || req.Session == nil || req.Params == nil {
+		return nil
 	}
 
-	result := output.WriteString(files)
+	token := req.Params.GetProgressToken()
 
-	return nil, Output{Terraform: result}, nil
+	if token == nil {
+		return nil
+	}
+
+	return progressFilter(func(stage string, progress int, total int) {
+		if err := req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Message:       progressMessages[stage],
+			Progress:      float64(progress),
+			Total:         float64(total),
+		}); err != nil {
+			log.Println(err.Error())
+		}
+	})
+}
+
+// progressFilter returns a function that passes the progress of an export to notify. MCP requires the progress to
+// increase with every notification, but each stage of an export counts its progress from zero. So the progress of
+// each stage is offset to start after the last progress sent by the previous stage, and the total is offset to
+// match, or is zero when the stage does not know its total. Files are generated concurrently, so progress may be
+// reported out of order, and any progress that does not move the export forward is dropped.
+func progressFilter(notify func(stage string, progress int, total int)) func(octoterra.Progress) {
+	var mu sync.Mutex
+	stage := -1
+	offset := 0
+	last := -1
+
+	return func(progress octoterra.Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		index := slices.Index(progressStages, progress.Stage)
+
+		if index < stage {
+			return
+		}
+
+		if index > stage {
+			stage = index
+			offset = last + 1
+		}
+
+		value := offset + progress.Completed
+
+		if value <= last {
+			return
+		}
+
+		last = value
+
+		total := 0
+		if progress.Total >= progress.Completed && progress.Total > 0 {
+			total = offset + progress.Total
+		}
+
+		notify(progress.Stage, value, total)
+	}
 }
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/pkg/octoterra"
+)
+
+func TestPrepareArgumentsClearsLocalArguments(t *testing.T) {
+	t.Setenv("OCTOPUS_CLI_SERVER", "https://example.octopus.app")
+	t.Setenv("OCTOPUS_CLI_API_KEY", "API-SERVER")
+
+	arguments := args.Arguments{
+		Url:                      "https://attacker.example.org",
+		ApiKey:                   "API-INPUT",
+		AccessToken:              "token",
+		RecordSnapshot:           "/tmp/record.zip",
+		ReplaySnapshot:           "/tmp/replay.zip",
+		ConfigFile:               "octoterra",
+		ConfigPath:               "/etc",
+		SecretsFile:              "/etc/passwd",
+		SecretsEnvironmentPrefix: "AWS_",
+		Destination:              "/tmp",
+		Profiling:                true,
+		Space:                    "Spaces-1",
+	}
+
+	prepareArguments(&arguments)
+
+	if arguments.Url != "https://example.octopus.app" || arguments.ApiKey != "API-SERVER" || arguments.AccessToken != "" {
+		t.Fatalf("the server credentials must be used")
+	}
+
+	if arguments.RecordSnapshot != "" || arguments.ReplaySnapshot != "" || arguments.ConfigFile != "" ||
+		arguments.ConfigPath != "" || arguments.SecretsFile != "" || arguments.SecretsEnvironmentPrefix != "" ||
+		arguments.Destination != "" || arguments.Profiling {
+		t.Fatalf("the local arguments must be cleared")
+	}
+
+	if !arguments.Console || !arguments.ExcludeSpaceCreation || arguments.Space != "Spaces-1" {
+		t.Fatalf("the export arguments must be kept")
+	}
+}
+
+func TestProgressNotifierWithoutToken(t *testing.T) {
+	if progressNotifier(t.Context(), nil) != nil {
+		t.Fatalf("no notifier must be returned when the client did not ask for progress")
+	}
+}
+
+func TestProgressFilter(t *testing.T) {
+	notified := []octoterra.Progress{}
+	filter := progressFilter(func(stage string, progress int, total int) {
+		notified = append(notified, octoterra.Progress{Stage: stage, Completed: progress, Total: total})
+	})
+
+	for _, progress := range []octoterra.Progress{
+		{Stage: octoterra.ProgressStageLoading, Completed: 0, Total: 0},
+		{Stage: octoterra.ProgressStageGenerating, Completed: 0, Total: 3},
+		{Stage: octoterra.ProgressStageGenerating, Completed: 2, Total: 3},
+		// Reported out of order by a concurrent goroutine
+		{Stage: octoterra.ProgressStageGenerating, Completed: 1, Total: 3},
+		{Stage: octoterra.ProgressStageGenerating, Completed: 3, Total: 3},
+		// Reported late by the loading stage
+		{Stage: octoterra.ProgressStageLoading, Completed: 5, Total: 5},
+		{Stage: octoterra.ProgressStageComplete, Completed: 2, Total: 2},
+	} {
+		filter(progress)
+	}
+
+	// Each stage continues from the progress sent by the previous stage, so the progress always increases
+	expected := []octoterra.Progress{
+		{Stage: octoterra.ProgressStageLoading, Completed: 0, Total: 0},
+		{Stage: octoterra.ProgressStageGenerating, Completed: 1, Total: 4},
+		{Stage: octoterra.ProgressStageGenerating, Completed: 3, Total: 4},
+		{Stage: octoterra.ProgressStageGenerating, Completed: 4, Total: 4},
+		{Stage: octoterra.ProgressStageComplete, Completed: 7, Total: 7},
+	}
+
+	if len(notified) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, notified)
+	}
+
+	for i := range expected {
+		if notified[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, notified)
+		}
+	}
+}
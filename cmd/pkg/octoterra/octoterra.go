@@ -0,0 +1,183 @@
+// Package octoterra exports Octopus spaces, projects and runbooks to Terraform configuration.
+//
+// It runs the same export as the octoterra CLI, so other Go applications can import it to run an export without
+// shelling out to the octoterra binary:
+//
+//	files, manifest, err := octoterra.Export(ctx, octoterra.Options{
+//		Url:          "https://myinstance.octopus.app",
+//		ApiKey:       apiKey,
+//		Space:        "Spaces-1",
+//		ProjectNames: []string{"My Project"},
+//		Arguments:    []string{"-excludeAllTargets"},
+//	})
+package octoterra
+
+import (
+	"context"
+	"errors"
+
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/args"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/client"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/entry"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/export"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/generators"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/model/octopus"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/strutil"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformExport/cmd/internal/writers"
+	"go.uber.org/zap"
+)
+
+// OctopusClient queries the Octopus API. Supply an implementation in Options.Client to control how the API is
+// accessed, for example to share a HTTP client or to serve responses from a cache.
+type OctopusClient = client.OctopusClient
+
+// Space is an Octopus space returned by an OctopusClient.
+type Space = octopus.Space
+
+// Manifest maps the Octopus resources included in an export to the Terraform that creates or looks up each resource.
+type Manifest = generators.ExportManifest
+
+// ManifestEntry describes a single resource in a Manifest.
+type ManifestEntry = generators.ExportManifestEntry
+
+const (
+	// ManifestKindResource is a resource created by the exported module
+	ManifestKindResource = generators.ManifestKindResource
+	// ManifestKindData is an existing resource looked up by a data source
+	ManifestKindData = generators.ManifestKindData
+	// ManifestKindStateless is a resource that is created only if a data source fails to find an existing resource
+	ManifestKindStateless = generators.ManifestKindStateless
+	// ManifestKindOther is a resource that is referenced by a variable or a hard coded value
+	ManifestKindOther = generators.ManifestKindOther
+)
+
+// ManifestInclusion records a reference that caused a resource to be included in an export.
+type ManifestInclusion = generators.ExportManifestInclusion
+
+// ManifestDummy records a variable that was assigned a dummy value.
+type ManifestDummy = generators.ExportManifestDummy
+
+// Progress describes how far an export has progressed.
+type Progress = entry.Progress
+
+const (
+	// ProgressStageLoading is reported while the resources are loaded from the Octopus API
+	ProgressStageLoading = entry.ProgressStageLoading
+	// ProgressStageGenerating is reported as the files are generated from the loaded resources
+	ProgressStageGenerating = entry.ProgressStageGenerating
+	// ProgressStageComplete is reported once the export is complete
+	ProgressStageComplete = entry.ProgressStageComplete
+)
+
+// Files maps the names of the exported files to their contents.
+type Files = export.Files
+
+// Writer saves the exported files, returning a description of where the files were written.
+type Writer = export.Writer
+
+// NewFileWriter returns a Writer that saves the files to the destination directory. When the destination is
+// empty, the files are saved to a new directory under the temp directory.
+func NewFileWriter(dest string) Writer {
+	return writers.NewFileWriter(dest)
+}
+
+// NewConsoleWriter returns a Writer that prints the files to stdout.
+func NewConsoleWriter() Writer {
+	return writers.ConsoleWriter{}
+}
+
+// Options defines the export. Any option supported by the octoterra CLI can be set in Arguments, while the other
+// fields take precedence over the equivalent arguments when they are set. The octoterra config file and OCTOTERRA_
+// environment variables are never read, so an export depends only on the options.
+type Options struct {
+	// Url is the Octopus server URL, e.g. https://myinstance.octopus.app
+	Url string
+	// ApiKey is the Octopus API key
+	ApiKey string
+	// AccessToken is the Octopus access token, used instead of an API key
+	AccessToken string
+	// Space is the name or ID of the space to export
+	Space string
+	// ProjectIds limits the export to the projects with the IDs
+	ProjectIds []string
+	// ProjectNames limits the export to the projects with the names
+	ProjectNames []string
+	// RunbookId limits the export to a single runbook
+	RunbookId string
+	// RunbookName limits the export to a single runbook. Requires a single project ID or name.
+	RunbookName string
+	// Arguments are octoterra command line arguments, like []string{"-excludeAllTargets", "-excludeProjects",
+	// "Test"}. Any argument that is not defined has the same default value as the CLI. Arguments that change global
+	// settings, like -insecureTls, are ignored.
+	Arguments []string
+	// Version is the version of the calling application, which is included in the user agent of API requests
+	Version string
+	// Client queries the Octopus API. When nil, a client is created from the URL, API key and space.
+	Client OctopusClient
+	// Logger receives the messages describing the export as a whole. When nil, the global zap logger is used. The
+	// messages logged while converting individual resources are always sent to the global zap logger.
+	Logger *zap.Logger
+	// Writer saves the exported files. When nil, the files are only returned.
+	Writer Writer
+	// Progress is called as the export progresses. It may be called from multiple goroutines.
+	Progress func(progress Progress)
+}
+
+// Export exports the Octopus resources defined by the options. It returns the exported files, which are ready to
+// be saved without further processing, and the manifest describing the exported resources. The manifest is empty
+// for stateless step template exports. Requests to the Octopus API stop, and the context error is returned, once the
+// context is done.
+func Export(ctx context.Context, options Options) (Files, Manifest, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, Manifest{}, err
+	}
+
+	arguments, err := options.buildArguments()
+
+	if err != nil {
+		return nil, Manifest{}, err
+	}
+
+	return export.Export(ctx, export.Options{
+		Arguments: arguments,
+		Version:   options.Version,
+		Client:    options.Client,
+		Logger:    options.Logger,
+		Writer:    options.Writer,
+		Progress:  options.Progress,
+	})
+}
+
+// buildArguments applies the option fields to the arguments.
+func (o Options) buildArguments() (args.Arguments, error) {
+	arguments, usage, err := args.ParseFlags(o.Arguments)
+
+	if err != nil {
+		if usage != "" {
+			return args.Arguments{}, errors.Join(err, errors.New(usage))
+		}
+		return args.Arguments{}, err
+	}
+
+	arguments.Url = strutil.DefaultIfEmpty(o.Url, arguments.Url)
+	arguments.ApiKey = strutil.DefaultIfEmpty(o.ApiKey, arguments.ApiKey)
+	arguments.AccessToken = strutil.DefaultIfEmpty(o.AccessToken, arguments.AccessToken)
+	arguments.Space = strutil.DefaultIfEmpty(o.Space, arguments.Space)
+	arguments.RunbookId = strutil.DefaultIfEmpty(o.RunbookId, arguments.RunbookId)
+	arguments.RunbookName = strutil.DefaultIfEmpty(o.RunbookName, arguments.RunbookName)
+
+	if len(o.ProjectIds) != 0 {
+		arguments.ProjectId = o.ProjectIds
+	}
+
+	if len(o.ProjectNames) != 0 {
+		arguments.ProjectName = o.ProjectNames
+	}
+
+	// The names in the Exclude<ResourceType>Except arguments are checked against the Octopus server once it is known
+	if err := arguments.ValidateExcludeExceptArgs(); err != nil {
+		return args.Arguments{}, err
+	}
+
+	return arguments, nil
+}
@@ -0,0 +1,89 @@
+package octoterra
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBuildArguments(t *testing.T) {
+	options := Options{
+		Url:          "https://example.org",
+		ApiKey:       "API-xxxx",
+		Space:        "Spaces-1",
+		ProjectNames: []string{"My Project"},
+		Arguments:    []string{"-space", "Spaces-2", "-excludeAllTargets"},
+	}
+
+	arguments, err := options.buildArguments()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if arguments.Space != "Spaces-1" {
+		t.Fatalf("The option fields must take precedence over the arguments")
+	}
+
+	if !arguments.ExcludeAllTargets {
+		t.Fatalf("The arguments must be applied")
+	}
+
+	if len(arguments.ProjectName) != 1 || arguments.ProjectName[0] != "My Project" {
+		t.Fatalf("The project names must be applied")
+	}
+}
+
+func TestBuildArgumentsUsesDefaults(t *testing.T) {
+	t.Setenv("OCTOPUS_CLI_SERVER", "https://example.org")
+	t.Setenv("OCTOPUS_CLI_API_KEY", "API-xxxx")
+	t.Setenv("OCTOTERRA_EXCLUDEALLTENANTS", "true")
+
+	arguments, err := Options{}.buildArguments()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if arguments.OutputFormat != "hcl" || !arguments.IncludeProviderServerDetails {
+		t.Fatalf("The arguments must have the CLI default values")
+	}
+
+	if arguments.Url != "" || arguments.ApiKey != "" || arguments.ExcludeAllTenants {
+		t.Fatalf("The environment variables must be ignored")
+	}
+
+	if _, err := (Options{Arguments: []string{"-unknownArgument"}}).buildArguments(); err == nil {
+		t.Fatalf("Unknown arguments must fail")
+	}
+}
+
+func TestExportValidatesOptions(t *testing.T) {
+	if _, _, err := Export(context.Background(), Options{Space: "Spaces-1"}); err == nil {
+		t.Fatalf("An export without a URL or client must fail")
+	}
+
+	options := Options{
+		Url:       "https://example.org",
+		ApiKey:    "API-xxxx",
+		Arguments: []string{"-outputFormat", "yaml"},
+	}
+
+	if _, _, err := Export(context.Background(), options); err == nil {
+		t.Fatalf("An invalid output format must fail")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, _, err := Export(ctx, Options{Url: "https://example.org", ApiKey: "API-xxxx"}); err == nil {
+		t.Fatalf("A cancelled context must stop the export")
+	}
+}
+
+func TestFilesString(t *testing.T) {
+	files := Files{"b.tf": "second", "a.tf": "first"}
+
+	if files.String() != "first\n\nsecond\n\n" {
+		t.Fatalf("The files must be concatenated in order of their names: %q", files.String())
+	}
+}